@@ -114,9 +114,9 @@ func demonstrateHealthCheck(ctx context.Context, client *zoptal.Client) {
 	}
 
 	fmt.Println("✅ API is healthy!")
-	fmt.Printf("   Status: %v\n", health["status"])
-	fmt.Printf("   Timestamp: %v\n", health["timestamp"])
-	fmt.Printf("   Version: %v\n", health["version"])
+	fmt.Printf("   Status: %s\n", health.Status)
+	fmt.Printf("   Timestamp: %s\n", health.Timestamp.Format(time.RFC3339))
+	fmt.Printf("   Version: %s\n", health.Version)
 }
 
 // demonstrateUserInfo shows getting user information
@@ -135,22 +135,23 @@ func demonstrateUserInfo(ctx context.Context, client *zoptal.Client) {
 	}
 
 	fmt.Println("✅ User information retrieved!")
-	fmt.Printf("   Name: %v\n", getOrDefault(userInfo, "name", "N/A"))
-	fmt.Printf("   Email: %v\n", getOrDefault(userInfo, "email", "N/A"))
-	fmt.Printf("   Plan: %v\n", getOrDefault(userInfo, "plan", "N/A"))
-	fmt.Printf("   Account ID: %v\n", getOrDefault(userInfo, "id", "N/A"))
+	fmt.Printf("   Name: %s\n", userInfo.Name)
+	fmt.Printf("   Email: %s\n", userInfo.Email)
+	fmt.Printf("   Plan: %s\n", userInfo.Plan)
+	fmt.Printf("   Account ID: %s\n", userInfo.ID)
 
-	// Get usage statistics
-	usage, err := client.GetUsageStats(ctx)
+	// Get usage statistics for the last 30 days
+	report, err := client.Usage.Query(ctx, nil)
 	if err != nil {
 		fmt.Printf("❌ Failed to get usage stats: %v\n", err)
 		return
 	}
+	usage := report.Totals()
 
-	fmt.Println("\n📊 Usage Statistics:")
-	fmt.Printf("   API Requests: %v\n", getOrDefault(usage, "api_requests", 0))
-	fmt.Printf("   AI Tokens: %v\n", getOrDefault(usage, "ai_tokens", 0))
-	fmt.Printf("   Storage Used: %v MB\n", getOrDefault(usage, "storage_used", 0))
+	fmt.Println("\n📊 Usage Statistics (last 30 days):")
+	fmt.Printf("   API Requests: %d\n", usage.APIRequests)
+	fmt.Printf("   AI Tokens: %d\n", usage.AITokens)
+	fmt.Printf("   Storage Used: %.1f MB\n", float64(usage.StorageBytes)/(1<<20))
 }
 
 // demonstrateAIFeatures shows AI code generation and analysis features
@@ -188,7 +189,7 @@ func demonstrateAIFeatures(ctx context.Context, client *zoptal.Client) {
 	ginResult, err := client.AI.GenerateCode(ctx, &zoptal.CodeGenerationRequest{
 		Prompt:    "Create a Gin HTTP handler for user authentication with JWT tokens",
 		Language:  "go",
-		Framework: stringPtr("gin"),
+		Framework: zoptal.String("gin"),
 		Context: map[string]interface{}{
 			"project_type":       "web_api",
 			"existing_packages":  []string{"github.com/gin-gonic/gin", "github.com/golang-jwt/jwt"},
@@ -244,7 +245,7 @@ func demonstrateProjectManagement(ctx context.Context, client *zoptal.Client) {
 
 	// List existing projects
 	projects, err := client.Projects.List(ctx, &zoptal.ProjectListOptions{
-		Limit: intPtr(5),
+		Limit: zoptal.Int(5),
 	})
 	if err != nil {
 		fmt.Printf("❌ Failed to list projects: %v\n", err)
@@ -295,7 +296,7 @@ func demonstrateProjectManagement(ctx context.Context, client *zoptal.Client) {
 
 	// Update project
 	_, err = client.Projects.Update(ctx, projectID, &zoptal.ProjectUpdateRequest{
-		Description: stringPtr("Updated description via Go SDK"),
+		Description: zoptal.String("Updated description via Go SDK"),
 	})
 	if err != nil {
 		fmt.Printf("❌ Failed to update project: %v\n", err)
@@ -439,8 +440,8 @@ func CalculateDiscount(price, discountPercent float64) (float64, error) {
 	tests, err := client.AI.GenerateTests(ctx, &zoptal.TestGenerationRequest{
 		Code:           testCode,
 		Language:       "go",
-		TestFramework:  stringPtr("testing"),
-		CoverageTarget: intPtr(95),
+		TestFramework:  zoptal.String("testing"),
+		CoverageTarget: zoptal.Int(95),
 	})
 	if err != nil {
 		fmt.Printf("❌ Test generation failed: %v\n", err)
@@ -517,13 +518,6 @@ func demonstrateErrorHandling(ctx context.Context, client *zoptal.Client) {
 
 // Helper functions
 
-func getOrDefault(m map[string]interface{}, key string, defaultValue interface{}) interface{} {
-	if value, exists := m[key]; exists {
-		return value
-	}
-	return defaultValue
-}
-
 func getStringOrDefault(value *string, defaultValue string) string {
 	if value != nil {
 		return *value
@@ -531,14 +525,6 @@ func getStringOrDefault(value *string, defaultValue string) string {
 	return defaultValue
 }
 
-func stringPtr(s string) *string {
-	return &s
-}
-
-func intPtr(i int) *int {
-	return &i
-}
-
 // Mock types (these would be defined in the actual SDK)
 
 // Note: In a real implementation, these types would be defined in the SDK package.
@@ -0,0 +1,50 @@
+package zoptal
+
+import "encoding/json"
+
+// HealthStatus is the health status of the Zoptal API.
+type HealthStatus struct {
+	Status    string            `json:"status"`
+	Version   string            `json:"version,omitempty"`
+	Timestamp Timestamp         `json:"timestamp"`
+	Services  map[string]string `json:"services,omitempty"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// UserInfo is information about the authenticated user.
+type UserInfo struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Plan      string    `json:"plan,omitempty"`
+	OrgID     string    `json:"org_id,omitempty"`
+	CreatedAt Timestamp `json:"created_at"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// UsageStats is usage statistics for the authenticated user.
+type UsageStats struct {
+	APIRequests           int64   `json:"api_requests"`
+	AITokens              int64   `json:"ai_tokens"`
+	StorageUsedMB         float64 `json:"storage_used"`
+	CollaborationSessions int64   `json:"collaboration_sessions"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// decodeTyped decodes a raw response into both a typed value and a generic
+// map, so typed responses keep access to fields the SDK does not model yet.
+func decodeTyped(raw json.RawMessage, typed interface{}, rawMap *map[string]interface{}) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	if err := decodeJSON(raw, typed); err != nil {
+		return err
+	}
+	return decodeJSON(raw, rawMap)
+}
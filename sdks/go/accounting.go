@@ -0,0 +1,385 @@
+package zoptal
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
+)
+
+// ResponseMetadata describes the HTTP exchanges made for an SDK call.
+//
+// To capture it, pass a context from WithResponseMetadata to the call and
+// read the metadata after the call returns (for streams, after the stream
+// is closed).
+type ResponseMetadata struct {
+	// Operation identifies the API operation, as "METHOD /path" with ID-like
+	// path segments replaced by {id}, e.g. "GET /projects/{id}/files/manifest"
+	Operation string
+
+	// StatusCode is the status code of the last attempt
+	StatusCode int
+
+	// RequestID is the server's request ID of the last attempt, for support requests
+	RequestID string
+
+	// Model is the model that served an AI request, when known; with
+	// ModelFallback it may differ from the requested model
+	Model string
+
+	// Attempts is the number of HTTP requests sent, including retries and hedges
+	Attempts int
+
+	// BytesSent is the number of request body bytes sent, after compression
+	BytesSent int64
+
+	// BytesReceived is the number of response body bytes received
+	BytesReceived int64
+
+	// Duration is the time from the first request to the end of the last response body read
+	Duration time.Duration
+
+	// Degraded is true if the result is a last-known result served while
+	// the AI service is down (see DegradationOptions)
+	Degraded bool
+}
+
+// OperationStats is the traffic of one API operation.
+type OperationStats struct {
+	Requests      int64
+	Errors        int64
+	BytesSent     int64
+	BytesReceived int64
+}
+
+// ClientStats is the traffic of a client since it was created or its stats
+// were last reset, for attributing bandwidth on metered egress.
+type ClientStats struct {
+	Requests      int64
+	Errors        int64
+	BytesSent     int64
+	BytesReceived int64
+
+	// Operations breaks the totals down by operation (see ResponseMetadata.Operation)
+	Operations map[string]OperationStats
+
+	// Since is when counting started
+	Since time.Time
+}
+
+// metadataKey is the context key of a metadataRecorder.
+type metadataKey struct{}
+
+// metadataRecorder fills in a ResponseMetadata from concurrent requests.
+type metadataRecorder struct {
+	mu    sync.Mutex
+	meta  *ResponseMetadata
+	start time.Time
+}
+
+// WithResponseMetadata returns a context that records metadata about the
+// HTTP requests made with it into meta.
+//
+// Parameters:
+//   - ctx: Parent context
+//   - meta: Metadata to fill in; it is reset first
+//
+// Returns the derived context.
+func WithResponseMetadata(ctx context.Context, meta *ResponseMetadata) context.Context {
+	*meta = ResponseMetadata{}
+	return context.WithValue(ctx, metadataKey{}, &metadataRecorder{meta: meta})
+}
+
+// attempt records a completed request.
+func (r *metadataRecorder) attempt(operation string, start time.Time, resp *http.Response, sent int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.start.IsZero() {
+		r.start = start
+	}
+	r.meta.Operation = operation
+	r.meta.Attempts++
+	r.meta.BytesSent += sent
+	if resp != nil {
+		r.meta.StatusCode = resp.StatusCode
+		r.meta.RequestID = resp.Header.Get("X-Request-ID")
+		r.meta.Model = resp.Header.Get("X-Zoptal-Model")
+	}
+	r.meta.Duration = time.Since(r.start)
+}
+
+// servedBy records the requested model of a successful AI request, unless
+// the server reported the model that served it.
+func (r *metadataRecorder) servedBy(model string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.meta.Model == "" {
+		r.meta.Model = model
+	}
+}
+
+// degraded records that a last-known result was served.
+func (r *metadataRecorder) degraded() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.meta.Degraded = true
+}
+
+// received records response body bytes.
+func (r *metadataRecorder) received(n int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.meta.BytesReceived += n
+	r.meta.Duration = time.Since(r.start)
+}
+
+// operationCounters are the traffic counters of one operation.
+type operationCounters struct {
+	requests      atomic.Int64
+	errors        atomic.Int64
+	bytesSent     atomic.Int64
+	bytesReceived atomic.Int64
+}
+
+// maxRecentRequests is the number of requests kept for debug bundles.
+const maxRecentRequests = 100
+
+// requestSummary summarizes a request for debug bundles. It identifies the
+// operation but records neither the request's path nor its bodies.
+type requestSummary struct {
+	Time       time.Time     `json:"time"`
+	Operation  string        `json:"operation"`
+	StatusCode int           `json:"status_code,omitempty"`
+	RequestID  string        `json:"request_id,omitempty"`
+	Duration   time.Duration `json:"duration_ns"`
+	BytesSent  int64         `json:"bytes_sent"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// trafficStats aggregates traffic by operation.
+type trafficStats struct {
+	mu         sync.Mutex
+	operations map[string]*operationCounters
+	since      time.Time
+
+	// recent holds the last maxRecentRequests requests, oldest at next once
+	// full
+	recent []requestSummary
+	next   int
+}
+
+// newTrafficStats creates empty traffic stats.
+func newTrafficStats() *trafficStats {
+	return &trafficStats{operations: make(map[string]*operationCounters), since: time.Now()}
+}
+
+// counters returns the counters of an operation, creating them if needed.
+func (s *trafficStats) counters(operation string) *operationCounters {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counters, ok := s.operations[operation]
+	if !ok {
+		counters = &operationCounters{}
+		s.operations[operation] = counters
+	}
+	return counters
+}
+
+// snapshot returns the current totals.
+func (s *trafficStats) snapshot() ClientStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := ClientStats{Operations: make(map[string]OperationStats, len(s.operations)), Since: s.since}
+	for operation, counters := range s.operations {
+		op := OperationStats{
+			Requests:      counters.requests.Load(),
+			Errors:        counters.errors.Load(),
+			BytesSent:     counters.bytesSent.Load(),
+			BytesReceived: counters.bytesReceived.Load(),
+		}
+		stats.Operations[operation] = op
+		stats.Requests += op.Requests
+		stats.Errors += op.Errors
+		stats.BytesSent += op.BytesSent
+		stats.BytesReceived += op.BytesReceived
+	}
+	return stats
+}
+
+// record adds a request to the recent requests.
+func (s *trafficStats) record(summary requestSummary) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.recent) < maxRecentRequests {
+		s.recent = append(s.recent, summary)
+		return
+	}
+	s.recent[s.next] = summary
+	s.next = (s.next + 1) % maxRecentRequests
+}
+
+// recentRequests returns the recent requests, oldest first.
+func (s *trafficStats) recentRequests() []requestSummary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	recent := make([]requestSummary, 0, len(s.recent))
+	recent = append(recent, s.recent[s.next:]...)
+	return append(recent, s.recent[:s.next]...)
+}
+
+// reset discards the totals.
+func (s *trafficStats) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.operations = make(map[string]*operationCounters)
+	s.since = time.Now()
+}
+
+// accountingTransport counts the body bytes of every request sent by the client.
+type accountingTransport struct {
+	base  http.RoundTripper
+	stats *trafficStats
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *accountingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	operation := operationName(req)
+	counters := t.stats.counters(operation)
+	recorder, _ := req.Context().Value(metadataKey{}).(*metadataRecorder)
+
+	var sent requestCounter
+	if req.Body != nil && req.Body != http.NoBody {
+		counted := req.Clone(req.Context())
+		sent.ReadCloser = req.Body
+		counted.Body = &sent
+		req = counted
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	// The body may still be written after RoundTrip returns only when the
+	// server replies early, in which case the rest is not sent.
+	bytesSent := sent.n.Load()
+	counters.requests.Add(1)
+	counters.bytesSent.Add(bytesSent)
+	if err != nil || resp.StatusCode >= 400 {
+		counters.errors.Add(1)
+	}
+	if recorder != nil {
+		recorder.attempt(operation, start, resp, bytesSent)
+	}
+	summary := requestSummary{Time: start, Operation: operation, Duration: time.Since(start), BytesSent: bytesSent}
+	if err != nil {
+		summary.Error = truncateMessage(err.Error())
+		t.stats.record(summary)
+		return nil, err
+	}
+	summary.StatusCode = resp.StatusCode
+	summary.RequestID = resp.Header.Get("X-Request-ID")
+	t.stats.record(summary)
+
+	resp.Body = &responseCounter{ReadCloser: resp.Body, counters: counters, recorder: recorder}
+	return resp, nil
+}
+
+// CloseIdleConnections closes the idle connections of the underlying transport.
+func (t *accountingTransport) CloseIdleConnections() {
+	if closer, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
+
+// requestCounter counts the bytes read from a request body.
+type requestCounter struct {
+	io.ReadCloser
+	n atomic.Int64
+}
+
+// Read implements io.Reader.
+func (r *requestCounter) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.n.Add(int64(n))
+	return n, err
+}
+
+// responseCounter counts the bytes read from a response body.
+type responseCounter struct {
+	io.ReadCloser
+	counters *operationCounters
+	recorder *metadataRecorder
+}
+
+// Read implements io.Reader.
+func (b *responseCounter) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.counters.bytesReceived.Add(int64(n))
+		if b.recorder != nil {
+			b.recorder.received(int64(n))
+		}
+	}
+	return n, err
+}
+
+// withAccounting returns a copy of client whose requests are counted in stats.
+func withAccounting(client *http.Client, stats *trafficStats) *http.Client {
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	accounted := *client
+	accounted.Transport = &accountingTransport{base: base, stats: stats}
+	return &accounted
+}
+
+// operationName returns "METHOD /path" for a request, with the API prefix
+// removed and ID-like path segments replaced by {id}.
+func operationName(req *http.Request) string {
+	path := req.URL.Path
+	if i := strings.Index(path, "/api/v1/"); i >= 0 {
+		path = path[i+len("/api/v1"):]
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		if isIDSegment(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return req.Method + " /" + strings.Join(segments, "/")
+}
+
+// isIDSegment reports whether a path segment looks like a resource ID rather
+// than a fixed part of the endpoint: fixed parts are lowercase words joined
+// by hyphens.
+func isIDSegment(segment string) bool {
+	if segment == "" {
+		return false
+	}
+	for _, r := range segment {
+		if r != '-' && !unicode.IsLower(r) {
+			return true
+		}
+	}
+	return len(segment) > 32
+}
+
+// Stats returns the request and byte counts of the client, in total and by
+// operation.
+//
+// Returns the traffic since the client was created or ResetStats was called.
+func (c *Client) Stats() ClientStats {
+	return c.httpClient.stats.snapshot()
+}
+
+// ResetStats discards the counts returned by Stats, for example after
+// exporting them.
+func (c *Client) ResetStats() {
+	c.httpClient.stats.reset()
+}
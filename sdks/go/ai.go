@@ -0,0 +1,133 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// AIService handles AI assistance: generating, reviewing, and explaining
+// code, and conversations with the Zoptal assistant.
+type AIService struct {
+	client *HTTPClient
+}
+
+// CodeGenerationRequest describes code to generate.
+type CodeGenerationRequest struct {
+	// Prompt describes the code to generate (required)
+	Prompt string `json:"prompt"`
+
+	// Language is the programming language, e.g. "go" (optional)
+	Language string `json:"language,omitempty"`
+
+	// Framework is the framework to use, e.g. "gin" (optional)
+	Framework string `json:"framework,omitempty"`
+
+	// Context carries additional information for the model, such as the
+	// project type or existing dependencies (optional)
+	Context map[string]interface{} `json:"context,omitempty"`
+}
+
+// CodeGenerationResponse is generated code.
+type CodeGenerationResponse struct {
+	Code        string `json:"code"`
+	Language    string `json:"language,omitempty"`
+	Explanation string `json:"explanation,omitempty"`
+
+	// Model is the model that generated the code
+	Model string `json:"model,omitempty"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// ChatRequest is a message to the Zoptal assistant.
+type ChatRequest struct {
+	// Message is the user's message (required)
+	Message string `json:"message"`
+
+	// ConversationID continues a conversation; nil starts a new one (optional)
+	ConversationID *string `json:"conversation_id,omitempty"`
+
+	// Context carries additional information for the assistant, such as
+	// the project the conversation is about (optional)
+	Context map[string]interface{} `json:"context,omitempty"`
+}
+
+// ChatResponse is the assistant's reply to a ChatRequest.
+type ChatResponse struct {
+	Response string `json:"response"`
+
+	// ConversationID identifies the conversation, to continue it in a
+	// later ChatRequest
+	ConversationID *string `json:"conversation_id,omitempty"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// GenerateCode generates code from a natural language prompt, returning it
+// once generation completes. Use GenerateCodeStream to receive the code as
+// it is produced.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - request: Code generation request
+//   - opts: Request options (optional)
+//
+// Returns the generated code or an error if the request fails.
+func (s *AIService) GenerateCode(ctx context.Context, request *CodeGenerationRequest, opts ...RequestOption) (*CodeGenerationResponse, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if request == nil || strings.TrimSpace(request.Prompt) == "" {
+		return nil, NewValidationError("prompt is required")
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Post(ctx, "/ai/generate-code", request, &raw); err != nil {
+		return nil, fmt.Errorf("failed to generate code: %w", err)
+	}
+
+	var response CodeGenerationResponse
+	if err := decodeTyped(raw, &response, &response.Raw); err != nil {
+		return nil, fmt.Errorf("failed to generate code: %w", err)
+	}
+	return &response, nil
+}
+
+// Chat sends a message to the Zoptal assistant and returns its reply.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - request: Chat message
+//   - opts: Request options (optional)
+//
+// Returns the reply or an error if the request fails.
+//
+// Example usage:
+//
+//	reply, err := client.AI.Chat(ctx, &zoptal.ChatRequest{Message: "How do I shut down an http.Server?"})
+//	if err != nil {
+//	    return err
+//	}
+//	followUp, err := client.AI.Chat(ctx, &zoptal.ChatRequest{
+//	    Message:        "Show an example with signal.NotifyContext",
+//	    ConversationID: reply.ConversationID,
+//	})
+func (s *AIService) Chat(ctx context.Context, request *ChatRequest, opts ...RequestOption) (*ChatResponse, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if request == nil || strings.TrimSpace(request.Message) == "" {
+		return nil, NewValidationError("message is required")
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Post(ctx, "/ai/chat", request, &raw); err != nil {
+		return nil, fmt.Errorf("failed to chat: %w", err)
+	}
+
+	var response ChatResponse
+	if err := decodeTyped(raw, &response, &response.Raw); err != nil {
+		return nil, fmt.Errorf("failed to chat: %w", err)
+	}
+	return &response, nil
+}
@@ -0,0 +1,227 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// RiskLevel is the risk level of a set of AI changes.
+type RiskLevel string
+
+// Risk levels, from lowest to highest.
+const (
+	RiskLow    RiskLevel = "low"
+	RiskMedium RiskLevel = "medium"
+	RiskHigh   RiskLevel = "high"
+)
+
+// rank orders risk levels; unknown levels rank highest, so that gates
+// comparing levels fail closed.
+func (l RiskLevel) rank() int {
+	switch l {
+	case RiskLow:
+		return 0
+	case RiskMedium:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// AtMost reports whether l is as risky as max or less.
+func (l RiskLevel) AtMost(max RiskLevel) bool {
+	return l.rank() <= max.rank()
+}
+
+// GenerateAndApplyRequest is a request to change a project with AI.
+type GenerateAndApplyRequest struct {
+	// ProjectID is the ID of the project to change (required)
+	ProjectID string `json:"-"`
+
+	// Prompt describes the change, e.g. "add rate limiting to the public
+	// API handlers" (required)
+	Prompt string `json:"prompt"`
+
+	// Paths restricts the change to these files and directories of the
+	// project (default: the whole project)
+	Paths []string `json:"paths,omitempty"`
+
+	// Model is the model generating the change (default: the project's model)
+	Model string `json:"model,omitempty"`
+
+	// Simulate computes the change, its test impact, and its risk without
+	// changing the project; the result can be applied as is with
+	// ApplySimulation once approved (default: false)
+	Simulate bool `json:"simulate,omitempty"`
+}
+
+// ProposedChange is a change to a file of a project.
+type ProposedChange struct {
+	Path   string     `json:"path"`
+	Action SyncAction `json:"action"`
+
+	// Diff is the change in unified diff format; empty for deleted files
+	Diff string `json:"diff,omitempty"`
+
+	// Content is the new content of the file; empty for deleted files
+	Content string `json:"content,omitempty"`
+}
+
+// TestImpact estimates the effect of a change on the tests of a project.
+type TestImpact struct {
+	// AffectedTests are the tests covering the changed code, e.g.
+	// "server/handler_test.go:TestRateLimit"
+	AffectedTests []string `json:"affected_tests"`
+
+	// UncoveredPaths are the changed files no test covers
+	UncoveredPaths []string `json:"uncovered_paths,omitempty"`
+
+	// FailureProbability is the estimated probability, from 0 to 1, that
+	// the change makes a test fail
+	FailureProbability float64 `json:"failure_probability"`
+}
+
+// ChangeRisk is the assessed risk of a change.
+type ChangeRisk struct {
+	// Score is the risk from 0 (trivial) to 1 (likely to break production)
+	Score float64 `json:"score"`
+
+	Level RiskLevel `json:"level"`
+
+	// Factors explain the score, e.g. "changes authentication middleware"
+	Factors []string `json:"factors,omitempty"`
+}
+
+// GenerateAndApplyResult is a change made, or proposed, by AI.
+type GenerateAndApplyResult struct {
+	// Simulated reports whether the project was left unchanged
+	Simulated bool `json:"simulated"`
+
+	// SimulationID identifies a simulated change for ApplySimulation; empty
+	// if the change was applied
+	SimulationID string `json:"simulation_id,omitempty"`
+
+	// ExpiresAt is when a simulated change can no longer be applied
+	ExpiresAt Timestamp `json:"expires_at"`
+
+	// Summary describes the change
+	Summary string `json:"summary"`
+
+	Changes    []ProposedChange `json:"changes"`
+	TestImpact TestImpact       `json:"test_impact"`
+	Risk       ChangeRisk       `json:"risk"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// Diff returns the changes as a single unified diff, e.g. for review.
+func (r *GenerateAndApplyResult) Diff() string {
+	var b strings.Builder
+	for _, change := range r.Changes {
+		b.WriteString(change.Diff)
+		if change.Diff != "" && !strings.HasSuffix(change.Diff, "\n") {
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+// GenerateAndApply generates a change to a project from a prompt and
+// applies it to the project's files. With Simulate, the project is left
+// unchanged and the result describes the proposed change, its test impact,
+// and its risk, so that a review gate can approve it before it lands with
+// ApplySimulation.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - request: Change to make
+//   - opts: Request options (optional)
+//
+// Returns the change or an error if the request fails.
+//
+// Example usage:
+//
+//	result, err := client.AI.GenerateAndApply(ctx, &zoptal.GenerateAndApplyRequest{
+//	    ProjectID: projectID,
+//	    Prompt:    "add rate limiting to the public API handlers",
+//	    Simulate:  true,
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	if !result.Risk.Level.AtMost(zoptal.RiskMedium) {
+//	    return requestReview(result.Diff(), result.Risk.Factors)
+//	}
+//	_, err = client.AI.ApplySimulation(ctx, projectID, result.SimulationID)
+func (s *AIService) GenerateAndApply(ctx context.Context, request *GenerateAndApplyRequest, opts ...RequestOption) (*GenerateAndApplyResult, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if request == nil || request.ProjectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+	if strings.TrimSpace(request.Prompt) == "" {
+		return nil, NewValidationError("prompt is required")
+	}
+	for _, path := range request.Paths {
+		if strings.TrimSpace(path) == "" {
+			return nil, NewValidationError("paths must not be empty")
+		}
+	}
+
+	var raw json.RawMessage
+	endpoint := "/projects/" + url.PathEscape(request.ProjectID) + "/ai/apply"
+	if err := s.client.Post(ctx, endpoint, request, &raw); err != nil {
+		return nil, fmt.Errorf("failed to generate and apply change: %w", err)
+	}
+	result, err := decodeGenerateAndApplyResult(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate and apply change: %w", err)
+	}
+	return result, nil
+}
+
+// ApplySimulation applies a change simulated by GenerateAndApply exactly
+// as it was proposed, without generating it again.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - simulationID: ID of the simulated change, from
+//     GenerateAndApplyResult.SimulationID
+//   - opts: Request options (optional)
+//
+// Returns the applied change or an error if the request fails; an APIError
+// with status 409 means a changed file was modified since the simulation,
+// and a NotFoundError that the simulation expired or was already applied.
+func (s *AIService) ApplySimulation(ctx context.Context, projectID, simulationID string, opts ...RequestOption) (*GenerateAndApplyResult, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+	if simulationID == "" {
+		return nil, NewValidationError("simulation ID is required")
+	}
+
+	var raw json.RawMessage
+	endpoint := "/projects/" + url.PathEscape(projectID) + "/ai/simulations/" + url.PathEscape(simulationID) + "/apply"
+	if err := s.client.Post(ctx, endpoint, nil, &raw); err != nil {
+		return nil, fmt.Errorf("failed to apply simulation %s: %w", simulationID, err)
+	}
+	result, err := decodeGenerateAndApplyResult(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply simulation %s: %w", simulationID, err)
+	}
+	return result, nil
+}
+
+// decodeGenerateAndApplyResult decodes a generate and apply response.
+func decodeGenerateAndApplyResult(raw json.RawMessage) (*GenerateAndApplyResult, error) {
+	var result GenerateAndApplyResult
+	if err := decodeTyped(raw, &result, &result.Raw); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
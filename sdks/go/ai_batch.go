@@ -0,0 +1,350 @@
+package zoptal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// BatchOperation is the AI operation of a batch item, named after its
+// endpoint under /ai.
+type BatchOperation string
+
+// Batch operations.
+const (
+	BatchGenerateCode  BatchOperation = "generate-code"
+	BatchAnalyzeCode   BatchOperation = "analyze-code"
+	BatchExplainCode   BatchOperation = "explain-code"
+	BatchGenerateTests BatchOperation = "generate-tests"
+	BatchRefactor      BatchOperation = "refactor"
+	BatchReviewDiff    BatchOperation = "review-diff"
+	BatchCodeMetrics   BatchOperation = "code-metrics"
+)
+
+// BatchStatus is the status of a batch.
+type BatchStatus string
+
+// Batch statuses.
+const (
+	BatchQueued    BatchStatus = "queued"
+	BatchRunning   BatchStatus = "running"
+	BatchCompleted BatchStatus = "completed"
+	BatchFailed    BatchStatus = "failed"
+)
+
+// Done reports whether the batch has finished, successfully or not.
+func (s BatchStatus) Done() bool {
+	return s == BatchCompleted || s == BatchFailed
+}
+
+// BatchItem is a request in a batch.
+type BatchItem struct {
+	// ID identifies the item in the results (default: its index in the batch)
+	ID string `json:"id"`
+
+	// Operation is the AI operation (required)
+	Operation BatchOperation `json:"operation"`
+
+	// Request is the request of the operation, such as a
+	// *CodeGenerationRequest for BatchGenerateCode or a *RefactorRequest for
+	// BatchRefactor (required)
+	Request interface{} `json:"request"`
+}
+
+// BatchItemResult is the outcome of a batch item.
+type BatchItemResult struct {
+	ID        string
+	Operation BatchOperation
+
+	// Result is the response the operation's endpoint would have returned;
+	// nil if the item failed
+	Result json.RawMessage
+
+	// Err is set if the item failed; the other items are unaffected
+	Err error
+}
+
+// Decode decodes the result of the item into v, such as a *RefactorResult
+// for BatchRefactor, or returns the item's error if it failed.
+func (r *BatchItemResult) Decode(v interface{}) error {
+	if r.Err != nil {
+		return r.Err
+	}
+	return decodeJSON(r.Result, v)
+}
+
+// BatchResult is a batch and the results of its items.
+type BatchResult struct {
+	ID     string      `json:"id"`
+	Status BatchStatus `json:"status"`
+
+	// Completed counts the items that have finished, successfully or not
+	Completed int `json:"completed"`
+	Total     int `json:"total"`
+
+	// Items are the results of the finished items, in the order they were
+	// submitted
+	Items []BatchItemResult `json:"-"`
+
+	// Error describes why the batch as a whole failed
+	Error string `json:"error,omitempty"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// Failed returns the results of the items that failed.
+func (r *BatchResult) Failed() []BatchItemResult {
+	var failed []BatchItemResult
+	for _, item := range r.Items {
+		if item.Err != nil {
+			failed = append(failed, item)
+		}
+	}
+	return failed
+}
+
+// BatchOptions contains options for running a batch.
+type BatchOptions struct {
+	// Model is the model for items whose request does not name one (optional)
+	Model string
+
+	// Async runs the batch as a background job, which the server may
+	// schedule when capacity allows; Batch polls it until it completes.
+	// Large batches may run as jobs even if Async is false (default: false)
+	Async bool
+
+	// PollInterval is the initial interval between checks of a running
+	// batch, which doubles after each check up to 30 seconds (default: 2 seconds)
+	PollInterval time.Duration
+
+	// OnProgress is called with the batch each time it is checked (optional)
+	OnProgress func(*BatchResult)
+}
+
+// batchItemResponse is an item of a batch response.
+type batchItemResponse struct {
+	ID         string          `json:"id"`
+	Operation  BatchOperation  `json:"operation"`
+	StatusCode int             `json:"status_code"`
+	Result     json.RawMessage `json:"result"`
+	Error      json.RawMessage `json:"error"`
+}
+
+// Batch runs many AI requests in a single API call, for bulk work such as
+// migrations, where sending each request separately would spend most of
+// the time on HTTP overhead. Each item succeeds or fails on its own: a
+// failed item is reported in its BatchItemResult and does not fail the
+// batch.
+//
+// Asynchronous batches are polled until they complete, so Batch may take
+// minutes; use ctx to bound it. Cancelling ctx stops the polling but not
+// the batch; the error names the batch, whose results can later be fetched
+// with GetBatch.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - items: Requests to run
+//   - options: Batch options (can be nil for defaults)
+//   - opts: Request options (optional)
+//
+// Returns the completed batch with the results of all items, or an error
+// if the batch as a whole fails or a request fails.
+//
+// Example usage:
+//
+//	items := make([]zoptal.BatchItem, len(files))
+//	for i, file := range files {
+//	    items[i] = zoptal.BatchItem{
+//	        ID:        file.Path,
+//	        Operation: zoptal.BatchRefactor,
+//	        Request:   &zoptal.RefactorRequest{Code: file.Content, Goal: "migrate to the v2 API"},
+//	    }
+//	}
+//	batch, err := client.AI.Batch(ctx, items, &zoptal.BatchOptions{Async: true})
+//	if err != nil {
+//	    return err
+//	}
+//	for _, item := range batch.Items {
+//	    var result zoptal.RefactorResult
+//	    if err := item.Decode(&result); err != nil {
+//	        log.Printf("%s: %v", item.ID, err)
+//	    }
+//	}
+func (s *AIService) Batch(ctx context.Context, items []BatchItem, options *BatchOptions, opts ...RequestOption) (*BatchResult, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if len(items) == 0 {
+		return nil, NewValidationError("at least one batch item is required")
+	}
+	if options == nil {
+		options = &BatchOptions{}
+	}
+	backoff := newPollBackoff(options.PollInterval, 0, 2*time.Second)
+
+	submitted := make([]BatchItem, len(items))
+	seen := make(map[string]bool, len(items))
+	for i, item := range items {
+		if item.Operation == "" {
+			return nil, NewValidationError(fmt.Sprintf("batch item %d: operation is required", i))
+		}
+		if item.Request == nil {
+			return nil, NewValidationError(fmt.Sprintf("batch item %d: request is required", i))
+		}
+		if item.ID == "" {
+			item.ID = strconv.Itoa(i)
+		}
+		if seen[item.ID] {
+			return nil, NewValidationError(fmt.Sprintf("batch item %d: duplicate ID %q", i, item.ID))
+		}
+		seen[item.ID] = true
+		submitted[i] = item
+	}
+
+	data := map[string]interface{}{"items": submitted}
+	if options.Model != "" {
+		data["model"] = options.Model
+	}
+	if options.Async {
+		data["async"] = true
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Post(ctx, "/ai/batch", data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to run batch: %w", err)
+	}
+	batch, err := decodeBatch(raw, submitted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run batch: %w", err)
+	}
+
+	for {
+		if options.OnProgress != nil {
+			options.OnProgress(batch)
+		}
+		switch batch.Status {
+		case BatchCompleted, "":
+			return batch, nil
+		case BatchFailed:
+			return nil, NewAIError(fmt.Sprintf("batch %s failed: %s", batch.ID, batch.Error))
+		}
+
+		if err := backoff.wait(ctx); err != nil {
+			return nil, fmt.Errorf("batch %s still running: %w", batch.ID, err)
+		}
+		if batch, err = s.getBatch(ctx, batch.ID, submitted); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// GetBatch gets a batch, with the results of the items finished so far.
+// Items are in the order the server reports them.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - batchID: ID of the batch
+//   - opts: Request options (optional)
+//
+// Returns the batch or an error if the request fails.
+func (s *AIService) GetBatch(ctx context.Context, batchID string, opts ...RequestOption) (*BatchResult, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if batchID == "" {
+		return nil, NewValidationError("batch ID is required")
+	}
+	return s.getBatch(ctx, batchID, nil)
+}
+
+// getBatch gets a batch, ordering its items as submitted if known.
+func (s *AIService) getBatch(ctx context.Context, batchID string, submitted []BatchItem) (*BatchResult, error) {
+	var raw json.RawMessage
+	if err := s.client.Get(ctx, "/ai/batch/"+url.PathEscape(batchID), nil, &raw); err != nil {
+		return nil, fmt.Errorf("failed to get batch: %w", err)
+	}
+	batch, err := decodeBatch(raw, submitted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch: %w", err)
+	}
+	return batch, nil
+}
+
+// decodeBatch decodes a batch response, ordering its items as submitted if
+// known.
+func decodeBatch(raw json.RawMessage, submitted []BatchItem) (*BatchResult, error) {
+	var batch BatchResult
+	if err := decodeTyped(raw, &batch, &batch.Raw); err != nil {
+		return nil, err
+	}
+	var envelope struct {
+		Items []batchItemResponse `json:"items"`
+	}
+	if err := decodeJSON(raw, &envelope); err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]BatchItemResult, len(envelope.Items))
+	for _, item := range envelope.Items {
+		result := BatchItemResult{ID: item.ID, Operation: item.Operation}
+		if isJSONValue(item.Error) {
+			result.Err = batchItemError(item.StatusCode, item.Error)
+		} else {
+			result.Result = item.Result
+		}
+		results[item.ID] = result
+	}
+
+	if submitted == nil {
+		for _, item := range envelope.Items {
+			batch.Items = append(batch.Items, results[item.ID])
+		}
+	} else {
+		for _, item := range submitted {
+			if result, ok := results[item.ID]; ok {
+				if result.Operation == "" {
+					result.Operation = item.Operation
+				}
+				batch.Items = append(batch.Items, result)
+			}
+		}
+		if batch.Total == 0 {
+			batch.Total = len(submitted)
+		}
+	}
+	if batch.Completed == 0 {
+		batch.Completed = len(batch.Items)
+	}
+	return &batch, nil
+}
+
+// isJSONValue reports whether raw holds a value other than null.
+func isJSONValue(raw json.RawMessage) bool {
+	raw = bytes.TrimSpace(raw)
+	return len(raw) > 0 && !bytes.Equal(raw, []byte("null"))
+}
+
+// batchItemError returns the error of a failed batch item, typed as the
+// same failure of a single request would be.
+func batchItemError(statusCode int, body json.RawMessage) error {
+	var message string
+	if json.Unmarshal(body, &message) == nil {
+		body, _ = json.Marshal(map[string]string{"message": message})
+	}
+	if err := modelUnavailable(statusCode, body); err != nil {
+		return err
+	}
+	message = errorMessage(body, "batch item failed", "message", "error", "detail")
+
+	switch statusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return NewValidationErrorWithFields(message, validationError(body, message).Fields)
+	case http.StatusNotFound:
+		return NewNotFoundError(message)
+	case http.StatusTooManyRequests:
+		return NewRateLimitError(message)
+	}
+	return NewAIError(message)
+}
@@ -0,0 +1,193 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// CodeActionKind is a kind of action offered by a code lens.
+type CodeActionKind string
+
+// Code action kinds.
+const (
+	CodeActionExplain       CodeActionKind = "explain"
+	CodeActionGenerateTests CodeActionKind = "generate_tests"
+	CodeActionOptimize      CodeActionKind = "optimize"
+	CodeActionDocument      CodeActionKind = "document"
+)
+
+// CodeLensRequest requests the code lenses of a file, given either its
+// content or its path in a project. Content takes precedence, so editors
+// can send unsaved changes along with the path.
+type CodeLensRequest struct {
+	// Content is the content of the file (required unless ProjectID and Path are set)
+	Content string `json:"content,omitempty"`
+
+	// ProjectID and Path locate the file in a project (required unless
+	// Content is set); with Content, Path only gives context
+	ProjectID string `json:"project_id,omitempty"`
+	Path      string `json:"path,omitempty"`
+
+	// Language is the language of the file; detected if empty (optional)
+	Language string `json:"language,omitempty"`
+
+	// Actions restricts the lenses to these kinds of actions (default: all)
+	Actions []CodeActionKind `json:"actions,omitempty"`
+}
+
+// CodeAction is an action of a code lens.
+type CodeAction struct {
+	// ID identifies the action for ExecuteAction. It is stable: the same
+	// action on the same code has the same ID across requests.
+	ID string `json:"id"`
+
+	Kind CodeActionKind `json:"kind"`
+
+	// Title is the label to show, e.g. "Generate tests"
+	Title string `json:"title"`
+}
+
+// CodeLens is a range of a file, such as a function, with the actions
+// available on it.
+type CodeLens struct {
+	// StartLine and EndLine are the 1-based, inclusive line range of the
+	// lens; editors show the lens above StartLine
+	StartLine int `json:"start_line"`
+	EndLine   int `json:"end_line"`
+
+	// Symbol is the name of the function or type in the range, if any
+	Symbol string `json:"symbol,omitempty"`
+
+	Actions []CodeAction `json:"actions"`
+}
+
+// CodeLenses are the code lenses of a file, in order of their start lines.
+type CodeLenses struct {
+	Lenses []CodeLens `json:"lenses"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// At returns the lenses whose range contains a 1-based line, innermost
+// first, such as a method and then its enclosing type.
+func (l *CodeLenses) At(line int) []CodeLens {
+	var lenses []CodeLens
+	for _, lens := range l.Lenses {
+		if lens.StartLine <= line && line <= lens.EndLine {
+			lenses = append([]CodeLens{lens}, lenses...)
+		}
+	}
+	return lenses
+}
+
+// ActionResult is the result of a code action.
+type ActionResult struct {
+	ActionID string         `json:"action_id"`
+	Kind     CodeActionKind `json:"kind"`
+
+	// Text is the result to show, in Markdown, such as an explanation
+	Text string `json:"text,omitempty"`
+
+	// Code is generated code, such as tests, if any
+	Code string `json:"code,omitempty"`
+
+	// Path is the suggested path of the generated code, e.g.
+	// "handler_test.go", if it belongs in another file
+	Path string `json:"path,omitempty"`
+
+	// Diff is the change to the file in unified diff format, such as an
+	// optimization or added documentation, if any
+	Diff string `json:"diff,omitempty"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// CodeLenses finds the ranges of a file, such as functions and types, on
+// which AI actions are available, for editors to show as code lenses.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - request: File to analyze
+//   - opts: Request options (optional)
+//
+// Returns the lenses or an error if the request fails.
+//
+// Example usage:
+//
+//	lenses, err := client.AI.CodeLenses(ctx, &zoptal.CodeLensRequest{
+//	    Content:  buffer.Text(),
+//	    Path:     "server/handler.go",
+//	    Language: "go",
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	for _, lens := range lenses.Lenses {
+//	    for _, action := range lens.Actions {
+//	        editor.AddLens(lens.StartLine, action.Title, action.ID)
+//	    }
+//	}
+func (s *AIService) CodeLenses(ctx context.Context, request *CodeLensRequest, opts ...RequestOption) (*CodeLenses, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if request == nil || (strings.TrimSpace(request.Content) == "" && (request.ProjectID == "" || request.Path == "")) {
+		return nil, NewValidationError("content, or project ID and path, are required")
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Post(ctx, "/ai/code-lenses", request, &raw); err != nil {
+		return nil, fmt.Errorf("failed to get code lenses: %w", err)
+	}
+
+	var lenses CodeLenses
+	if err := decodeTyped(raw, &lenses, &lenses.Raw); err != nil {
+		return nil, fmt.Errorf("failed to get code lenses: %w", err)
+	}
+	return &lenses, nil
+}
+
+// ExecuteAction runs the action of a code lens, such as explaining a
+// function or generating its tests.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - actionID: ID of the action, from CodeAction.ID
+//   - opts: Request options (optional)
+//
+// Returns the result of the action or an error if the request fails; a
+// NotFoundError means the action is no longer available, for example
+// because the code changed, and the lenses should be refreshed.
+//
+// Example usage:
+//
+//	result, err := client.AI.ExecuteAction(ctx, action.ID)
+//	if err != nil {
+//	    return err
+//	}
+//	if result.Diff != "" {
+//	    _, err = client.Files.ApplyDiff(ctx, projectID, path, result.Diff)
+//	}
+func (s *AIService) ExecuteAction(ctx context.Context, actionID string, opts ...RequestOption) (*ActionResult, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if actionID == "" {
+		return nil, NewValidationError("action ID is required")
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Post(ctx, "/ai/actions/"+url.PathEscape(actionID)+"/execute", nil, &raw); err != nil {
+		return nil, fmt.Errorf("failed to execute action %s: %w", actionID, err)
+	}
+
+	var result ActionResult
+	if err := decodeTyped(raw, &result, &result.Raw); err != nil {
+		return nil, fmt.Errorf("failed to execute action %s: %w", actionID, err)
+	}
+	if result.ActionID == "" {
+		result.ActionID = actionID
+	}
+	return &result, nil
+}
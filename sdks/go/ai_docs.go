@@ -0,0 +1,220 @@
+package zoptal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// maxDocsTokens is the largest token budget of a documentation request.
+const maxDocsTokens = 50000
+
+// DocsRequest requests the documentation of a library, or of one topic of
+// it, for a specific version.
+type DocsRequest struct {
+	// Library is the name of the library or framework, e.g. "react",
+	// "next.js", or "github.com/gin-gonic/gin" (required)
+	Library string `json:"library"`
+
+	// Version is the version of the library, e.g. "18.3" or "v1.10.0"
+	// (default: the latest version)
+	Version string `json:"version,omitempty"`
+
+	// Topic narrows the documentation to a subject, e.g. "routing" or
+	// "server components" (optional)
+	Topic string `json:"topic,omitempty"`
+
+	// MaxTokens is the token budget of the returned snippets, up to 50000
+	// (default: decided by the server)
+	MaxTokens int `json:"max_tokens,omitempty"`
+}
+
+// DocSnippet is a curated excerpt of library documentation.
+type DocSnippet struct {
+	Title string `json:"title"`
+
+	// Content is the excerpt, in Markdown, usually including code examples
+	Content string `json:"content"`
+
+	// SourceURL is the page of the documentation the excerpt comes from
+	SourceURL string `json:"source_url,omitempty"`
+
+	// Tokens is the size of the excerpt in tokens
+	Tokens int `json:"tokens"`
+}
+
+// LibraryDocs contains the documentation snippets of a library, most
+// relevant first, within the token budget of the request.
+type LibraryDocs struct {
+	Library string `json:"library"`
+
+	// Version is the version the documentation is for, resolved from the
+	// requested version, e.g. "18.3.1" for "18.3"
+	Version string `json:"version"`
+
+	Snippets []DocSnippet `json:"snippets"`
+
+	// Tokens is the total size of the snippets in tokens
+	Tokens int `json:"tokens"`
+
+	// Truncated is true if relevant snippets were left out to fit the budget
+	Truncated bool `json:"truncated,omitempty"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// Text formats the documentation as Markdown, for inclusion in a prompt.
+func (d *LibraryDocs) Text() string {
+	var b strings.Builder
+	b.WriteString("# " + d.Library)
+	if d.Version != "" {
+		b.WriteString(" " + d.Version)
+	}
+	b.WriteString(" documentation\n")
+	for _, snippet := range d.Snippets {
+		b.WriteString("\n")
+		if snippet.Title != "" {
+			b.WriteString("## " + snippet.Title + "\n\n")
+		}
+		b.WriteString(strings.TrimSpace(snippet.Content) + "\n")
+		if snippet.SourceURL != "" {
+			b.WriteString("\nSource: " + snippet.SourceURL + "\n")
+		}
+	}
+	return b.String()
+}
+
+// FetchDocs retrieves curated documentation snippets of a library version,
+// for example to ground prompts in the API of the exact framework version a
+// project uses. To attach documentation to code generation requests
+// automatically, use WithLibraryDocs instead.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - request: Documentation request
+//   - opts: Request options (optional)
+//
+// Returns the documentation or an error if the request fails.
+//
+// Example usage:
+//
+//	docs, err := client.AI.FetchDocs(ctx, &zoptal.DocsRequest{
+//	    Library:   "next.js",
+//	    Version:   "14",
+//	    Topic:     "app router",
+//	    MaxTokens: 4000,
+//	})
+//	prompt := docs.Text() + "\n\n" + question
+func (s *AIService) FetchDocs(ctx context.Context, request *DocsRequest, opts ...RequestOption) (*LibraryDocs, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	return s.client.fetchDocs(ctx, request)
+}
+
+// fetchDocs retrieves the documentation of a library.
+func (c *HTTPClient) fetchDocs(ctx context.Context, request *DocsRequest) (*LibraryDocs, error) {
+	if err := request.validate(); err != nil {
+		return nil, err
+	}
+
+	var raw json.RawMessage
+	if err := c.Post(ctx, "/ai/docs/fetch", request, &raw); err != nil {
+		return nil, fmt.Errorf("failed to fetch docs of %s: %w", request.Library, err)
+	}
+
+	var docs LibraryDocs
+	if err := decodeTyped(raw, &docs, &docs.Raw); err != nil {
+		return nil, fmt.Errorf("failed to fetch docs of %s: %w", request.Library, err)
+	}
+	return &docs, nil
+}
+
+// validate checks a documentation request.
+func (r *DocsRequest) validate() error {
+	if r == nil || strings.TrimSpace(r.Library) == "" {
+		return NewValidationError("library is required")
+	}
+	if r.MaxTokens < 0 || r.MaxTokens > maxDocsTokens {
+		return NewValidationError(fmt.Sprintf("max tokens must be between 1 and %d", maxDocsTokens))
+	}
+	return nil
+}
+
+// WithLibraryDocs attaches the documentation of libraries to the code
+// generation requests of a call, such as AI.GenerateCodeStream, so that the
+// generated code uses the APIs of the requested versions. The documentation
+// is fetched as by AI.FetchDocs before each request and added to its context
+// under "library_docs"; a failure to fetch it fails the request. Other
+// requests are unaffected.
+//
+// Parameters:
+//   - requests: Libraries to document, with their token budgets
+//
+// Returns the request option.
+//
+// Example usage:
+//
+//	stream, err := client.AI.GenerateCodeStream(ctx, request,
+//	    zoptal.WithLibraryDocs(
+//	        &zoptal.DocsRequest{Library: "react", Version: "18", Topic: "hooks", MaxTokens: 3000},
+//	        &zoptal.DocsRequest{Library: "tailwindcss", Version: "3.4", MaxTokens: 2000},
+//	    ))
+func WithLibraryDocs(requests ...*DocsRequest) RequestOption {
+	return requestOptionFunc(func(options *requestOptions) {
+		// Copy rather than share the parent context's slice.
+		options.libraryDocs = append(options.libraryDocs[:len(options.libraryDocs):len(options.libraryDocs)], requests...)
+	})
+}
+
+// generatesCode reports whether an endpoint generates code, and so accepts
+// library documentation.
+func generatesCode(endpoint string) bool {
+	return strings.HasPrefix(strings.Trim(endpoint, "/"), "ai/generate-code")
+}
+
+// attachLibraryDocs adds the documentation requested with WithLibraryDocs to
+// the context of a code generation request body. Other bodies are returned
+// unchanged.
+func (c *HTTPClient) attachLibraryDocs(ctx context.Context, endpoint string, body []byte) ([]byte, error) {
+	options := requestOptionsFrom(ctx)
+	if options == nil || len(options.libraryDocs) == 0 || !generatesCode(endpoint) {
+		return body, nil
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(body), []byte("{")) {
+		return body, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return body, nil
+	}
+	requestContext := make(map[string]interface{})
+	if raw, ok := fields["context"]; ok && !bytes.Equal(raw, []byte("null")) {
+		if err := json.Unmarshal(raw, &requestContext); err != nil {
+			return nil, NewValidationError("request context must be an object to attach library docs")
+		}
+	}
+
+	var texts []string
+	for _, request := range options.libraryDocs {
+		docs, err := c.fetchDocs(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		texts = append(texts, docs.Text())
+		if c.debug {
+			log.Printf("Attaching %d tokens of %s %s docs to %s", docs.Tokens, docs.Library, docs.Version, endpoint)
+		}
+	}
+	requestContext["library_docs"] = strings.Join(texts, "\n")
+
+	encoded, err := json.Marshal(requestContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to attach library docs: %w", err)
+	}
+	fields["context"] = encoded
+	return CanonicalJSON(fields)
+}
@@ -0,0 +1,213 @@
+package zoptal
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// maxDiffLines bounds the line diff of a draft and its verified version;
+// longer outputs are reported as a single edit.
+const maxDiffLines = 4000
+
+// DraftAndVerifyOptions configures speculative generation: a fast model
+// drafts the code, which is shown as soon as it is ready, and a slower,
+// more accurate model then verifies and corrects it.
+type DraftAndVerifyOptions struct {
+	// DraftModel is the fast model that writes the draft (required)
+	DraftModel string
+
+	// VerifyModel is the accurate model that verifies the draft (required)
+	VerifyModel string
+
+	// OnDraft is called with the draft before verification starts, so that
+	// interactive callers can show it immediately (optional)
+	OnDraft func(draft string)
+}
+
+// DraftEdit is a change the verifying model made to the draft: the draft
+// lines [DraftStart, DraftEnd) were replaced by Replacement.
+type DraftEdit struct {
+	// DraftStart and DraftEnd are 0-based line indexes into the draft
+	DraftStart int
+	DraftEnd   int
+
+	// Replacement is the replacing text, with a trailing newline per line
+	Replacement string
+}
+
+// DraftAndVerifyResult is the outcome of a speculative generation.
+type DraftAndVerifyResult struct {
+	// Draft is the code written by the draft model
+	Draft string
+
+	// Final is the code after verification
+	Final string
+
+	// Edits are the changes verification made to the draft, in order; empty
+	// if the draft was accepted unchanged
+	Edits []DraftEdit
+
+	DraftModel  string
+	VerifyModel string
+
+	// DraftLatency is the time until the draft was ready
+	DraftLatency time.Duration
+
+	// TotalLatency is the time until verification completed
+	TotalLatency time.Duration
+}
+
+// Accepted reports whether verification left the draft unchanged.
+func (r *DraftAndVerifyResult) Accepted() bool {
+	return len(r.Edits) == 0
+}
+
+// DraftAndVerify generates code speculatively: a fast model drafts it and a
+// slower model verifies and edits the draft. Interactive callers get a
+// usable answer at the latency of the draft model, through OnDraft, and the
+// accuracy of the verifying model once verification completes, at the cost
+// of two generations.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - request: Code generation request
+//   - options: Draft and verification models
+//   - opts: Request options (optional)
+//
+// Returns the draft, the verified code, and the edits between them, or an
+// error if either generation fails.
+func (s *AIService) DraftAndVerify(ctx context.Context, request *CodeGenerationRequest, options *DraftAndVerifyOptions, opts ...RequestOption) (*DraftAndVerifyResult, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if request == nil || strings.TrimSpace(request.Prompt) == "" {
+		return nil, NewValidationError("prompt is required")
+	}
+	if options == nil || options.DraftModel == "" || options.VerifyModel == "" {
+		return nil, NewValidationError("draft and verify models are required")
+	}
+
+	start := time.Now()
+	draft, err := s.generateWith(ctx, options.DraftModel, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to draft code: %w", err)
+	}
+	draftLatency := time.Since(start)
+	if options.OnDraft != nil {
+		options.OnDraft(draft)
+	}
+
+	verify := *request
+	verify.Prompt = fmt.Sprintf("Task:\n%s\n\nDraft solution:\n%s\n\n"+
+		"Verify that the draft correctly and completely solves the task. Reply with the complete corrected code. "+
+		"If the draft is correct, reply with it unchanged. Keep the draft's structure and formatting wherever it is correct.",
+		request.Prompt, draft)
+	final, err := s.generateWith(ctx, options.VerifyModel, &verify)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify draft: %w", err)
+	}
+
+	return &DraftAndVerifyResult{
+		Draft:        draft,
+		Final:        final,
+		Edits:        diffLines(draft, final),
+		DraftModel:   options.DraftModel,
+		VerifyModel:  options.VerifyModel,
+		DraftLatency: draftLatency,
+		TotalLatency: time.Since(start),
+	}, nil
+}
+
+// generateWith generates code with a model and returns the complete output.
+func (s *AIService) generateWith(ctx context.Context, model string, request *CodeGenerationRequest) (string, error) {
+	stream, err := s.GenerateCodeStream(WithModel(ctx, model), request)
+	if err != nil {
+		return "", err
+	}
+	defer stream.Close()
+
+	code, err := io.ReadAll(stream.Reader())
+	if err != nil {
+		return "", err
+	}
+	return string(code), nil
+}
+
+// diffLines returns the edits turning a into b, line by line, following a
+// longest common subsequence of their lines.
+func diffLines(a, b string) []DraftEdit {
+	if a == b {
+		return nil
+	}
+	x, y := splitLines(a), splitLines(b)
+	if len(x) > maxDiffLines || len(y) > maxDiffLines {
+		return []DraftEdit{{DraftStart: 0, DraftEnd: len(x), Replacement: b}}
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of x[i:] and y[j:].
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			switch {
+			case x[i] == y[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var edits []DraftEdit
+	var pending *DraftEdit
+	var replacement strings.Builder
+	flush := func() {
+		if pending != nil {
+			pending.Replacement = replacement.String()
+			edits = append(edits, *pending)
+			pending = nil
+			replacement.Reset()
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			flush()
+			i++
+			j++
+		case j < len(y) && (i == len(x) || lcs[i][j+1] >= lcs[i+1][j]):
+			if pending == nil {
+				pending = &DraftEdit{DraftStart: i, DraftEnd: i}
+			}
+			replacement.WriteString(y[j])
+			j++
+		default:
+			if pending == nil {
+				pending = &DraftEdit{DraftStart: i, DraftEnd: i}
+			}
+			i++
+			pending.DraftEnd = i
+		}
+	}
+	flush()
+	return edits
+}
+
+// splitLines splits s into lines, keeping their line endings.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,163 @@
+package zoptal
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// maxFeedbackComment is the longest comment accepted with feedback.
+const maxFeedbackComment = 4000
+
+// Rating is a rating of an AI response, from 1 (worst) to 5 (best).
+type Rating int
+
+// Ratings of thumbs up/down feedback, for products without a rating scale.
+const (
+	RatingThumbsDown Rating = 1
+	RatingThumbsUp   Rating = 5
+)
+
+// FeedbackLabel categorizes what was good or wrong about an AI response.
+type FeedbackLabel string
+
+// Feedback labels. Other labels are accepted, for product-specific categories.
+const (
+	FeedbackHelpful       FeedbackLabel = "helpful"
+	FeedbackIncorrect     FeedbackLabel = "incorrect"
+	FeedbackDoesNotBuild  FeedbackLabel = "does_not_build"
+	FeedbackInsecure      FeedbackLabel = "insecure"
+	FeedbackOutdated      FeedbackLabel = "outdated"
+	FeedbackHallucination FeedbackLabel = "hallucination"
+	FeedbackOffTopic      FeedbackLabel = "off_topic"
+	FeedbackStyle         FeedbackLabel = "style"
+)
+
+// Feedback is a user's assessment of an AI response, reported to improve
+// model quality.
+type Feedback struct {
+	// ResponseID identifies the rated response (required; see ResponseIDOf)
+	ResponseID string `json:"response_id"`
+
+	// Rating is the rating of the response, from 1 to 5 (required)
+	Rating Rating `json:"rating"`
+
+	// Labels categorize the rating (optional)
+	Labels []FeedbackLabel `json:"labels,omitempty"`
+
+	// Comment is the user's comment, up to 4000 characters (optional)
+	Comment string `json:"comment,omitempty"`
+}
+
+// FeedbackFor returns feedback on the response of a prior AI result, with
+// the response ID taken from the result (see ResponseIDOf).
+//
+// Parameters:
+//   - result: Result of an AI call, or the ResponseMetadata captured for it
+//   - rating: Rating of the response
+//   - labels: Labels categorizing the rating (optional)
+//
+// Returns the feedback, to submit with AI.SubmitFeedback after adding a
+// comment if any.
+//
+// Example usage:
+//
+//	var meta zoptal.ResponseMetadata
+//	review, err := client.AI.ReviewDiff(zoptal.WithResponseMetadata(ctx, &meta), request)
+//	...
+//	// Later, when the user dismisses the review as wrong:
+//	feedback := zoptal.FeedbackFor(&meta, zoptal.RatingThumbsDown, zoptal.FeedbackIncorrect)
+//	feedback.Comment = "flagged a nil check that is already there"
+//	err = client.AI.SubmitFeedback(ctx, feedback)
+func FeedbackFor(result interface{}, rating Rating, labels ...FeedbackLabel) *Feedback {
+	return &Feedback{ResponseID: ResponseIDOf(result), Rating: rating, Labels: labels}
+}
+
+// ResponseIDOf returns the response ID of a prior AI result: the ResponseID
+// of a CodeStream, the RequestID of a ResponseMetadata, or else the
+// "response_id" field of the Raw response of a typed result. It returns ""
+// if the result has no response ID.
+//
+// Parameters:
+//   - result: Result of an AI call, or the ResponseMetadata captured for it
+//
+// Returns the response ID.
+func ResponseIDOf(result interface{}) string {
+	switch r := result.(type) {
+	case nil:
+		return ""
+	case interface{ ResponseID() string }:
+		return r.ResponseID()
+	case *ResponseMetadata:
+		if r == nil {
+			return ""
+		}
+		return r.RequestID
+	case map[string]interface{}:
+		id, _ := r["response_id"].(string)
+		return id
+	}
+
+	// Typed results keep the undecoded response in their Raw field.
+	value := reflect.ValueOf(result)
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return ""
+	}
+	field := value.FieldByName("Raw")
+	if !field.IsValid() || !field.CanInterface() {
+		return ""
+	}
+	raw, _ := field.Interface().(map[string]interface{})
+	id, _ := raw["response_id"].(string)
+	return id
+}
+
+// ResponseID returns the ID of the generation, for AI.SubmitFeedback, or ""
+// if the server did not report one.
+func (s *CodeStream) ResponseID() string {
+	return s.requestID
+}
+
+// SubmitFeedback reports a user's rating of an AI response, such as a thumbs
+// up or down on generated code, to close the model quality feedback loop.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - feedback: Feedback to submit, e.g. from FeedbackFor
+//   - opts: Request options (optional)
+//
+// Returns an error if the feedback is invalid or the request fails.
+//
+// Example usage:
+//
+//	err := client.AI.SubmitFeedback(ctx, &zoptal.Feedback{
+//	    ResponseID: stream.ResponseID(),
+//	    Rating:     zoptal.RatingThumbsUp,
+//	    Labels:     []zoptal.FeedbackLabel{zoptal.FeedbackHelpful},
+//	})
+func (s *AIService) SubmitFeedback(ctx context.Context, feedback *Feedback, opts ...RequestOption) error {
+	ctx = WithRequestOptions(ctx, opts...)
+	if feedback == nil || feedback.ResponseID == "" {
+		return NewValidationError("response ID is required; capture it with WithResponseMetadata or use FeedbackFor with the result")
+	}
+	if feedback.Rating < 1 || feedback.Rating > 5 {
+		return NewValidationError("rating must be between 1 and 5")
+	}
+	for _, label := range feedback.Labels {
+		if strings.TrimSpace(string(label)) == "" {
+			return NewValidationError("labels must not be empty")
+		}
+	}
+	if len([]rune(feedback.Comment)) > maxFeedbackComment {
+		return NewValidationError(fmt.Sprintf("comment must be at most %d characters", maxFeedbackComment))
+	}
+
+	if err := s.client.Post(ctx, "/ai/feedback", feedback, nil); err != nil {
+		return fmt.Errorf("failed to submit feedback: %w", err)
+	}
+	return nil
+}
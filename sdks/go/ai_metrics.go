@@ -0,0 +1,192 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MetricsRequest requests code metrics for a snippet of code or for the
+// files of a project. Exactly one of Code and ProjectID is required.
+type MetricsRequest struct {
+	// Code is the code to measure
+	Code string `json:"code,omitempty"`
+
+	// Language is the language of Code; detected if empty (optional)
+	Language string `json:"language,omitempty"`
+
+	// ProjectID is the ID of the project whose files are measured
+	ProjectID string `json:"project_id,omitempty"`
+
+	// Paths restricts a project's files to those matching these glob
+	// patterns, e.g. "internal/**/*.go" (optional)
+	Paths []string `json:"paths,omitempty"`
+}
+
+// FunctionMetrics are the metrics of a function or method.
+type FunctionMetrics struct {
+	Name string `json:"name"`
+
+	// StartLine and EndLine are the 1-based, inclusive line range of the function
+	StartLine int `json:"start_line"`
+	EndLine   int `json:"end_line"`
+
+	// CyclomaticComplexity is the number of independent paths through the function
+	CyclomaticComplexity int `json:"cyclomatic_complexity"`
+
+	// MaintainabilityIndex is in the range [0, 100]; higher is more maintainable
+	MaintainabilityIndex float64 `json:"maintainability_index"`
+
+	Lines int `json:"lines"`
+}
+
+// FileMetrics are the metrics of a file, or of the snippet of a
+// MetricsRequest with Code.
+type FileMetrics struct {
+	// Path is the path of the file; empty for a snippet
+	Path     string `json:"path,omitempty"`
+	Language string `json:"language"`
+
+	// Lines counts all lines; CodeLines excludes blank and comment lines
+	Lines     int `json:"lines"`
+	CodeLines int `json:"code_lines"`
+
+	// CyclomaticComplexity is the highest complexity of the file's functions
+	CyclomaticComplexity int `json:"cyclomatic_complexity"`
+
+	// Duplication is the fraction of code lines duplicated elsewhere, in the range [0, 1]
+	Duplication float64 `json:"duplication"`
+
+	// MaintainabilityIndex is in the range [0, 100]; higher is more maintainable
+	MaintainabilityIndex float64 `json:"maintainability_index"`
+
+	Functions []FunctionMetrics `json:"functions,omitempty"`
+}
+
+// CodeMetrics are the metrics of the measured code.
+type CodeMetrics struct {
+	Files []FileMetrics `json:"files"`
+
+	// Duplication and MaintainabilityIndex summarize all files, weighted by code lines
+	Duplication          float64 `json:"duplication"`
+	MaintainabilityIndex float64 `json:"maintainability_index"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// MetricsThresholds are limits for gating changes on code metrics. Zero
+// fields are not checked.
+type MetricsThresholds struct {
+	// MaxComplexity is the highest cyclomatic complexity allowed per function
+	MaxComplexity int
+
+	// MaxDuplication is the highest duplicated fraction allowed per file
+	MaxDuplication float64
+
+	// MinMaintainability is the lowest maintainability index allowed per function
+	MinMaintainability float64
+}
+
+// MetricsViolation is a file or function exceeding a threshold.
+type MetricsViolation struct {
+	Path string
+
+	// Function is the violating function, or "" for file-level metrics
+	Function string
+	Line     int
+
+	// Metric is "cyclomatic_complexity", "duplication", or "maintainability_index"
+	Metric string
+
+	Value float64
+	Limit float64
+}
+
+// String returns a description of the violation, such as
+// "handler.go:42 serve: cyclomatic_complexity 23 exceeds 15".
+func (v MetricsViolation) String() string {
+	location := v.Path
+	if v.Line > 0 {
+		location = fmt.Sprintf("%s:%d", location, v.Line)
+	}
+	if v.Function != "" {
+		location += " " + v.Function
+	}
+	relation := "exceeds"
+	if v.Metric == "maintainability_index" {
+		relation = "is below"
+	}
+	return fmt.Sprintf("%s: %s %g %s %g", location, v.Metric, v.Value, relation, v.Limit)
+}
+
+// Check returns the files and functions exceeding thresholds, for failing a
+// merge check, in file order.
+func (m *CodeMetrics) Check(thresholds MetricsThresholds) []MetricsViolation {
+	var violations []MetricsViolation
+	for _, file := range m.Files {
+		if thresholds.MaxDuplication > 0 && file.Duplication > thresholds.MaxDuplication {
+			violations = append(violations, MetricsViolation{
+				Path: file.Path, Metric: "duplication",
+				Value: file.Duplication, Limit: thresholds.MaxDuplication,
+			})
+		}
+		for _, fn := range file.Functions {
+			if thresholds.MaxComplexity > 0 && fn.CyclomaticComplexity > thresholds.MaxComplexity {
+				violations = append(violations, MetricsViolation{
+					Path: file.Path, Function: fn.Name, Line: fn.StartLine, Metric: "cyclomatic_complexity",
+					Value: float64(fn.CyclomaticComplexity), Limit: float64(thresholds.MaxComplexity),
+				})
+			}
+			if thresholds.MinMaintainability > 0 && fn.MaintainabilityIndex < thresholds.MinMaintainability {
+				violations = append(violations, MetricsViolation{
+					Path: file.Path, Function: fn.Name, Line: fn.StartLine, Metric: "maintainability_index",
+					Value: fn.MaintainabilityIndex, Limit: thresholds.MinMaintainability,
+				})
+			}
+		}
+	}
+	return violations
+}
+
+// CodeMetrics measures cyclomatic complexity, duplication, and the
+// maintainability index of a snippet of code or of the files of a project,
+// per file and per function.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - request: Code or project to measure
+//   - opts: Request options (optional)
+//
+// Returns the metrics or an error if the request fails.
+//
+// Example usage:
+//
+//	metrics, err := client.AI.CodeMetrics(ctx, &zoptal.MetricsRequest{ProjectID: projectID})
+//	if err != nil {
+//	    return err
+//	}
+//	for _, v := range metrics.Check(zoptal.MetricsThresholds{MaxComplexity: 15}) {
+//	    fmt.Println(v)
+//	}
+func (s *AIService) CodeMetrics(ctx context.Context, request *MetricsRequest, opts ...RequestOption) (*CodeMetrics, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if request == nil || (strings.TrimSpace(request.Code) == "" && request.ProjectID == "") {
+		return nil, NewValidationError("code or project ID is required")
+	}
+	if request.Code != "" && request.ProjectID != "" {
+		return nil, NewValidationError("only one of code and project ID may be set")
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Post(ctx, "/ai/code-metrics", request, &raw); err != nil {
+		return nil, fmt.Errorf("failed to compute code metrics: %w", err)
+	}
+
+	var metrics CodeMetrics
+	if err := decodeTyped(raw, &metrics, &metrics.Raw); err != nil {
+		return nil, fmt.Errorf("failed to compute code metrics: %w", err)
+	}
+	return &metrics, nil
+}
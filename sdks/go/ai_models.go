@@ -0,0 +1,93 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Model capabilities reported in AIModel.Capabilities.
+const (
+	ModelCapabilityText      = "text"
+	ModelCapabilityCode      = "code"
+	ModelCapabilityChat      = "chat"
+	ModelCapabilityReasoning = "reasoning"
+	ModelCapabilityAnalysis  = "analysis"
+	ModelCapabilityVision    = "vision"
+)
+
+// AIModel describes a model available for AI requests.
+type AIModel struct {
+	// ID is the model identifier used in requests, e.g. "gpt-4"
+	ID string `json:"id"`
+
+	// Provider is the provider serving the model, e.g. "openai" or "anthropic"
+	Provider string `json:"provider"`
+
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+
+	// ContextWindow is the maximum number of prompt and output tokens of a request
+	ContextWindow int `json:"context_window"`
+
+	// MaxOutputTokens is the maximum number of output tokens of a request
+	MaxOutputTokens int `json:"max_output_tokens,omitempty"`
+
+	// InputCostPer1K and OutputCostPer1K are the prices in US dollars of
+	// 1000 prompt and output tokens
+	InputCostPer1K  float64 `json:"input_cost_per_1k"`
+	OutputCostPer1K float64 `json:"output_cost_per_1k"`
+
+	// Capabilities lists what the model is suited for (see the ModelCapability constants)
+	Capabilities []string `json:"capabilities"`
+
+	// Status is "available", "degraded", or "unavailable"
+	Status string `json:"status"`
+
+	// Default is true for the model used when a request does not name one
+	Default bool `json:"default,omitempty"`
+
+	// Raw is the undecoded model, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// HasCapability reports whether the model has a capability.
+func (m *AIModel) HasCapability(capability string) bool {
+	for _, c := range m.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// Available reports whether the model can currently serve requests.
+func (m *AIModel) Available() bool {
+	return m.Status == "" || m.Status == "available" || m.Status == "degraded"
+}
+
+// ListModels lists the models available to the authenticated user, with
+// their capabilities, context size, and pricing.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - opts: Request options (optional)
+//
+// Returns the models or an error if the request fails.
+func (s *AIService) ListModels(ctx context.Context, opts ...RequestOption) ([]AIModel, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	var response struct {
+		Models []json.RawMessage `json:"models"`
+	}
+	if err := s.client.Get(ctx, "/ai/models", nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to list models: %w", err)
+	}
+
+	models := make([]AIModel, len(response.Models))
+	for i, raw := range response.Models {
+		if err := decodeTyped(raw, &models[i], &models[i].Raw); err != nil {
+			return nil, fmt.Errorf("failed to list models: %w", err)
+		}
+	}
+	return models, nil
+}
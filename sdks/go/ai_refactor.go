@@ -0,0 +1,83 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// RefactorRequest is a request to refactor code.
+type RefactorRequest struct {
+	// Code is the code to refactor (required)
+	Code string `json:"code"`
+
+	// Goal describes the refactoring, e.g. "extract the retry loop into a
+	// helper function" (required)
+	Goal string `json:"goal"`
+
+	// Language is the language of the code, e.g. "go" (optional)
+	Language string `json:"language,omitempty"`
+
+	// Path is the path of the code's file, used in the headers of the diff,
+	// e.g. "internal/client.go" (optional)
+	Path string `json:"path,omitempty"`
+}
+
+// RefactorResult is refactored code.
+type RefactorResult struct {
+	// Code is the complete refactored code
+	Code string `json:"code"`
+
+	// Diff is the change from the original code in unified diff format,
+	// which FileService.ApplyDiff applies to a file in a project
+	Diff string `json:"diff"`
+
+	// Explanation describes the changes made
+	Explanation string `json:"explanation,omitempty"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// Refactor refactors code toward a goal, returning both the refactored
+// code and a unified diff that can be reviewed and applied.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - request: Refactoring request
+//   - opts: Request options (optional)
+//
+// Returns the refactored code or an error if the request fails.
+//
+// Example usage:
+//
+//	result, err := client.AI.Refactor(ctx, &zoptal.RefactorRequest{
+//	    Code: source,
+//	    Goal: "replace the global logger with an injected one",
+//	    Path: "server/handler.go",
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	_, err = client.Files.ApplyDiff(ctx, projectID, "server/handler.go", result.Diff)
+func (s *AIService) Refactor(ctx context.Context, request *RefactorRequest, opts ...RequestOption) (*RefactorResult, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if request == nil || strings.TrimSpace(request.Code) == "" {
+		return nil, NewValidationError("code is required")
+	}
+	if strings.TrimSpace(request.Goal) == "" {
+		return nil, NewValidationError("refactoring goal is required")
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Post(ctx, "/ai/refactor", request, &raw); err != nil {
+		return nil, fmt.Errorf("failed to refactor code: %w", err)
+	}
+
+	var result RefactorResult
+	if err := decodeTyped(raw, &result, &result.Raw); err != nil {
+		return nil, fmt.Errorf("failed to refactor code: %w", err)
+	}
+	return &result, nil
+}
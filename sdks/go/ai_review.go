@@ -0,0 +1,149 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ReviewSeverity is the severity of a review finding.
+type ReviewSeverity string
+
+// Review severities, from least to most severe.
+const (
+	SeverityInfo     ReviewSeverity = "info"
+	SeverityWarning  ReviewSeverity = "warning"
+	SeverityError    ReviewSeverity = "error"
+	SeverityCritical ReviewSeverity = "critical"
+)
+
+// rank orders severities; unknown severities rank lowest.
+func (s ReviewSeverity) rank() int {
+	switch s {
+	case SeverityWarning:
+		return 1
+	case SeverityError:
+		return 2
+	case SeverityCritical:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// AtLeast reports whether s is as severe as min or more.
+func (s ReviewSeverity) AtLeast(min ReviewSeverity) bool {
+	return s.rank() >= min.rank()
+}
+
+// DiffReviewRequest is a request to review a change.
+type DiffReviewRequest struct {
+	// Diff is the change in unified diff format, e.g. the output of git diff (required)
+	Diff string `json:"diff"`
+
+	// Language is the main language of the change, e.g. "go"; detected
+	// from the file names when empty (optional)
+	Language string `json:"language,omitempty"`
+
+	// Guidelines are team conventions the change is reviewed against, e.g.
+	// "errors must be wrapped with context" (optional)
+	Guidelines []string `json:"guidelines,omitempty"`
+
+	// ProjectID gives the review the context of the project the change
+	// applies to (optional)
+	ProjectID string `json:"project_id,omitempty"`
+
+	// MinSeverity omits findings below this severity (default: SeverityInfo)
+	MinSeverity ReviewSeverity `json:"min_severity,omitempty"`
+}
+
+// ReviewFinding is an issue found in a change.
+type ReviewFinding struct {
+	Severity ReviewSeverity `json:"severity"`
+
+	// Category is the kind of issue, e.g. "bug", "security", "performance", or "style"
+	Category string `json:"category,omitempty"`
+
+	// File is the path of the file, as named in the diff
+	File string `json:"file"`
+
+	// StartLine and EndLine are the 1-based, inclusive line range in the new
+	// version of the file
+	StartLine int `json:"start_line"`
+	EndLine   int `json:"end_line"`
+
+	// Title is a one-line summary of the issue
+	Title string `json:"title"`
+
+	// Message explains the issue
+	Message string `json:"message"`
+
+	// Suggestion is a unified diff fixing the issue, applicable on top of
+	// the reviewed change; empty if there is no mechanical fix
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// DiffReview is the result of a change review.
+type DiffReview struct {
+	// Summary is an overall assessment of the change
+	Summary string `json:"summary"`
+
+	// Findings are the issues found, most severe first
+	Findings []ReviewFinding `json:"findings"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// FindingsAtLeast returns the findings of severity min or higher, for
+// example to fail a CI check on errors.
+func (r *DiffReview) FindingsAtLeast(min ReviewSeverity) []ReviewFinding {
+	var findings []ReviewFinding
+	for _, finding := range r.Findings {
+		if finding.Severity.AtLeast(min) {
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// ReviewDiff reviews a change and returns structured findings, for CI bots
+// that post automated reviews.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - request: Review request
+//   - opts: Request options (optional)
+//
+// Returns the review or an error if the request fails.
+//
+// Example usage:
+//
+//	review, err := client.AI.ReviewDiff(ctx, &zoptal.DiffReviewRequest{
+//	    Diff:       diff,
+//	    Guidelines: []string{"public functions have doc comments"},
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	if blocking := review.FindingsAtLeast(zoptal.SeverityError); len(blocking) > 0 {
+//	    os.Exit(1)
+//	}
+func (s *AIService) ReviewDiff(ctx context.Context, request *DiffReviewRequest, opts ...RequestOption) (*DiffReview, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if request == nil || strings.TrimSpace(request.Diff) == "" {
+		return nil, NewValidationError("diff is required")
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Post(ctx, "/ai/review-diff", request, &raw); err != nil {
+		return nil, fmt.Errorf("failed to review diff: %w", err)
+	}
+
+	var review DiffReview
+	if err := decodeTyped(raw, &review, &review.Raw); err != nil {
+		return nil, fmt.Errorf("failed to review diff: %w", err)
+	}
+	return &review, nil
+}
@@ -0,0 +1,115 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// maxSearchResults is the largest TopK accepted by code search.
+const maxSearchResults = 100
+
+// CodeSearchRequest is a semantic search over the code of a project.
+type CodeSearchRequest struct {
+	// ProjectID is the ID of the project to search (required)
+	ProjectID string `json:"project_id"`
+
+	// Query describes the code to find in natural language or as a code
+	// fragment, e.g. "where are JWT tokens validated" (required)
+	Query string `json:"query"`
+
+	// TopK is the maximum number of hits to return, up to 100 (default: 10)
+	TopK int `json:"top_k,omitempty"`
+
+	// Paths restricts the search to files matching these glob patterns,
+	// e.g. "src/**/*.go" (optional)
+	Paths []string `json:"paths,omitempty"`
+
+	// Languages restricts the search to files in these languages (optional)
+	Languages []string `json:"languages,omitempty"`
+
+	// MinScore drops hits with a lower relevance score, in the range [0, 1] (optional)
+	MinScore float64 `json:"min_score,omitempty"`
+}
+
+// CodeSearchHit is a ranked match of a code search.
+type CodeSearchHit struct {
+	// Path is the path of the matching file within the project
+	Path string `json:"path"`
+
+	// StartLine and EndLine are the 1-based, inclusive line range of the match
+	StartLine int `json:"start_line"`
+	EndLine   int `json:"end_line"`
+
+	// Snippet is the matching code
+	Snippet string `json:"snippet"`
+
+	// Score is the relevance of the match in the range [0, 1]; higher is more relevant
+	Score float64 `json:"score"`
+
+	// Language is the language of the file
+	Language string `json:"language,omitempty"`
+
+	// Symbol is the enclosing function or type, if known
+	Symbol string `json:"symbol,omitempty"`
+}
+
+// CodeSearchResult contains the hits of a code search, most relevant first.
+type CodeSearchResult struct {
+	Hits []CodeSearchHit `json:"hits"`
+
+	// IndexedAt is when the project's search index was last updated; files
+	// changed since then may be missing from the results
+	IndexedAt Timestamp `json:"indexed_at"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// SearchCode searches the code of a project by meaning rather than by exact
+// text, using the server-side index of the project.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - request: Search request
+//   - opts: Request options (optional)
+//
+// Returns the ranked hits or an error if the request fails.
+//
+// Example usage:
+//
+//	result, err := client.AI.SearchCode(ctx, &zoptal.CodeSearchRequest{
+//	    ProjectID: projectID,
+//	    Query:     "where are JWT tokens validated",
+//	    TopK:      5,
+//	})
+//	for _, hit := range result.Hits {
+//	    fmt.Printf("%s:%d (%.2f)\n", hit.Path, hit.StartLine, hit.Score)
+//	}
+func (s *AIService) SearchCode(ctx context.Context, request *CodeSearchRequest, opts ...RequestOption) (*CodeSearchResult, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if request == nil || request.ProjectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+	if strings.TrimSpace(request.Query) == "" {
+		return nil, NewValidationError("query is required")
+	}
+	if request.TopK < 0 || request.TopK > maxSearchResults {
+		return nil, NewValidationError(fmt.Sprintf("top k must be between 1 and %d", maxSearchResults))
+	}
+	if request.MinScore < 0 || request.MinScore > 1 {
+		return nil, NewValidationError("min score must be between 0 and 1")
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Post(ctx, "/ai/search-code", request, &raw); err != nil {
+		return nil, fmt.Errorf("failed to search code: %w", err)
+	}
+
+	var result CodeSearchResult
+	if err := decodeTyped(raw, &result, &result.Raw); err != nil {
+		return nil, fmt.Errorf("failed to search code: %w", err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,174 @@
+package zoptal
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// CodeChunk is a part of the code produced by a streaming code generation.
+type CodeChunk struct {
+	// Delta is the code produced since the previous chunk
+	Delta string `json:"delta"`
+
+	// Index is the position of the chunk in the stream, starting at 0
+	Index int `json:"index"`
+
+	// Done is true for the final chunk
+	Done bool `json:"done"`
+
+	// FinishReason is why generation stopped, e.g. "stop" or "length"; set on the final chunk
+	FinishReason string `json:"finish_reason,omitempty"`
+
+	// Explanation explains the generated code; set on the final chunk
+	Explanation string `json:"explanation,omitempty"`
+}
+
+// CodeStream is a code generation in progress.
+//
+// A CodeStream is a stream: it must be closed when no longer needed. Its
+// methods must not be called concurrently, except Close.
+type CodeStream struct {
+	*streamState
+	events *sseReader
+	code   strings.Builder
+	final  *CodeChunk
+	err    error
+
+	// ctx, client, and requestID report a cancellation of the generation
+	ctx       context.Context
+	client    *HTTPClient
+	requestID string
+}
+
+// GenerateCodeStream generates code from a natural language prompt,
+// delivering the code as it is produced, so that editors can show it
+// before generation completes.
+//
+// Parameters:
+//   - ctx: Context that cancels the generation
+//   - request: Code generation request
+//   - opts: Request options (optional)
+//
+// Returns the stream, which must be closed, or an error if generation
+// cannot be started.
+func (s *AIService) GenerateCodeStream(ctx context.Context, request *CodeGenerationRequest, opts ...RequestOption) (*CodeStream, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if request == nil || strings.TrimSpace(request.Prompt) == "" {
+		return nil, NewValidationError("prompt is required")
+	}
+
+	resp, err := s.client.PostStream(ctx, "/ai/generate-code-stream", request, "text/event-stream")
+	if err != nil {
+		return nil, fmt.Errorf("failed to stream code generation: %w", err)
+	}
+
+	return &CodeStream{
+		streamState: newStreamState(resp.Body, s.client.metrics),
+		events:      newSSEReader(resp.Body),
+		ctx:         ctx,
+		client:      s.client,
+		requestID:   resp.Header.Get("X-Request-ID"),
+	}, nil
+}
+
+// Recv returns the next chunk of code. It returns io.EOF after the final
+// chunk, ErrStreamClosed once the stream is closed, and a *CanceledError if
+// the stream's context was cancelled with a reason (see WithCancelReason).
+func (s *CodeStream) Recv() (*CodeChunk, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	chunk, err := s.recv()
+	if err != nil {
+		switch {
+		case s.isClosed():
+			err = ErrStreamClosed
+		case s.ctx.Err() != nil:
+			err = canceled(s.ctx)
+			s.client.cancelOnServer(s.requestID, CancelReason(s.ctx))
+		}
+		s.err = err
+		s.Close()
+		return nil, err
+	}
+	s.code.WriteString(chunk.Delta)
+	if chunk.Done {
+		s.final = chunk
+		s.err = io.EOF
+		s.Close()
+	}
+	return chunk, nil
+}
+
+// recv reads the next chunk event.
+func (s *CodeStream) recv() (*CodeChunk, error) {
+	for {
+		event, err := s.events.next()
+		if err == io.EOF {
+			return nil, NewAIError("code generation stream ended before the final chunk")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read code generation stream: %w", err)
+		}
+
+		switch event.Event {
+		case "error":
+			return nil, NewAIError(errorMessage(event.Data, "code generation failed", "message", "error"))
+		case "", "chunk", "done":
+			if string(event.Data) == "[DONE]" {
+				return &CodeChunk{Done: true}, nil
+			}
+			var chunk CodeChunk
+			if err := decodeJSON(event.Data, &chunk); err != nil {
+				return nil, err
+			}
+			if event.Event == "done" {
+				chunk.Done = true
+			}
+			return &chunk, nil
+		}
+		// Other events, such as progress notices, are skipped.
+	}
+}
+
+// Code returns the code received so far.
+func (s *CodeStream) Code() string {
+	return s.code.String()
+}
+
+// Final returns the final chunk, with the finish reason and explanation, or
+// nil if it has not been received yet.
+func (s *CodeStream) Final() *CodeChunk {
+	return s.final
+}
+
+// Reader returns an io.Reader of the generated code, receiving chunks from
+// the stream as it is read. The reader returns io.EOF at the end of the
+// code, and the stream's error if generation fails.
+func (s *CodeStream) Reader() io.Reader {
+	return &codeReader{stream: s}
+}
+
+// codeReader adapts a CodeStream to io.Reader.
+type codeReader struct {
+	stream  *CodeStream
+	pending string
+}
+
+// Read implements io.Reader.
+func (r *codeReader) Read(p []byte) (int, error) {
+	for r.pending == "" {
+		chunk, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.pending = chunk.Delta
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
@@ -0,0 +1,230 @@
+package zoptal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuditOutcome is the outcome of an audited call.
+type AuditOutcome string
+
+// Audit outcomes.
+const (
+	AuditSucceeded AuditOutcome = "succeeded"
+	AuditFailed    AuditOutcome = "failed"
+)
+
+// AuditRecord is a structured record of a mutating SDK call: who made it,
+// what it changed, when, and with which result.
+//
+// Records form a hash chain: each record's Hash covers its fields and the
+// Hash of the record before it, in PrevHash, so that a trail with records
+// altered, removed, or reordered no longer verifies.
+type AuditRecord struct {
+	Time time.Time `json:"time"`
+
+	// Actor is the application user the call was made for (see
+	// WithAuditActor and AuditOptions.Actor)
+	Actor string `json:"actor,omitempty"`
+
+	// Credential identifies the credential the call was made with, as
+	// "api-key:" and a fingerprint of the key, or "oauth"
+	Credential string `json:"credential"`
+
+	// Scopes are the scopes of a client derived with WithScopes
+	Scopes []string `json:"scopes,omitempty"`
+
+	// Operation identifies the API operation, as in ResponseMetadata, e.g.
+	// "DELETE /projects/{id}"; Path is the path with the actual IDs
+	Operation string `json:"operation"`
+	Method    string `json:"method"`
+	Path      string `json:"path"`
+
+	Outcome    AuditOutcome `json:"outcome"`
+	StatusCode int          `json:"status_code,omitempty"`
+	RequestID  string       `json:"request_id,omitempty"`
+	Error      string       `json:"error,omitempty"`
+
+	// Duration is the duration of the call, including retries
+	Duration time.Duration `json:"duration_ns"`
+
+	// PrevHash is the Hash of the previous record, or "" for the first
+	PrevHash string `json:"prev_hash,omitempty"`
+
+	// Hash is the hex-encoded SHA-256 of the record's other fields
+	Hash string `json:"hash"`
+}
+
+// ComputeHash returns the hash of the record's fields other than Hash, for
+// verifying a trail: a record is intact if its Hash equals ComputeHash and
+// its PrevHash equals the Hash of the record before it.
+func (r AuditRecord) ComputeHash() string {
+	r.Hash = ""
+	data, err := CanonicalJSON(r)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// AuditSink receives the audit records of a client. The zoptalaudit package
+// provides sinks writing JSON Lines files and OpenTelemetry log records.
+//
+// WriteAudit is called once per mutating call, after it completes, and never
+// concurrently. ctx is the context of the call, which may already be done;
+// use it for values such as trace IDs rather than for cancellation.
+type AuditSink interface {
+	WriteAudit(ctx context.Context, record AuditRecord) error
+}
+
+// AuditOptions enables a local audit trail of the mutating calls made with
+// a client (POST, PUT, PATCH, and DELETE requests other than AI requests,
+// and the execution of code lens actions), so that applications can keep
+// their own record of the actions they performed through Zoptal.
+type AuditOptions struct {
+	// Sink receives the records (required)
+	Sink AuditSink
+
+	// Actor is the actor of calls whose context has none (optional)
+	Actor string
+
+	// PrevHash is the Hash of the last record of an existing trail, to
+	// continue its chain (optional)
+	PrevHash string
+
+	// OnError is called when the sink fails to write a record; the call
+	// itself is unaffected. Failed records are not chained (default: the
+	// error is logged in debug mode)
+	OnError func(record AuditRecord, err error)
+}
+
+// auditActorKey is the context key of the audit actor.
+type auditActorKey struct{}
+
+// WithAuditActor returns a context whose mutating calls are recorded in the
+// audit trail as made for actor, such as the ID of the application user on
+// whose behalf the call is made.
+//
+// Parameters:
+//   - ctx: Parent context
+//   - actor: Actor to record
+//
+// Returns the derived context.
+func WithAuditActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, actor)
+}
+
+// auditor chains audit records and writes them to the sink.
+type auditor struct {
+	options AuditOptions
+	debug   bool
+
+	mu       sync.Mutex
+	prevHash string
+}
+
+// newAuditor creates an auditor, or returns nil if auditing is disabled.
+func newAuditor(options *AuditOptions, debug bool) *auditor {
+	if options == nil || options.Sink == nil {
+		return nil
+	}
+	return &auditor{options: *options, debug: debug, prevHash: options.PrevHash}
+}
+
+// write chains a record and writes it to the sink.
+func (a *auditor) write(ctx context.Context, record AuditRecord) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	record.PrevHash = a.prevHash
+	record.Hash = record.ComputeHash()
+	if err := a.options.Sink.WriteAudit(ctx, record); err != nil {
+		if a.options.OnError != nil {
+			a.options.OnError(record, err)
+		} else if a.debug {
+			log.Printf("Zoptal audit sink failed for %s: %v", record.Operation, err)
+		}
+		return
+	}
+	a.prevHash = record.Hash
+}
+
+// audited runs a call, recording it in the audit trail if it mutates.
+func (c *HTTPClient) audited(ctx context.Context, method, endpoint string, call func(ctx context.Context) error) error {
+	if c.audit == nil || !isMutation(method, endpoint) {
+		return call(ctx)
+	}
+
+	recorder, ok := ctx.Value(metadataKey{}).(*metadataRecorder)
+	if !ok {
+		ctx = WithResponseMetadata(ctx, &ResponseMetadata{})
+		recorder = ctx.Value(metadataKey{}).(*metadataRecorder)
+	}
+	start := time.Now()
+	err := call(ctx)
+
+	recorder.mu.Lock()
+	meta := *recorder.meta
+	recorder.mu.Unlock()
+
+	record := AuditRecord{
+		Time:       start.UTC(),
+		Credential: c.credentialID(),
+		Operation:  meta.Operation,
+		Method:     method,
+		Path:       "/" + endpointPath(endpoint),
+		Outcome:    AuditSucceeded,
+		StatusCode: meta.StatusCode,
+		RequestID:  meta.RequestID,
+		Duration:   time.Since(start),
+	}
+	if actor, _ := ctx.Value(auditActorKey{}).(string); actor != "" {
+		record.Actor = actor
+	} else {
+		record.Actor = c.audit.options.Actor
+	}
+	if c.scopes != nil {
+		record.Scopes = c.scopes.names
+	}
+	if record.Operation == "" {
+		record.Operation = method + " " + record.Path
+	}
+	if err != nil {
+		record.Outcome = AuditFailed
+		record.Error = truncateMessage(err.Error())
+	}
+	c.audit.write(ctx, record)
+	return err
+}
+
+// credentialID identifies the client's credential without revealing it.
+func (c *HTTPClient) credentialID() string {
+	if c.tokens != nil {
+		return "oauth"
+	}
+	sum := sha256.Sum256([]byte(c.apiKey))
+	return "api-key:" + hex.EncodeToString(sum[:6])
+}
+
+// isMutation reports whether a request changes state, as those requiring a
+// write scope do, and AI requests with side effects, such as executing the
+// action of a code lens.
+func isMutation(method, endpoint string) bool {
+	if method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions {
+		return false
+	}
+	return strings.HasSuffix(requiredScope(method, endpoint), ":write") || isAIAction(endpoint)
+}
+
+// isAIAction reports whether an endpoint executes the action of a code lens.
+func isAIAction(endpoint string) bool {
+	parts := strings.Split(endpointPath(endpoint), "/")
+	return len(parts) == 4 && parts[0] == "ai" && parts[1] == "actions" && parts[3] == "execute"
+}
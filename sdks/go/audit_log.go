@@ -0,0 +1,300 @@
+package zoptal
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// AuditExportFormat is the format of an audit log export.
+type AuditExportFormat string
+
+// Supported audit log export formats.
+const (
+	// AuditExportJSONL is one JSON object per line, as received from the API
+	AuditExportJSONL AuditExportFormat = "jsonl"
+
+	// AuditExportCSV is CSV with a header row; see auditCSVHeader
+	AuditExportCSV AuditExportFormat = "csv"
+)
+
+// auditCSVHeader is the header row of CSV exports.
+var auditCSVHeader = []string{
+	"id", "time", "actor", "actor_type", "action", "resource_type", "resource_id",
+	"outcome", "ip_address", "user_agent", "request_id", "metadata",
+}
+
+// AuditService queries the audit log of the organization: the activity of
+// its members and API keys as recorded by Zoptal, for compliance reviews and
+// for pulling into a SIEM.
+//
+// Unlike the AuditSink of AuditOptions, which records the calls made by this
+// client, the audit log covers activity from all clients and the Zoptal app.
+type AuditService struct {
+	client *HTTPClient
+}
+
+// AuditEvent is an event of the audit log.
+type AuditEvent struct {
+	ID string `json:"id"`
+
+	// Time is when the event occurred
+	Time Timestamp `json:"time"`
+
+	// Actor is the ID of the user or API key that acted, and ActorType is
+	// "user", "api_key", or "system"
+	Actor     string `json:"actor"`
+	ActorType string `json:"actor_type,omitempty"`
+
+	// Action is what was done, e.g. "project.delete" or "member.invite"
+	Action string `json:"action"`
+
+	// ResourceType and ResourceID identify the resource acted on, if any
+	ResourceType string `json:"resource_type,omitempty"`
+	ResourceID   string `json:"resource_id,omitempty"`
+
+	// Outcome is "succeeded" or "failed"
+	Outcome string `json:"outcome,omitempty"`
+
+	IPAddress string `json:"ip_address,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+
+	// Metadata holds action-specific details, e.g. the role of an invitation
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+
+	// Raw is the undecoded event, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// AuditQuery contains filters for querying the audit log.
+type AuditQuery struct {
+	// Actor filters to the events of a user or API key ID (optional)
+	Actor string
+
+	// Action filters to an action, e.g. "project.delete", or to a prefix
+	// ending in ".", e.g. "project." (optional)
+	Action string
+
+	// TimeRange filters to the events in a time range (optional)
+	TimeRange TimeRange
+
+	// Pagination selects the page of results (optional); Export starts at
+	// its page and continues to the last page
+	Pagination *Pagination
+}
+
+// AuditEventList is a page of audit events, newest first.
+type AuditEventList struct {
+	Events []AuditEvent `json:"-"`
+	Total  int          `json:"total"`
+	Page   int          `json:"page"`
+	Pages  int          `json:"pages"`
+}
+
+// AuditExportResult summarizes an audit log export.
+type AuditExportResult struct {
+	Format AuditExportFormat
+	Events int
+}
+
+// List lists a page of audit events, newest first.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - query: Filters (can be nil to list all events)
+//   - opts: Request options (optional)
+//
+// Returns a page of events or an error if the request fails.
+//
+// Example usage:
+//
+//	events, err := client.Audit.List(ctx, &zoptal.AuditQuery{
+//	    Action:    "project.delete",
+//	    TimeRange: zoptal.TimeRange{From: time.Now().AddDate(0, 0, -7)},
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	for _, event := range events.Events {
+//	    fmt.Printf("%s %s deleted %s\n", event.Time, event.Actor, event.ResourceID)
+//	}
+func (s *AuditService) List(ctx context.Context, query *AuditQuery, opts ...RequestOption) (*AuditEventList, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if query == nil {
+		query = &AuditQuery{}
+	}
+	if err := query.TimeRange.validate(); err != nil {
+		return nil, err
+	}
+
+	list, _, err := s.list(ctx, query, query.Pagination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+	return list, nil
+}
+
+// Export writes the audit events matching a query to w, newest first as in
+// List, fetching page after page until the last one.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - query: Filters (can be nil to export all events)
+//   - w: Destination for the export
+//   - format: Export format (AuditExportJSONL or AuditExportCSV)
+//   - opts: Request options (optional)
+//
+// Returns a summary of the export or an error if a request fails. On error,
+// w holds the events of the pages fetched so far.
+//
+// Example usage:
+//
+//	f, err := os.Create("audit.jsonl")
+//	if err != nil {
+//	    return err
+//	}
+//	defer f.Close()
+//	result, err := client.Audit.Export(ctx, &zoptal.AuditQuery{
+//	    TimeRange: zoptal.TimeRange{From: lastExport, To: time.Now()},
+//	}, f, zoptal.AuditExportJSONL)
+func (s *AuditService) Export(ctx context.Context, query *AuditQuery, w io.Writer, format AuditExportFormat, opts ...RequestOption) (*AuditExportResult, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if query == nil {
+		query = &AuditQuery{}
+	}
+	if format != AuditExportJSONL && format != AuditExportCSV {
+		return nil, NewValidationError(fmt.Sprintf("unsupported audit export format %q", format))
+	}
+	if w == nil {
+		return nil, NewValidationError("writer is required")
+	}
+	if err := query.TimeRange.validate(); err != nil {
+		return nil, err
+	}
+
+	buffered := bufio.NewWriter(w)
+	var csvWriter *csv.Writer
+	if format == AuditExportCSV {
+		csvWriter = csv.NewWriter(buffered)
+		if err := csvWriter.Write(auditCSVHeader); err != nil {
+			return nil, fmt.Errorf("failed to export audit events: %w", err)
+		}
+	}
+
+	// Pages are fetched at the largest page size to keep the number of
+	// requests down for long time ranges.
+	page := Pagination{Page: 1, Limit: 100}
+	if query.Pagination != nil && query.Pagination.Page > 0 {
+		page.Page = query.Pagination.Page
+	}
+	result := &AuditExportResult{Format: format}
+	for {
+		list, raws, err := s.list(ctx, query, &page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export audit events: %w", err)
+		}
+		for i := range list.Events {
+			if csvWriter != nil {
+				err = csvWriter.Write(list.Events[i].csvRecord())
+			} else {
+				_, err = fmt.Fprintf(buffered, "%s\n", raws[i])
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to export audit events: %w", err)
+			}
+		}
+		result.Events += len(list.Events)
+
+		// Flush each page, so that an interrupted export keeps whole pages.
+		if csvWriter != nil {
+			csvWriter.Flush()
+			err = csvWriter.Error()
+		}
+		if err == nil {
+			err = buffered.Flush()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to export audit events: %w", err)
+		}
+
+		if len(list.Events) == 0 || list.Page >= list.Pages {
+			return result, nil
+		}
+		page.Page = list.Page + 1
+	}
+}
+
+// list fetches a page of audit events, returning the compacted JSON of each
+// event along with the list.
+func (s *AuditService) list(ctx context.Context, query *AuditQuery, pagination *Pagination) (*AuditEventList, [][]byte, error) {
+	params := NewQuery().TimeRange("time", query.TimeRange.From, query.TimeRange.To)
+	if query.Actor != "" {
+		params.Set("actor", query.Actor)
+	}
+	if query.Action != "" {
+		params.Set("action", query.Action)
+	}
+	pagination.apply(params)
+
+	var response struct {
+		AuditEventList
+		Events []json.RawMessage `json:"events"`
+	}
+	if err := s.client.GetQuery(ctx, "/audit/events", params, &response); err != nil {
+		return nil, nil, err
+	}
+
+	list := response.AuditEventList
+	list.Events = make([]AuditEvent, len(response.Events))
+	raws := make([][]byte, len(response.Events))
+	for i, raw := range response.Events {
+		if err := decodeTyped(raw, &list.Events[i], &list.Events[i].Raw); err != nil {
+			return nil, nil, err
+		}
+		// Events are compacted so that each takes exactly one line of a
+		// JSONL export.
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, raw); err != nil {
+			return nil, nil, err
+		}
+		raws[i] = compacted.Bytes()
+	}
+	return &list, raws, nil
+}
+
+// csvRecord returns the event as a row of a CSV export, in the columns of
+// auditCSVHeader.
+func (e *AuditEvent) csvRecord() []string {
+	var when, metadata string
+	if !e.Time.IsZero() {
+		when = e.Time.UTC().Format(time.RFC3339Nano)
+	}
+	if len(e.Metadata) > 0 {
+		if data, err := json.Marshal(e.Metadata); err == nil {
+			metadata = string(data)
+		}
+	}
+	record := []string{
+		e.ID, when, e.Actor, e.ActorType, e.Action, e.ResourceType, e.ResourceID,
+		e.Outcome, e.IPAddress, e.UserAgent, e.RequestID, metadata,
+	}
+	for i, field := range record {
+		record[i] = csvSafe(field)
+	}
+	return record
+}
+
+// csvSafe prevents a field, such as a user agent, from being evaluated as a
+// formula when a CSV export is opened in a spreadsheet.
+func csvSafe(field string) string {
+	if field != "" && (field[0] == '=' || field[0] == '+' || field[0] == '-' || field[0] == '@') {
+		return "'" + field
+	}
+	return field
+}
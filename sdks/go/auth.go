@@ -0,0 +1,7 @@
+package zoptal
+
+// AuthService handles the authentication and authorization of the client's
+// credentials, such as introspecting the permissions they grant.
+type AuthService struct {
+	client *HTTPClient
+}
@@ -0,0 +1,175 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// PermissionAction is an action on a resource, for CheckPermission.
+type PermissionAction string
+
+// Permission actions.
+const (
+	PermissionRead   PermissionAction = "read"
+	PermissionCreate PermissionAction = "create"
+	PermissionUpdate PermissionAction = "update"
+	PermissionDelete PermissionAction = "delete"
+)
+
+// Permissions are the permissions of the authenticated user or API key.
+type Permissions struct {
+	// Scopes are the scopes granted, narrowed to those of the client if it
+	// was derived with WithScopes
+	Scopes []Scope `json:"scopes"`
+
+	// Role is the role of the user in the organization requests act on
+	Role OrgRole `json:"role,omitempty"`
+
+	// OrgID is the ID of the organization requests act on
+	OrgID string `json:"org_id,omitempty"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// Grants reports whether the permissions grant a scope, directly or through
+// the bare resource scope, e.g. "projects" for "projects:write".
+func (p *Permissions) Grants(scope Scope) bool {
+	return newScopeSet(p.Scopes).grants(string(scope))
+}
+
+// PermissionCheck is the answer to whether an action is allowed.
+type PermissionCheck struct {
+	Allowed bool `json:"allowed"`
+
+	// Scope is the scope the action requires
+	Scope Scope `json:"scope,omitempty"`
+
+	// Reason explains a refusal, e.g. "role viewer cannot delete projects"
+	Reason string `json:"reason,omitempty"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// ListPermissions lists the scopes and role of the authenticated user or API
+// key. For a client derived with WithScopes, only the scopes the client was
+// also granted are listed.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - opts: Request options (optional)
+//
+// Returns the permissions or an error if the request fails.
+//
+// Example usage:
+//
+//	permissions, err := client.Auth.ListPermissions(ctx)
+//	if err != nil {
+//	    return err
+//	}
+//	if !permissions.Grants(zoptal.ScopeSecurityWrite) {
+//	    log.Println("security scans disabled: API key lacks security:write")
+//	}
+func (s *AuthService) ListPermissions(ctx context.Context, opts ...RequestOption) (*Permissions, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	var raw json.RawMessage
+	if err := s.client.Get(ctx, "/permissions", nil, &raw); err != nil {
+		return nil, fmt.Errorf("failed to list permissions: %w", err)
+	}
+
+	var permissions Permissions
+	if err := decodeTyped(raw, &permissions, &permissions.Raw); err != nil {
+		return nil, fmt.Errorf("failed to list permissions: %w", err)
+	}
+	if s.client.scopes != nil {
+		// Intersect, keeping e.g. "projects:read" of the client when the
+		// credentials grant "projects".
+		granted := newScopeSet(permissions.Scopes)
+		effective := s.client.scopes.narrow(permissions.Scopes)
+		for _, name := range s.client.scopes.names {
+			if granted.grants(name) {
+				effective = append(effective, Scope(name))
+			}
+		}
+		permissions.Scopes = make([]Scope, 0, len(effective))
+		for _, name := range newScopeSet(effective).names {
+			permissions.Scopes = append(permissions.Scopes, Scope(name))
+		}
+	}
+	return &permissions, nil
+}
+
+// CheckPermission checks whether an action on a resource is allowed, so
+// that an operation can be pre-flighted, or its control disabled in a user
+// interface, before it is attempted. The check considers both the scopes of
+// the credentials and the role of the user; for a client derived with
+// WithScopes, an action outside the client's scopes is refused without a
+// request.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - resource: Path of the resource, as in the API, e.g. "projects/proj_123"
+//     or "projects" for creating projects
+//   - action: Action on the resource
+//   - opts: Request options (optional)
+//
+// Returns the answer or an error if the request fails; a refusal is not an
+// error.
+//
+// Example usage:
+//
+//	check, err := client.Auth.CheckPermission(ctx, "projects/"+projectID, zoptal.PermissionDelete)
+//	if err != nil {
+//	    return err
+//	}
+//	if !check.Allowed {
+//	    return fmt.Errorf("cannot delete project: %s", check.Reason)
+//	}
+func (s *AuthService) CheckPermission(ctx context.Context, resource string, action PermissionAction, opts ...RequestOption) (*PermissionCheck, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	resource = endpointPath(resource)
+	if resource == "" {
+		return nil, NewValidationError("resource is required")
+	}
+	if action == "" {
+		return nil, NewValidationError("action is required")
+	}
+
+	method := http.MethodPost
+	switch action {
+	case PermissionRead:
+		method = http.MethodGet
+	case PermissionUpdate:
+		method = http.MethodPatch
+	case PermissionDelete:
+		method = http.MethodDelete
+	}
+	scope := requiredScope(method, resource)
+	if s.client.scopes != nil && scope != "" && !s.client.scopes.grants(scope) {
+		return &PermissionCheck{
+			Scope:  Scope(scope),
+			Reason: fmt.Sprintf("the client was not granted scope %q", scope),
+		}, nil
+	}
+
+	data := map[string]interface{}{
+		"resource": resource,
+		"action":   action,
+	}
+	var raw json.RawMessage
+	if err := s.client.Post(ctx, "/permissions/check", data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to check permission to %s %s: %w", action, resource, err)
+	}
+
+	var check PermissionCheck
+	if err := decodeTyped(raw, &check, &check.Raw); err != nil {
+		return nil, fmt.Errorf("failed to check permission to %s %s: %w", action, resource, err)
+	}
+	if check.Scope == "" {
+		check.Scope = Scope(scope)
+	}
+	return &check, nil
+}
@@ -0,0 +1,250 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Health statuses reported by Client.Health.
+const (
+	HealthOK        = "ok"
+	HealthDegraded  = "degraded"
+	HealthUnhealthy = "unhealthy"
+)
+
+// BackgroundOptions contains options for running a client as part of a
+// long-lived daemon.
+type BackgroundOptions struct {
+	// HealthAddr is the local address of an HTTP server exposing /healthz,
+	// which reports Client.Health with status 503 when unhealthy, and
+	// /stats, which reports Client.Stats and Client.RuntimeMetrics, e.g.
+	// "127.0.0.1:9090" (optional)
+	HealthAddr string
+
+	// JanitorInterval is how often expired entries are removed from the
+	// response cache and the cache of last-known AI results (default: 5
+	// minutes)
+	JanitorInterval time.Duration
+
+	// CacheMaxAge is how long responses are kept in ClientOptions.Cache by
+	// the janitor; it applies to caches with a Prune method, such as
+	// MemoryCache (default: 24 hours)
+	CacheMaxAge time.Duration
+}
+
+// HealthReport is the health of a client.
+type HealthReport struct {
+	// Status is HealthOK, HealthDegraded while the AI service is down (see
+	// DegradationOptions), or HealthUnhealthy while the OAuth token has
+	// expired and cannot be refreshed
+	Status string `json:"status"`
+
+	// Endpoint is the base URL requests are sent to (see ActiveEndpoint)
+	Endpoint string `json:"endpoint"`
+
+	Degraded       bool `json:"degraded"`
+	QueuedRequests int  `json:"queued_requests"`
+
+	// TokenExpiresAt is when the current OAuth token expires, if any
+	TokenExpiresAt *time.Time `json:"token_expires_at,omitempty"`
+
+	Time time.Time `json:"time"`
+}
+
+// pruner is implemented by response caches whose old entries can be removed.
+type pruner interface {
+	Prune(olderThan time.Time) int
+}
+
+// StartBackground runs the periodic maintenance of a long-lived client until
+// ctx is done or the client is closed: it removes expired cache entries,
+// sends the requests queued while the AI service was down as soon as the
+// API is reachable again, and optionally serves health and stats endpoints
+// for the daemon's supervisor. OAuth tokens are refreshed in the background
+// for the client's whole lifetime, with or without StartBackground.
+//
+// Parameters:
+//   - ctx: Context bounding the background work
+//   - options: Background options
+//
+// Returns an error if the health server cannot listen on HealthAddr or
+// background work is already running.
+//
+// Example usage:
+//
+//	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+//	defer stop()
+//	if err := client.StartBackground(ctx, zoptal.BackgroundOptions{HealthAddr: "127.0.0.1:9090"}); err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Client) StartBackground(ctx context.Context, options BackgroundOptions) error {
+	if !c.background.CompareAndSwap(false, true) {
+		return NewValidationError("background work is already running")
+	}
+	if options.JanitorInterval <= 0 {
+		options.JanitorInterval = 5 * time.Minute
+	}
+	if options.CacheMaxAge <= 0 {
+		options.CacheMaxAge = 24 * time.Hour
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-c.done:
+			cancel()
+		}
+	}()
+
+	if options.HealthAddr != "" {
+		listener, err := net.Listen("tcp", options.HealthAddr)
+		if err != nil {
+			cancel()
+			c.background.Store(false)
+			return fmt.Errorf("failed to start health server: %w", err)
+		}
+		go c.serveHealth(ctx, listener)
+	}
+	go c.runJanitor(ctx, options)
+	if d := c.httpClient.degradation; d != nil {
+		go c.drainQueue(ctx, d.retryInterval)
+	}
+	go func() {
+		<-ctx.Done()
+		c.background.Store(false)
+	}()
+	return nil
+}
+
+// Health reports the health of the client.
+//
+// Returns the health report.
+func (c *Client) Health() HealthReport {
+	report := HealthReport{
+		Status:   HealthOK,
+		Endpoint: c.ActiveEndpoint(),
+		Time:     time.Now(),
+	}
+	if d := c.httpClient.degradation; d != nil {
+		d.mu.Lock()
+		report.Degraded, report.QueuedRequests = d.degraded, len(d.queue)
+		d.mu.Unlock()
+		if report.Degraded {
+			report.Status = HealthDegraded
+		}
+	}
+	if m := c.httpClient.tokens; m != nil {
+		m.mu.Lock()
+		token := m.token
+		m.mu.Unlock()
+		if token != nil && !token.ExpiresAt.IsZero() {
+			expiresAt := token.ExpiresAt
+			report.TokenExpiresAt = &expiresAt
+			if time.Now().After(expiresAt) {
+				report.Status = HealthUnhealthy
+			}
+		}
+	}
+	return report
+}
+
+// serveHealth serves the health and stats endpoints until ctx is done.
+func (c *Client) serveHealth(ctx context.Context, listener net.Listener) {
+	defer c.httpClient.metrics.track(GoroutineWorkers)()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		report := c.Health()
+		status := http.StatusOK
+		if report.Status == HealthUnhealthy {
+			status = http.StatusServiceUnavailable
+		}
+		writeJSON(w, status, report)
+	})
+	mux.HandleFunc("/stats", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"traffic": c.Stats(),
+			"runtime": c.RuntimeMetrics(),
+		})
+	})
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) && c.debug {
+		log.Printf("Zoptal health server failed: %v", err)
+	}
+}
+
+// writeJSON writes a JSON response.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// runJanitor removes expired cache entries every interval until ctx is done.
+func (c *Client) runJanitor(ctx context.Context, options BackgroundOptions) {
+	defer c.httpClient.metrics.track(GoroutinePollers)()
+
+	ticker := time.NewTicker(options.JanitorInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		pruned := 0
+		if cache, ok := c.httpClient.cache.(pruner); ok {
+			pruned += cache.Prune(time.Now().Add(-options.CacheMaxAge))
+		}
+		if d := c.httpClient.degradation; d != nil {
+			if cache, ok := d.cache.(pruner); ok {
+				pruned += cache.Prune(time.Now().Add(-d.maxAge))
+			}
+		}
+		if c.debug && pruned > 0 {
+			log.Printf("Zoptal cache janitor removed %d expired entries", pruned)
+		}
+	}
+}
+
+// drainQueue checks every interval, while the client is degraded, whether
+// the API is reachable again, and if so leaves degraded mode and sends the
+// queued requests, until ctx is done.
+func (c *Client) drainQueue(ctx context.Context, interval time.Duration) {
+	d := c.httpClient.degradation
+	defer c.httpClient.metrics.track(GoroutinePollers)()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		if !d.isDegraded() || d.bypass() || !c.probe(c.ActiveEndpoint()) {
+			continue
+		}
+		if queue := d.succeeded(); len(queue) > 0 {
+			c.httpClient.replay(queue)
+		}
+	}
+}
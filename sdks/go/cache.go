@@ -0,0 +1,241 @@
+package zoptal
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResponseCache stores GET responses so they can be revalidated with
+// conditional requests.
+//
+// Implementations must be safe for concurrent use. NewMemoryCache provides an
+// in-memory implementation; other backends can be plugged in through
+// ClientOptions.Cache.
+type ResponseCache interface {
+	// Get returns the cached response for key, if any.
+	Get(key string) (*CachedResponse, bool)
+
+	// Set stores a response under key.
+	Set(key string, entry *CachedResponse)
+
+	// Delete removes the response stored under key.
+	Delete(key string)
+}
+
+// CachedResponse is a GET response body with its validators.
+type CachedResponse struct {
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	ContentType  string    `json:"content_type,omitempty"`
+	Link         string    `json:"link,omitempty"`
+	Body         []byte    `json:"body"`
+	StoredAt     time.Time `json:"stored_at"`
+}
+
+// MemoryCache is an in-memory ResponseCache with least-recently-used eviction.
+type MemoryCache struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+// memoryCacheItem is a single entry in a MemoryCache.
+type memoryCacheItem struct {
+	key   string
+	entry *CachedResponse
+}
+
+// NewMemoryCache creates an in-memory response cache.
+//
+// Parameters:
+//   - maxEntries: Maximum number of responses to keep (default: 1000 if <= 0)
+//
+// Returns a new MemoryCache instance.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	if maxEntries <= 0 {
+		maxEntries = 1000
+	}
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// Get implements ResponseCache.
+func (c *MemoryCache) Get(key string) (*CachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*memoryCacheItem).entry, true
+}
+
+// Set implements ResponseCache.
+func (c *MemoryCache) Set(key string, entry *CachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*memoryCacheItem).entry = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&memoryCacheItem{key: key, entry: entry})
+	for c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryCacheItem).key)
+	}
+}
+
+// Delete implements ResponseCache.
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+	}
+}
+
+// Prune removes the responses stored before olderThan.
+//
+// Parameters:
+//   - olderThan: Cutoff time
+//
+// Returns the number of removed responses.
+func (c *MemoryCache) Prune(olderThan time.Time) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pruned := 0
+	for key, elem := range c.entries {
+		if elem.Value.(*memoryCacheItem).entry.StoredAt.Before(olderThan) {
+			c.order.Remove(elem)
+			delete(c.entries, key)
+			pruned++
+		}
+	}
+	return pruned
+}
+
+// Len returns the number of cached responses.
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// cacheKey returns the cache key for a request. The key includes a
+// fingerprint of the principal and organization of the request so that
+// clients sharing a cache never see each other's responses.
+func (c *HTTPClient) cacheKey(req *http.Request) (string, error) {
+	principal, err := c.principal(req.Context(), req.Header.Get("X-Zoptal-Organization"))
+	if err != nil {
+		return "", err
+	}
+	return principal + " " + req.URL.String(), nil
+}
+
+// principal returns a fingerprint of the principal requests are made as,
+// and the organization they are made in, without revealing the credential.
+// The principal of an OAuth client is the subject of its token or, without
+// one, the access token.
+func (c *HTTPClient) principal(ctx context.Context, org string) (string, error) {
+	id := "api-key\x00" + c.apiKey
+	if c.tokens != nil {
+		token, err := c.tokens.currentToken(ctx)
+		if err != nil {
+			return "", err
+		}
+		id = "token\x00" + token.AccessToken
+		if token.Subject != "" {
+			id = "subject\x00" + token.Subject
+		}
+	}
+	sum := sha256.Sum256([]byte(id + "\x00" + org))
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+// doCached sends a GET request, revalidating any cached response with
+// If-None-Match / If-Modified-Since and serving the cached body on 304.
+func (c *HTTPClient) doCached(req *http.Request) (*http.Response, error) {
+	key, err := c.cacheKey(req)
+	if err != nil {
+		return nil, err
+	}
+	cached, ok := c.cache.Get(key)
+	if ok && c.swr != nil {
+		if resp := c.serveStale(req, key, cached); resp != nil {
+			return resp, nil
+		}
+	}
+	if ok {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && ok {
+		resp.Body.Close()
+		resp.StatusCode = http.StatusOK
+		resp.Status = "200 OK"
+		resp.Body = io.NopCloser(bytes.NewReader(cached.Body))
+		resp.ContentLength = int64(len(cached.Body))
+		if cached.Link != "" && resp.Header.Get("Link") == "" {
+			resp.Header.Set("Link", cached.Link)
+		}
+		return resp, nil
+	}
+
+	etag := resp.Header.Get("ETag")
+	lastModified := resp.Header.Get("Last-Modified")
+	stale := c.swr != nil && c.swr.maxStaleFor(c.endpointOf(req.URL)) > 0
+	if resp.StatusCode != http.StatusOK || (etag == "" && lastModified == "" && !stale) {
+		return resp, nil
+	}
+
+	body, buffered, err := bufferBody(resp, defaultMaxResponseBytes)
+	if err != nil {
+		return nil, err
+	}
+	if !buffered {
+		// Streamed responses, such as downloads, are too large to cache.
+		return resp, nil
+	}
+
+	c.cache.Set(key, &CachedResponse{
+		ETag:         etag,
+		LastModified: lastModified,
+		ContentType:  resp.Header.Get("Content-Type"),
+		Link:         strings.Join(resp.Header.Values("Link"), ", "),
+		Body:         body,
+		StoredAt:     time.Now(),
+	})
+	return resp, nil
+}
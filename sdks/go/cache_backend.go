@@ -0,0 +1,150 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrCacheMiss is returned by CacheBackend.Get when a key is not present.
+var ErrCacheMiss = errors.New("zoptal: cache miss")
+
+// CacheBackend is a shared key/value store, such as Redis or memcached,
+// that lets several replicas of a service share SDK state.
+//
+// Implementations must be safe for concurrent use. Adapters for Redis and
+// memcached clients are provided by the zoptalcache package.
+type CacheBackend interface {
+	// Get returns the value stored under key, or ErrCacheMiss.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Set stores value under key, expiring it after ttl (no expiry if ttl <= 0).
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes the value stored under key.
+	Delete(ctx context.Context, key string) error
+}
+
+// CounterBackend is a CacheBackend that also supports atomic counters, used
+// to share rate-limit state between replicas.
+type CounterBackend interface {
+	CacheBackend
+
+	// Increment atomically adds delta to the counter stored under key and
+	// returns the new value. A new counter starts at zero and expires after
+	// ttl (no expiry if ttl <= 0).
+	Increment(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error)
+}
+
+// SharedCacheOptions contains options for a SharedCache.
+type SharedCacheOptions struct {
+	// Prefix is prepended to every key (default: "zoptal:cache:")
+	Prefix string
+
+	// TTL is how long responses are kept in the backend (default: 24 hours)
+	TTL time.Duration
+
+	// Timeout bounds each backend operation; a slow backend is treated as a
+	// miss rather than delaying requests (default: 250 milliseconds)
+	Timeout time.Duration
+
+	// OnError is called when a backend operation fails (optional)
+	OnError func(error)
+}
+
+// SharedCache is a ResponseCache stored in a CacheBackend, so that all
+// replicas of a service revalidate against, and serve from, the same cached
+// responses instead of each refreshing them independently.
+//
+// Backend failures never fail requests: a failed lookup is treated as a
+// miss and a failed store is dropped.
+type SharedCache struct {
+	backend CacheBackend
+	prefix  string
+	ttl     time.Duration
+	timeout time.Duration
+	onError func(error)
+}
+
+// NewSharedCache creates a response cache backed by a shared store.
+//
+// Parameters:
+//   - backend: Shared store, e.g. zoptalcache.NewRedis(...)
+//   - options: Shared cache options (can be nil for defaults)
+//
+// Returns a new SharedCache instance.
+func NewSharedCache(backend CacheBackend, options *SharedCacheOptions) *SharedCache {
+	if options == nil {
+		options = &SharedCacheOptions{}
+	}
+	c := &SharedCache{
+		backend: backend,
+		prefix:  options.Prefix,
+		ttl:     options.TTL,
+		timeout: options.Timeout,
+		onError: options.OnError,
+	}
+	if c.prefix == "" {
+		c.prefix = "zoptal:cache:"
+	}
+	if c.ttl <= 0 {
+		c.ttl = 24 * time.Hour
+	}
+	if c.timeout <= 0 {
+		c.timeout = 250 * time.Millisecond
+	}
+	return c
+}
+
+// Get implements ResponseCache.
+func (c *SharedCache) Get(key string) (*CachedResponse, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	data, err := c.backend.Get(ctx, c.prefix+key)
+	if err != nil {
+		if !errors.Is(err, ErrCacheMiss) {
+			c.report(err)
+		}
+		return nil, false
+	}
+
+	var entry CachedResponse
+	if err := json.Unmarshal(data, &entry); err != nil {
+		c.report(err)
+		return nil, false
+	}
+	return &entry, true
+}
+
+// Set implements ResponseCache.
+func (c *SharedCache) Set(key string, entry *CachedResponse) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		c.report(err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	if err := c.backend.Set(ctx, c.prefix+key, data, c.ttl); err != nil {
+		c.report(err)
+	}
+}
+
+// Delete implements ResponseCache.
+func (c *SharedCache) Delete(key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	if err := c.backend.Delete(ctx, c.prefix+key); err != nil {
+		c.report(err)
+	}
+}
+
+// report passes a backend error to the error callback.
+func (c *SharedCache) report(err error) {
+	if c.onError != nil {
+		c.onError(err)
+	}
+}
@@ -0,0 +1,107 @@
+package zoptal
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"sync/atomic"
+	"time"
+)
+
+// cancelReasonKey is the context key of the innermost cancelReason.
+type cancelReasonKey struct{}
+
+// cancelReason is the reason attached to a context by WithCancelReason.
+type cancelReason struct {
+	reason string
+	parent *cancelReason
+	ctx    context.Context
+
+	// cancelled is set if the context was cancelled by its own cancel
+	// function, rather than by its parent or a deadline
+	cancelled atomic.Bool
+}
+
+// WithCancelReason returns a copy of ctx that is cancelled, with the given
+// reason, when the returned cancel function is called. Operations aborted
+// by that cancellation fail with a *CanceledError carrying the reason,
+// which still matches context.Canceled with errors.Is; streams report it
+// when they end, and servers that support it are told why a generation was
+// abandoned. This makes "who cancelled this generation" answerable from
+// logs in services where many components share a context:
+//
+//	ctx, cancel := zoptal.WithCancelReason(ctx, "editor tab closed")
+//	defer cancel()
+//
+// If ctx is cancelled by its parent or a deadline instead, the reason of
+// the outer context that was cancelled, if any, is reported.
+//
+// Parameters:
+//   - ctx: Parent context
+//   - reason: Why the returned context would be cancelled, for error messages and logs
+//
+// Returns the derived context and its cancel function.
+func WithCancelReason(ctx context.Context, reason string) (context.Context, context.CancelFunc) {
+	parent, _ := ctx.Value(cancelReasonKey{}).(*cancelReason)
+	ctx, cancel := context.WithCancel(ctx)
+	r := &cancelReason{reason: reason, parent: parent, ctx: ctx}
+	return context.WithValue(ctx, cancelReasonKey{}, r), func() {
+		if ctx.Err() == nil {
+			r.cancelled.Store(true)
+		}
+		cancel()
+	}
+}
+
+// CancelReason returns the reason ctx was cancelled, as given to
+// WithCancelReason, or "" if ctx is not cancelled or no reason is known.
+//
+// Parameters:
+//   - ctx: Context to inspect
+//
+// Returns the cancellation reason.
+func CancelReason(ctx context.Context) string {
+	if ctx.Err() == nil {
+		return ""
+	}
+	r, _ := ctx.Value(cancelReasonKey{}).(*cancelReason)
+	for ; r != nil; r = r.parent {
+		if r.cancelled.Load() {
+			return r.reason
+		}
+	}
+	return ""
+}
+
+// canceled returns the error of a done context: a *CanceledError if the
+// reason for its cancellation is known, or ctx.Err() otherwise.
+func canceled(ctx context.Context) error {
+	err := ctx.Err()
+	if reason := CancelReason(ctx); reason != "" {
+		return NewCanceledError(reason, err)
+	}
+	return err
+}
+
+// cancelOnServer tells the server why the request with the given ID was
+// abandoned, if the server supports cancellation reasons. It does not wait
+// for the server's answer.
+func (c *HTTPClient) cancelOnServer(requestID, reason string) {
+	if requestID == "" || reason == "" || !c.capabilities.snapshot().Supports(FeatureCancelReasons) {
+		return
+	}
+	if c.debug {
+		log.Printf("Cancelling request %s: %s", requestID, reason)
+	}
+
+	go func() {
+		defer c.metrics.track(GoroutineWorkers)()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		body := map[string]string{"reason": reason}
+		if err := c.Post(ctx, "/requests/"+url.PathEscape(requestID)+"/cancel", body, nil); err != nil && c.debug {
+			log.Printf("Failed to report cancellation of request %s: %v", requestID, err)
+		}
+	}()
+}
@@ -0,0 +1,142 @@
+package zoptal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CanonicalJSON encodes v as canonical JSON.
+//
+// Canonical JSON has object keys sorted, no insignificant whitespace, no HTML
+// escaping, and a single textual form for every number, so the same logical
+// value always produces the same bytes. The SDK uses it for request bodies so
+// that request signatures and recorded test fixtures stay stable.
+//
+// Parameters:
+//   - v: Value to encode (anything accepted by encoding/json)
+//
+// Returns the canonical encoding or an error if v cannot be encoded.
+func CanonicalJSON(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return CanonicalizeJSON(data)
+}
+
+// CanonicalizeJSON rewrites a JSON document into canonical form.
+//
+// Parameters:
+//   - data: JSON document
+//
+// Returns the canonical encoding or an error if data is not valid JSON.
+func CanonicalizeJSON(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("invalid JSON: unexpected data after top-level value")
+	}
+
+	var buf bytes.Buffer
+	if err := writeCanonical(&buf, value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeCanonical writes the canonical encoding of a decoded JSON value.
+func writeCanonical(buf *bytes.Buffer, value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case json.Number:
+		number, err := canonicalNumber(v)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(number)
+	case string:
+		writeCanonicalString(buf, v)
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, elem := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonical(buf, elem); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonicalString(buf, key)
+			buf.WriteByte(':')
+			if err := writeCanonical(buf, v[key]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("unexpected JSON value of type %T", value)
+	}
+	return nil
+}
+
+// writeCanonicalString writes a JSON string without HTML escaping.
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	var encoded bytes.Buffer
+	encoder := json.NewEncoder(&encoded)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(s) // encoding a string cannot fail
+	buf.Write(bytes.TrimSuffix(encoded.Bytes(), []byte("\n")))
+}
+
+// canonicalNumber returns the canonical text of a JSON number. Integers keep
+// full precision; other numbers are formatted as the shortest float64
+// representation, using exponent notation outside [1e-6, 1e21).
+func canonicalNumber(n json.Number) (string, error) {
+	text := string(n)
+	if !strings.ContainsAny(text, ".eE") {
+		integer, ok := new(big.Int).SetString(text, 10)
+		if !ok {
+			return "", fmt.Errorf("invalid JSON number %q", text)
+		}
+		return integer.String(), nil
+	}
+
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid JSON number %q: %w", text, err)
+	}
+	if f == 0 {
+		return "0", nil
+	}
+	if abs := math.Abs(f); abs >= 1e-6 && abs < 1e21 {
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	}
+	return strconv.FormatFloat(f, 'e', -1, 64), nil
+}
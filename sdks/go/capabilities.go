@@ -0,0 +1,132 @@
+package zoptal
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Version is the version of this SDK.
+const Version = "1.0.0"
+
+// Feature flags exchanged with the API. The client advertises the features it
+// supports in the X-Zoptal-SDK-Features request header, and servers advertise
+// theirs in the X-Zoptal-Capabilities response header.
+const (
+	FeatureIdempotencyKeys = "idempotency-keys"
+	FeatureGzipRequests    = "gzip-requests"
+	FeatureConditionalGET  = "conditional-get"
+	FeatureMultipartUpload = "multipart-upload"
+	FeatureCancelReasons   = "cancel-reasons"
+)
+
+// sdkFeatures is the feature set advertised by this SDK version.
+var sdkFeatures = []string{
+	FeatureIdempotencyKeys,
+	FeatureGzipRequests,
+	FeatureConditionalGET,
+	FeatureMultipartUpload,
+	FeatureCancelReasons,
+}
+
+// ServerCapabilities describes the features advertised by the API server.
+//
+// Capabilities are learned from response headers, so they are unknown until
+// the first response has been received. Older self-hosted servers that do not
+// send capability headers remain unknown, and the client keeps its default
+// behavior for them.
+type ServerCapabilities struct {
+	// Known reports whether the server has advertised its capabilities
+	Known bool
+
+	// APIVersion is the server API version, if advertised
+	APIVersion string
+
+	// Features is the set of features the server supports
+	Features map[string]bool
+}
+
+// Supports reports whether the server advertised feature.
+func (c ServerCapabilities) Supports(feature string) bool {
+	return c.Features[feature]
+}
+
+// capabilities tracks the capabilities advertised by the server.
+type capabilities struct {
+	mu         sync.RWMutex
+	known      bool
+	apiVersion string
+	features   map[string]bool
+}
+
+// observe updates the capabilities from response headers.
+func (c *capabilities) observe(header http.Header) {
+	advertised := header.Get("X-Zoptal-Capabilities")
+	if advertised == "" {
+		return
+	}
+
+	features := make(map[string]bool)
+	for _, feature := range strings.Split(advertised, ",") {
+		if feature = strings.TrimSpace(feature); feature != "" {
+			features[strings.ToLower(feature)] = true
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.known = true
+	c.features = features
+	c.apiVersion = header.Get("X-Zoptal-API-Version")
+}
+
+// allows reports whether feature may be used: either the server supports it
+// or the server's capabilities are not known yet.
+func (c *capabilities) allows(feature string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return !c.known || c.features[feature]
+}
+
+// snapshot returns the current capabilities.
+func (c *capabilities) snapshot() ServerCapabilities {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	snapshot := ServerCapabilities{
+		Known:      c.known,
+		APIVersion: c.apiVersion,
+		Features:   make(map[string]bool, len(c.features)),
+	}
+	for feature := range c.features {
+		snapshot.Features[feature] = true
+	}
+	return snapshot
+}
+
+// sdkFeaturesHeader returns the value of the X-Zoptal-SDK-Features header.
+func sdkFeaturesHeader() string {
+	features := append([]string(nil), sdkFeatures...)
+	sort.Strings(features)
+	return strings.Join(features, ",")
+}
+
+// newIdempotencyKey returns a random idempotency key.
+func newIdempotencyKey() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+// ServerCapabilities returns the capabilities advertised by the API server.
+//
+// Returns the capabilities learned from the most recent response that
+// advertised them.
+func (c *Client) ServerCapabilities() ServerCapabilities {
+	return c.httpClient.capabilities.snapshot()
+}
@@ -11,13 +11,22 @@
 //	    log.Fatal(err)
 //	}
 //	fmt.Printf("Found %d projects\n", len(projects.Projects))
+//
+// The SDK also builds for WebAssembly (GOOS=js GOARCH=wasm), for browser-based
+// tools: requests are then sent with the browser's fetch API, and streaming
+// responses are read as they arrive. Functions that use the local file system,
+// such as Files.SyncUp and Files.WatchDirectory, need a file system provided
+// by the host environment.
 package zoptal
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -32,14 +41,38 @@ type Client struct {
 	AI            *AIService
 	Collaboration *CollaborationService
 	Files         *FileService
+	Templates     *TemplateService
+	Notifications *NotificationsService
+	Security      *SecurityService
+	Jobs          *JobsService
+	Marketplace   *MarketplaceService
+	Integrations  *IntegrationsService
+	Experiments   *ExperimentsService
+	Orgs          *OrgsService
+	Audit         *AuditService
+	Index         *IndexService
+	Usage         *UsageService
+	Quotas        *QuotasService
 
 	// Internal HTTP client
 	httpClient *HTTPClient
 	apiKey     string
 	baseURL    string
-	timeout    time.Duration
-	maxRetries int
 	debug      bool
+
+	// Background lifecycle
+	done      chan struct{}
+	closeOnce sync.Once
+	ready     readiness
+
+	// root is the client this one was derived from with WithScopes, or nil
+	root *Client
+
+	// pooled is true for the clients of a ClientPool, whose connections are
+	// shared with the pool's other clients
+	pooled bool
+	// background is set while StartBackground's work is running
+	background atomic.Bool
 }
 
 // ClientOptions contains options for configuring the Zoptal client.
@@ -47,17 +80,116 @@ type ClientOptions struct {
 	// BaseURL is the base URL for the Zoptal API (default: "https://api.zoptal.com")
 	BaseURL string
 
+	// Organization is the ID of the organization requests act on, for
+	// credentials with access to several (default: the credentials' own)
+	Organization string
+
 	// Timeout is the request timeout (default: 30 seconds)
 	Timeout time.Duration
 
-	// MaxRetries is the maximum number of retries for failed requests (default: 3)
+	// MaxRetries is the maximum number of retries for failed requests; a
+	// negative value disables retries (default: 3)
 	MaxRetries int
 
-	// Debug enables debug logging (default: false)
+	// Debug enables debug logging, including a dump of every request and
+	// response as with OnRequest and OnResponse (default: false)
 	Debug bool
 
 	// HTTPClient is a custom HTTP client to use (optional)
 	HTTPClient *http.Client
+
+	// Hedging enables hedged GET requests to reduce tail latency (optional)
+	Hedging *HedgingOptions
+
+	// RetryPolicy decides which failed requests are retried and how long to
+	// wait between attempts (default: DefaultRetryPolicy())
+	RetryPolicy RetryPolicy
+
+	// Cache enables ETag/Last-Modified revalidation of GET responses
+	// (optional, e.g. NewMemoryCache(1000), or NewSharedCache to share
+	// responses between replicas)
+	Cache ResponseCache
+
+	// StaleWhileRevalidate serves cached GET responses immediately while
+	// refreshing them in the background; requires Cache (optional)
+	StaleWhileRevalidate *StaleWhileRevalidateOptions
+
+	// MetricsHook is called periodically with a snapshot of the goroutines
+	// and internal queues owned by the SDK (optional)
+	MetricsHook func(RuntimeMetrics)
+
+	// MetricsInterval is how often MetricsHook is called (default: 10 seconds)
+	MetricsInterval time.Duration
+
+	// CompressRequests gzip-compresses large request bodies (default: false)
+	CompressRequests bool
+
+	// CompressionThreshold is the minimum request body size in bytes that is
+	// compressed when CompressRequests is enabled (default: 1024)
+	CompressionThreshold int
+
+	// Transport tunes connection pooling, keep-alives, and HTTP/2 on the
+	// default transport; ignored when HTTPClient is set (optional)
+	Transport *TransportOptions
+
+	// Preconnect establishes the API connection and validates the API key in
+	// the background when the client is created; see Client.Ready (default: false)
+	Preconnect bool
+
+	// Mirror duplicates GET requests to a secondary deployment and reports
+	// response differences, for validating a migration (optional)
+	Mirror *MirrorOptions
+
+	// Failover configures fallback endpoints that are switched to on
+	// sustained failures of BaseURL (optional)
+	Failover *FailoverOptions
+
+	// RateLimit enables the client-side rate limiter, optionally shared
+	// between replicas (optional)
+	RateLimit *RateLimitOptions
+
+	// TokenSource authenticates with OAuth access tokens instead of the API
+	// key; tokens are refreshed in the background before they expire (optional)
+	TokenSource TokenSource
+
+	// Credentials looks up the API key when none is passed to the
+	// constructor, e.g. in a secret store; ignored with TokenSource (optional)
+	Credentials CredentialsProvider
+
+	// TokenRefresh tunes the background refresh of OAuth tokens (optional)
+	TokenRefresh *TokenRefreshOptions
+
+	// ModelRouting picks the model of AI requests that do not name one, by
+	// operation class and request size (optional)
+	ModelRouting *ModelRoutingOptions
+
+	// ModelFallback retries AI requests against other models while the
+	// requested model is over capacity or unavailable (optional)
+	ModelFallback *ModelFallbackOptions
+
+	// Encryption encrypts file contents before upload and decrypts them
+	// after download, for end-to-end encrypted projects (optional)
+	Encryption *EncryptionOptions
+
+	// Audit records every mutating call in a local audit trail (optional)
+	Audit *AuditOptions
+
+	// Policy consults a policy engine before guarded operations, such as
+	// deleting a project, and refuses those it denies (optional)
+	Policy *PolicyOptions
+
+	// Degradation serves last-known results and queues non-urgent requests
+	// while the AI service is down, instead of failing them (optional)
+	Degradation *DegradationOptions
+
+	// OnRequest is called with a dump of every HTTP request sent, including
+	// retries, with credentials redacted and bodies truncated to 16 KiB
+	// (optional)
+	OnRequest func(dump []byte)
+
+	// OnResponse is called with a dump of every HTTP response received, like
+	// OnRequest; the bodies of event streams are not included (optional)
+	OnResponse func(dump []byte)
 }
 
 // NewClient creates a new Zoptal client with default settings.
@@ -65,7 +197,8 @@ type ClientOptions struct {
 // Parameters:
 //   - apiKey: Your Zoptal API key
 //
-// Returns a new Client instance configured with default settings.
+// Returns a new Client instance configured with default settings. It panics
+// if apiKey is empty; New returns an error instead.
 func NewClient(apiKey string) *Client {
 	return NewClientWithOptions(apiKey, nil)
 }
@@ -76,11 +209,23 @@ func NewClient(apiKey string) *Client {
 //   - apiKey: Your Zoptal API key
 //   - options: Custom client options (can be nil for defaults)
 //
-// Returns a new Client instance configured with the specified options.
+// Returns a new Client instance configured with the specified options. It
+// panics if apiKey is empty and neither Credentials nor TokenSource provide
+// credentials, or if options.RateLimit is invalid; New returns an error
+// instead and validates the other options too.
 func NewClientWithOptions(apiKey string, options *ClientOptions) *Client {
-	if apiKey == "" {
+	apiKey, err := resolveAPIKey(apiKey, options)
+	if err != nil {
+		panic(err.Error())
+	}
+	if apiKey == "" && (options == nil || options.TokenSource == nil) {
 		panic("API key is required")
 	}
+	if options != nil && options.RateLimit != nil {
+		if err := options.RateLimit.validate(); err != nil {
+			panic(err.Error())
+		}
+	}
 
 	// Set default options
 	if options == nil {
@@ -94,6 +239,8 @@ func NewClientWithOptions(apiKey string, options *ClientOptions) *Client {
 	}
 	if options.MaxRetries == 0 {
 		options.MaxRetries = 3
+	} else if options.MaxRetries < 0 {
+		options.MaxRetries = 0
 	}
 
 	// Configure logging
@@ -103,29 +250,72 @@ func NewClientWithOptions(apiKey string, options *ClientOptions) *Client {
 
 	// Create HTTP client
 	httpClient := NewHTTPClient(HTTPClientConfig{
-		BaseURL:    options.BaseURL,
-		APIKey:     apiKey,
-		Timeout:    options.Timeout,
-		MaxRetries: options.MaxRetries,
-		Debug:      options.Debug,
-		HTTPClient: options.HTTPClient,
+		BaseURL:     options.BaseURL,
+		APIKey:      apiKey,
+		Timeout:     options.Timeout,
+		MaxRetries:  options.MaxRetries,
+		Debug:       options.Debug,
+		HTTPClient:  options.HTTPClient,
+		Hedging:     options.Hedging,
+		RetryPolicy: options.RetryPolicy,
+		Cache:       options.Cache,
+
+		Organization:         options.Organization,
+		StaleWhileRevalidate: options.StaleWhileRevalidate,
+
+		CompressRequests:     options.CompressRequests,
+		CompressionThreshold: options.CompressionThreshold,
+
+		Transport: options.Transport,
+		Mirror:    options.Mirror,
+		Failover:  options.Failover,
+		RateLimit: options.RateLimit,
+
+		TokenSource:  options.TokenSource,
+		TokenRefresh: options.TokenRefresh,
+
+		ModelRouting:  options.ModelRouting,
+		ModelFallback: options.ModelFallback,
+
+		Encryption: options.Encryption,
+		Audit:      options.Audit,
+		Policy:     options.Policy,
+
+		Degradation: options.Degradation,
+
+		OnRequest:  options.OnRequest,
+		OnResponse: options.OnResponse,
 	})
 
 	client := &Client{
 		httpClient: httpClient,
 		apiKey:     apiKey,
 		baseURL:    options.BaseURL,
-		timeout:    options.Timeout,
-		maxRetries: options.MaxRetries,
 		debug:      options.Debug,
+		done:       make(chan struct{}),
+	}
+
+	client.initServices()
+
+	if options.Preconnect {
+		client.startWarmup()
+	}
+
+	if httpClient.tokens != nil {
+		go client.refreshTokens()
+	}
+
+	if httpClient.failover != nil && len(httpClient.failover.endpoints) > 1 {
+		go client.probeEndpoints()
 	}
 
-	// Initialize service managers
-	client.Auth = &AuthService{client: httpClient}
-	client.Projects = &ProjectService{client: httpClient}
-	client.AI = &AIService{client: httpClient}
-	client.Collaboration = &CollaborationService{client: httpClient}
-	client.Files = &FileService{client: httpClient}
+	if options.MetricsHook != nil {
+		interval := options.MetricsInterval
+		if interval <= 0 {
+			interval = 10 * time.Second
+		}
+		go client.reportRuntimeMetrics(options.MetricsHook, interval)
+	}
 
 	if options.Debug {
 		log.Println("Zoptal SDK client initialized")
@@ -139,14 +329,19 @@ func NewClientWithOptions(apiKey string, options *ClientOptions) *Client {
 // Parameters:
 //   - ctx: Request context for cancellation and timeouts
 //
-// Returns a map containing health status information or an error if the health check fails.
-func (c *Client) HealthCheck(ctx context.Context) (map[string]interface{}, error) {
-	var result map[string]interface{}
-	err := c.httpClient.Get(ctx, "/health", nil, &result)
+// Returns the health status or an error if the health check fails.
+func (c *Client) HealthCheck(ctx context.Context) (*HealthStatus, error) {
+	var raw json.RawMessage
+	err := c.httpClient.Get(ctx, "/health", nil, &raw)
 	if err != nil {
 		return nil, fmt.Errorf("health check failed: %w", err)
 	}
-	return result, nil
+
+	var result HealthStatus
+	if err := decodeTyped(raw, &result, &result.Raw); err != nil {
+		return nil, fmt.Errorf("health check failed: %w", err)
+	}
+	return &result, nil
 }
 
 // GetUserInfo gets information about the authenticated user.
@@ -154,17 +349,22 @@ func (c *Client) HealthCheck(ctx context.Context) (map[string]interface{}, error
 // Parameters:
 //   - ctx: Request context for cancellation and timeouts
 //
-// Returns a map containing user information or an error if the request fails.
-func (c *Client) GetUserInfo(ctx context.Context) (map[string]interface{}, error) {
-	var result map[string]interface{}
-	err := c.httpClient.Get(ctx, "/user/profile", nil, &result)
+// Returns the user information or an error if the request fails.
+func (c *Client) GetUserInfo(ctx context.Context) (*UserInfo, error) {
+	var raw json.RawMessage
+	err := c.httpClient.Get(ctx, "/user/profile", nil, &raw)
 	if err != nil {
 		if IsAuthenticationError(err) {
 			return nil, NewAuthenticationError("invalid API key or expired token")
 		}
 		return nil, fmt.Errorf("failed to get user info: %w", err)
 	}
-	return result, nil
+
+	var result UserInfo
+	if err := decodeTyped(raw, &result, &result.Raw); err != nil {
+		return nil, fmt.Errorf("failed to get user info: %w", err)
+	}
+	return &result, nil
 }
 
 // GetUsageStats gets usage statistics for the authenticated user.
@@ -172,15 +372,23 @@ func (c *Client) GetUserInfo(ctx context.Context) (map[string]interface{}, error
 // Parameters:
 //   - ctx: Request context for cancellation and timeouts
 //
-// Returns a map containing usage statistics including API requests made,
-// AI tokens consumed, storage used, and collaboration sessions, or an error if the request fails.
-func (c *Client) GetUsageStats(ctx context.Context) (map[string]interface{}, error) {
-	var result map[string]interface{}
-	err := c.httpClient.Get(ctx, "/user/usage", nil, &result)
+// Returns usage statistics including API requests made, AI tokens consumed,
+// storage used, and collaboration sessions, or an error if the request fails.
+//
+// Deprecated: Use Usage.Query, which reports usage over a chosen period as
+// typed time series.
+func (c *Client) GetUsageStats(ctx context.Context) (*UsageStats, error) {
+	var raw json.RawMessage
+	err := c.httpClient.Get(ctx, "/user/usage", nil, &raw)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get usage stats: %w", err)
 	}
-	return result, nil
+
+	var result UsageStats
+	if err := decodeTyped(raw, &result, &result.Raw); err != nil {
+		return nil, fmt.Errorf("failed to get usage stats: %w", err)
+	}
+	return &result, nil
 }
 
 // GetAPIKey returns the API key being used by this client (masked for security).
@@ -204,14 +412,14 @@ func (c *Client) GetBaseURL() string {
 //
 // Returns the timeout duration.
 func (c *Client) GetTimeout() time.Duration {
-	return c.timeout
+	return c.httpClient.current().timeout
 }
 
 // GetMaxRetries returns the maximum retries setting for this client.
 //
 // Returns the maximum number of retries for failed requests.
 func (c *Client) GetMaxRetries() int {
-	return c.maxRetries
+	return c.httpClient.current().maxRetries
 }
 
 // IsDebugEnabled returns whether debug logging is enabled.
@@ -221,12 +429,39 @@ func (c *Client) IsDebugEnabled() bool {
 	return c.debug
 }
 
+// initServices creates the service managers.
+func (c *Client) initServices() {
+	c.Auth = &AuthService{client: c.httpClient}
+	c.Projects = &ProjectService{client: c.httpClient}
+	c.AI = &AIService{client: c.httpClient}
+	c.Collaboration = &CollaborationService{client: c.httpClient}
+	c.Files = &FileService{client: c.httpClient}
+	c.Templates = &TemplateService{client: c.httpClient}
+	c.Notifications = &NotificationsService{client: c.httpClient}
+	c.Security = &SecurityService{client: c.httpClient}
+	c.Jobs = &JobsService{client: c.httpClient}
+	c.Marketplace = &MarketplaceService{client: c.httpClient}
+	c.Integrations = &IntegrationsService{client: c.httpClient}
+	c.Experiments = &ExperimentsService{client: c.httpClient}
+	c.Orgs = &OrgsService{client: c.httpClient}
+	c.Audit = &AuditService{client: c.httpClient}
+	c.Index = &IndexService{client: c.httpClient}
+	c.Usage = &UsageService{client: c.httpClient}
+	c.Quotas = &QuotasService{client: c.httpClient}
+}
+
 // Close closes the client and cleans up resources.
 //
 // This should be called when you're done using the client,
-// especially in long-running applications.
+// especially in long-running applications. Closing a client derived with
+// WithScopes or owned by a ClientPool stops only its own background work;
+// its connections are shared with the client it was derived from or the
+// pool's other clients.
 func (c *Client) Close() error {
-	if c.httpClient != nil {
+	c.closeOnce.Do(func() {
+		close(c.done)
+	})
+	if c.httpClient != nil && c.root == nil && !c.pooled {
 		c.httpClient.Close()
 	}
 	if c.debug {
@@ -240,5 +475,5 @@ func (c *Client) Close() error {
 // Returns a formatted string with client configuration details.
 func (c *Client) String() string {
 	return fmt.Sprintf("ZoptalClient{baseURL: %s, timeout: %v, maxRetries: %d}",
-		c.baseURL, c.timeout, c.maxRetries)
-}
\ No newline at end of file
+		c.baseURL, c.GetTimeout(), c.GetMaxRetries())
+}
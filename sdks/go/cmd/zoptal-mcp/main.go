@@ -0,0 +1,104 @@
+// Command zoptal-mcp is a Model Context Protocol (MCP) server exposing Zoptal
+// projects, files, and AI operations as tools, so that AI agents and IDEs
+// supporting MCP can operate on Zoptal resources.
+//
+// The server speaks MCP over standard input and output, as launched by MCP
+// clients. It authenticates like NewClientFromEnv: with ZOPTAL_API_KEY, the
+// selected profile of ~/.zoptal/config.yaml, or the OS keychain. Requests
+// are rate limited, and the tools can be restricted to read-only scopes and
+// to a set of projects.
+//
+// Usage:
+//
+//	zoptal-mcp [-profile name] [-read-only] [-projects id,...] [-rps n]
+//
+// For example, in the MCP configuration of a client:
+//
+//	{
+//	  "mcpServers": {
+//	    "zoptal": {
+//	      "command": "zoptal-mcp",
+//	      "args": ["-read-only", "-projects", "proj_123"]
+//	    }
+//	  }
+//	}
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	zoptal "github.com/zoptal/zoptal-go-sdk"
+)
+
+// readOnlyScopes are the scopes of the -read-only flag.
+var readOnlyScopes = []zoptal.Scope{
+	zoptal.ScopeUserRead,
+	zoptal.ScopeProjectsRead,
+	zoptal.ScopeFilesRead,
+	zoptal.ScopeAI,
+}
+
+func main() {
+	profile := flag.String("profile", "", "profile of ~/.zoptal/config.yaml (default: $ZOPTAL_PROFILE, or \"default\")")
+	readOnly := flag.Bool("read-only", false, "expose only tools that do not modify projects")
+	projects := flag.String("projects", "", "comma-separated IDs of the projects the tools may access (default: all)")
+	rps := flag.Float64("rps", 5, "maximum Zoptal API requests per second")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: zoptal-mcp [-profile name] [-read-only] [-projects id,...] [-rps n]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// Standard output carries the protocol; everything else goes to standard error.
+	log.SetOutput(os.Stderr)
+	log.SetPrefix("zoptal-mcp: ")
+	log.SetFlags(0)
+
+	if *rps <= 0 {
+		log.Fatal("-rps must be positive")
+	}
+
+	settings, err := zoptal.LoadProfile(*profile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	client, err := zoptal.New("",
+		settings.Option(),
+		zoptal.WithCredentials(zoptal.DefaultCredentials(*profile)),
+		zoptal.WithRateLimit(&zoptal.RateLimitOptions{RequestsPerSecond: *rps}),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+
+	if *readOnly {
+		client = client.WithScopes(readOnlyScopes...)
+	}
+
+	server := newServer(newToolset(client, splitList(*projects)))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := server.serve(ctx, os.Stdin, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// splitList splits a comma-separated flag value, dropping empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
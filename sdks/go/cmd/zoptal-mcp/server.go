@@ -0,0 +1,264 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+
+	zoptal "github.com/zoptal/zoptal-go-sdk"
+)
+
+// protocolVersions are the MCP versions the server supports, latest first.
+var protocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// maxMessageSize bounds the size of a message read from the client.
+const maxMessageSize = 16 << 20
+
+// JSON-RPC error codes.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// message is a JSON-RPC request or notification from the client.
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// response is a JSON-RPC response to the client.
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is the error of a JSON-RPC response.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// server is an MCP server of a toolset, speaking newline-delimited JSON-RPC
+// over a stream as in the MCP stdio transport.
+type server struct {
+	tools *toolset
+
+	mu sync.Mutex
+	w  io.Writer
+
+	// inflight cancels the tool calls in progress, by request ID, when the
+	// client sends notifications/cancelled
+	inflight map[string]context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+// newServer creates a server of tools.
+func newServer(tools *toolset) *server {
+	return &server{tools: tools, inflight: make(map[string]context.CancelFunc)}
+}
+
+// serve handles the messages read from r, writing responses to w, until r
+// ends or ctx is done. Tool calls run concurrently; when r ends, serve waits
+// for those in progress to answer, and when ctx is done, it cancels them.
+func (s *server) serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	s.w = w
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		cancel()
+		s.wg.Wait()
+	}()
+
+	lines := make(chan []byte)
+	errs := make(chan error, 1)
+	go func() {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64<<10), maxMessageSize)
+		for scanner.Scan() {
+			line := append([]byte(nil), scanner.Bytes()...)
+			select {
+			case lines <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+		errs <- scanner.Err()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errs:
+			// Let the calls in progress answer before the client goes away.
+			s.wg.Wait()
+			if err != nil {
+				return fmt.Errorf("failed to read message: %w", err)
+			}
+			return nil
+		case line := <-lines:
+			if len(line) > 0 {
+				s.handle(ctx, line)
+			}
+		}
+	}
+}
+
+// handle handles a message.
+func (s *server) handle(ctx context.Context, line []byte) {
+	var msg message
+	if err := json.Unmarshal(line, &msg); err != nil {
+		s.reply(nil, nil, &rpcError{Code: codeParseError, Message: "invalid JSON: " + err.Error()})
+		return
+	}
+	if msg.JSONRPC != "2.0" || msg.Method == "" {
+		if msg.ID != nil {
+			s.reply(msg.ID, nil, &rpcError{Code: codeInvalidRequest, Message: "not a JSON-RPC 2.0 request"})
+		}
+		return
+	}
+
+	if msg.ID == nil {
+		s.notify(msg)
+		return
+	}
+
+	switch msg.Method {
+	case "initialize":
+		s.reply(msg.ID, s.initialize(msg.Params), nil)
+	case "ping":
+		s.reply(msg.ID, struct{}{}, nil)
+	case "tools/list":
+		s.reply(msg.ID, map[string]interface{}{"tools": s.tools.list()}, nil)
+	case "tools/call":
+		var params struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			s.reply(msg.ID, nil, &rpcError{Code: codeInvalidParams, Message: "invalid tool call: " + err.Error()})
+			return
+		}
+		t := s.tools.get(params.Name)
+		if t == nil {
+			s.reply(msg.ID, nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown tool %q", params.Name)})
+			return
+		}
+		s.call(ctx, msg.ID, t, params.Arguments)
+	default:
+		s.reply(msg.ID, nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method %q not found", msg.Method)})
+	}
+}
+
+// notify handles a notification.
+func (s *server) notify(msg message) {
+	if msg.Method != "notifications/cancelled" {
+		// notifications/initialized and others need no action.
+		return
+	}
+	var params struct {
+		RequestID json.RawMessage `json:"requestId"`
+	}
+	if err := json.Unmarshal(msg.Params, &params); err != nil {
+		return
+	}
+	s.mu.Lock()
+	cancel := s.inflight[string(params.RequestID)]
+	s.mu.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+}
+
+// initialize answers the initialize request, agreeing on the protocol
+// version the client asked for if supported, or else on the latest one.
+func (s *server) initialize(params json.RawMessage) interface{} {
+	var request struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	_ = json.Unmarshal(params, &request)
+	version := protocolVersions[0]
+	for _, supported := range protocolVersions {
+		if request.ProtocolVersion == supported {
+			version = supported
+		}
+	}
+
+	return map[string]interface{}{
+		"protocolVersion": version,
+		"capabilities": map[string]interface{}{
+			"tools": map[string]interface{}{"listChanged": false},
+		},
+		"serverInfo": map[string]interface{}{
+			"name":    "zoptal-mcp",
+			"version": zoptal.Version,
+		},
+		"instructions": "Tools operate on Zoptal projects, identified by project IDs, " +
+			"and on their files, identified by paths within a project.",
+	}
+}
+
+// call runs a tool call in the background, replying when it is done. Tool
+// failures are reported as tool results with isError, so that the model
+// sees them, rather than as protocol errors.
+func (s *server) call(ctx context.Context, id json.RawMessage, t *tool, arguments json.RawMessage) {
+	ctx, cancel := context.WithCancel(ctx)
+	key := string(id)
+	s.mu.Lock()
+	s.inflight[key] = cancel
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() {
+			s.mu.Lock()
+			delete(s.inflight, key)
+			s.mu.Unlock()
+			cancel()
+		}()
+
+		if len(arguments) == 0 || string(arguments) == "null" {
+			arguments = json.RawMessage("{}")
+		}
+		text, err := t.call(ctx, arguments)
+		if errors.Is(ctx.Err(), context.Canceled) {
+			// The client cancelled the request and expects no response.
+			return
+		}
+		result := map[string]interface{}{"isError": err != nil}
+		if err != nil {
+			text = err.Error()
+			log.Printf("%s: %v", t.Name, err)
+		}
+		result["content"] = []map[string]interface{}{{"type": "text", "text": text}}
+		s.reply(id, result, nil)
+	}()
+}
+
+// reply writes a response.
+func (s *server) reply(id json.RawMessage, result interface{}, rpcErr *rpcError) {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	data, err := json.Marshal(response{JSONRPC: "2.0", ID: id, Result: result, Error: rpcErr})
+	if err != nil {
+		data, _ = json.Marshal(response{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: codeInvalidRequest, Message: err.Error()}})
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.w.Write(append(data, '\n')); err != nil {
+		log.Printf("failed to write response: %v", err)
+	}
+}
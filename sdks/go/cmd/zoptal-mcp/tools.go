@@ -0,0 +1,449 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	zoptal "github.com/zoptal/zoptal-go-sdk"
+)
+
+// maxReadFileBytes bounds the size of a file returned by read_file, which
+// ends up in the context window of the model.
+const maxReadFileBytes = 256 << 10
+
+// tool is an MCP tool.
+type tool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"inputSchema"`
+
+	// scopes are the scopes the tool needs; it is hidden from clients
+	// without them
+	scopes []zoptal.Scope
+
+	// call runs the tool with its JSON arguments and returns its text result
+	call func(ctx context.Context, arguments json.RawMessage) (string, error)
+}
+
+// toolset is the tools available to a client.
+type toolset struct {
+	client *zoptal.Client
+
+	// projects are the IDs of the projects the tools may access; nil allows all
+	projects map[string]bool
+
+	tools []*tool
+}
+
+// newToolset creates the tools of client, restricted to projects if not
+// empty, leaving out those the client lacks the scopes for.
+func newToolset(client *zoptal.Client, projects []string) *toolset {
+	ts := &toolset{client: client}
+	if len(projects) > 0 {
+		ts.projects = make(map[string]bool)
+		for _, id := range projects {
+			ts.projects[id] = true
+		}
+	}
+	for _, t := range ts.all() {
+		if hasScopes(client.Scopes(), t.scopes) {
+			ts.tools = append(ts.tools, t)
+		}
+	}
+	return ts
+}
+
+// list returns the tools.
+func (ts *toolset) list() []*tool {
+	return ts.tools
+}
+
+// get returns the tool with a name, or nil.
+func (ts *toolset) get(name string) *tool {
+	for _, t := range ts.tools {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// checkProject returns an error unless the tools may access a project.
+func (ts *toolset) checkProject(projectID string) error {
+	if projectID == "" {
+		return errors.New("project_id is required")
+	}
+	if ts.projects != nil && !ts.projects[projectID] {
+		return fmt.Errorf("project %s is not accessible to this server", projectID)
+	}
+	return nil
+}
+
+// all returns every tool.
+func (ts *toolset) all() []*tool {
+	c := ts.client
+	return []*tool{
+		{
+			Name:        "whoami",
+			Description: "Returns the Zoptal user and organization the server acts as.",
+			InputSchema: schema(nil),
+			scopes:      []zoptal.Scope{zoptal.ScopeUserRead},
+			call: func(ctx context.Context, arguments json.RawMessage) (string, error) {
+				user, err := c.GetUserInfo(ctx)
+				if err != nil {
+					return "", err
+				}
+				return toJSON(user)
+			},
+		},
+		{
+			Name:        "list_files",
+			Description: "Lists the files of a project with their sizes and SHA-256 checksums.",
+			InputSchema: schema([]string{"project_id"}, projectIDProperty),
+			scopes:      []zoptal.Scope{zoptal.ScopeFilesRead},
+			call: func(ctx context.Context, arguments json.RawMessage) (string, error) {
+				var args struct {
+					ProjectID string `json:"project_id"`
+				}
+				if err := decode(arguments, &args); err != nil {
+					return "", err
+				}
+				if err := ts.checkProject(args.ProjectID); err != nil {
+					return "", err
+				}
+				files, err := c.Files.Manifest(ctx, args.ProjectID)
+				if err != nil {
+					return "", err
+				}
+				return toJSON(files)
+			},
+		},
+		{
+			Name:        "read_file",
+			Description: "Returns the content of a text file of a project.",
+			InputSchema: schema([]string{"project_id", "path"}, projectIDProperty, pathProperty),
+			scopes:      []zoptal.Scope{zoptal.ScopeFilesRead},
+			call: func(ctx context.Context, arguments json.RawMessage) (string, error) {
+				var args struct {
+					ProjectID string `json:"project_id"`
+					Path      string `json:"path"`
+				}
+				if err := decode(arguments, &args); err != nil {
+					return "", err
+				}
+				if err := ts.checkProject(args.ProjectID); err != nil {
+					return "", err
+				}
+				w := &limitedBuffer{max: maxReadFileBytes}
+				_, err := c.Files.DownloadTo(ctx, args.ProjectID, args.Path, w, nil)
+				if w.truncated {
+					return "", fmt.Errorf("%s is larger than %d KiB", args.Path, maxReadFileBytes>>10)
+				}
+				if err != nil {
+					return "", err
+				}
+				if !utf8.Valid(w.Bytes()) {
+					return "", fmt.Errorf("%s is not a text file", args.Path)
+				}
+				return w.String(), nil
+			},
+		},
+		{
+			Name:        "write_file",
+			Description: "Creates or replaces a file of a project with the given content.",
+			InputSchema: schema([]string{"project_id", "path", "content"}, projectIDProperty, pathProperty,
+				property("content", "string", "New content of the file")),
+			scopes: []zoptal.Scope{zoptal.ScopeFilesWrite},
+			call: func(ctx context.Context, arguments json.RawMessage) (string, error) {
+				var args struct {
+					ProjectID string `json:"project_id"`
+					Path      string `json:"path"`
+					Content   string `json:"content"`
+				}
+				if err := decode(arguments, &args); err != nil {
+					return "", err
+				}
+				if err := ts.checkProject(args.ProjectID); err != nil {
+					return "", err
+				}
+				result, err := c.Files.Upload(ctx, args.ProjectID, args.Path, strings.NewReader(args.Content), &zoptal.UploadOptions{Overwrite: true})
+				if err != nil {
+					return "", err
+				}
+				return toJSON(result)
+			},
+		},
+		{
+			Name:        "apply_diff",
+			Description: "Applies a unified diff to a file of a project, failing if it does not apply cleanly.",
+			InputSchema: schema([]string{"project_id", "path", "diff"}, projectIDProperty, pathProperty,
+				property("diff", "string", "Change to the file in unified diff format")),
+			scopes: []zoptal.Scope{zoptal.ScopeFilesWrite},
+			call: func(ctx context.Context, arguments json.RawMessage) (string, error) {
+				var args struct {
+					ProjectID string `json:"project_id"`
+					Path      string `json:"path"`
+					Diff      string `json:"diff"`
+				}
+				if err := decode(arguments, &args); err != nil {
+					return "", err
+				}
+				if err := ts.checkProject(args.ProjectID); err != nil {
+					return "", err
+				}
+				result, err := c.Files.ApplyDiff(ctx, args.ProjectID, args.Path, args.Diff)
+				if err != nil {
+					return "", err
+				}
+				return toJSON(result)
+			},
+		},
+		{
+			Name:        "clone_project",
+			Description: "Creates a copy of a project and returns the new project.",
+			InputSchema: schema([]string{"project_id"}, projectIDProperty,
+				property("name", "string", "Name of the new project (default: the source name with \" (copy)\" appended)")),
+			scopes: []zoptal.Scope{zoptal.ScopeProjectsWrite},
+			call: func(ctx context.Context, arguments json.RawMessage) (string, error) {
+				var args struct {
+					ProjectID string `json:"project_id"`
+					Name      string `json:"name"`
+				}
+				if err := decode(arguments, &args); err != nil {
+					return "", err
+				}
+				if err := ts.checkProject(args.ProjectID); err != nil {
+					return "", err
+				}
+				project, err := c.Projects.Clone(ctx, args.ProjectID, &zoptal.CloneOptions{Name: args.Name})
+				if err != nil {
+					return "", err
+				}
+				return toJSON(project)
+			},
+		},
+		{
+			Name:        "search_code",
+			Description: "Searches the code of a project by meaning, e.g. \"where are JWT tokens validated\", and returns the best matching snippets.",
+			InputSchema: schema([]string{"project_id", "query"}, projectIDProperty,
+				property("query", "string", "What to find, in natural language or as a code fragment"),
+				property("top_k", "integer", "Maximum number of matches, up to 100 (default: 10)")),
+			scopes: []zoptal.Scope{zoptal.ScopeAI},
+			call: func(ctx context.Context, arguments json.RawMessage) (string, error) {
+				var args struct {
+					ProjectID string `json:"project_id"`
+					Query     string `json:"query"`
+					TopK      int    `json:"top_k"`
+				}
+				if err := decode(arguments, &args); err != nil {
+					return "", err
+				}
+				if err := ts.checkProject(args.ProjectID); err != nil {
+					return "", err
+				}
+				result, err := c.AI.SearchCode(ctx, &zoptal.CodeSearchRequest{ProjectID: args.ProjectID, Query: args.Query, TopK: args.TopK})
+				if err != nil {
+					return "", err
+				}
+				return toJSON(result)
+			},
+		},
+		{
+			Name:        "generate_code",
+			Description: "Generates code from a description with Zoptal AI.",
+			InputSchema: schema([]string{"prompt"},
+				property("prompt", "string", "Description of the code to generate"),
+				property("language", "string", "Programming language, e.g. \"go\""),
+				property("framework", "string", "Framework to use, e.g. \"gin\"")),
+			scopes: []zoptal.Scope{zoptal.ScopeAI},
+			call: func(ctx context.Context, arguments json.RawMessage) (string, error) {
+				var args struct {
+					Prompt    string `json:"prompt"`
+					Language  string `json:"language"`
+					Framework string `json:"framework"`
+				}
+				if err := decode(arguments, &args); err != nil {
+					return "", err
+				}
+				stream, err := c.AI.GenerateCodeStream(ctx, &zoptal.CodeGenerationRequest{
+					Prompt:    args.Prompt,
+					Language:  args.Language,
+					Framework: args.Framework,
+				})
+				if err != nil {
+					return "", err
+				}
+				defer stream.Close()
+				for {
+					chunk, err := stream.Recv()
+					if err != nil {
+						return "", err
+					}
+					if chunk.Done {
+						break
+					}
+				}
+				code := stream.Code()
+				if final := stream.Final(); final != nil && final.Explanation != "" {
+					code += "\n\n" + final.Explanation
+				}
+				return code, nil
+			},
+		},
+		{
+			Name:        "review_diff",
+			Description: "Reviews a change in unified diff format and returns findings with severities and suggested fixes.",
+			InputSchema: schema([]string{"diff"},
+				property("diff", "string", "The change in unified diff format, e.g. the output of git diff"),
+				property("project_id", "string", "ID of the project the change applies to, for context")),
+			scopes: []zoptal.Scope{zoptal.ScopeAI},
+			call: func(ctx context.Context, arguments json.RawMessage) (string, error) {
+				var args struct {
+					Diff      string `json:"diff"`
+					ProjectID string `json:"project_id"`
+				}
+				if err := decode(arguments, &args); err != nil {
+					return "", err
+				}
+				if args.ProjectID != "" {
+					if err := ts.checkProject(args.ProjectID); err != nil {
+						return "", err
+					}
+				}
+				review, err := c.AI.ReviewDiff(ctx, &zoptal.DiffReviewRequest{Diff: args.Diff, ProjectID: args.ProjectID})
+				if err != nil {
+					return "", err
+				}
+				return toJSON(review)
+			},
+		},
+		{
+			Name:        "scan_code",
+			Description: "Scans a code snippet for security vulnerabilities.",
+			InputSchema: schema([]string{"code", "language"},
+				property("code", "string", "Code to scan"),
+				property("language", "string", "Programming language of the code, e.g. \"python\"")),
+			scopes: []zoptal.Scope{zoptal.ScopeSecurityWrite},
+			call: func(ctx context.Context, arguments json.RawMessage) (string, error) {
+				var args struct {
+					Code     string `json:"code"`
+					Language string `json:"language"`
+				}
+				if err := decode(arguments, &args); err != nil {
+					return "", err
+				}
+				findings, err := c.Security.ScanCode(ctx, args.Code, args.Language)
+				if err != nil {
+					return "", err
+				}
+				return toJSON(findings)
+			},
+		},
+		{
+			Name:        "list_models",
+			Description: "Lists the AI models available to the account.",
+			InputSchema: schema(nil),
+			scopes:      []zoptal.Scope{zoptal.ScopeAI},
+			call: func(ctx context.Context, arguments json.RawMessage) (string, error) {
+				models, err := c.AI.ListModels(ctx)
+				if err != nil {
+					return "", err
+				}
+				return toJSON(models)
+			},
+		},
+	}
+}
+
+// Common properties of the tool input schemas.
+var (
+	projectIDProperty = property("project_id", "string", "ID of the Zoptal project")
+	pathProperty      = property("path", "string", "Path of the file within the project, e.g. \"src/main.go\"")
+)
+
+// schemaProperty is a property of a tool input schema.
+type schemaProperty struct {
+	name   string
+	schema map[string]interface{}
+}
+
+// property returns a property of a tool input schema.
+func property(name, typ, description string) schemaProperty {
+	return schemaProperty{name: name, schema: map[string]interface{}{"type": typ, "description": description}}
+}
+
+// schema returns the JSON schema of a tool input object.
+func schema(required []string, properties ...schemaProperty) map[string]interface{} {
+	props := make(map[string]interface{}, len(properties))
+	for _, p := range properties {
+		props[p.name] = p.schema
+	}
+	s := map[string]interface{}{"type": "object", "properties": props}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+// decode decodes the arguments of a tool call.
+func decode(arguments json.RawMessage, v interface{}) error {
+	if err := json.Unmarshal(arguments, v); err != nil {
+		return fmt.Errorf("invalid arguments: %w", err)
+	}
+	return nil
+}
+
+// toJSON returns v as indented JSON, the text result of most tools.
+func toJSON(v interface{}) (string, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// hasScopes reports whether granted includes each of needed; nil grants
+// everything. A bare resource scope, such as "files", grants both levels.
+func hasScopes(granted, needed []zoptal.Scope) bool {
+	if granted == nil {
+		return true
+	}
+	for _, need := range needed {
+		resource, _, _ := strings.Cut(string(need), ":")
+		found := false
+		for _, scope := range granted {
+			if scope == need || string(scope) == resource {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// errFileTooLarge stops a download into a limitedBuffer once it is full.
+var errFileTooLarge = errors.New("file too large")
+
+// limitedBuffer is a buffer failing writes beyond max bytes.
+type limitedBuffer struct {
+	bytes.Buffer
+	max       int
+	truncated bool
+}
+
+// Write implements io.Writer.
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.max {
+		b.truncated = true
+		return 0, errFileTooLarge
+	}
+	return b.Buffer.Write(p)
+}
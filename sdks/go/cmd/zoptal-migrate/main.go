@@ -0,0 +1,355 @@
+// Command zoptal-migrate rewrites Go code written against the v1 Zoptal SDK
+// so that it builds against v2.
+//
+// In v2, methods that returned map[string]interface{} return typed results.
+// zoptal-migrate rewrites each call to such a method into a call to the
+// equivalent zoptalcompat function, which still returns the v1 map, and adds
+// the zoptalcompat import:
+//
+//	client.GetUserInfo(ctx)  =>  zoptalcompat.GetUserInfo(ctx, client)
+//
+// Calls are only rewritten when the type checker resolves their receiver to
+// a *zoptal.Client (or an addressable zoptal.Client), so methods of the same
+// name on other types are left alone. The packages of the given files are
+// therefore type-checked from source, and must be part of a module whose
+// dependencies are available; files excluded by build constraints are
+// reported and left unchanged.
+//
+// Usage:
+//
+//	zoptal-migrate [-w] [-d] [paths]
+//
+// By default the rewritten files are printed to standard output. With -w the
+// files are rewritten in place; with -d a list of changed call sites is
+// printed instead. Paths may be files, directories, or directory trees
+// ending in "/...".
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	sdkImportPath    = "github.com/zoptal/zoptal-go-sdk"
+	compatImportPath = sdkImportPath + "/zoptalcompat"
+)
+
+// compatMethods lists the Client methods whose v1 results are provided by
+// zoptalcompat functions of the same name taking (ctx, client).
+var compatMethods = map[string]bool{
+	"HealthCheck":   true,
+	"GetUserInfo":   true,
+	"GetUsageStats": true,
+}
+
+func main() {
+	write := flag.Bool("w", false, "write result to source files instead of stdout")
+	list := flag.Bool("d", false, "list rewritten call sites instead of printing files")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: zoptal-migrate [-w] [-d] [paths]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	files, err := collectFiles(paths)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "zoptal-migrate: %v\n", err)
+		os.Exit(2)
+	}
+
+	parsed, err := loadFiles(files)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "zoptal-migrate: %v\n", err)
+		os.Exit(2)
+	}
+
+	for _, path := range files {
+		if err := migrateFile(path, parsed[path], *write, *list); err != nil {
+			fmt.Fprintf(os.Stderr, "zoptal-migrate: %s: %v\n", path, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// typedFile is a parsed file with the type information of its package.
+type typedFile struct {
+	fset *token.FileSet
+	file *ast.File
+	info *types.Info
+}
+
+// loadFiles type-checks the packages containing files, including their
+// tests, and returns the parsed files by path.
+func loadFiles(files []string) (map[string]*typedFile, error) {
+	want := make(map[string]string, len(files))
+	var dirs []string
+	for _, path := range files {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := want[abs]; !ok {
+			want[abs] = path
+		}
+		if dir := filepath.Dir(abs); len(dirs) == 0 || dirs[len(dirs)-1] != dir {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	fset := token.NewFileSet()
+	imp := importer.ForCompiler(fset, "source", nil)
+	parsed := make(map[string]*typedFile, len(files))
+	checked := make(map[string]bool)
+	for _, dir := range dirs {
+		if checked[dir] {
+			continue
+		}
+		checked[dir] = true
+
+		pkg, err := build.ImportDir(dir, 0)
+		if err != nil {
+			if _, ok := err.(*build.NoGoError); ok {
+				continue
+			}
+			return nil, err
+		}
+		// The package is checked with its internal tests; external tests
+		// are a package of their own.
+		for _, names := range [][]string{append(pkg.GoFiles, pkg.TestGoFiles...), pkg.XTestGoFiles} {
+			if err := checkPackage(fset, imp, dir, names, want, parsed); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return parsed, nil
+}
+
+// checkPackage parses and type-checks the files names of a package in dir,
+// adding those in want to parsed.
+func checkPackage(fset *token.FileSet, imp types.Importer, dir string, names []string, want map[string]string, parsed map[string]*typedFile) error {
+	if len(names) == 0 {
+		return nil
+	}
+	var syntax []*ast.File
+	for _, name := range names {
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return err
+		}
+		syntax = append(syntax, file)
+	}
+
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	// Code written against v1 does not type-check against v2, so type
+	// errors are expected; receivers still resolve.
+	conf := types.Config{Importer: imp, Error: func(error) {}}
+	conf.Check(syntax[0].Name.Name, fset, syntax, info)
+
+	for i, name := range names {
+		if path, ok := want[filepath.Join(dir, name)]; ok {
+			parsed[path] = &typedFile{fset: fset, file: syntax[i], info: info}
+		}
+	}
+	return nil
+}
+
+// collectFiles expands paths into a list of Go source files.
+func collectFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		recursive := strings.HasSuffix(path, "/...")
+		root := strings.TrimSuffix(path, "/...")
+
+		info, err := os.Stat(root)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, root)
+			continue
+		}
+
+		err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if p != root && (!recursive || d.Name() == "vendor" || strings.HasPrefix(d.Name(), ".")) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if strings.HasSuffix(p, ".go") {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// migrateFile rewrites a single file; typed is nil if the file is not part
+// of a loaded package.
+func migrateFile(path string, typed *typedFile, write, list bool) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if typed == nil {
+		fmt.Fprintf(os.Stderr, "zoptal-migrate: %s: not part of a package for this build, skipped\n", path)
+		if !write && !list {
+			_, err := os.Stdout.Write(src)
+			return err
+		}
+		return nil
+	}
+
+	fset, file := typed.fset, typed.file
+	compatName, hasCompat := importName(file, compatImportPath, "zoptalcompat")
+
+	changed := 0
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || !compatMethods[sel.Sel.Name] || len(call.Args) != 1 {
+			return true
+		}
+		receiver, ok := clientReceiver(typed.info, sel)
+		if !ok {
+			return true
+		}
+
+		if list {
+			fmt.Printf("%s: %s -> %s.%s\n", fset.Position(call.Pos()), sel.Sel.Name, compatName, sel.Sel.Name)
+		}
+		call.Fun = &ast.SelectorExpr{X: ast.NewIdent(compatName), Sel: ast.NewIdent(sel.Sel.Name)}
+		call.Args = []ast.Expr{call.Args[0], receiver}
+		changed++
+		return true
+	})
+
+	if changed == 0 {
+		if !write && !list {
+			_, err := os.Stdout.Write(src)
+			return err
+		}
+		return nil
+	}
+	if !hasCompat {
+		addImport(file, compatImportPath)
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return err
+	}
+
+	switch {
+	case list:
+		return nil
+	case write:
+		return os.WriteFile(path, buf.Bytes(), 0o644)
+	default:
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+}
+
+// clientReceiver returns the *zoptal.Client argument for a compat function
+// replacing the method call sel, and whether sel is a method call on a
+// *zoptal.Client or an addressable zoptal.Client.
+func clientReceiver(info *types.Info, sel *ast.SelectorExpr) (ast.Expr, bool) {
+	if selection, ok := info.Selections[sel]; !ok || selection.Kind() != types.MethodVal {
+		return nil, false
+	}
+	tv, ok := info.Types[sel.X]
+	if !ok {
+		return nil, false
+	}
+
+	t, pointer := tv.Type, false
+	if ptr, ok := t.(*types.Pointer); ok {
+		t, pointer = ptr.Elem(), true
+	}
+	named, ok := t.(*types.Named)
+	if !ok {
+		return nil, false
+	}
+	obj := named.Obj()
+	if obj.Pkg() == nil || obj.Pkg().Path() != sdkImportPath || obj.Name() != "Client" {
+		return nil, false
+	}
+	if pointer {
+		return sel.X, true
+	}
+	if !tv.Addressable() {
+		return nil, false
+	}
+	return &ast.UnaryExpr{Op: token.AND, X: sel.X}, true
+}
+
+// importName returns the local name under which file imports path.
+func importName(file *ast.File, path, defaultName string) (string, bool) {
+	for _, spec := range file.Imports {
+		p, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || p != path {
+			continue
+		}
+		if spec.Name != nil {
+			return spec.Name.Name, true
+		}
+		return defaultName, true
+	}
+	return defaultName, false
+}
+
+// addImport adds an import of path to the file's first import declaration.
+func addImport(file *ast.File, path string) {
+	spec := &ast.ImportSpec{Path: &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(path)}}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		last := gen.Specs[len(gen.Specs)-1]
+		spec.Path.ValuePos = last.End()
+		gen.Specs = append(gen.Specs, spec)
+		if !gen.Lparen.IsValid() {
+			gen.Lparen = gen.Pos()
+			gen.Rparen = spec.End()
+		}
+		file.Imports = append(file.Imports, spec)
+		return
+	}
+
+	file.Decls = append([]ast.Decl{&ast.GenDecl{Tok: token.IMPORT, Specs: []ast.Spec{spec}}}, file.Decls...)
+	file.Imports = append(file.Imports, spec)
+}
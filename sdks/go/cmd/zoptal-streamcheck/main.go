@@ -0,0 +1,461 @@
+// Command zoptal-streamcheck reports Zoptal SDK streams that are never closed.
+//
+// It is a vet-style checker for the SDK's stream ownership contract: every
+// stream, session, watcher, or iterator returned by an SDK method must be
+// closed by the caller. A stream is considered handled if, within the function
+// that obtained it, Close is called on it, or ownership is transferred by
+// returning it, passing it to another function, or storing it elsewhere.
+//
+// A call is only checked when the type checker resolves it to a method
+// declared in the SDK, or one of its subpackages, that follows the naming
+// convention for stream methods and returns a value with a Close method, so
+// functions such as os.Open or the Watch methods of other libraries are left
+// alone. The packages of the given files are therefore type-checked from
+// source, and must be part of a module whose dependencies are available;
+// files excluded by build constraints are skipped.
+//
+// Usage:
+//
+//	go run github.com/zoptal/zoptal-go-sdk/cmd/zoptal-streamcheck ./...
+//
+// The command exits with status 1 if any problems are found.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// sdkImportPath is the import path of the Zoptal SDK.
+const sdkImportPath = "github.com/zoptal/zoptal-go-sdk"
+
+// finding is a single un-closed stream.
+type finding struct {
+	pos     token.Position
+	message string
+}
+
+func main() {
+	extra := flag.String("funcs", "", "comma-separated additional SDK method names that return streams")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: zoptal-streamcheck [-funcs Name,...] [packages]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	checker := &checker{extra: make(map[string]bool)}
+	for _, name := range strings.Split(*extra, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			checker.extra[name] = true
+		}
+	}
+
+	patterns := flag.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	var files []string
+	for _, pattern := range patterns {
+		matched, err := collectFiles(pattern)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "zoptal-streamcheck: %v\n", err)
+			os.Exit(2)
+		}
+		files = append(files, matched...)
+	}
+
+	parsed, err := loadFiles(files)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "zoptal-streamcheck: %v\n", err)
+		os.Exit(2)
+	}
+	for _, path := range files {
+		if typed := parsed[path]; typed != nil {
+			checker.checkFile(typed)
+		}
+	}
+
+	for _, f := range checker.findings {
+		fmt.Printf("%s: %s\n", f.pos, f.message)
+	}
+	if len(checker.findings) > 0 {
+		os.Exit(1)
+	}
+}
+
+// checker walks Go source files looking for un-closed SDK streams.
+type checker struct {
+	fset     *token.FileSet
+	info     *types.Info
+	extra    map[string]bool
+	findings []finding
+}
+
+// collectFiles expands a directory, a directory tree for patterns ending in
+// "/...", or a file into a list of Go source files.
+func collectFiles(pattern string) ([]string, error) {
+	var files []string
+	if strings.HasSuffix(pattern, "/...") || pattern == "..." {
+		root := strings.TrimSuffix(strings.TrimSuffix(pattern, "..."), "/")
+		if root == "" {
+			root = "."
+		}
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				name := d.Name()
+				if path != root && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if strings.HasSuffix(path, ".go") {
+				files = append(files, path)
+			}
+			return nil
+		})
+		return files, err
+	}
+
+	if strings.HasSuffix(pattern, ".go") {
+		return []string{pattern}, nil
+	}
+
+	entries, err := os.ReadDir(pattern)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".go") {
+			files = append(files, filepath.Join(pattern, entry.Name()))
+		}
+	}
+	return files, nil
+}
+
+// typedFile is a parsed file with the type information of its package.
+type typedFile struct {
+	fset *token.FileSet
+	file *ast.File
+	info *types.Info
+}
+
+// loadFiles type-checks the packages containing files, including their
+// tests, and returns the parsed files by path. Files excluded by build
+// constraints are left out.
+func loadFiles(files []string) (map[string]*typedFile, error) {
+	want := make(map[string]string, len(files))
+	var dirs []string
+	for _, path := range files {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := want[abs]; !ok {
+			want[abs] = path
+		}
+		if dir := filepath.Dir(abs); len(dirs) == 0 || dirs[len(dirs)-1] != dir {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	fset := token.NewFileSet()
+	imp := importer.ForCompiler(fset, "source", nil)
+	parsed := make(map[string]*typedFile, len(files))
+	checked := make(map[string]bool)
+	for _, dir := range dirs {
+		if checked[dir] {
+			continue
+		}
+		checked[dir] = true
+
+		pkg, err := build.ImportDir(dir, 0)
+		if err != nil {
+			if _, ok := err.(*build.NoGoError); ok {
+				continue
+			}
+			return nil, err
+		}
+		// The package is checked with its internal tests; external tests
+		// are a package of their own.
+		for _, names := range [][]string{append(pkg.GoFiles, pkg.TestGoFiles...), pkg.XTestGoFiles} {
+			if err := checkPackage(fset, imp, dir, names, want, parsed); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return parsed, nil
+}
+
+// checkPackage parses and type-checks the files names of a package in dir,
+// adding those in want to parsed.
+func checkPackage(fset *token.FileSet, imp types.Importer, dir string, names []string, want map[string]string, parsed map[string]*typedFile) error {
+	if len(names) == 0 {
+		return nil
+	}
+	var syntax []*ast.File
+	for _, name := range names {
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return err
+		}
+		syntax = append(syntax, file)
+	}
+
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	// Type errors elsewhere in the package do not prevent the calls that
+	// do resolve from being checked.
+	conf := types.Config{Importer: imp, Error: func(error) {}}
+	conf.Check(syntax[0].Name.Name, fset, syntax, info)
+
+	for i, name := range names {
+		if path, ok := want[filepath.Join(dir, name)]; ok {
+			parsed[path] = &typedFile{fset: fset, file: syntax[i], info: info}
+		}
+	}
+	return nil
+}
+
+// checkFile checks a single parsed Go source file.
+func (c *checker) checkFile(typed *typedFile) {
+	file := typed.file
+	if !importsSDK(file) {
+		return
+	}
+	c.fset, c.info = typed.fset, typed.info
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		var body *ast.BlockStmt
+		switch fn := n.(type) {
+		case *ast.FuncDecl:
+			body = fn.Body
+		case *ast.FuncLit:
+			body = fn.Body
+		}
+		if body != nil {
+			c.checkBody(body)
+		}
+		return true
+	})
+}
+
+// importsSDK reports whether file imports the SDK or one of its subpackages.
+func importsSDK(file *ast.File) bool {
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err == nil && (path == sdkImportPath || strings.HasPrefix(path, sdkImportPath+"/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// isStreamMethod reports whether a method name follows the SDK convention
+// for methods returning streams.
+func (c *checker) isStreamMethod(name string) bool {
+	if c.extra[name] {
+		return true
+	}
+	return strings.HasSuffix(name, "Stream") ||
+		strings.HasPrefix(name, "Open") ||
+		strings.HasPrefix(name, "Watch") ||
+		strings.HasPrefix(name, "Subscribe")
+}
+
+// checkBody checks the streams obtained directly in a function body.
+func (c *checker) checkBody(body *ast.BlockStmt) {
+	ast.Inspect(body, func(n ast.Node) bool {
+		if _, ok := n.(*ast.FuncLit); ok {
+			// Nested function literals are checked separately.
+			return false
+		}
+
+		switch stmt := n.(type) {
+		case *ast.AssignStmt:
+			if len(stmt.Rhs) != 1 || len(stmt.Lhs) == 0 {
+				return true
+			}
+			method, ok := c.streamCall(stmt.Rhs[0])
+			if !ok {
+				return true
+			}
+			ident, ok := stmt.Lhs[0].(*ast.Ident)
+			if !ok {
+				// Assigned to a field or index expression: ownership moves there.
+				return true
+			}
+			if ident.Name == "_" {
+				c.report(stmt.Pos(), fmt.Sprintf("stream returned by %s is discarded and can never be closed", method))
+				return true
+			}
+			if !handled(body, ident.Name, stmt) {
+				c.report(stmt.Pos(), fmt.Sprintf("stream %s returned by %s is never closed", ident.Name, method))
+			}
+		case *ast.ExprStmt:
+			if method, ok := c.streamCall(stmt.X); ok {
+				c.report(stmt.Pos(), fmt.Sprintf("stream returned by %s is discarded and can never be closed", method))
+			}
+		}
+		return true
+	})
+}
+
+// streamCall reports whether expr is a call to an SDK stream method, returning
+// the method name.
+func (c *checker) streamCall(expr ast.Expr) (string, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return "", false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !c.isStreamMethod(sel.Sel.Name) {
+		return "", false
+	}
+
+	selection, ok := c.info.Selections[sel]
+	if !ok || selection.Kind() != types.MethodVal || !isSDKPath(selection.Obj().Pkg()) {
+		return "", false
+	}
+	results := selection.Obj().Type().(*types.Signature).Results()
+	if results.Len() == 0 || !hasClose(results.At(0).Type()) {
+		return "", false
+	}
+	return sel.Sel.Name, true
+}
+
+// isSDKPath reports whether pkg is the SDK or one of its subpackages.
+func isSDKPath(pkg *types.Package) bool {
+	return pkg != nil && (pkg.Path() == sdkImportPath || strings.HasPrefix(pkg.Path(), sdkImportPath+"/"))
+}
+
+// hasClose reports whether values of type t have a Close method.
+func hasClose(t types.Type) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t, true, nil, "Close")
+	_, ok := obj.(*types.Func)
+	return ok
+}
+
+// handled reports whether the stream held in variable name is closed or has
+// its ownership transferred anywhere in body after the assignment.
+func handled(body *ast.BlockStmt, name string, assign *ast.AssignStmt) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found || n == nil || n.Pos() < assign.End() && !containsPos(n, assign) {
+			return !found
+		}
+
+		switch node := n.(type) {
+		case *ast.CallExpr:
+			// name.Close()
+			if sel, ok := node.Fun.(*ast.SelectorExpr); ok && isIdent(sel.X, name) && sel.Sel.Name == "Close" {
+				found = true
+				return false
+			}
+			// Passed to another function.
+			for _, arg := range node.Args {
+				if isIdent(arg, name) {
+					found = true
+					return false
+				}
+			}
+		case *ast.ReturnStmt:
+			for _, result := range node.Results {
+				if refersTo(result, name) {
+					found = true
+					return false
+				}
+			}
+		case *ast.AssignStmt:
+			if node == assign || allBlank(node.Lhs) {
+				return true
+			}
+			for _, rhs := range node.Rhs {
+				if refersTo(rhs, name) {
+					found = true
+					return false
+				}
+			}
+		case *ast.CompositeLit:
+			for _, elt := range node.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					elt = kv.Value
+				}
+				if isIdent(elt, name) {
+					found = true
+					return false
+				}
+			}
+		case *ast.SendStmt:
+			if isIdent(node.Value, name) {
+				found = true
+				return false
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// containsPos reports whether node encloses the assignment.
+func containsPos(node ast.Node, assign *ast.AssignStmt) bool {
+	return node.Pos() <= assign.Pos() && assign.End() <= node.End()
+}
+
+// allBlank reports whether every expression in exprs is the blank identifier.
+func allBlank(exprs []ast.Expr) bool {
+	for _, expr := range exprs {
+		if !isIdent(expr, "_") {
+			return false
+		}
+	}
+	return true
+}
+
+// isIdent reports whether expr is the identifier name.
+func isIdent(expr ast.Expr, name string) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == name
+}
+
+// refersTo reports whether expr is name, &name, or a composite literal
+// containing name.
+func refersTo(expr ast.Expr, name string) bool {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name == name
+	case *ast.UnaryExpr:
+		return refersTo(e.X, name)
+	case *ast.CompositeLit:
+		for _, elt := range e.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				elt = kv.Value
+			}
+			if refersTo(elt, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// report records a finding.
+func (c *checker) report(pos token.Pos, message string) {
+	c.findings = append(c.findings, finding{pos: c.fset.Position(pos), message: message})
+}
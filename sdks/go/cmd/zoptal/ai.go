@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	zoptal "github.com/zoptal/zoptal-go-sdk"
+)
+
+// docsFlag collects -doc flags, each naming a library as "name",
+// "name@version", or "name@version#topic".
+type docsFlag []*zoptal.DocsRequest
+
+// String implements flag.Value.
+func (d *docsFlag) String() string {
+	var libraries []string
+	for _, request := range *d {
+		libraries = append(libraries, request.Library)
+	}
+	return strings.Join(libraries, ",")
+}
+
+// Set implements flag.Value.
+func (d *docsFlag) Set(value string) error {
+	request := &zoptal.DocsRequest{}
+	value, request.Topic, _ = strings.Cut(value, "#")
+	request.Library, request.Version, _ = strings.Cut(value, "@")
+	if request.Library == "" {
+		return errors.New("library is required")
+	}
+	*d = append(*d, request)
+	return nil
+}
+
+// aiGenerate generates code from a prompt, streaming it to standard output
+// or a file.
+func aiGenerate(ctx context.Context, env *environment, args []string) error {
+	flags := newFlagSet("ai generate", "[-language lang] [-framework name] [-doc lib[@version][#topic]]... [-o file] [-explain] prompt|-")
+	language := flags.String("language", "", "language of the code, e.g. go or typescript")
+	framework := flags.String("framework", "", "framework of the code, e.g. gin or react")
+	output := flags.String("o", "", "write the code to a file instead of standard output")
+	explain := flags.Bool("explain", false, "print the explanation of the code to standard error")
+	var docs docsFlag
+	flags.Var(&docs, "doc", "attach the documentation of a library version, e.g. react@18#hooks (repeatable)")
+	if err := parseFlags(flags, args, 1, -1); err != nil {
+		return err
+	}
+
+	prompt := strings.Join(flags.Args(), " ")
+	if prompt == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read prompt: %w", err)
+		}
+		prompt = string(data)
+	}
+	if strings.TrimSpace(prompt) == "" {
+		return errors.New("prompt is empty")
+	}
+
+	client, err := env.client()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	var opts []zoptal.RequestOption
+	if len(docs) > 0 {
+		opts = append(opts, zoptal.WithLibraryDocs(docs...))
+	}
+	stream, err := client.AI.GenerateCodeStream(ctx, &zoptal.CodeGenerationRequest{
+		Prompt:    prompt,
+		Language:  *language,
+		Framework: *framework,
+	}, opts...)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	w := bufio.NewWriter(os.Stdout)
+	var file *os.File
+	if *output != "" {
+		if file, err = os.Create(*output); err != nil {
+			return err
+		}
+		defer file.Close()
+		w = bufio.NewWriter(file)
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			w.Flush()
+			return err
+		}
+		if _, err := w.WriteString(chunk.Delta); err != nil {
+			return err
+		}
+		if *output == "" {
+			// Show the code as it arrives.
+			w.Flush()
+		}
+	}
+	if code := stream.Code(); code != "" && !strings.HasSuffix(code, "\n") && *output == "" {
+		w.WriteString("\n")
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if final := stream.Final(); final != nil {
+		if *explain && final.Explanation != "" {
+			fmt.Fprintf(os.Stderr, "\n%s\n", final.Explanation)
+		}
+		if final.FinishReason == "length" {
+			fmt.Fprintln(os.Stderr, "zoptal: the code was truncated at the output token limit")
+		}
+	}
+	if file != nil {
+		return file.Close()
+	}
+	return nil
+}
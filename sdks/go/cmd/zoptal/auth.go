@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	zoptal "github.com/zoptal/zoptal-go-sdk"
+	"gopkg.in/yaml.v3"
+)
+
+// authLogin verifies an API key read from standard input and stores it in
+// the selected profile of the configuration file, creating the file or the
+// profile if needed and keeping the rest of the file as is.
+func authLogin(ctx context.Context, env *environment, args []string) error {
+	flags := newFlagSet("auth login", "[-base-url url] < api-key")
+	baseURL := flags.String("base-url", "", "base URL of the API, stored in the profile")
+	if err := parseFlags(flags, args, 0, 0); err != nil {
+		return err
+	}
+
+	name := env.profile
+	if name == "" {
+		name = os.Getenv(zoptal.EnvProfile)
+	}
+	if name == "" {
+		name = zoptal.DefaultProfile
+	}
+	path, err := zoptal.ConfigFilePath()
+	if err != nil {
+		return err
+	}
+
+	settings := &zoptal.Profile{}
+	config, err := zoptal.LoadConfigFile(path)
+	switch {
+	case err == nil:
+		if existing, ok := config.Profiles[name]; ok {
+			settings = existing
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		return err
+	}
+	// The key is verified against the base URL it is stored with, unless
+	// ZOPTAL_BASE_URL overrides it as for other commands.
+	if *baseURL != "" {
+		settings.BaseURL = *baseURL
+	} else if envURL := os.Getenv(zoptal.EnvBaseURL); envURL != "" {
+		settings.BaseURL = envURL
+	}
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+
+	apiKey, err := readAPIKey()
+	if err != nil {
+		return err
+	}
+
+	options := []zoptal.Option{settings.Option()}
+	if env.debug {
+		options = append(options, zoptal.WithDebug())
+	}
+	client, err := zoptal.New(apiKey, options...)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	user, err := client.GetUserInfo(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to verify API key: %w", err)
+	}
+
+	fields := map[string]string{"api_key": apiKey}
+	if *baseURL != "" {
+		fields["base_url"] = *baseURL
+	}
+	if err := saveProfile(path, name, fields); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Logged in as %s <%s>; saved profile %q to %s\n", user.Name, user.Email, name, path)
+	if os.Getenv(zoptal.EnvAPIKey) != "" {
+		fmt.Fprintf(os.Stderr, "Note: %s is set and takes precedence over the profile\n", zoptal.EnvAPIKey)
+	}
+	return nil
+}
+
+// readAPIKey reads an API key from the first line of standard input,
+// prompting for it if standard input is a terminal.
+func readAPIKey() (string, error) {
+	if info, err := os.Stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		fmt.Fprint(os.Stderr, "Zoptal API key: ")
+	}
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read API key: %w", err)
+	}
+	apiKey := strings.TrimSpace(line)
+	if apiKey == "" {
+		return "", errors.New("no API key given on standard input")
+	}
+	return apiKey, nil
+}
+
+// saveProfile sets fields of a profile in the configuration file at path,
+// editing the YAML document so that comments and other settings are kept.
+// The file is created, readable by the user only, if it does not exist.
+func saveProfile(path, name string, fields map[string]string) error {
+	var document yaml.Node
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			return fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+	case errors.Is(err, fs.ErrNotExist):
+	default:
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	if document.Kind == 0 {
+		document = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("config file %s is not a mapping", path)
+	}
+
+	profiles := mappingValue(root, "profiles")
+	profile := mappingValue(profiles, name)
+	for key, value := range fields {
+		node := mappingValue(profile, key)
+		*node = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return fmt.Errorf("failed to encode config file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// mappingValue returns the value of key in a YAML mapping, adding an empty
+// mapping under key if it is missing or null.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		*mapping = yaml.Node{Kind: yaml.MappingNode}
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			value := mapping.Content[i+1]
+			if value.Kind == yaml.ScalarNode && value.Tag == "!!null" {
+				*value = yaml.Node{Kind: yaml.MappingNode}
+			}
+			return value
+		}
+	}
+	value := &yaml.Node{Kind: yaml.MappingNode}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+	return value
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	zoptal "github.com/zoptal/zoptal-go-sdk"
+)
+
+// filesPush pushes a local directory to a project.
+func filesPush(ctx context.Context, env *environment, args []string) error {
+	return filesSync(ctx, env, "files push", args, func(client *zoptal.Client, projectID, dir string, options *zoptal.SyncOptions) (*zoptal.SyncResult, error) {
+		return client.Files.SyncUp(ctx, projectID, dir, options)
+	})
+}
+
+// filesPull pulls a project into a local directory.
+func filesPull(ctx context.Context, env *environment, args []string) error {
+	return filesSync(ctx, env, "files pull", args, func(client *zoptal.Client, projectID, dir string, options *zoptal.SyncOptions) (*zoptal.SyncResult, error) {
+		return client.Files.SyncDown(ctx, projectID, dir, options)
+	})
+}
+
+// filesSync runs a sync in either direction, printing each change as it
+// is made and a summary at the end.
+func filesSync(ctx context.Context, env *environment, name string, args []string,
+	sync func(client *zoptal.Client, projectID, dir string, options *zoptal.SyncOptions) (*zoptal.SyncResult, error)) error {
+	flags := newFlagSet(name, "[-delete] [-dry-run] [-ignore pattern,...] [-concurrency n] project-id [dir]")
+	deleteExtra := flags.Bool("delete", false, "delete files missing from the source")
+	dryRun := flags.Bool("dry-run", false, "print the changes without making them")
+	ignore := flags.String("ignore", "", "comma-separated glob patterns of files to leave alone, e.g. node_modules,*.log")
+	concurrency := flags.Int("concurrency", 4, "maximum number of concurrent transfers")
+	if err := parseFlags(flags, args, 1, 2); err != nil {
+		return err
+	}
+	dir := "."
+	if flags.NArg() == 2 {
+		dir = flags.Arg(1)
+	}
+
+	client, err := env.client()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	result, err := sync(client, flags.Arg(0), dir, &zoptal.SyncOptions{
+		Concurrency: *concurrency,
+		Delete:      *deleteExtra,
+		Ignore:      splitList(*ignore),
+		DryRun:      *dryRun,
+		OnChange: func(path string, action zoptal.SyncAction) {
+			fmt.Printf("%-8s %s\n", action, path)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, syncErr := range result.Errors {
+		fmt.Fprintf(os.Stderr, "zoptal %s: %v\n", name, syncErr)
+	}
+	summary := fmt.Sprintf("%d created, %d updated, %d deleted, %d unchanged, %d bytes transferred",
+		len(result.Created), len(result.Updated), len(result.Deleted), result.Unchanged, result.BytesTransferred)
+	if *dryRun {
+		summary += " (dry run)"
+	}
+	fmt.Fprintln(os.Stderr, summary)
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("%d files failed to sync", len(result.Errors))
+	}
+	return nil
+}
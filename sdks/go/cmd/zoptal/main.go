@@ -0,0 +1,169 @@
+// Command zoptal is the Zoptal command-line interface, for managing
+// projects, generating code, and syncing files from a terminal or a script.
+// It is built entirely on the public API of the Go SDK.
+//
+// Usage:
+//
+//	zoptal [-profile name] [-debug] <command> <subcommand> [flags] [args]
+//
+// The commands are:
+//
+//	auth login       store an API key in the configuration file
+//	projects list    list projects
+//	projects create  create a project
+//	ai generate      generate code from a prompt
+//	files push       push a local directory to a project
+//	files pull       pull a project into a local directory
+//
+// Run "zoptal <command> <subcommand> -h" for the flags of a subcommand.
+//
+// The client is configured like NewClientFromEnv: from the selected profile
+// of ~/.zoptal/config.yaml and the ZOPTAL_* environment variables, with the
+// API key taken from ZOPTAL_API_KEY, the profile, or the OS keychain.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+
+	zoptal "github.com/zoptal/zoptal-go-sdk"
+)
+
+// command is a subcommand of the CLI, such as "projects list".
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, env *environment, args []string) error
+}
+
+// commands are the subcommands, by command and subcommand name.
+var commands = []command{
+	{name: "auth login", summary: "store an API key in the configuration file", run: authLogin},
+	{name: "projects list", summary: "list projects", run: projectsList},
+	{name: "projects create", summary: "create a project", run: projectsCreate},
+	{name: "ai generate", summary: "generate code from a prompt", run: aiGenerate},
+	{name: "files push", summary: "push a local directory to a project", run: filesPush},
+	{name: "files pull", summary: "pull a project into a local directory", run: filesPull},
+}
+
+// environment is the global configuration of a run.
+type environment struct {
+	profile string
+	debug   bool
+}
+
+// errUsage is returned by subcommands for invalid arguments, after printing
+// their usage.
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	env := &environment{}
+	flag.StringVar(&env.profile, "profile", "", "profile of ~/.zoptal/config.yaml (default: $ZOPTAL_PROFILE, or \"default\")")
+	flag.BoolVar(&env.debug, "debug", false, "log API requests to standard error")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() < 2 {
+		usage()
+		os.Exit(2)
+	}
+	name := flag.Arg(0) + " " + flag.Arg(1)
+	var cmd *command
+	for i := range commands {
+		if commands[i].name == name {
+			cmd = &commands[i]
+		}
+	}
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "zoptal: unknown command %q\n", name)
+		usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := cmd.run(ctx, env, flag.Args()[2:])
+	stop()
+	switch {
+	case errors.Is(err, errUsage) || errors.Is(err, flag.ErrHelp):
+		os.Exit(2)
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "zoptal %s: %v\n", name, err)
+		os.Exit(1)
+	}
+}
+
+// usage prints the usage of the CLI.
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: zoptal [-profile name] [-debug] <command> <subcommand> [flags] [args]\n\nCommands:\n")
+	names := make([]string, len(commands))
+	summaries := make(map[string]string)
+	for i, cmd := range commands {
+		names[i] = cmd.name
+		summaries[cmd.name] = cmd.summary
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-16s %s\n", name, summaries[name])
+	}
+	fmt.Fprintf(os.Stderr, "\nFlags:\n")
+	flag.PrintDefaults()
+}
+
+// newFlagSet returns the flag set of a subcommand, printing usage as
+// "zoptal name synopsis".
+func newFlagSet(name, synopsis string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: zoptal %s %s\n", name, synopsis)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// parseFlags parses the arguments of a subcommand, requiring between min
+// and max positional arguments; max < 0 means no maximum.
+func parseFlags(flags *flag.FlagSet, args []string, min, max int) error {
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() < min || (max >= 0 && flags.NArg() > max) {
+		flags.Usage()
+		return errUsage
+	}
+	return nil
+}
+
+// client creates the client of the selected profile.
+func (e *environment) client() (*zoptal.Client, error) {
+	profile, err := zoptal.LoadProfile(e.profile)
+	if err != nil {
+		return nil, err
+	}
+	options := []zoptal.Option{profile.Option(), zoptal.WithCredentials(zoptal.DefaultCredentials(e.profile))}
+	if e.debug {
+		options = append(options, zoptal.WithDebug())
+	}
+	client, err := zoptal.New("", options...)
+	if errors.Is(err, zoptal.ErrNoCredentials) {
+		return nil, fmt.Errorf("%w\nRun \"zoptal auth login\" or set %s.", err, zoptal.EnvAPIKey)
+	}
+	return client, err
+}
+
+// splitList splits a comma-separated flag value, dropping empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
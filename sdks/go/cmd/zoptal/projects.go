@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	zoptal "github.com/zoptal/zoptal-go-sdk"
+)
+
+// projectsList lists projects, as a table or as JSON lines.
+func projectsList(ctx context.Context, env *environment, args []string) error {
+	flags := newFlagSet("projects list", "[-json] [-limit n]")
+	asJSON := flags.Bool("json", false, "print each project as a line of JSON")
+	limit := flags.Int("limit", 0, "maximum number of projects to list (default: all)")
+	if err := parseFlags(flags, args, 0, 0); err != nil {
+		return err
+	}
+
+	client, err := env.client()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	out := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	if !*asJSON {
+		fmt.Fprintln(out, "ID\tNAME\tSTATUS\tVISIBILITY\tUPDATED")
+	}
+	encoder := json.NewEncoder(os.Stdout)
+
+	it := zoptal.Paginate[zoptal.Project](client, "/projects", nil)
+	defer it.Close()
+	for count := 0; (*limit <= 0 || count < *limit) && it.Next(ctx); count++ {
+		project := it.Item()
+		if *asJSON {
+			if err := encoder.Encode(project); err != nil {
+				return err
+			}
+			continue
+		}
+		updated := ""
+		if !project.UpdatedAt.IsZero() {
+			updated = project.UpdatedAt.Local().Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(out, "%s\t%s\t%s\t%s\t%s\n", project.ID, project.Name, project.Status, project.Visibility, updated)
+	}
+	if err := it.Err(); err != nil {
+		return fmt.Errorf("failed to list projects: %w", err)
+	}
+	return out.Flush()
+}
+
+// projectsCreate creates a project and prints its ID.
+func projectsCreate(ctx context.Context, env *environment, args []string) error {
+	flags := newFlagSet("projects create", "[-template id] [-description text] [-visibility private|public|team] [-wait] name")
+	template := flags.String("template", "", "template to create the project from")
+	description := flags.String("description", "", "description of the project")
+	visibility := flags.String("visibility", "private", "visibility of the project: private, public, or team")
+	wait := flags.Bool("wait", false, "wait until the project is ready")
+	if err := parseFlags(flags, args, 1, 1); err != nil {
+		return err
+	}
+
+	client, err := env.client()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	project, err := client.Projects.Create(ctx, &zoptal.ProjectCreateRequest{
+		Name:        flags.Arg(0),
+		Template:    *template,
+		Description: *description,
+		Visibility:  *visibility,
+	})
+	if err != nil {
+		return err
+	}
+	if *wait {
+		if project, err = client.Projects.WaitUntilReady(ctx, project.ID, nil); err != nil {
+			return err
+		}
+	}
+	fmt.Println(project.ID)
+	return nil
+}
@@ -0,0 +1,448 @@
+package zoptal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// documentPollWait is how long the server holds a poll for new operations open.
+const documentPollWait = 20 * time.Second
+
+// DocumentChange reports a change to a shared document made by another
+// collaborator, or a synchronization failure.
+type DocumentChange struct {
+	// Operation is the remote edit, transformed to apply to the local
+	// document as it was before the change; nil for errors
+	Operation *TextOperation
+
+	// Revision is the server revision the document is synchronized to
+	Revision int64
+
+	// ClientID identifies the collaborator that made the edit
+	ClientID string
+
+	// Resynced is true if the document was reloaded from the server,
+	// replacing its contents and discarding unsent local edits
+	Resynced bool
+
+	// Err is set if synchronizing with the server failed
+	Err error
+}
+
+// documentRevision is an operation recorded by the server.
+type documentRevision struct {
+	ID        string         `json:"id"`
+	ClientID  string         `json:"client_id"`
+	Revision  int64          `json:"revision"`
+	Operation *TextOperation `json:"operation"`
+}
+
+// Document is a text file in a project edited collaboratively with
+// operational transformation.
+//
+// Local edits are applied immediately to the local document and sent to the
+// server in the background; edits made by other collaborators are
+// transformed against unacknowledged local edits and applied as they
+// arrive, so that every collaborator converges on the same text. Concurrent
+// inserts at the same position are ordered by the server.
+//
+// The document keeps a shadow copy of the last text confirmed by the server.
+// If the local document cannot be reconciled with the server (for example
+// because an edit was rejected), it is reloaded from the server and unsent
+// local edits are discarded, which is reported as a DocumentChange with
+// Resynced set.
+//
+// A Document is a stream: it must be closed when no longer needed.
+type Document struct {
+	*streamState
+	service   *CollaborationService
+	projectID string
+	path      string
+	clientID  string
+	changes   chan DocumentChange
+	kick      chan struct{}
+
+	mu       sync.Mutex
+	text     string
+	shadow   string
+	revision int64
+
+	// pending has been sent to the server but not acknowledged; buffer
+	// holds local edits made since, to be sent once pending is acknowledged
+	pending   *TextOperation
+	pendingID string
+	buffer    *TextOperation
+}
+
+// OpenDocument opens a file of a project for collaborative editing.
+//
+// Parameters:
+//   - ctx: Context that stops synchronization when cancelled
+//   - projectID: ID of the project
+//   - path: Path of the file within the project
+//   - opts: Request options (optional)
+//
+// Returns the document, which must be closed, or an error if it cannot be loaded.
+func (s *CollaborationService) OpenDocument(ctx context.Context, projectID, path string, opts ...RequestOption) (*Document, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+	if path == "" {
+		return nil, NewValidationError("path is required")
+	}
+
+	d := &Document{
+		service:   s,
+		projectID: projectID,
+		path:      path,
+		clientID:  newIdempotencyKey(),
+		changes:   make(chan DocumentChange, 64),
+		kick:      make(chan struct{}, 1),
+	}
+	if err := d.load(ctx); err != nil {
+		return nil, fmt.Errorf("failed to open document %s: %w", path, err)
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	d.streamState = newStreamState(nil, s.client.metrics)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		d.receiveLoop(runCtx)
+	}()
+	go func() {
+		defer wg.Done()
+		d.sendLoop(runCtx)
+	}()
+	go func() {
+		select {
+		case <-runCtx.Done():
+		case <-d.Done():
+		}
+		cancel()
+		wg.Wait()
+		close(d.changes)
+		d.Close()
+	}()
+
+	return d, nil
+}
+
+// Changes returns the channel on which remote edits and synchronization
+// errors are reported. The channel is closed when the document is closed.
+// Changes are dropped if the channel is not drained; Text always returns
+// the current document.
+func (d *Document) Changes() <-chan DocumentChange {
+	return d.changes
+}
+
+// Text returns the local document, including unacknowledged local edits.
+func (d *Document) Text() string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.text
+}
+
+// Shadow returns the last document text confirmed by the server.
+func (d *Document) Shadow() string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.shadow
+}
+
+// Revision returns the server revision the document is synchronized to.
+func (d *Document) Revision() int64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.revision
+}
+
+// Synced reports whether all local edits have been acknowledged by the server.
+func (d *Document) Synced() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.pending == nil && d.buffer == nil
+}
+
+// Apply applies a local edit to the document and queues it for sending.
+//
+// Parameters:
+//   - op: Edit whose BaseLength is the length of the current local document
+//
+// Returns an error if the edit does not fit the document.
+func (d *Document) Apply(op *TextOperation) error {
+	if d.isClosed() {
+		return ErrStreamClosed
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.applyLocked(op)
+}
+
+// Insert inserts text at a position of the local document.
+//
+// Parameters:
+//   - pos: Position in characters, from 0 to the document length
+//   - text: Text to insert
+//
+// Returns an error if the position is out of range.
+func (d *Document) Insert(pos int, text string) error {
+	if d.isClosed() {
+		return ErrStreamClosed
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	length := len([]rune(d.text))
+	if pos < 0 || pos > length {
+		return NewValidationError(fmt.Sprintf("position %d is outside the document", pos))
+	}
+	return d.applyLocked(NewTextOperation().Retain(pos).Insert(text).Retain(length - pos))
+}
+
+// Delete deletes characters from the local document.
+//
+// Parameters:
+//   - pos: Position of the first character to delete
+//   - count: Number of characters to delete
+//
+// Returns an error if the range is outside the document.
+func (d *Document) Delete(pos, count int) error {
+	if d.isClosed() {
+		return ErrStreamClosed
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	length := len([]rune(d.text))
+	if pos < 0 || count < 0 || pos+count > length {
+		return NewValidationError(fmt.Sprintf("range %d+%d is outside the document", pos, count))
+	}
+	return d.applyLocked(NewTextOperation().Retain(pos).Delete(count).Retain(length - pos - count))
+}
+
+// applyLocked applies a local edit and adds it to the buffer. d.mu must be held.
+func (d *Document) applyLocked(op *TextOperation) error {
+	if op.IsNoop() && op.BaseLength == op.TargetLength {
+		return nil
+	}
+
+	text, err := op.Apply(d.text)
+	if err != nil {
+		return err
+	}
+	if d.buffer == nil {
+		d.buffer = op
+	} else {
+		buffer, err := ComposeTextOperations(d.buffer, op)
+		if err != nil {
+			return err
+		}
+		d.buffer = buffer
+	}
+	d.text = text
+
+	select {
+	case d.kick <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// load replaces the document with the server's copy.
+func (d *Document) load(ctx context.Context) error {
+	var snapshot struct {
+		Content  string `json:"content"`
+		Revision int64  `json:"revision"`
+	}
+	params := map[string]string{"path": d.path}
+	if err := d.service.client.Get(ctx, fmt.Sprintf("/projects/%s/documents", d.projectID), params, &snapshot); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	d.text, d.shadow, d.revision = snapshot.Content, snapshot.Content, snapshot.Revision
+	d.pending, d.pendingID, d.buffer = nil, "", nil
+	d.mu.Unlock()
+	return nil
+}
+
+// resync reloads the document after it could not be reconciled with the server.
+func (d *Document) resync(ctx context.Context, cause error) {
+	if err := d.load(ctx); err != nil {
+		d.emit(DocumentChange{Err: fmt.Errorf("failed to resync document %s: %w", d.path, err)})
+		return
+	}
+	d.emit(DocumentChange{
+		Revision: d.Revision(),
+		Resynced: true,
+		Err:      NewCollaborationError(fmt.Sprintf("document %s was reloaded, discarding unsent edits: %v", d.path, cause)),
+	})
+}
+
+// emit reports a change, dropping it if the channel is full.
+func (d *Document) emit(change DocumentChange) {
+	select {
+	case d.changes <- change:
+	default:
+	}
+}
+
+// sendLoop sends buffered local edits, one operation at a time.
+func (d *Document) sendLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.kick:
+		}
+
+		d.mu.Lock()
+		if d.pending != nil || d.buffer == nil {
+			d.mu.Unlock()
+			continue
+		}
+		d.pending, d.pendingID, d.buffer = d.buffer, newIdempotencyKey(), nil
+		data := map[string]interface{}{
+			"id":        d.pendingID,
+			"client_id": d.clientID,
+			"path":      d.path,
+			"revision":  d.revision,
+			"operation": d.pending,
+		}
+		d.mu.Unlock()
+
+		// The operation is acknowledged when it comes back from the server
+		// in receiveLoop, in order with the operations of other collaborators.
+		delay := time.Second
+		for {
+			err := d.service.client.Post(ctx, fmt.Sprintf("/projects/%s/documents/operations", d.projectID), data, nil)
+			if err == nil || ctx.Err() != nil {
+				break
+			}
+			var validation *ValidationError
+			if errors.As(err, &validation) {
+				d.resync(ctx, err)
+				break
+			}
+
+			d.emit(DocumentChange{Err: fmt.Errorf("failed to send edit to document %s: %w", d.path, err)})
+			select {
+			case <-time.After(jitter(delay)):
+			case <-ctx.Done():
+				return
+			}
+			if delay < 30*time.Second {
+				delay *= 2
+			}
+		}
+	}
+}
+
+// receiveLoop long-polls the server for new operations and applies them.
+func (d *Document) receiveLoop(ctx context.Context) {
+	delay := time.Second
+	for {
+		var result struct {
+			Operations []documentRevision `json:"operations"`
+		}
+		params := map[string]string{
+			"path":  d.path,
+			"since": strconv.FormatInt(d.Revision(), 10),
+			"wait":  strconv.Itoa(int(documentPollWait / time.Second)),
+		}
+		err := d.service.client.Get(ctx, fmt.Sprintf("/projects/%s/documents/operations", d.projectID), params, &result)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			d.emit(DocumentChange{Err: fmt.Errorf("failed to receive edits to document %s: %w", d.path, err)})
+			select {
+			case <-time.After(jitter(delay)):
+			case <-ctx.Done():
+				return
+			}
+			if delay < 30*time.Second {
+				delay *= 2
+			}
+			continue
+		}
+		delay = time.Second
+
+		for _, rev := range result.Operations {
+			if err := d.receive(rev); err != nil {
+				d.resync(ctx, err)
+				break
+			}
+		}
+	}
+}
+
+// receive applies an operation recorded by the server: either the
+// acknowledgement of the pending local edit, or a remote edit.
+func (d *Document) receive(rev documentRevision) error {
+	d.mu.Lock()
+	if rev.Revision <= d.revision {
+		d.mu.Unlock()
+		return nil
+	}
+	if rev.Operation == nil {
+		d.mu.Unlock()
+		return NewCollaborationError(fmt.Sprintf("revision %d has no operation", rev.Revision))
+	}
+
+	shadow, err := rev.Operation.Apply(d.shadow)
+	if err != nil {
+		d.mu.Unlock()
+		return err
+	}
+
+	if d.pending != nil && rev.ID == d.pendingID {
+		d.shadow, d.revision = shadow, rev.Revision
+		d.pending, d.pendingID = nil, ""
+		d.mu.Unlock()
+
+		select {
+		case d.kick <- struct{}{}:
+		default:
+		}
+		return nil
+	}
+
+	// Transform the remote edit past the local edits the server has not
+	// seen yet, and those past the remote edit.
+	op := rev.Operation
+	pending, buffer := d.pending, d.buffer
+	if pending != nil {
+		if pending, op, err = TransformTextOperations(pending, op); err != nil {
+			d.mu.Unlock()
+			return err
+		}
+	}
+	if buffer != nil {
+		if buffer, op, err = TransformTextOperations(buffer, op); err != nil {
+			d.mu.Unlock()
+			return err
+		}
+	}
+	text, err := op.Apply(d.text)
+	if err != nil {
+		d.mu.Unlock()
+		return err
+	}
+
+	d.text, d.shadow, d.revision = text, shadow, rev.Revision
+	d.pending, d.buffer = pending, buffer
+	d.mu.Unlock()
+
+	d.emit(DocumentChange{Operation: op, Revision: rev.Revision, ClientID: rev.ClientID})
+	return nil
+}
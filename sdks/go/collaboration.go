@@ -0,0 +1,7 @@
+package zoptal
+
+// CollaborationService handles real-time collaboration on project files,
+// such as editing a shared document with other users.
+type CollaborationService struct {
+	client *HTTPClient
+}
@@ -0,0 +1,75 @@
+package zoptal
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"log"
+	"net/http"
+	"sync/atomic"
+)
+
+// defaultCompressionThreshold is the minimum request body size that is compressed.
+const defaultCompressionThreshold = 1024
+
+// compressor gzip-compresses large request bodies.
+//
+// If the API rejects a compressed body with 415 Unsupported Media Type, the
+// request is resent uncompressed and compression is disabled for the rest of
+// the client's lifetime.
+type compressor struct {
+	threshold int
+	disabled  atomic.Bool
+}
+
+// newCompressor creates a compressor, applying the default threshold.
+func newCompressor(threshold int) *compressor {
+	if threshold <= 0 {
+		threshold = defaultCompressionThreshold
+	}
+	return &compressor{threshold: threshold}
+}
+
+// doCompressed sends a request with a body, gzip-compressing the body when it
+// is at least the compression threshold in size.
+func (c *HTTPClient) doCompressed(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	if len(body) < c.compressor.threshold || req.Header.Get("Content-Encoding") != "" {
+		return c.send(req)
+	}
+
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	if _, err := zw.Write(body); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	gzipReq := req.Clone(req.Context())
+	gzipReq.Body = io.NopCloser(bytes.NewReader(compressed.Bytes()))
+	gzipReq.ContentLength = int64(compressed.Len())
+	gzipReq.Header.Set("Content-Encoding", "gzip")
+
+	resp, err := c.send(gzipReq)
+	if err != nil || resp.StatusCode != http.StatusUnsupportedMediaType {
+		return resp, err
+	}
+
+	// The API does not accept compressed bodies; fall back to plain requests.
+	resp.Body.Close()
+	c.compressor.disabled.Store(true)
+	if c.debug {
+		log.Printf("HTTP %s %s: server rejected gzip request body, disabling compression", req.Method, req.URL)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	return c.send(req)
+}
@@ -0,0 +1,410 @@
+package zoptal
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configPollInterval is how often a watched configuration file is checked for changes.
+var configPollInterval = 2 * time.Second
+
+// clientSettings are the settings of an HTTPClient that can be reloaded
+// while it is in use. A clientSettings is never modified once stored;
+// reloading replaces it as a whole, so that each request sees either the
+// old or the new settings, never a mix.
+type clientSettings struct {
+	client        *http.Client
+	timeout       time.Duration
+	maxRetries    int
+	rateLimit     *RateLimitOptions
+	limiter       *rateLimiter
+	modelRouting  *ModelRoutingOptions
+	modelFallback *ModelFallbackOptions
+}
+
+// liveSettings holds the settings in effect and those the client was
+// constructed with. It is shared by a client and the clients derived from
+// it with WithScopes, so that a reload applies to all of them.
+type liveSettings struct {
+	mu      sync.Mutex
+	current atomic.Pointer[clientSettings]
+	base    *clientSettings
+}
+
+// current returns the settings in effect.
+func (c *HTTPClient) current() *clientSettings {
+	return c.settings.current.Load()
+}
+
+// FileConfig is the contents of a YAML client configuration file:
+//
+//	timeout: 20s
+//	max_retries: 5
+//	rate_limit:
+//	  requests_per_second: 10
+//	  burst: 20
+//	  max_wait: 5s
+//	model_routing:
+//	  routes:
+//	    - class: chat
+//	      max_request_tokens: 2000
+//	      model: gpt-4o-mini
+//	  default: gpt-4o
+//	model_fallback:
+//	  - gpt-4o-mini
+//
+// All fields are optional; unknown fields are rejected so that misspelled
+// settings do not go unnoticed.
+type FileConfig struct {
+	Timeout       *time.Duration    `yaml:"timeout"`
+	MaxRetries    *int              `yaml:"max_retries"`
+	RateLimit     *FileRateLimit    `yaml:"rate_limit"`
+	ModelRouting  *FileModelRouting `yaml:"model_routing"`
+	ModelFallback []string          `yaml:"model_fallback"`
+}
+
+// FileRateLimit is the rate_limit section of a FileConfig.
+type FileRateLimit struct {
+	RequestsPerSecond float64       `yaml:"requests_per_second"`
+	Burst             int           `yaml:"burst"`
+	MaxWait           time.Duration `yaml:"max_wait"`
+}
+
+// FileModelRouting is the model_routing section of a FileConfig.
+type FileModelRouting struct {
+	Routes  []FileModelRoute `yaml:"routes"`
+	Default string           `yaml:"default"`
+}
+
+// FileModelRoute is a route of a FileModelRouting.
+type FileModelRoute struct {
+	Class            OperationClass `yaml:"class"`
+	MaxRequestTokens int            `yaml:"max_request_tokens"`
+	Model            string         `yaml:"model"`
+}
+
+// LoadFileConfig reads and validates a YAML client configuration file.
+//
+// Parameters:
+//   - path: Path of the configuration file
+//
+// Returns the configuration, or an error if the file cannot be read, is
+// not valid YAML, has unknown fields, or has invalid values.
+func LoadFileConfig(path string) (*FileConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return parseFileConfig(data)
+}
+
+// parseFileConfig decodes and validates the contents of a configuration file.
+func parseFileConfig(data []byte) (*FileConfig, error) {
+	config := &FileConfig{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Validate checks the values of the configuration.
+//
+// Returns a ValidationError for the first invalid value, or nil.
+func (f *FileConfig) Validate() error {
+	if f.Timeout != nil && *f.Timeout <= 0 {
+		return NewValidationError("timeout must be positive")
+	}
+	if f.MaxRetries != nil && *f.MaxRetries < 0 {
+		return NewValidationError("max_retries must not be negative")
+	}
+	if r := f.RateLimit; r != nil {
+		if !(r.RequestsPerSecond > 0) || math.IsInf(r.RequestsPerSecond, 1) {
+			return NewValidationError("rate_limit.requests_per_second must be positive and finite")
+		}
+		if r.Burst < 0 || r.MaxWait < 0 {
+			return NewValidationError("rate_limit.burst and rate_limit.max_wait must not be negative")
+		}
+	}
+	if r := f.ModelRouting; r != nil {
+		for i, route := range r.Routes {
+			switch route.Class {
+			case "", OperationChat, OperationCompletion, OperationAnalysis:
+			default:
+				return NewValidationError(fmt.Sprintf("model_routing.routes[%d]: unknown class %q", i, route.Class))
+			}
+			if route.Model == "" {
+				return NewValidationError(fmt.Sprintf("model_routing.routes[%d]: model is required", i))
+			}
+			if route.MaxRequestTokens < 0 {
+				return NewValidationError(fmt.Sprintf("model_routing.routes[%d]: max_request_tokens must not be negative", i))
+			}
+		}
+	}
+	for i, model := range f.ModelFallback {
+		if model == "" {
+			return NewValidationError(fmt.Sprintf("model_fallback[%d]: model is required", i))
+		}
+	}
+	return nil
+}
+
+// ConfigEvent reports a change of a watched configuration file.
+type ConfigEvent struct {
+	// Path is the path of the configuration file
+	Path string
+
+	// Applied reports whether the new configuration is in effect
+	Applied bool
+
+	// Changes describes the settings that changed, such as
+	// "timeout: 30s -> 20s"; empty if the file changed but no setting did
+	Changes []string
+
+	// Err is set if the configuration was rejected; the client keeps its
+	// previous settings
+	Err error
+}
+
+// ConfigWatcher applies changes of a configuration file to a client.
+//
+// A ConfigWatcher is a stream: it must be closed when no longer needed.
+type ConfigWatcher struct {
+	*streamState
+}
+
+// WatchConfig applies the settings of a YAML configuration file (see
+// FileConfig) to a live client, and applies them again whenever the file
+// changes, so that timeouts, retries, rate limits, and model routing can be
+// tuned without restarting the service.
+//
+// Each change is validated as a whole: if any value is invalid, the change
+// is rejected and the client keeps its previous settings. Valid changes are
+// applied atomically; requests already in flight finish with the settings
+// they started with. Settings the file does not set revert to those the
+// client was created with. Other options, such as the base URL and
+// credentials, cannot be changed this way.
+//
+// Parameters:
+//   - client: Client to configure
+//   - path: Path of the configuration file
+//   - onChange: Called with each applied or rejected change (optional)
+//
+// Returns the watcher, which must be closed, or an error if the file
+// cannot be loaded initially.
+func WatchConfig(client *Client, path string, onChange func(ConfigEvent)) (*ConfigWatcher, error) {
+	if client == nil {
+		return nil, NewValidationError("client is required")
+	}
+	if path == "" {
+		return nil, NewValidationError("config path is required")
+	}
+
+	data, info, err := readConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config, err := parseFileConfig(data)
+	if err != nil {
+		return nil, err
+	}
+	client.httpClient.applyConfig(config)
+
+	w := &ConfigWatcher{
+		streamState: newStreamState(nil, client.httpClient.metrics),
+	}
+	go w.poll(client.httpClient, path, info, sha256.Sum256(data), onChange)
+	return w, nil
+}
+
+// poll checks the configuration file for changes until the watcher is closed.
+func (w *ConfigWatcher) poll(c *HTTPClient, path string, info os.FileInfo, sum [sha256.Size]byte, onChange func(ConfigEvent)) {
+	defer c.metrics.track(GoroutinePollers)()
+
+	ticker := time.NewTicker(configPollInterval)
+	defer ticker.Stop()
+
+	emit := func(event ConfigEvent) {
+		event.Path = path
+		if c.debug {
+			if event.Err != nil {
+				log.Printf("Rejected configuration %s: %v", path, event.Err)
+			} else {
+				log.Printf("Applied configuration %s: %v", path, event.Changes)
+			}
+		}
+		if onChange != nil {
+			onChange(event)
+		}
+	}
+
+	var lastErr string
+	for {
+		select {
+		case <-w.Done():
+			return
+		case <-ticker.C:
+		}
+
+		stat, err := os.Stat(path)
+		if err == nil && info != nil && stat.ModTime().Equal(info.ModTime()) && stat.Size() == info.Size() {
+			continue
+		}
+		data, stat, err := readConfigFile(path)
+		if err != nil {
+			// Report a missing or unreadable file once, not on every poll.
+			if err.Error() != lastErr {
+				lastErr = err.Error()
+				emit(ConfigEvent{Err: err})
+			}
+			info = nil
+			continue
+		}
+		info = stat
+		next := sha256.Sum256(data)
+		if next == sum && lastErr == "" {
+			continue
+		}
+		sum = next
+		lastErr = ""
+
+		config, err := parseFileConfig(data)
+		if err != nil {
+			lastErr = err.Error()
+			emit(ConfigEvent{Err: err})
+			continue
+		}
+		emit(ConfigEvent{Applied: true, Changes: c.applyConfig(config)})
+	}
+}
+
+// readConfigFile reads a configuration file and its metadata.
+func readConfigFile(path string) ([]byte, os.FileInfo, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return data, info, nil
+}
+
+// applyConfig replaces the client's settings with those of a validated
+// configuration, falling back to the construction settings for those it
+// does not set, and returns descriptions of the settings that changed.
+func (c *HTTPClient) applyConfig(config *FileConfig) []string {
+	c.settings.mu.Lock()
+	defer c.settings.mu.Unlock()
+
+	prev := c.current()
+	next := *c.settings.base
+	var changes []string
+
+	if config.Timeout != nil {
+		next.timeout = *config.Timeout
+	}
+	switch {
+	case next.timeout == prev.timeout:
+		next.client = prev.client
+	case next.timeout != c.settings.base.timeout:
+		client := *c.settings.base.client
+		client.Timeout = next.timeout
+		next.client = &client
+	}
+	if next.timeout != prev.timeout {
+		changes = append(changes, fmt.Sprintf("timeout: %v -> %v", prev.timeout, next.timeout))
+	}
+
+	if config.MaxRetries != nil {
+		next.maxRetries = *config.MaxRetries
+	}
+	if next.maxRetries != prev.maxRetries {
+		changes = append(changes, fmt.Sprintf("max_retries: %d -> %d", prev.maxRetries, next.maxRetries))
+	}
+
+	if r := config.RateLimit; r != nil {
+		options := &RateLimitOptions{
+			RequestsPerSecond: r.RequestsPerSecond,
+			Burst:             r.Burst,
+			MaxWait:           r.MaxWait,
+		}
+		if base := c.settings.base.rateLimit; base != nil {
+			options.Backend = base.Backend
+			options.Key = base.Key
+		}
+		next.rateLimit = options
+		next.limiter = newRateLimiter(options, c.apiKey, c.debug)
+	}
+	if reflect.DeepEqual(next.rateLimit, prev.rateLimit) {
+		// Keep the bucket, and the requests it has counted, if the limit is unchanged.
+		next.rateLimit, next.limiter = prev.rateLimit, prev.limiter
+	} else {
+		changes = append(changes, fmt.Sprintf("rate_limit: %s -> %s", describeRateLimit(prev.rateLimit), describeRateLimit(next.rateLimit)))
+	}
+
+	if r := config.ModelRouting; r != nil {
+		routing := &ModelRoutingOptions{Default: r.Default}
+		for _, route := range r.Routes {
+			routing.Routes = append(routing.Routes, ModelRoute(route))
+		}
+		next.modelRouting = routing
+	}
+	if !reflect.DeepEqual(next.modelRouting, prev.modelRouting) {
+		changes = append(changes, "model_routing")
+	}
+
+	if config.ModelFallback != nil {
+		fallback := &ModelFallbackOptions{Models: config.ModelFallback}
+		if c.settings.base.modelFallback != nil {
+			fallback.OnFallback = c.settings.base.modelFallback.OnFallback
+		}
+		next.modelFallback = fallback
+	}
+	if fallbackModels(next.modelFallback) != fallbackModels(prev.modelFallback) {
+		changes = append(changes, fmt.Sprintf("model_fallback: [%s] -> [%s]", fallbackModels(prev.modelFallback), fallbackModels(next.modelFallback)))
+	} else {
+		next.modelFallback = prev.modelFallback
+	}
+
+	c.settings.current.Store(&next)
+	return changes
+}
+
+// describeRateLimit returns a short description of a rate limit.
+func describeRateLimit(options *RateLimitOptions) string {
+	if options == nil {
+		return "none"
+	}
+	if options.Burst <= 0 {
+		return fmt.Sprintf("%g/s", options.RequestsPerSecond)
+	}
+	return fmt.Sprintf("%g/s burst %d", options.RequestsPerSecond, options.Burst)
+}
+
+// fallbackModels returns the fallback models of options, comma-separated.
+func fallbackModels(options *ModelFallbackOptions) string {
+	if options == nil {
+		return ""
+	}
+	return strings.Join(options.Models, ", ")
+}
@@ -0,0 +1,214 @@
+package zoptal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// credentialsTimeout bounds the resolution of credentials when a client is
+// created, so that an unreachable secret store does not hang the caller.
+const credentialsTimeout = 30 * time.Second
+
+// ErrNoCredentials is returned, possibly wrapped, by a CredentialsProvider
+// that has no credentials, so that a chain tries the next provider.
+var ErrNoCredentials = errors.New("no credentials")
+
+// Credentials are the credentials a client authenticates with.
+type Credentials struct {
+	APIKey string
+
+	// Source describes where the credentials were found, e.g. "environment",
+	// for debug logging
+	Source string
+}
+
+// CredentialsProvider looks up the API key of a client, for example in a
+// secret store such as Vault or AWS Secrets Manager. Providers are
+// combined with ChainCredentials.
+type CredentialsProvider interface {
+	// Credentials returns the credentials, or an error wrapping
+	// ErrNoCredentials if the provider has none. Other errors stop a chain.
+	Credentials(ctx context.Context) (*Credentials, error)
+}
+
+// CredentialsProviderFunc is a CredentialsProvider implemented by a
+// function.
+//
+// Example usage:
+//
+//	vault := zoptal.CredentialsProviderFunc(func(ctx context.Context) (*zoptal.Credentials, error) {
+//	    secret, err := vaultClient.KVv2("secret").Get(ctx, "zoptal")
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    apiKey, _ := secret.Data["api_key"].(string)
+//	    return &zoptal.Credentials{APIKey: apiKey, Source: "vault"}, nil
+//	})
+//	client, err := zoptal.New("", zoptal.WithCredentials(zoptal.ChainCredentials(zoptal.EnvCredentials(), vault)))
+type CredentialsProviderFunc func(ctx context.Context) (*Credentials, error)
+
+// Credentials implements CredentialsProvider.
+func (f CredentialsProviderFunc) Credentials(ctx context.Context) (*Credentials, error) {
+	return f(ctx)
+}
+
+// StaticCredentials returns a provider of a fixed API key.
+//
+// Parameters:
+//   - apiKey: API key; the provider has no credentials if it is empty
+//
+// Returns the provider.
+func StaticCredentials(apiKey string) CredentialsProvider {
+	return CredentialsProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		if apiKey == "" {
+			return nil, fmt.Errorf("%w: no API key given", ErrNoCredentials)
+		}
+		return &Credentials{APIKey: apiKey, Source: "static"}, nil
+	})
+}
+
+// EnvCredentials returns a provider of the API key in the ZOPTAL_API_KEY
+// environment variable.
+//
+// Returns the provider.
+func EnvCredentials() CredentialsProvider {
+	return CredentialsProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		apiKey := os.Getenv(EnvAPIKey)
+		if apiKey == "" {
+			return nil, fmt.Errorf("%w: %s is not set", ErrNoCredentials, EnvAPIKey)
+		}
+		return &Credentials{APIKey: apiKey, Source: "environment"}, nil
+	})
+}
+
+// ProfileCredentials returns a provider of the API key of a profile in the
+// configuration file (see ConfigFilePath). A missing file or profile means
+// no credentials; an invalid file is an error.
+//
+// Parameters:
+//   - profile: Name of the profile (default: ZOPTAL_PROFILE, or "default")
+//
+// Returns the provider.
+func ProfileCredentials(profile string) CredentialsProvider {
+	return CredentialsProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		name := profileName(profile)
+		path, err := ConfigFilePath()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrNoCredentials, err)
+		}
+		config, err := LoadConfigFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s does not exist", ErrNoCredentials, path)
+		}
+		if err != nil {
+			return nil, err
+		}
+		selected, ok := config.Profiles[name]
+		if !ok || selected.APIKey == "" {
+			return nil, fmt.Errorf("%w: no api_key in profile %q of %s", ErrNoCredentials, name, path)
+		}
+		return &Credentials{APIKey: selected.APIKey, Source: fmt.Sprintf("profile %q of %s", name, path)}, nil
+	})
+}
+
+// ChainCredentials returns a provider trying providers in order, returning
+// the first credentials found. A provider error not wrapping
+// ErrNoCredentials stops the chain, so that a failing secret store is not
+// silently bypassed.
+//
+// Parameters:
+//   - providers: Providers, most preferred first
+//
+// Returns the provider.
+func ChainCredentials(providers ...CredentialsProvider) CredentialsProvider {
+	return CredentialsProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		var reasons []string
+		for _, provider := range providers {
+			if provider == nil {
+				continue
+			}
+			credentials, err := provider.Credentials(ctx)
+			if err == nil && credentials != nil && credentials.APIKey != "" {
+				return credentials, nil
+			}
+			if err != nil && !errors.Is(err, ErrNoCredentials) {
+				return nil, err
+			}
+			if err != nil {
+				reasons = append(reasons, strings.TrimPrefix(err.Error(), ErrNoCredentials.Error()+": "))
+			}
+		}
+		if len(reasons) == 0 {
+			return nil, ErrNoCredentials
+		}
+		return nil, fmt.Errorf("%w: %s", ErrNoCredentials, strings.Join(reasons, "; "))
+	})
+}
+
+// DefaultCredentials returns the provider chain of NewClientFromEnv: the
+// ZOPTAL_API_KEY environment variable, then the profile's api_key in the
+// configuration file, then the OS keychain (see KeychainCredentials).
+//
+// Parameters:
+//   - profile: Name of the profile (default: ZOPTAL_PROFILE, or "default")
+//
+// Returns the provider.
+func DefaultCredentials(profile string) CredentialsProvider {
+	return ChainCredentials(
+		EnvCredentials(),
+		ProfileCredentials(profile),
+		KeychainCredentials(profile),
+	)
+}
+
+// WithCredentials looks up the API key with a provider when none is passed
+// to New; an API key passed to New takes precedence.
+//
+// Parameters:
+//   - provider: Credentials provider, e.g. DefaultCredentials("")
+//
+// Returns the option.
+func WithCredentials(provider CredentialsProvider) Option {
+	return optionFunc(func(o *ClientOptions) error {
+		o.Credentials = provider
+		return nil
+	})
+}
+
+// resolveAPIKey returns apiKey, or else the API key of the credentials
+// provider of options, if any.
+func resolveAPIKey(apiKey string, options *ClientOptions) (string, error) {
+	if apiKey != "" || options == nil || options.Credentials == nil || options.TokenSource != nil {
+		return apiKey, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), credentialsTimeout)
+	defer cancel()
+	credentials, err := options.Credentials.Credentials(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve credentials: %w", err)
+	}
+	if credentials == nil || credentials.APIKey == "" {
+		return "", fmt.Errorf("failed to resolve credentials: %w", ErrNoCredentials)
+	}
+	if options.Debug {
+		log.Printf("Using Zoptal credentials from %s", credentials.Source)
+	}
+	return credentials.APIKey, nil
+}
+
+// profileName returns the name of the selected profile: name, or else
+// ZOPTAL_PROFILE, or else "default".
+func profileName(name string) string {
+	if name == "" {
+		name = os.Getenv(EnvProfile)
+	}
+	if name == "" {
+		name = DefaultProfile
+	}
+	return name
+}
@@ -0,0 +1,74 @@
+package zoptal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// keychainService is the service name of API keys in the OS keychain.
+const keychainService = "zoptal"
+
+// keychainCommand returns the command printing the API key of a profile
+// stored in the OS keychain, or nil if the OS keychain is not supported.
+var keychainCommand = func(profile string) []string {
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{"security", "find-generic-password", "-s", keychainService, "-a", profile, "-w"}
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return []string{"secret-tool", "lookup", "service", keychainService, "profile", profile}
+	default:
+		return nil
+	}
+}
+
+// KeychainCredentials returns a provider of the API key of a profile stored
+// in the OS keychain: the macOS keychain, or the Secret Service (GNOME
+// Keyring, KWallet) on Linux and BSD. The key is looked up with the
+// security and secret-tool commands; if they are not installed, or the
+// platform is not supported, the provider has no credentials.
+//
+// Store a key with:
+//
+//	security add-generic-password -s zoptal -a default -w              # macOS
+//	secret-tool store --label=Zoptal service zoptal profile default   # Linux
+//
+// Parameters:
+//   - profile: Name of the profile (default: ZOPTAL_PROFILE, or "default")
+//
+// Returns the provider.
+func KeychainCredentials(profile string) CredentialsProvider {
+	return CredentialsProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		name := profileName(profile)
+		command := keychainCommand(name)
+		if command == nil {
+			return nil, fmt.Errorf("%w: the OS keychain is not supported on %s", ErrNoCredentials, runtime.GOOS)
+		}
+
+		var stdout, stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		err := cmd.Run()
+		var exitErr *exec.ExitError
+		switch {
+		case errors.Is(err, exec.ErrNotFound):
+			return nil, fmt.Errorf("%w: %s is not installed", ErrNoCredentials, command[0])
+		case errors.As(err, &exitErr) && ctx.Err() == nil:
+			// Both commands fail when the item does not exist.
+			return nil, fmt.Errorf("%w: profile %q not in the OS keychain", ErrNoCredentials, name)
+		case err != nil:
+			return nil, fmt.Errorf("failed to read the OS keychain: %w", err)
+		}
+
+		apiKey := strings.TrimSpace(stdout.String())
+		if apiKey == "" {
+			return nil, fmt.Errorf("%w: profile %q not in the OS keychain", ErrNoCredentials, name)
+		}
+		return &Credentials{APIKey: apiKey, Source: fmt.Sprintf("OS keychain (profile %q)", name)}, nil
+	})
+}
@@ -0,0 +1,165 @@
+package zoptal
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"time"
+)
+
+// bundleConfig is the configuration of a client in a debug bundle, with
+// secrets reduced to fingerprints and optional features to whether they
+// are enabled.
+type bundleConfig struct {
+	BaseURL        string        `json:"base_url"`
+	ActiveEndpoint string        `json:"active_endpoint"`
+	Credential     string        `json:"credential"`
+	Scopes         []string      `json:"scopes,omitempty"`
+	Timeout        time.Duration `json:"timeout_ns"`
+	MaxRetries     int           `json:"max_retries"`
+	Debug          bool          `json:"debug"`
+
+	FailoverEndpoints   int                `json:"failover_endpoints"`
+	Features            map[string]bool    `json:"features"`
+	ServerCapabilities  ServerCapabilities `json:"server_capabilities"`
+	ModelRouting        bool               `json:"model_routing"`
+	ModelFallbackModels []string           `json:"model_fallback_models,omitempty"`
+}
+
+// bundleRateLimit is the state of the client-side rate limiter in a debug
+// bundle.
+type bundleRateLimit struct {
+	Enabled           bool          `json:"enabled"`
+	RequestsPerSecond float64       `json:"requests_per_second,omitempty"`
+	Burst             int           `json:"burst,omitempty"`
+	Shared            bool          `json:"shared"`
+	MaxWait           time.Duration `json:"max_wait_ns,omitempty"`
+
+	// AvailableTokens is the content of the in-process bucket; with a
+	// shared backend it is used only while the backend fails
+	AvailableTokens float64 `json:"available_tokens,omitempty"`
+}
+
+// DebugBundle writes a diagnostic bundle for SDK bug reports to w: a zip
+// archive of the client's configuration, traffic stats, health, rate
+// limiter state, goroutines (including open streams), and its last 100
+// requests. The bundle is sanitized for sharing: credentials appear only as
+// fingerprints, and requests are identified by operation and request ID,
+// without paths, resource IDs, or bodies.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - w: Destination of the zip archive
+//
+// Returns an error if writing fails.
+//
+// Example usage:
+//
+//	f, err := os.Create("zoptal-debug.zip")
+//	if err != nil {
+//	    return err
+//	}
+//	defer f.Close()
+//	if err := client.DebugBundle(ctx, f); err != nil {
+//	    return err
+//	}
+func (c *Client) DebugBundle(ctx context.Context, w io.Writer) error {
+	entries := []struct {
+		name  string
+		value func() interface{}
+	}{
+		{"manifest.json", func() interface{} {
+			return map[string]string{
+				"sdk_version": Version,
+				"go_version":  runtime.Version(),
+				"platform":    runtime.GOOS + "/" + runtime.GOARCH,
+				"created_at":  time.Now().UTC().Format(time.RFC3339),
+			}
+		}},
+		{"config.json", func() interface{} { return c.bundleConfig() }},
+		{"health.json", func() interface{} { return c.Health() }},
+		{"stats.json", func() interface{} { return c.Stats() }},
+		{"runtime.json", func() interface{} { return c.RuntimeMetrics() }},
+		{"rate_limit.json", func() interface{} { return c.bundleRateLimit() }},
+		{"requests.json", func() interface{} { return c.httpClient.stats.recentRequests() }},
+	}
+
+	archive := zip.NewWriter(w)
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return canceled(ctx)
+		}
+		data, err := json.MarshalIndent(entry.value(), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", entry.name, err)
+		}
+		f, err := archive.Create(entry.name)
+		if err != nil {
+			return fmt.Errorf("failed to write debug bundle: %w", err)
+		}
+		if _, err := f.Write(data); err != nil {
+			return fmt.Errorf("failed to write debug bundle: %w", err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to write debug bundle: %w", err)
+	}
+	return nil
+}
+
+// bundleConfig returns the sanitized configuration of the client.
+func (c *Client) bundleConfig() bundleConfig {
+	h := c.httpClient
+	settings := h.current()
+	config := bundleConfig{
+		BaseURL:            h.baseURL,
+		ActiveEndpoint:     h.activeBaseURL(),
+		Credential:         h.credentialID(),
+		Timeout:            settings.timeout,
+		MaxRetries:         settings.maxRetries,
+		Debug:              h.debug,
+		ServerCapabilities: h.capabilities.snapshot(),
+		ModelRouting:       settings.modelRouting != nil,
+		Features: map[string]bool{
+			"cache":                  h.cache != nil,
+			"stale_while_revalidate": h.swr != nil,
+			"hedging":                h.hedger != nil,
+			"compression":            h.compressor != nil,
+			"mirror":                 h.mirror != nil,
+			"failover":               h.failover != nil,
+			"encryption":             h.encryption != nil,
+			"audit":                  h.audit != nil,
+			"degradation":            h.degradation != nil,
+			"oauth":                  h.tokens != nil,
+		},
+	}
+	if h.scopes != nil {
+		config.Scopes = h.scopes.names
+	}
+	if h.failover != nil {
+		config.FailoverEndpoints = len(h.failover.endpoints) - 1
+	}
+	if settings.modelFallback != nil {
+		config.ModelFallbackModels = settings.modelFallback.Models
+	}
+	return config
+}
+
+// bundleRateLimit returns the state of the client-side rate limiter.
+func (c *Client) bundleRateLimit() bundleRateLimit {
+	l := c.httpClient.current().limiter
+	if l == nil {
+		return bundleRateLimit{}
+	}
+	return bundleRateLimit{
+		Enabled:           true,
+		RequestsPerSecond: l.rate,
+		Burst:             l.burst,
+		Shared:            l.backend != nil,
+		MaxWait:           l.maxWait,
+		AvailableTokens:   l.local.available(l.rate, l.burst),
+	}
+}
@@ -0,0 +1,237 @@
+package zoptal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// defaultMaxResponseBytes is the largest response body the client will read.
+const defaultMaxResponseBytes = 32 << 20
+
+// maxErrorMessageLength caps server-provided error messages copied into errors.
+const maxErrorMessageLength = 1024
+
+// maxErrorBodyLength caps the error response bodies kept in errors.
+const maxErrorBodyLength = 64 << 10
+
+// readBody reads at most limit bytes from r, returning a DecodeError if the
+// body is larger than that.
+func readBody(r io.Reader, limit int64) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if int64(len(body)) > limit {
+		return nil, NewDecodeError(fmt.Sprintf("response body exceeds %d bytes", limit), nil)
+	}
+	return body, nil
+}
+
+// bufferBody reads the body of resp into memory if it holds at most limit
+// bytes, replacing resp.Body with a reader of the buffered body, and reports
+// whether it did. A larger body, such as that of a download, is left to be
+// streamed: resp.Body is replaced with one that reads the bytes read so far
+// and then the rest.
+func bufferBody(resp *http.Response, limit int64) ([]byte, bool, error) {
+	if resp.ContentLength > limit {
+		return nil, false, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, false, err
+	}
+	if int64(len(body)) > limit {
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return nil, false, nil
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return body, true, nil
+}
+
+// decodeJSON decodes a JSON response body into v.
+//
+// All typed response decoding goes through decodeJSON so that malformed or
+// adversarial bodies (truncated JSON, mismatched types, out-of-range numbers,
+// or panicking custom unmarshalers) surface as a DecodeError instead of an
+// untyped error or a panic.
+func decodeJSON(data []byte, v interface{}) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = NewDecodeError(fmt.Sprintf("failed to parse response JSON: %v", r), nil)
+		}
+	}()
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return NewDecodeError(fmt.Sprintf("failed to parse response JSON: %v", err), err)
+	}
+	return nil
+}
+
+// errorMessage extracts the first string-valued field among keys from a JSON
+// error body, returning fallback if the body is not a JSON object or none of
+// the keys hold a string.
+func errorMessage(body []byte, fallback string, keys ...string) string {
+	var errorData map[string]interface{}
+	if decodeJSON(body, &errorData) != nil {
+		return fallback
+	}
+	for _, key := range keys {
+		if message, ok := errorData[key].(string); ok && message != "" {
+			return truncateMessage(message)
+		}
+	}
+	return fallback
+}
+
+// withResponse records the status code, request ID, and body of the error
+// response err was created from in err.
+func withResponse(err error, resp *http.Response, body []byte) error {
+	e, ok := err.(interface{ base() *ZoptalError })
+	if !ok {
+		return err
+	}
+	base := e.base()
+	base.StatusCode = resp.StatusCode
+	base.RequestID = truncateMessage(resp.Header.Get("X-Request-ID"))
+	if len(body) > maxErrorBodyLength {
+		body = body[:maxErrorBodyLength]
+	}
+	base.Body = append([]byte(nil), body...)
+	return err
+}
+
+// truncateMessage shortens s to at most maxErrorMessageLength bytes without
+// splitting a UTF-8 sequence.
+func truncateMessage(s string) string {
+	if len(s) <= maxErrorMessageLength {
+		return s
+	}
+	cut := maxErrorMessageLength
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "..."
+}
+
+// validationError converts a validation error body into a ValidationError
+// listing the invalid inputs. It understands field lists under "errors",
+// "fields", or "detail" (as FastAPI reports them), with each entry naming
+// its input by "field", "path", or "loc", and maps of field names to
+// messages under "errors".
+func validationError(body []byte, fallback string) *ValidationError {
+	message := errorMessage(body, fallback, "detail", "message")
+
+	var errorData map[string]interface{}
+	if decodeJSON(body, &errorData) != nil {
+		return NewValidationError(message)
+	}
+	var fields []FieldError
+	for _, key := range []string{"errors", "fields", "detail"} {
+		if fields = fieldErrors(errorData[key]); len(fields) > 0 {
+			break
+		}
+	}
+	if len(fields) > 0 && message == fallback {
+		summary := make([]string, len(fields))
+		for i, field := range fields {
+			summary[i] = field.Field + ": " + field.Message
+		}
+		message = truncateMessage(fallback + ": " + strings.Join(summary, "; "))
+	}
+	return NewValidationErrorWithFields(message, fields)
+}
+
+// fieldErrors decodes a list of field errors, or a map of field names to
+// messages, returning nil for any other value.
+func fieldErrors(value interface{}) []FieldError {
+	var fields []FieldError
+	switch value := value.(type) {
+	case []interface{}:
+		for _, entry := range value {
+			entry, ok := entry.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			field := FieldError{
+				Field:   fieldPath(entry),
+				Code:    firstString(entry, "code", "type"),
+				Message: truncateMessage(firstString(entry, "message", "msg")),
+			}
+			if field.Field != "" || field.Message != "" {
+				fields = append(fields, field)
+			}
+		}
+	case map[string]interface{}:
+		names := make([]string, 0, len(value))
+		for name := range value {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			switch messages := value[name].(type) {
+			case string:
+				fields = append(fields, FieldError{Field: name, Message: truncateMessage(messages)})
+			case []interface{}:
+				for _, message := range messages {
+					if message, ok := message.(string); ok {
+						fields = append(fields, FieldError{Field: name, Message: truncateMessage(message)})
+					}
+				}
+			case map[string]interface{}:
+				fields = append(fields, FieldError{
+					Field:   name,
+					Code:    firstString(messages, "code", "type"),
+					Message: truncateMessage(firstString(messages, "message", "msg")),
+				})
+			}
+		}
+	}
+	return fields
+}
+
+// fieldPath returns the input path of a field error entry, dropping the
+// request location ("body", "query", or "path") that leads FastAPI paths.
+func fieldPath(entry map[string]interface{}) string {
+	if field := firstString(entry, "field", "path"); field != "" {
+		return field
+	}
+	loc, ok := entry["loc"].([]interface{})
+	if !ok {
+		return ""
+	}
+	parts := make([]string, 0, len(loc))
+	for i, part := range loc {
+		switch part := part.(type) {
+		case string:
+			if i == 0 && (part == "body" || part == "query" || part == "path") && len(loc) > 1 {
+				continue
+			}
+			parts = append(parts, part)
+		case float64:
+			parts = append(parts, strconv.FormatFloat(part, 'f', -1, 64))
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// firstString returns the first string-valued field among keys, or "".
+func firstString(data map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if value, ok := data[key].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
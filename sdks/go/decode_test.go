@@ -0,0 +1,187 @@
+package zoptal
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// panickingValue panics when decoded, like a buggy custom unmarshaler.
+type panickingValue struct{}
+
+func (panickingValue) UnmarshalJSON([]byte) error {
+	panic("unmarshaler bug")
+}
+
+var decodeSeeds = []string{
+	`{}`,
+	`{"files":[{"path":"a.go","size":12,"sha256":"ab","updated_at":"2024-01-02T03:04:05Z"}]}`,
+	`{"files":[{"path":1}]}`,
+	`{"files":[{"size":1e400}]}`,
+	`{"error":"boom","message":"bad request"}`,
+	`{"detail":[{"loc":["body","name"],"msg":"field required","type":"missing"}]}`,
+	`{"files":[`,
+	`[1,2,3]`,
+	`null`,
+	`"\ud800"`,
+	"",
+}
+
+func FuzzDecodeJSON(f *testing.F) {
+	for _, seed := range decodeSeeds {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		targets := []interface{}{
+			&map[string]interface{}{},
+			&struct {
+				Files []RemoteFile `json:"files"`
+			}{},
+			&UsageReport{},
+			&struct {
+				Value panickingValue `json:"value"`
+			}{},
+		}
+		for _, target := range targets {
+			if err := decodeJSON(data, target); err != nil && !IsDecodeError(err) {
+				t.Fatalf("decodeJSON(%q) into %T returned %T, want *DecodeError: %v", data, target, err, err)
+			}
+		}
+	})
+}
+
+func FuzzErrorMessage(f *testing.F) {
+	for _, seed := range decodeSeeds {
+		f.Add([]byte(seed))
+	}
+	f.Add([]byte(`{"error":"` + strings.Repeat("é", maxErrorMessageLength) + `"}`))
+	f.Fuzz(func(t *testing.T, body []byte) {
+		const fallback = "HTTP 400"
+		message := errorMessage(body, fallback, "error", "message")
+		if message == fallback {
+			return
+		}
+		if len(message) > maxErrorMessageLength+len("...") {
+			t.Fatalf("errorMessage returned %d bytes, want at most %d", len(message), maxErrorMessageLength+len("..."))
+		}
+		if json.Valid(body) && !utf8.ValidString(message) {
+			t.Fatalf("errorMessage split a UTF-8 sequence: %q", message)
+		}
+		validationError(body, fallback)
+	})
+}
+
+func FuzzHandleResponse(f *testing.F) {
+	for _, status := range []int{200, 204, 400, 401, 404, 409, 422, 429, 500, 503} {
+		for _, seed := range decodeSeeds {
+			f.Add(status, "", "", []byte(seed))
+		}
+	}
+	f.Add(429, "30", "req-1", []byte(`{}`))
+	f.Add(429, "Wed, 21 Oct 2015 07:28:00 GMT", "", []byte(""))
+	f.Add(429, "-1", strings.Repeat("é", maxErrorMessageLength), []byte("{"))
+	f.Fuzz(func(t *testing.T, status int, retryAfter, requestID string, body []byte) {
+		if status < 100 || status > 999 {
+			return
+		}
+		header := http.Header{}
+		header.Set("Content-Type", "application/json")
+		header.Set("Retry-After", retryAfter)
+		header.Set("X-Request-ID", requestID)
+		req := httptest.NewRequest(http.MethodGet, "https://api.zoptal.com/v1/projects", nil)
+		resp := &http.Response{
+			StatusCode: status,
+			Header:     header,
+			Body:       io.NopCloser(bytes.NewReader(body)),
+			Request:    req,
+		}
+
+		var result map[string]interface{}
+		err := (&HTTPClient{}).handleResponse(resp, &result)
+		if err == nil {
+			if status >= 400 {
+				t.Fatalf("handleResponse(%d, %q) returned no error", status, body)
+			}
+			return
+		}
+		typed, ok := err.(interface{ base() *ZoptalError })
+		if !ok {
+			t.Fatalf("handleResponse(%d, %q) returned %T, want an SDK error: %v", status, body, err, err)
+		}
+		if status >= 400 && typed.base().StatusCode != status {
+			t.Fatalf("handleResponse(%d, %q) recorded status %d", status, body, typed.base().StatusCode)
+		}
+	})
+}
+
+func TestReadBodyLimit(t *testing.T) {
+	body, err := readBody(bytes.NewReader(make([]byte, defaultMaxResponseBytes)), defaultMaxResponseBytes)
+	if err != nil {
+		t.Fatalf("readBody at the limit: %v", err)
+	}
+	if len(body) != defaultMaxResponseBytes {
+		t.Fatalf("readBody returned %d bytes, want %d", len(body), defaultMaxResponseBytes)
+	}
+
+	_, err = readBody(bytes.NewReader(make([]byte, defaultMaxResponseBytes+1)), defaultMaxResponseBytes)
+	if !IsDecodeError(err) {
+		t.Fatalf("readBody over the limit returned %v, want a *DecodeError", err)
+	}
+}
+
+func TestDecodeJSONRecoversPanic(t *testing.T) {
+	var v struct {
+		Value panickingValue `json:"value"`
+	}
+	err := decodeJSON([]byte(`{"value":1}`), &v)
+	if !IsDecodeError(err) {
+		t.Fatalf("decodeJSON returned %v, want a *DecodeError", err)
+	}
+	if !strings.Contains(err.Error(), "unmarshaler bug") {
+		t.Errorf("error %q does not mention the panic", err)
+	}
+}
+
+func TestDecodeJSONTruncated(t *testing.T) {
+	var v map[string]interface{}
+	err := decodeJSON([]byte(`{"files":[{"path":"a.go"`), &v)
+	if !IsDecodeError(err) {
+		t.Fatalf("decodeJSON returned %v, want a *DecodeError", err)
+	}
+}
+
+func TestTruncateMessage(t *testing.T) {
+	short := "not found"
+	if got := truncateMessage(short); got != short {
+		t.Errorf("truncateMessage(%q) = %q", short, got)
+	}
+
+	// "é" is two bytes, so the limit falls inside a rune.
+	long := "x" + strings.Repeat("é", maxErrorMessageLength)
+	got := truncateMessage(long)
+	if !strings.HasSuffix(got, "...") {
+		t.Errorf("truncated message %q does not end with an ellipsis", got[len(got)-8:])
+	}
+	if len(got) > maxErrorMessageLength+len("...") {
+		t.Errorf("truncated message is %d bytes, want at most %d", len(got), maxErrorMessageLength+len("..."))
+	}
+	if !utf8.ValidString(got) {
+		t.Error("truncated message splits a UTF-8 sequence")
+	}
+}
+
+func TestErrorMessageTruncated(t *testing.T) {
+	body := []byte(`{"message":"` + strings.Repeat("a", 4*maxErrorMessageLength) + `"}`)
+	got := errorMessage(body, "fallback", "error", "message")
+	if len(got) != maxErrorMessageLength+len("...") {
+		t.Errorf("errorMessage returned %d bytes, want %d", len(got), maxErrorMessageLength+len("..."))
+	}
+	if got := errorMessage([]byte(`{"message":42}`), "fallback", "message"); got != "fallback" {
+		t.Errorf("errorMessage with a non-string message = %q, want the fallback", got)
+	}
+}
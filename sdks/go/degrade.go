@@ -0,0 +1,355 @@
+package zoptal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// DegradationOptions contains options for degrading politely while the AI
+// service is down.
+//
+// After FailureThreshold consecutive AI requests fail because the service is
+// unreachable or erroring, the client enters degraded mode and stops waiting
+// on the service: AI requests are answered with the last-known result of the
+// same request when one is cached, non-urgent requests (see WithNonUrgent)
+// are queued, and other requests fail fast with a *DegradedError. Every
+// RetryInterval one request is let through; when it succeeds the client
+// leaves degraded mode and sends the queued requests.
+type DegradationOptions struct {
+	// FailureThreshold is the number of consecutive failed AI requests
+	// after which the client enters degraded mode (default: 3)
+	FailureThreshold int
+
+	// RetryInterval is how often a request is let through to check whether
+	// the service has recovered (default: 30 seconds)
+	RetryInterval time.Duration
+
+	// Cache stores the last-known results of AI requests (default: an
+	// in-memory cache of 500 results)
+	Cache ResponseCache
+
+	// MaxAge is how old a last-known result may be and still be served
+	// (default: 24 hours)
+	MaxAge time.Duration
+
+	// QueueSize is the maximum number of queued non-urgent requests; requests
+	// beyond it fail with a *DegradedError (default: 100)
+	QueueSize int
+
+	// OnEvent is called when the client enters or leaves degraded mode (optional)
+	OnEvent func(DegradationEvent)
+
+	// OnReplay is called with the outcome of each queued request once it is
+	// sent (optional)
+	OnReplay func(request QueuedRequest, result json.RawMessage, err error)
+}
+
+// DegradationEventType is the kind of a DegradationEvent.
+type DegradationEventType string
+
+// Degradation event types.
+const (
+	DegradedModeEntered DegradationEventType = "degraded_mode_entered"
+	DegradedModeExited  DegradationEventType = "degraded_mode_exited"
+)
+
+// DegradationEvent reports that the client entered or left degraded mode.
+type DegradationEvent struct {
+	Type DegradationEventType
+
+	// Err is the failure that put the client in degraded mode
+	Err error
+
+	// Queued is the number of queued requests; on DegradedModeExited they
+	// are about to be sent
+	Queued int
+
+	Time time.Time
+}
+
+// QueuedRequest is a non-urgent AI request queued while the client was in
+// degraded mode.
+type QueuedRequest struct {
+	Endpoint string
+	Body     json.RawMessage
+	QueuedAt time.Time
+}
+
+// nonUrgentKey is the context key marking non-urgent requests.
+type nonUrgentKey struct{}
+
+// WithNonUrgent returns a context whose AI requests are queued rather than
+// failed while the client is in degraded mode, for work such as background
+// reviews whose result can arrive later through DegradationOptions.OnReplay.
+//
+// Parameters:
+//   - ctx: Parent context
+//
+// Returns the derived context.
+func WithNonUrgent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, nonUrgentKey{}, true)
+}
+
+// degradation tracks AI service health and serves requests while it is down.
+type degradation struct {
+	threshold     int
+	retryInterval time.Duration
+	cache         ResponseCache
+	maxAge        time.Duration
+	queueSize     int
+	onEvent       func(DegradationEvent)
+	onReplay      func(QueuedRequest, json.RawMessage, error)
+	debug         bool
+
+	mu        sync.Mutex
+	failures  int
+	degraded  bool
+	lastErr   error
+	lastProbe time.Time
+	queue     []QueuedRequest
+}
+
+// newDegradation creates a degradation from the given options, applying defaults.
+func newDegradation(options *DegradationOptions, debug bool) *degradation {
+	d := &degradation{
+		threshold:     options.FailureThreshold,
+		retryInterval: options.RetryInterval,
+		cache:         options.Cache,
+		maxAge:        options.MaxAge,
+		queueSize:     options.QueueSize,
+		onEvent:       options.OnEvent,
+		onReplay:      options.OnReplay,
+		debug:         debug,
+	}
+	if d.threshold <= 0 {
+		d.threshold = 3
+	}
+	if d.retryInterval <= 0 {
+		d.retryInterval = 30 * time.Second
+	}
+	if d.cache == nil {
+		d.cache = NewMemoryCache(500)
+	}
+	if d.maxAge <= 0 {
+		d.maxAge = 24 * time.Hour
+	}
+	if d.queueSize <= 0 {
+		d.queueSize = 100
+	}
+	return d
+}
+
+// bypass reports whether a request should be answered without contacting
+// the service: true while degraded, except for one request per
+// retryInterval, which checks for recovery.
+func (d *degradation) bypass() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.degraded || time.Since(d.lastProbe) >= d.retryInterval {
+		d.lastProbe = time.Now()
+		return false
+	}
+	return true
+}
+
+// succeeded records a successful request, leaving degraded mode. It returns
+// the queued requests to send.
+func (d *degradation) succeeded() []QueuedRequest {
+	d.mu.Lock()
+	d.failures = 0
+	if !d.degraded {
+		d.mu.Unlock()
+		return nil
+	}
+	d.degraded = false
+	queue := d.queue
+	d.queue = nil
+	d.mu.Unlock()
+
+	d.emit(DegradationEvent{Type: DegradedModeExited, Queued: len(queue), Time: time.Now()})
+	return queue
+}
+
+// failed records a request that failed because the service is down,
+// entering degraded mode after threshold consecutive failures. It reports
+// whether the client is degraded.
+func (d *degradation) failed(err error) bool {
+	d.mu.Lock()
+	d.failures++
+	d.lastErr = err
+	if d.degraded || d.failures < d.threshold {
+		degraded := d.degraded
+		d.mu.Unlock()
+		return degraded
+	}
+	d.degraded = true
+	d.lastProbe = time.Now()
+	queued := len(d.queue)
+	d.mu.Unlock()
+
+	d.emit(DegradationEvent{Type: DegradedModeEntered, Err: err, Queued: queued, Time: time.Now()})
+	return true
+}
+
+// isDegraded reports whether the client is in degraded mode.
+func (d *degradation) isDegraded() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.degraded
+}
+
+// enqueue queues a request, reporting false if the queue is full.
+func (d *degradation) enqueue(endpoint string, body []byte) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.queue) >= d.queueSize {
+		return false
+	}
+	d.queue = append(d.queue, QueuedRequest{Endpoint: endpoint, Body: body, QueuedAt: time.Now()})
+	return true
+}
+
+// emit reports a change of mode.
+func (d *degradation) emit(event DegradationEvent) {
+	if d.debug {
+		if event.Type == DegradedModeEntered {
+			log.Printf("Zoptal AI service degraded: %v", event.Err)
+		} else {
+			log.Printf("Zoptal AI service recovered, sending %d queued requests", event.Queued)
+		}
+	}
+	if d.onEvent != nil {
+		d.onEvent(event)
+	}
+}
+
+// sendAI sends an AI request, falling back to other models and degrading
+// while the AI service is down, as configured.
+func (c *HTTPClient) sendAI(ctx context.Context, endpoint string, body []byte, result interface{}) error {
+	send := func(ctx context.Context, result interface{}) error {
+		if c.fallsBack(endpoint) {
+			return c.withModelFallback(ctx, body, func(ctx context.Context, body []byte) error {
+				return c.sendBody(ctx, http.MethodPost, endpoint, body, result)
+			})
+		}
+		return c.sendBody(ctx, http.MethodPost, endpoint, body, result)
+	}
+	d := c.degradation
+	if d == nil {
+		return send(ctx, result)
+	}
+
+	key, err := c.degradationKey(ctx, endpoint, body)
+	if err != nil {
+		return err
+	}
+	if d.bypass() {
+		d.mu.Lock()
+		err := d.lastErr
+		d.mu.Unlock()
+		return c.degrade(ctx, key, endpoint, body, result, err)
+	}
+
+	var raw json.RawMessage
+	err = send(ctx, &raw)
+	if err == nil {
+		d.cache.Set(key, &CachedResponse{Body: raw, StoredAt: time.Now()})
+		if queue := d.succeeded(); len(queue) > 0 {
+			go c.replay(queue)
+		}
+		if result == nil || len(raw) == 0 {
+			return nil
+		}
+		return decodeJSON(raw, result)
+	}
+	if ctx.Err() != nil || !isOutage(err) || !d.failed(err) {
+		return err
+	}
+	return c.degrade(ctx, key, endpoint, body, result, err)
+}
+
+// degrade answers an AI request while degraded: with its last-known result
+// if one is cached, by queuing it if it is non-urgent, or with a
+// *DegradedError.
+func (c *HTTPClient) degrade(ctx context.Context, key, endpoint string, body []byte, result interface{}, cause error) error {
+	d := c.degradation
+	if cached, ok := d.cache.Get(key); ok && time.Since(cached.StoredAt) <= d.maxAge {
+		if recorder, ok := ctx.Value(metadataKey{}).(*metadataRecorder); ok {
+			recorder.degraded()
+		}
+		if result == nil || len(cached.Body) == 0 {
+			return nil
+		}
+		return decodeJSON(cached.Body, result)
+	}
+	if nonUrgent, _ := ctx.Value(nonUrgentKey{}).(bool); nonUrgent && d.enqueue(endpoint, body) {
+		return NewDegradedError(true, cause)
+	}
+	return NewDegradedError(false, cause)
+}
+
+// replay sends the requests queued while degraded, in order.
+func (c *HTTPClient) replay(queue []QueuedRequest) {
+	defer c.metrics.track(GoroutineWorkers)()
+
+	for _, request := range queue {
+		timeout := c.current().timeout
+		if timeout <= 0 {
+			timeout = 30 * time.Second
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		var raw json.RawMessage
+		err := c.sendAI(ctx, request.Endpoint, request.Body, &raw)
+		cancel()
+		if c.debug && err != nil {
+			log.Printf("Zoptal queued request to %s failed: %v", request.Endpoint, err)
+		}
+		if c.degradation.onReplay != nil {
+			c.degradation.onReplay(request, raw, err)
+		}
+	}
+}
+
+// degradationKey returns the key of the last-known result of an AI request.
+// Like cacheKey, it includes a fingerprint of the principal and organization
+// of the request so that clients sharing a cache never see each other's
+// results.
+func (c *HTTPClient) degradationKey(ctx context.Context, endpoint string, body []byte) (string, error) {
+	org := c.org
+	if options := requestOptionsFrom(ctx); options != nil && options.header.Get("X-Zoptal-Organization") != "" {
+		org = options.header.Get("X-Zoptal-Organization")
+	}
+	principal, err := c.principal(ctx, org)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(body)
+	return principal + " POST " + endpointPath(endpoint) + " " + hex.EncodeToString(sum[:]), nil
+}
+
+// isOutage reports whether an error means the AI service is down, rather
+// than that the request was rejected.
+func isOutage(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// Degraded reports whether the client is in degraded mode because the AI
+// service is down (see ClientOptions.Degradation).
+//
+// Returns true while degraded.
+func (c *Client) Degraded() bool {
+	return c.httpClient.degradation != nil && c.httpClient.degradation.isDegraded()
+}
@@ -0,0 +1,182 @@
+package zoptal
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/http/httputil"
+	"strconv"
+	"strings"
+)
+
+// maxDumpBodyBytes is the number of body bytes included in a request or
+// response dump; longer bodies are truncated.
+const maxDumpBodyBytes = 16 << 10
+
+// redactedHeaders are the headers whose values never appear in dumps.
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+
+// dumpTransport passes a sanitized dump of every request sent and every
+// response received to hooks.
+type dumpTransport struct {
+	base       http.RoundTripper
+	onRequest  func(dump []byte)
+	onResponse func(dump []byte)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *dumpTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.onRequest != nil {
+		dumped := req.Clone(req.Context())
+		dumped.Header = redactHeader(req.Header)
+
+		var body []byte
+		var truncated bool
+		if req.Body != nil && req.Body != http.NoBody {
+			peeked, rest, err := peekBody(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			body, truncated = peeked, len(peeked) > maxDumpBodyBytes
+			forwarded := req.Clone(req.Context())
+			forwarded.Body = rest
+			req = forwarded
+		}
+		dump, err := httputil.DumpRequestOut(dumped, false)
+		if err == nil {
+			t.onRequest(appendDumpBody(dump, req.Header, body, truncated))
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || t.onResponse == nil {
+		return resp, err
+	}
+
+	dumped := *resp
+	dumped.Header = redactHeader(resp.Header)
+	dumped.Body = nil
+	dump, dumpErr := httputil.DumpResponse(&dumped, false)
+	if dumpErr != nil {
+		return resp, nil
+	}
+	if isEventStream(resp.Header) {
+		// Reading ahead would hold back the first events.
+		t.onResponse(append(dump, "[streamed body]"...))
+		return resp, nil
+	}
+	body, rest, err := peekBody(resp.Body)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	resp.Body = rest
+	t.onResponse(appendDumpBody(dump, resp.Header, body, len(body) > maxDumpBodyBytes))
+	return resp, nil
+}
+
+// CloseIdleConnections closes the idle connections of the underlying transport.
+func (t *dumpTransport) CloseIdleConnections() {
+	if closer, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
+
+// peekedBody is a body whose beginning was read for a dump.
+type peekedBody struct {
+	io.Reader
+	io.Closer
+}
+
+// peekBody reads the first maxDumpBodyBytes+1 bytes of body. It returns them
+// and a body that yields the whole content, including the bytes read.
+func peekBody(body io.ReadCloser) ([]byte, io.ReadCloser, error) {
+	peeked, err := io.ReadAll(io.LimitReader(body, maxDumpBodyBytes+1))
+	if err != nil {
+		body.Close()
+		return nil, nil, err
+	}
+	return peeked, &peekedBody{Reader: io.MultiReader(bytes.NewReader(peeked), body), Closer: body}, nil
+}
+
+// appendDumpBody appends the body of a request or response to its header
+// dump: textual bodies up to maxDumpBodyBytes, and only the size of others.
+func appendDumpBody(dump []byte, header http.Header, body []byte, truncated bool) []byte {
+	if len(body) == 0 {
+		return dump
+	}
+	if header.Get("Content-Encoding") != "" || !isTextual(header.Get("Content-Type")) {
+		if truncated {
+			return append(dump, "[binary body]"...)
+		}
+		return append(dump, "["+strconv.Itoa(len(body))+" bytes of binary body]"...)
+	}
+	if truncated {
+		return append(append(dump, body[:maxDumpBodyBytes]...), "\n[truncated]"...)
+	}
+	return append(dump, body...)
+}
+
+// redactHeader returns a copy of header with credentials replaced. The
+// authentication scheme of Authorization headers is kept.
+func redactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, name := range redactedHeaders {
+		values := redacted.Values(name)
+		for i, value := range values {
+			if scheme, _, ok := strings.Cut(value, " "); ok && strings.HasSuffix(name, "Authorization") {
+				values[i] = scheme + " [REDACTED]"
+			} else {
+				values[i] = "[REDACTED]"
+			}
+		}
+	}
+	return redacted
+}
+
+// isTextual reports whether a content type is text that can be dumped as is.
+func isTextual(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(mediaType, "text/") || strings.HasSuffix(mediaType, "json") ||
+		strings.HasSuffix(mediaType, "xml") || mediaType == "application/x-www-form-urlencoded"
+}
+
+// isEventStream reports whether a response is a server-sent event stream.
+func isEventStream(header http.Header) bool {
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	return mediaType == "text/event-stream"
+}
+
+// withDumps returns a copy of client whose requests and responses are dumped
+// to the hooks, and logged in debug mode.
+func withDumps(client *http.Client, onRequest, onResponse func(dump []byte), debug bool) *http.Client {
+	if debug {
+		onRequest = logDump("request", onRequest)
+		onResponse = logDump("response", onResponse)
+	}
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	dumped := *client
+	dumped.Transport = &dumpTransport{base: base, onRequest: onRequest, onResponse: onResponse}
+	return &dumped
+}
+
+// logDump returns a hook that logs dumps before passing them to hook.
+func logDump(kind string, hook func(dump []byte)) func(dump []byte) {
+	return func(dump []byte) {
+		log.Printf("Zoptal HTTP %s:\n%s", kind, dump)
+		if hook != nil {
+			hook(dump)
+		}
+	}
+}
@@ -1,20 +1,41 @@
 package zoptal
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // ZoptalError is the base error type for all Zoptal SDK errors.
 type ZoptalError struct {
 	Message   string
 	ErrorCode string
 	Cause     error
+
+	// StatusCode, RequestID, and Body describe the error response the error
+	// was created from, if any: its HTTP status code, the server's request
+	// ID from the X-Request-ID header, for support tickets, and its body, up
+	// to 64 KiB
+	StatusCode int
+	RequestID  string
+	Body       []byte
 }
 
 // Error implements the error interface.
 func (e *ZoptalError) Error() string {
+	message := e.Message
 	if e.ErrorCode != "" {
-		return fmt.Sprintf("[%s] %s", e.ErrorCode, e.Message)
+		message = fmt.Sprintf("[%s] %s", e.ErrorCode, e.Message)
 	}
-	return e.Message
+	if e.RequestID != "" {
+		message += fmt.Sprintf(" (request ID %s)", e.RequestID)
+	}
+	return message
+}
+
+// base returns e, giving access to the ZoptalError embedded in every error
+// type.
+func (e *ZoptalError) base() *ZoptalError {
+	return e
 }
 
 // Unwrap returns the underlying cause of the error.
@@ -40,7 +61,6 @@ func NewAuthenticationError(message string) *AuthenticationError {
 // APIError represents a general API error.
 type APIError struct {
 	*ZoptalError
-	StatusCode int
 }
 
 // NewAPIError creates a new API error.
@@ -57,16 +77,30 @@ func NewAPIError(message string) *APIError {
 func NewAPIErrorWithStatus(message string, statusCode int) *APIError {
 	return &APIError{
 		ZoptalError: &ZoptalError{
-			Message:   message,
-			ErrorCode: "API_ERROR",
+			Message:    message,
+			ErrorCode:  "API_ERROR",
+			StatusCode: statusCode,
 		},
-		StatusCode: statusCode,
 	}
 }
 
 // RateLimitError represents a rate limiting error.
 type RateLimitError struct {
 	*ZoptalError
+
+	// RetryAfter is how long to wait before retrying, from the Retry-After
+	// header, or zero if unknown
+	RetryAfter time.Duration
+
+	// Limit and Remaining are the request quota of the current window and
+	// what is left of it, from the X-RateLimit-Limit and
+	// X-RateLimit-Remaining headers, or -1 if not reported
+	Limit     int
+	Remaining int
+
+	// Reset is when the quota window resets, from the X-RateLimit-Reset
+	// header, or zero if not reported
+	Reset time.Time
 }
 
 // NewRateLimitError creates a new rate limit error.
@@ -76,6 +110,8 @@ func NewRateLimitError(message string) *RateLimitError {
 			Message:   message,
 			ErrorCode: "RATE_LIMIT",
 		},
+		Limit:     -1,
+		Remaining: -1,
 	}
 }
 
@@ -97,16 +133,49 @@ func NewNotFoundError(message string) *NotFoundError {
 // ValidationError represents a validation error.
 type ValidationError struct {
 	*ZoptalError
+
+	// Fields lists the invalid inputs, when the server reported them
+	Fields []FieldError
+}
+
+// FieldError describes an invalid input of a request.
+type FieldError struct {
+	// Field is the path of the input, with nested fields and list indexes
+	// separated by dots, e.g. "settings.branches.0"
+	Field string `json:"field"`
+
+	// Code identifies the kind of failure, e.g. "required" (optional)
+	Code string `json:"code,omitempty"`
+
+	Message string `json:"message"`
 }
 
 // NewValidationError creates a new validation error.
 func NewValidationError(message string) *ValidationError {
+	return NewValidationErrorWithFields(message, nil)
+}
+
+// NewValidationErrorWithFields creates a new validation error listing the
+// invalid inputs.
+func NewValidationErrorWithFields(message string, fields []FieldError) *ValidationError {
 	return &ValidationError{
 		ZoptalError: &ZoptalError{
 			Message:   message,
 			ErrorCode: "VALIDATION_ERROR",
 		},
+		Fields: fields,
+	}
+}
+
+// Field returns the error of the input with the given path, or nil if it is
+// valid.
+func (e *ValidationError) Field(field string) *FieldError {
+	for i := range e.Fields {
+		if e.Fields[i].Field == field {
+			return &e.Fields[i]
+		}
 	}
+	return nil
 }
 
 // ProjectError represents a project-related error.
@@ -169,6 +238,200 @@ func NewCollaborationError(message string) *CollaborationError {
 	}
 }
 
+// DecodeError represents a response body that could not be decoded.
+type DecodeError struct {
+	*ZoptalError
+}
+
+// NewDecodeError creates a new decode error.
+func NewDecodeError(message string, cause error) *DecodeError {
+	return &DecodeError{
+		ZoptalError: &ZoptalError{
+			Message:   message,
+			ErrorCode: "DECODE_ERROR",
+			Cause:     cause,
+		},
+	}
+}
+
+// ConflictError represents a file that was changed both locally and remotely.
+type ConflictError struct {
+	*ZoptalError
+	Path         string
+	LocalSHA256  string
+	RemoteSHA256 string
+}
+
+// NewConflictError creates a new conflict error.
+func NewConflictError(path, localSHA256, remoteSHA256 string) *ConflictError {
+	return &ConflictError{
+		ZoptalError: &ZoptalError{
+			Message:   fmt.Sprintf("%s was modified remotely since it was last synced", path),
+			ErrorCode: "CONFLICT",
+		},
+		Path:         path,
+		LocalSHA256:  localSHA256,
+		RemoteSHA256: remoteSHA256,
+	}
+}
+
+// ModelUnavailableError represents an AI model that is over capacity or
+// temporarily unavailable.
+type ModelUnavailableError struct {
+	*ZoptalError
+	Model string
+}
+
+// NewModelUnavailableError creates a new model unavailable error.
+func NewModelUnavailableError(model, message string, statusCode int) *ModelUnavailableError {
+	return &ModelUnavailableError{
+		ZoptalError: &ZoptalError{
+			Message:    message,
+			ErrorCode:  "MODEL_UNAVAILABLE",
+			StatusCode: statusCode,
+		},
+		Model: model,
+	}
+}
+
+// ImmutableResourceError represents a change rejected because the resource
+// is under legal hold or marked immutable.
+type ImmutableResourceError struct {
+	*ZoptalError
+
+	// Resource is the kind of resource, such as "project" or "file"
+	Resource string
+
+	// ResourceID is the ID or path of the resource
+	ResourceID string
+
+	// LegalHold reports whether the resource is held by a legal hold
+	LegalHold bool
+}
+
+// NewImmutableResourceError creates a new immutable resource error.
+func NewImmutableResourceError(resource, resourceID, message string, legalHold bool) *ImmutableResourceError {
+	return &ImmutableResourceError{
+		ZoptalError: &ZoptalError{
+			Message:   message,
+			ErrorCode: "IMMUTABLE_RESOURCE",
+		},
+		Resource:   resource,
+		ResourceID: resourceID,
+		LegalHold:  legalHold,
+	}
+}
+
+// CanceledError represents an operation aborted because its context was
+// cancelled with a reason (see WithCancelReason). It matches
+// context.Canceled with errors.Is.
+type CanceledError struct {
+	*ZoptalError
+	Reason string
+}
+
+// NewCanceledError creates a new canceled error.
+func NewCanceledError(reason string, cause error) *CanceledError {
+	return &CanceledError{
+		ZoptalError: &ZoptalError{
+			Message:   fmt.Sprintf("operation canceled: %s", reason),
+			ErrorCode: "CANCELED",
+			Cause:     cause,
+		},
+		Reason: reason,
+	}
+}
+
+// ScopeError represents a request refused by the client because its
+// endpoint is outside the scopes of a client derived with WithScopes. The
+// request is not sent.
+type ScopeError struct {
+	*ZoptalError
+
+	// Scope is the scope the request requires
+	Scope string
+
+	// Method and Endpoint identify the refused request
+	Method   string
+	Endpoint string
+}
+
+// NewScopeError creates a new scope error.
+func NewScopeError(scope, method, endpoint string) *ScopeError {
+	return &ScopeError{
+		ZoptalError: &ZoptalError{
+			Message:   fmt.Sprintf("%s %s requires scope %q, which the client was not granted", method, endpoint, scope),
+			ErrorCode: "SCOPE_NOT_GRANTED",
+		},
+		Scope:    scope,
+		Method:   method,
+		Endpoint: endpoint,
+	}
+}
+
+// DegradedError represents an AI request that was not answered because the
+// client is in degraded mode (see DegradationOptions) and no last-known
+// result was available. Cause is the failure that put the client in degraded
+// mode.
+type DegradedError struct {
+	*ZoptalError
+
+	// Queued is true if the request was queued to be sent once the AI
+	// service recovers (see WithNonUrgent)
+	Queued bool
+}
+
+// NewDegradedError creates a new degraded error.
+func NewDegradedError(queued bool, cause error) *DegradedError {
+	message := "AI service is unavailable"
+	if queued {
+		message += "; the request was queued and will be sent when it recovers"
+	}
+	return &DegradedError{
+		ZoptalError: &ZoptalError{
+			Message:   message,
+			ErrorCode: "SERVICE_DEGRADED",
+			Cause:     cause,
+		},
+		Queued: queued,
+	}
+}
+
+// PolicyDeniedError represents a guarded operation refused by the client's
+// PolicyEngine (see PolicyOptions). The request is not sent.
+type PolicyDeniedError struct {
+	*ZoptalError
+
+	// Action is the refused operation
+	Action PolicyAction
+
+	// Rule names the violated rule, if the engine reported one
+	Rule string
+
+	// Reason explains the denial, if the engine reported one
+	Reason string
+}
+
+// NewPolicyDeniedError creates a new policy denied error.
+func NewPolicyDeniedError(action PolicyAction, rule, reason string) *PolicyDeniedError {
+	message := fmt.Sprintf("%s denied by policy", action)
+	if rule != "" {
+		message += fmt.Sprintf(" (rule %s)", rule)
+	}
+	if reason != "" {
+		message += ": " + reason
+	}
+	return &PolicyDeniedError{
+		ZoptalError: &ZoptalError{
+			Message:   message,
+			ErrorCode: "POLICY_DENIED",
+		},
+		Action: action,
+		Rule:   rule,
+		Reason: reason,
+	}
+}
+
 // Error type checking functions
 
 // IsZoptalError checks if an error is a Zoptal SDK error.
@@ -229,4 +492,52 @@ func IsAIError(err error) bool {
 func IsCollaborationError(err error) bool {
 	_, ok := err.(*CollaborationError)
 	return ok
+}
+
+// IsDecodeError checks if an error is a response decoding error.
+func IsDecodeError(err error) bool {
+	_, ok := err.(*DecodeError)
+	return ok
+}
+
+// IsConflictError checks if an error is a file conflict error.
+func IsConflictError(err error) bool {
+	_, ok := err.(*ConflictError)
+	return ok
+}
+
+// IsModelUnavailableError checks if an error is a model unavailable error.
+func IsModelUnavailableError(err error) bool {
+	_, ok := err.(*ModelUnavailableError)
+	return ok
+}
+
+// IsCanceledError checks if an error is a canceled error.
+func IsCanceledError(err error) bool {
+	_, ok := err.(*CanceledError)
+	return ok
+}
+
+// IsImmutableResourceError checks if an error is an immutable resource error.
+func IsImmutableResourceError(err error) bool {
+	_, ok := err.(*ImmutableResourceError)
+	return ok
+}
+
+// IsScopeError checks if an error is a scope error.
+func IsScopeError(err error) bool {
+	_, ok := err.(*ScopeError)
+	return ok
+}
+
+// IsDegradedError checks if an error is a degraded error.
+func IsDegradedError(err error) bool {
+	_, ok := err.(*DegradedError)
+	return ok
+}
+
+// IsPolicyDeniedError checks if an error is a policy denied error.
+func IsPolicyDeniedError(err error) bool {
+	_, ok := err.(*PolicyDeniedError)
+	return ok
 }
\ No newline at end of file
@@ -0,0 +1,315 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxOutcomesPerRequest is the largest number of outcomes tracked per request.
+const maxOutcomesPerRequest = 1000
+
+// ExperimentsService assigns users and sessions to the variants of A/B
+// experiments configured in Zoptal, such as a new prompt or model for an AI
+// feature, and collects the outcome metrics that measure their impact, so
+// that no separate experimentation stack is needed.
+//
+// Assignments are sticky: a subject gets the same variant of an experiment
+// on every call until the experiment ends.
+type ExperimentsService struct {
+	client *HTTPClient
+}
+
+// AssignmentRequest identifies the subject to assign to experiment variants.
+// At least one of UserID and SessionID is required; the UserID, if set, is
+// the unit of assignment.
+type AssignmentRequest struct {
+	// UserID is the ID of the user in the caller's product (optional)
+	UserID string `json:"user_id,omitempty"`
+
+	// SessionID is the ID of the session, for anonymous users (optional)
+	SessionID string `json:"session_id,omitempty"`
+
+	// Experiments are the keys of the experiments to assign (default: all
+	// running experiments)
+	Experiments []string `json:"experiments,omitempty"`
+
+	// Attributes describe the subject for the targeting rules of
+	// experiments, e.g. {"plan": "pro", "country": "DE"} (optional)
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// Assignment is the variant of an experiment a subject is assigned to.
+type Assignment struct {
+	// Experiment is the key of the experiment
+	Experiment string `json:"experiment"`
+
+	// Variant is the key of the assigned variant, e.g. "control"
+	Variant string `json:"variant"`
+
+	// InExperiment is false if the subject is not targeted by the experiment
+	// or not in its traffic allocation; Variant is then the variant to show
+	// without counting the subject in the results
+	InExperiment bool `json:"in_experiment"`
+
+	// Config is the configuration of the variant, such as the "model" or
+	// "prompt" to use
+	Config map[string]interface{} `json:"config,omitempty"`
+}
+
+// Assignments are the experiment variants of a subject, by experiment key.
+type Assignments struct {
+	Assignments map[string]*Assignment `json:"assignments"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// Variant returns the variant of an experiment, or "" if the subject is not
+// assigned to it.
+func (a *Assignments) Variant(experiment string) string {
+	if assignment, ok := a.Assignments[experiment]; ok {
+		return assignment.Variant
+	}
+	return ""
+}
+
+// Model returns the model of the variant, from its "model" configuration,
+// or "".
+func (a *Assignment) Model() string {
+	model, _ := a.Config["model"].(string)
+	return model
+}
+
+// Apply returns a context whose AI requests are attributed to the variant
+// and, if the variant configures a model, use that model (see WithModel).
+//
+// Parameters:
+//   - ctx: Parent context
+//
+// Returns the derived context.
+//
+// Example usage:
+//
+//	assignments, err := client.Experiments.Assign(ctx, &zoptal.AssignmentRequest{UserID: userID})
+//	...
+//	ctx = assignments.Assignments["review-model"].Apply(ctx)
+//	review, err := client.AI.ReviewDiff(ctx, request)
+func (a *Assignment) Apply(ctx context.Context) context.Context {
+	if a == nil {
+		return ctx
+	}
+	ctx = WithRequestOptions(ctx, WithHeader("X-Zoptal-Experiment", a.Experiment+"="+a.Variant))
+	if model := a.Model(); model != "" {
+		ctx = WithModel(ctx, model)
+	}
+	return ctx
+}
+
+// Outcome is a measurement of an outcome metric for a subject, such as the
+// acceptance of generated code or the time to merge a reviewed change.
+type Outcome struct {
+	// Experiment is the key of the experiment (required)
+	Experiment string `json:"experiment"`
+
+	// Variant is the variant the subject saw (default: the assigned variant)
+	Variant string `json:"variant,omitempty"`
+
+	// UserID and SessionID identify the subject as in the AssignmentRequest
+	UserID    string `json:"user_id,omitempty"`
+	SessionID string `json:"session_id,omitempty"`
+
+	// Metric is the name of the metric, e.g. "suggestion_accepted" (required)
+	Metric string `json:"metric"`
+
+	// Value is the measurement, e.g. 1 for a conversion or a duration in
+	// seconds (default: 0)
+	Value float64 `json:"value"`
+
+	// Timestamp is when the outcome occurred (default: when it is tracked)
+	Timestamp time.Time `json:"-"`
+}
+
+// ExperimentResults are the results of an experiment so far, per variant
+// and metric.
+type ExperimentResults struct {
+	Experiment string `json:"experiment"`
+
+	// Status is "draft", "running", or "stopped"
+	Status string `json:"status"`
+
+	// Control is the key of the variant the others are compared with
+	Control string `json:"control"`
+
+	Variants []VariantResults `json:"variants"`
+
+	UpdatedAt Timestamp `json:"updated_at"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// VariantResults are the results of a variant of an experiment.
+type VariantResults struct {
+	Variant string `json:"variant"`
+
+	// Subjects is the number of subjects assigned to the variant
+	Subjects int64 `json:"subjects"`
+
+	// Metrics are the results of each metric, by metric name
+	Metrics map[string]MetricResult `json:"metrics"`
+}
+
+// MetricResult is the result of a metric for a variant.
+type MetricResult struct {
+	// Count is the number of outcomes tracked
+	Count int64 `json:"count"`
+
+	// Mean is the mean value per subject
+	Mean float64 `json:"mean"`
+
+	// Lift is the relative difference of Mean from the control's, e.g. 0.05
+	// for 5% higher; zero for the control
+	Lift float64 `json:"lift"`
+
+	// ConfidenceLow and ConfidenceHigh bound the 95% confidence interval of Lift
+	ConfidenceLow  float64 `json:"confidence_low"`
+	ConfidenceHigh float64 `json:"confidence_high"`
+
+	// Significant is true if the confidence interval excludes zero
+	Significant bool `json:"significant"`
+}
+
+// Assign assigns a subject to the variants of experiments, recording an
+// exposure for each experiment the subject is in.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - request: Subject to assign
+//   - opts: Request options (optional)
+//
+// Returns the assignments or an error if the request fails.
+//
+// Example usage:
+//
+//	assignments, err := client.Experiments.Assign(ctx, &zoptal.AssignmentRequest{
+//	    UserID:      userID,
+//	    Experiments: []string{"codegen-prompt-v2"},
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	if assignments.Variant("codegen-prompt-v2") == "treatment" {
+//	    request.Prompt = promptV2(request.Prompt)
+//	}
+func (s *ExperimentsService) Assign(ctx context.Context, request *AssignmentRequest, opts ...RequestOption) (*Assignments, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if request == nil || (request.UserID == "" && request.SessionID == "") {
+		return nil, NewValidationError("user ID or session ID is required")
+	}
+	for _, experiment := range request.Experiments {
+		if strings.TrimSpace(experiment) == "" {
+			return nil, NewValidationError("experiment keys must not be empty")
+		}
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Post(ctx, "/experiments/assignments", request, &raw); err != nil {
+		return nil, fmt.Errorf("failed to assign experiments: %w", err)
+	}
+	var assignments Assignments
+	if err := decodeTyped(raw, &assignments, &assignments.Raw); err != nil {
+		return nil, fmt.Errorf("failed to assign experiments: %w", err)
+	}
+	if assignments.Assignments == nil {
+		assignments.Assignments = make(map[string]*Assignment)
+	}
+	for key, assignment := range assignments.Assignments {
+		if assignment.Experiment == "" {
+			assignment.Experiment = key
+		}
+	}
+	return &assignments, nil
+}
+
+// Track reports outcome metrics of experiment subjects. Outcomes of
+// subjects not in an experiment are ignored by the server.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - outcomes: Outcomes to report, up to 1000
+//   - opts: Request options (optional)
+//
+// Returns an error if an outcome is invalid or the request fails.
+//
+// Example usage:
+//
+//	err := client.Experiments.Track(ctx, []zoptal.Outcome{{
+//	    Experiment: "codegen-prompt-v2",
+//	    UserID:     userID,
+//	    Metric:     "suggestion_accepted",
+//	    Value:      1,
+//	}})
+func (s *ExperimentsService) Track(ctx context.Context, outcomes []Outcome, opts ...RequestOption) error {
+	ctx = WithRequestOptions(ctx, opts...)
+	if len(outcomes) == 0 {
+		return nil
+	}
+	if len(outcomes) > maxOutcomesPerRequest {
+		return NewValidationError(fmt.Sprintf("at most %d outcomes can be tracked per request", maxOutcomesPerRequest))
+	}
+
+	type outcomeData struct {
+		Outcome
+		Timestamp *Timestamp `json:"timestamp,omitempty"`
+	}
+	data := make([]outcomeData, len(outcomes))
+	for i, outcome := range outcomes {
+		switch {
+		case outcome.Experiment == "":
+			return NewValidationError(fmt.Sprintf("outcome %d: experiment is required", i))
+		case outcome.Metric == "":
+			return NewValidationError(fmt.Sprintf("outcome %d: metric is required", i))
+		case outcome.UserID == "" && outcome.SessionID == "":
+			return NewValidationError(fmt.Sprintf("outcome %d: user ID or session ID is required", i))
+		}
+		data[i].Outcome = outcome
+		if !outcome.Timestamp.IsZero() {
+			timestamp := NewTimestamp(outcome.Timestamp)
+			data[i].Timestamp = &timestamp
+		}
+	}
+
+	if err := s.client.Post(ctx, "/experiments/outcomes", map[string]interface{}{"outcomes": data}, nil); err != nil {
+		return fmt.Errorf("failed to track %d experiment outcomes: %w", len(outcomes), err)
+	}
+	return nil
+}
+
+// Results gets the results of an experiment so far.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - experiment: Key of the experiment
+//   - opts: Request options (optional)
+//
+// Returns the results or an error if the request fails.
+func (s *ExperimentsService) Results(ctx context.Context, experiment string, opts ...RequestOption) (*ExperimentResults, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if experiment == "" {
+		return nil, NewValidationError("experiment key is required")
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Get(ctx, "/experiments/"+url.PathEscape(experiment)+"/results", nil, &raw); err != nil {
+		return nil, fmt.Errorf("failed to get results of experiment %s: %w", experiment, err)
+	}
+	var results ExperimentResults
+	if err := decodeTyped(raw, &results, &results.Raw); err != nil {
+		return nil, fmt.Errorf("failed to get results of experiment %s: %w", experiment, err)
+	}
+	return &results, nil
+}
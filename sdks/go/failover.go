@@ -0,0 +1,258 @@
+package zoptal
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FailoverOptions contains options for failing over between API endpoints.
+//
+// Endpoints are tried in order of preference: ClientOptions.BaseURL first,
+// then each of Endpoints. After FailureThreshold consecutive failures the
+// client switches to the next endpoint. While a less preferred endpoint is
+// active, the more preferred ones are probed every ProbeInterval and the
+// client switches back as soon as one of them is healthy again.
+type FailoverOptions struct {
+	// Endpoints lists the fallback base URLs in order of preference, for
+	// example a regional deployment followed by a self-hosted one (required)
+	Endpoints []string
+
+	// FailureThreshold is the number of consecutive failed requests after
+	// which the client fails over (default: 3)
+	FailureThreshold int
+
+	// ProbeInterval is how often more preferred endpoints are probed for
+	// recovery (default: 30 seconds)
+	ProbeInterval time.Duration
+
+	// OnSwitch is called when the active endpoint changes (optional)
+	OnSwitch func(FailoverEvent)
+}
+
+// FailoverEvent describes a switch between endpoints.
+type FailoverEvent struct {
+	From string
+	To   string
+
+	// Recovered is true when switching back to a more preferred endpoint
+	// after a successful probe
+	Recovered bool
+
+	// Err is the last error seen on From when failing over
+	Err error
+
+	Time time.Time
+}
+
+// failover tracks endpoint health and the active endpoint.
+type failover struct {
+	endpoints     []string
+	threshold     int
+	probeInterval time.Duration
+	onSwitch      func(FailoverEvent)
+	debug         bool
+
+	mu       sync.Mutex
+	active   int
+	failures int
+}
+
+// newFailover creates a failover from the given options, applying defaults.
+func newFailover(baseURL string, options *FailoverOptions, debug bool) *failover {
+	f := &failover{
+		endpoints:     []string{baseURL},
+		threshold:     options.FailureThreshold,
+		probeInterval: options.ProbeInterval,
+		onSwitch:      options.OnSwitch,
+		debug:         debug,
+	}
+	for _, endpoint := range options.Endpoints {
+		if endpoint = strings.TrimRight(endpoint, "/"); endpoint != "" {
+			f.endpoints = append(f.endpoints, endpoint)
+		}
+	}
+	if f.threshold <= 0 {
+		f.threshold = 3
+	}
+	if f.probeInterval <= 0 {
+		f.probeInterval = 30 * time.Second
+	}
+	return f
+}
+
+// current returns the active endpoint.
+func (f *failover) current() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.endpoints[f.active]
+}
+
+// rebase rewrites a request built for any known endpoint to target the
+// active endpoint.
+func (f *failover) rebase(req *http.Request) error {
+	target := f.current()
+	u := req.URL.String()
+	for _, endpoint := range f.endpoints {
+		if endpoint == target || !strings.HasPrefix(u, endpoint) {
+			continue
+		}
+		rebased, err := url.Parse(target + strings.TrimPrefix(u, endpoint))
+		if err != nil {
+			return err
+		}
+		req.URL = rebased
+		req.Host = ""
+		return nil
+	}
+	return nil
+}
+
+// record records the outcome of a request sent to endpoint, failing over
+// once the active endpoint has failed threshold times in a row.
+func (f *failover) record(endpoint string, resp *http.Response, err error) {
+	failed := err != nil && !errors.Is(err, context.Canceled)
+	if resp != nil {
+		switch resp.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			failed = true
+			err = NewAPIErrorWithStatus(resp.Status, resp.StatusCode)
+		}
+	}
+
+	f.mu.Lock()
+	if f.endpoints[f.active] != endpoint {
+		// A stale result from before the last switch.
+		f.mu.Unlock()
+		return
+	}
+	if !failed {
+		f.failures = 0
+		f.mu.Unlock()
+		return
+	}
+	f.failures++
+	if f.failures < f.threshold || len(f.endpoints) == 1 {
+		f.mu.Unlock()
+		return
+	}
+	from := f.endpoints[f.active]
+	f.active = (f.active + 1) % len(f.endpoints)
+	f.failures = 0
+	to := f.endpoints[f.active]
+	f.mu.Unlock()
+
+	f.emit(FailoverEvent{From: from, To: to, Err: err, Time: time.Now()})
+}
+
+// switchTo makes the endpoint at index active if it is more preferred than
+// the current one.
+func (f *failover) switchTo(index int) {
+	f.mu.Lock()
+	if index >= f.active {
+		f.mu.Unlock()
+		return
+	}
+	from := f.endpoints[f.active]
+	f.active = index
+	f.failures = 0
+	to := f.endpoints[f.active]
+	f.mu.Unlock()
+
+	f.emit(FailoverEvent{From: from, To: to, Recovered: true, Time: time.Now()})
+}
+
+// emit reports a switch.
+func (f *failover) emit(event FailoverEvent) {
+	if f.debug {
+		log.Printf("Zoptal endpoint switched from %s to %s", event.From, event.To)
+	}
+	if f.onSwitch != nil {
+		f.onSwitch(event)
+	}
+}
+
+// doFailover sends a request to the active endpoint and records the outcome.
+func (c *HTTPClient) doFailover(req *http.Request) (*http.Response, error) {
+	if err := c.failover.rebase(req); err != nil {
+		return nil, err
+	}
+	endpoint := c.failover.current()
+
+	var resp *http.Response
+	var err error
+	if c.mirror != nil && req.Method == http.MethodGet {
+		resp, err = c.doMirrored(req)
+	} else {
+		resp, err = c.dispatch(req)
+	}
+	c.failover.record(endpoint, resp, err)
+	return resp, err
+}
+
+// activeBaseURL returns the base URL requests are currently sent to.
+func (c *HTTPClient) activeBaseURL() string {
+	if c.failover != nil {
+		return c.failover.current()
+	}
+	return c.baseURL
+}
+
+// probeEndpoints periodically probes the endpoints preferred over the active
+// one and switches back to the first healthy one, until the client is closed.
+func (c *Client) probeEndpoints() {
+	f := c.httpClient.failover
+	defer c.httpClient.metrics.track(GoroutinePollers)()
+
+	ticker := time.NewTicker(f.probeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-c.done:
+			return
+		}
+
+		f.mu.Lock()
+		active := f.active
+		f.mu.Unlock()
+
+		for i := 0; i < active; i++ {
+			if c.probe(f.endpoints[i]) {
+				f.switchTo(i)
+				break
+			}
+		}
+	}
+}
+
+// probe reports whether the health endpoint of baseURL responds successfully.
+func (c *Client) probe(baseURL string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), c.GetTimeout())
+	defer cancel()
+
+	req, err := c.httpClient.createRequest(ctx, http.MethodGet, baseURL+"/api/v1/health", nil)
+	if err != nil {
+		return false
+	}
+	resp, err := c.httpClient.current().client.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode < 500
+}
+
+// ActiveEndpoint returns the base URL the client is currently sending
+// requests to. It differs from ClientOptions.BaseURL only after a failover.
+//
+// Returns the active base URL.
+func (c *Client) ActiveEndpoint() string {
+	return c.httpClient.activeBaseURL()
+}
@@ -0,0 +1,94 @@
+package zoptal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/zoptal/zoptal-go-sdk/internal/unidiff"
+)
+
+// ApplyDiff applies a unified diff, such as RefactorResult.Diff, to a file
+// in a project and uploads the result.
+//
+// The diff may describe several files; the one whose path matches filePath
+// is applied, or the only one if the paths do not match. Hunks whose lines
+// have moved since the diff was made are applied where their context now
+// is; if a hunk's context is not found, nothing is uploaded and a
+// ValidationError is returned. A diff creating the file applies to a file
+// that does not exist yet.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - filePath: Path of the file within the project
+//   - diff: Change in unified diff format
+//   - opts: Request options (optional)
+//
+// Returns the uploaded file information or an error if the diff does not
+// apply or a request fails.
+func (s *FileService) ApplyDiff(ctx context.Context, projectID, filePath, diff string, opts ...RequestOption) (*UploadResult, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+	if filePath == "" {
+		return nil, NewValidationError("file path is required")
+	}
+
+	files, err := unidiff.Parse(diff)
+	if err != nil {
+		return nil, NewValidationError(fmt.Sprintf("invalid diff: %v", err))
+	}
+	file, err := diffForPath(files, filePath)
+	if err != nil {
+		return nil, err
+	}
+	if file.NewPath == "/dev/null" {
+		return nil, NewValidationError(fmt.Sprintf("diff deletes %s; use Delete to remove files", filePath))
+	}
+
+	var original bytes.Buffer
+	var contentType string
+	if file.OldPath != "/dev/null" {
+		download, err := s.DownloadTo(ctx, projectID, filePath, &original, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply diff to %s: %w", filePath, err)
+		}
+		contentType = download.ContentType
+	}
+
+	patched, err := unidiff.Apply(original.String(), file.Hunks)
+	if err != nil {
+		var mismatch *unidiff.MismatchError
+		if errors.As(err, &mismatch) {
+			return nil, NewValidationError(fmt.Sprintf("diff does not apply to %s: %v", filePath, err))
+		}
+		return nil, fmt.Errorf("failed to apply diff to %s: %w", filePath, err)
+	}
+
+	result, err := s.Upload(ctx, projectID, filePath, strings.NewReader(patched), &UploadOptions{
+		ContentType: contentType,
+		Overwrite:   true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply diff to %s: %w", filePath, err)
+	}
+	return result, nil
+}
+
+// diffForPath returns the file diff of a multi-file diff that applies to path.
+func diffForPath(files []unidiff.FileDiff, path string) (*unidiff.FileDiff, error) {
+	path = strings.TrimPrefix(path, "/")
+	for i := range files {
+		if files[i].NewPath == path || files[i].OldPath == path {
+			return &files[i], nil
+		}
+	}
+	if len(files) == 1 {
+		return &files[0], nil
+	}
+	return nil, NewValidationError(fmt.Sprintf("diff has no changes for %s", path))
+}
@@ -0,0 +1,119 @@
+package zoptal
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// DownloadOptions contains options for downloading a file.
+type DownloadOptions struct {
+	// Version downloads a specific version of the file (default: latest)
+	Version int
+
+	// Progress is called as data is written with the number of bytes
+	// transferred so far and the total size, or -1 if the size is unknown (optional)
+	Progress func(transferred, total int64)
+
+	// MaxResumeAttempts is the number of times DownloadToFile resumes an
+	// interrupted transfer before giving up (default: 3)
+	MaxResumeAttempts int
+}
+
+// DownloadResult describes a downloaded file.
+type DownloadResult struct {
+	Path        string
+	Size        int64
+	ContentType string
+
+	// Checksum is the server-provided SHA-256 of the file, if any; for
+	// encrypted files, that of the ciphertext
+	Checksum string
+
+	// KeyID is the ID of the key the file was encrypted with, or "" if it
+	// was not encrypted (see EncryptionOptions)
+	KeyID string
+}
+
+// DownloadTo streams a file from a project to w.
+//
+// The content is copied to w as it arrives rather than being buffered in
+// memory, and the optional progress callback can drive a progress bar.
+// Encrypted files are decrypted as they arrive (see EncryptionOptions).
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - filePath: Path of the file within the project
+//   - w: Destination for the file content
+//   - options: Download options (can be nil for defaults)
+//   - opts: Request options (optional)
+//
+// Returns the downloaded file information or an error if the download fails.
+func (s *FileService) DownloadTo(ctx context.Context, projectID, filePath string, w io.Writer, options *DownloadOptions, opts ...RequestOption) (*DownloadResult, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+	if filePath == "" {
+		return nil, NewValidationError("file path is required")
+	}
+	if w == nil {
+		return nil, NewValidationError("writer is required")
+	}
+	if options == nil {
+		options = &DownloadOptions{}
+	}
+
+	params := map[string]string{"path": filePath}
+	if options.Version > 0 {
+		params["version"] = fmt.Sprintf("%d", options.Version)
+	}
+
+	resp, err := s.client.GetRaw(ctx, fmt.Sprintf("/projects/%s/files/download", projectID), params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", filePath, err)
+	}
+	defer resp.Body.Close()
+
+	body := io.Reader(resp.Body)
+	if options.Progress != nil {
+		body = &progressReader{r: resp.Body, total: resp.ContentLength, progress: options.Progress}
+		options.Progress(0, resp.ContentLength)
+	}
+	keyID := resp.Header.Get(encryptionKeyHeader)
+	if body, err = s.client.decrypt(keyID, body); err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", filePath, err)
+	}
+
+	n, err := io.Copy(w, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", filePath, err)
+	}
+
+	return &DownloadResult{
+		Path:        filePath,
+		Size:        n,
+		ContentType: resp.Header.Get("Content-Type"),
+		Checksum:    resp.Header.Get("X-Checksum-SHA256"),
+		KeyID:       keyID,
+	}, nil
+}
+
+// progressReader reports progress as data is read through it.
+type progressReader struct {
+	r           io.Reader
+	transferred int64
+	total       int64
+	progress    func(transferred, total int64)
+}
+
+// Read implements io.Reader.
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.transferred += int64(n)
+		p.progress(p.transferred, p.total)
+	}
+	return n, err
+}
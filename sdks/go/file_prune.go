@@ -0,0 +1,90 @@
+package zoptal
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PruneOptions contains options for pruning old file versions and orphaned artifacts.
+type PruneOptions struct {
+	// KeepVersions is the number of most recent versions to keep per file,
+	// which may be 0 (default: server setting)
+	KeepVersions Optional[int]
+
+	// OlderThan limits pruning to versions and artifacts older than this age (default: no limit)
+	OlderThan time.Duration
+
+	// DryRun reports what would be deleted without deleting anything (default: false)
+	DryRun bool
+}
+
+// PruneResult describes the versions and artifacts that were (or, for a dry
+// run, would be) deleted.
+type PruneResult struct {
+	DryRun         bool         `json:"dry_run"`
+	Deleted        []PrunedItem `json:"deleted"`
+	ReclaimedBytes int64        `json:"reclaimed_bytes"`
+}
+
+// PrunedItem is a single file version or orphaned artifact selected for deletion.
+type PrunedItem struct {
+	Path      string    `json:"path"`
+	Version   int       `json:"version,omitempty"`
+	Kind      string    `json:"kind"` // "version" or "orphaned_artifact"
+	Bytes     int64     `json:"bytes"`
+	CreatedAt Timestamp `json:"created_at"`
+}
+
+// pruneRequest is the request body for the prune endpoint.
+type pruneRequest struct {
+	KeepVersions     Optional[int] `json:"keep_versions"`
+	OlderThanSeconds int64         `json:"older_than_seconds,omitempty"`
+	DryRun           bool          `json:"dry_run"`
+}
+
+// MarshalJSON implements json.Marshaler, omitting KeepVersions when unset.
+func (r pruneRequest) MarshalJSON() ([]byte, error) {
+	type plain pruneRequest
+	return marshalOmittingUnset(plain(r))
+}
+
+// Prune deletes old file versions and orphaned artifacts from a project.
+//
+// Use DryRun to preview what would be deleted before running it for real.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - options: Prune options (can be nil for server defaults)
+//   - opts: Request options (optional)
+//
+// Returns the deleted items and reclaimed bytes or an error if the request fails.
+func (s *FileService) Prune(ctx context.Context, projectID string, options *PruneOptions, opts ...RequestOption) (*PruneResult, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+	if options == nil {
+		options = &PruneOptions{}
+	}
+	if keep, ok := options.KeepVersions.Get(); ok && keep < 0 {
+		return nil, NewValidationError("keep versions cannot be negative")
+	}
+	if options.OlderThan < 0 {
+		return nil, NewValidationError("older than cannot be negative")
+	}
+
+	data := pruneRequest{
+		KeepVersions:     options.KeepVersions,
+		OlderThanSeconds: int64(options.OlderThan / time.Second),
+		DryRun:           options.DryRun,
+	}
+
+	var result PruneResult
+	err := s.client.Post(ctx, fmt.Sprintf("/projects/%s/files/prune", projectID), data, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prune files: %w", err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,340 @@
+package zoptal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// partialSuffix is appended to the local path while a download is in progress.
+const partialSuffix = ".zoptal-partial"
+
+// validatorSuffix is appended to the local path for the file holding the
+// ETag or Last-Modified date of a partial download, with which it is resumed.
+const validatorSuffix = ".zoptal-partial-validator"
+
+// isPartialDownload reports whether a file name is that of an interrupted
+// download's data or validator, which directory scans must skip.
+func isPartialDownload(name string) bool {
+	return strings.HasSuffix(name, partialSuffix) || strings.HasSuffix(name, validatorSuffix)
+}
+
+// DownloadToFile downloads a file from a project to a local path, resuming
+// interrupted transfers with HTTP Range requests.
+//
+// Data is written to localPath plus a ".zoptal-partial" suffix and renamed
+// into place once complete. If a previous download was interrupted, the
+// partial file is continued from where it stopped rather than restarted, and
+// a transfer that fails midway is resumed up to options.MaxResumeAttempts
+// times. Transfers are only resumed with the ETag or Last-Modified date of
+// the partial file's response, kept next to it with a
+// ".zoptal-partial-validator" suffix, so that a file changed on the server
+// is downloaded again rather than spliced; without either, the download
+// restarts. When the server provides a SHA-256 checksum, the completed file is
+// verified against it before being renamed; on mismatch the partial file is
+// removed and an error is returned. Encrypted files are decrypted once the
+// download is complete (see EncryptionOptions).
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - filePath: Path of the file within the project
+//   - localPath: Local destination path
+//   - options: Download options (can be nil for defaults)
+//   - opts: Request options (optional)
+//
+// Returns the downloaded file information or an error if the download fails.
+func (s *FileService) DownloadToFile(ctx context.Context, projectID, filePath, localPath string, options *DownloadOptions, opts ...RequestOption) (*DownloadResult, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+	if filePath == "" {
+		return nil, NewValidationError("file path is required")
+	}
+	if localPath == "" {
+		return nil, NewValidationError("local path is required")
+	}
+	if options == nil {
+		options = &DownloadOptions{}
+	}
+	maxAttempts := options.MaxResumeAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+
+	partialPath := localPath + partialSuffix
+	f, err := os.OpenFile(partialPath, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", filePath, err)
+	}
+	defer f.Close()
+
+	validator := &partialValidator{path: localPath + validatorSuffix}
+	validator.load()
+
+	params := map[string]string{"path": filePath}
+	if options.Version > 0 {
+		params["version"] = strconv.Itoa(options.Version)
+	}
+	endpoint := fmt.Sprintf("/projects/%s/files/download", projectID)
+
+	var result DownloadResult
+	for attempt := 0; ; attempt++ {
+		offset, err := f.Seek(0, io.SeekEnd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download %s: %w", filePath, err)
+		}
+
+		done, err := s.downloadRange(ctx, endpoint, params, f, offset, validator, &result, options.Progress)
+		if err == nil && done {
+			break
+		}
+		if ctx.Err() != nil {
+			return nil, canceled(ctx)
+		}
+		if err != nil && (!isResumable(err) || attempt+1 >= maxAttempts) {
+			return nil, fmt.Errorf("failed to download %s: %w", filePath, err)
+		}
+		if s.client.debug {
+			log.Printf("Download of %s interrupted at %d bytes, resuming: %v", filePath, offset, err)
+		}
+	}
+
+	validator.remove()
+	if err := verifyChecksum(f, result.Checksum); err != nil {
+		f.Close()
+		os.Remove(partialPath)
+		return nil, fmt.Errorf("failed to download %s: %w", filePath, err)
+	}
+
+	if result.KeyID != "" {
+		size, err := s.client.decryptFile(result.KeyID, f, localPath)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to download %s: %w", filePath, err)
+		}
+		os.Remove(partialPath)
+		result.Path = filePath
+		result.Size = size
+		return &result, nil
+	}
+
+	size, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", filePath, err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", filePath, err)
+	}
+	if err := os.Rename(partialPath, localPath); err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", filePath, err)
+	}
+
+	result.Path = filePath
+	result.Size = size
+	return &result, nil
+}
+
+// downloadRange requests the file from offset and appends it to f. It
+// returns true once the whole file has been written.
+func (s *FileService) downloadRange(ctx context.Context, endpoint string, params map[string]string, f *os.File, offset int64, validator *partialValidator, result *DownloadResult, progress func(transferred, total int64)) (bool, error) {
+	if offset > 0 && validator.value == "" {
+		// Without a validator, the partial file cannot be shown to be a
+		// prefix of the current file; start over.
+		if err := f.Truncate(0); err != nil {
+			return false, err
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return false, err
+		}
+		offset = 0
+	}
+
+	header := make(http.Header)
+	if offset > 0 {
+		header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		header.Set("If-Range", validator.value)
+	}
+
+	resp, err := s.client.GetRaw(ctx, endpoint, params, header)
+	if err != nil {
+		if apiErr, ok := err.(*APIError); ok && apiErr.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+			// The partial file is not a prefix of the current file; start over.
+			return false, f.Truncate(0)
+		}
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return false, &resumableError{err: err}
+		}
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	total := resp.ContentLength
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		if start, size, ok := parseContentRange(resp.Header.Get("Content-Range")); ok {
+			if start != offset {
+				return false, fmt.Errorf("server resumed at byte %d, expected %d", start, offset)
+			}
+			total = size
+		} else if total >= 0 {
+			total += offset
+		}
+	default:
+		// The server ignored the range (or the file changed): restart.
+		if err := f.Truncate(0); err != nil {
+			return false, err
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return false, err
+		}
+		offset = 0
+	}
+
+	if err := validator.store(resp.Header); err != nil {
+		return false, err
+	}
+	if value := resp.Header.Get("X-Checksum-SHA256"); value != "" {
+		result.Checksum = value
+	}
+	result.ContentType = resp.Header.Get("Content-Type")
+	result.KeyID = resp.Header.Get(encryptionKeyHeader)
+
+	body := io.Reader(resp.Body)
+	if progress != nil {
+		body = &progressReader{r: resp.Body, transferred: offset, total: total, progress: progress}
+		progress(offset, total)
+	}
+
+	n, err := io.Copy(f, body)
+	if err != nil {
+		return false, &resumableError{err: err}
+	}
+	if total >= 0 && offset+n < total {
+		return false, &resumableError{err: io.ErrUnexpectedEOF}
+	}
+	return true, nil
+}
+
+// partialValidator is the validator of a partial download: the ETag or
+// Last-Modified date of the response it was written from, persisted next to
+// it so that a later call resumes only the same version of the file.
+type partialValidator struct {
+	path  string
+	value string
+}
+
+// load reads the persisted validator, if any.
+func (v *partialValidator) load() {
+	data, err := os.ReadFile(v.path)
+	if err == nil {
+		v.value = strings.TrimSpace(string(data))
+	}
+}
+
+// store persists the validator of a response, or removes the persisted one
+// if the response has none. Weak ETags cannot be used with If-Range, so the
+// Last-Modified date is preferred to them.
+func (v *partialValidator) store(header http.Header) error {
+	value := header.Get("ETag")
+	if value == "" || strings.HasPrefix(value, "W/") {
+		value = header.Get("Last-Modified")
+	}
+	if value == v.value {
+		return nil
+	}
+	v.value = value
+	if value == "" {
+		v.remove()
+		return nil
+	}
+	return os.WriteFile(v.path, []byte(value+"\n"), 0o644)
+}
+
+// remove deletes the persisted validator.
+func (v *partialValidator) remove() {
+	v.value = ""
+	os.Remove(v.path)
+}
+
+// resumableError marks a transfer interruption after which the download can
+// be resumed.
+type resumableError struct {
+	err error
+}
+
+func (e *resumableError) Error() string { return e.err.Error() }
+func (e *resumableError) Unwrap() error { return e.err }
+
+// isResumable reports whether a download can be resumed after err.
+func isResumable(err error) bool {
+	var resumable *resumableError
+	return errors.As(err, &resumable)
+}
+
+// parseContentRange parses a "bytes start-end/size" Content-Range header.
+// size is -1 if unknown.
+func parseContentRange(value string) (start, size int64, ok bool) {
+	value = strings.TrimPrefix(value, "bytes ")
+	rangePart, sizePart, found := strings.Cut(value, "/")
+	if !found {
+		return 0, 0, false
+	}
+	startPart, _, found := strings.Cut(rangePart, "-")
+	if !found {
+		return 0, 0, false
+	}
+
+	start, err := strconv.ParseInt(startPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	size = -1
+	if sizePart != "*" {
+		if size, err = strconv.ParseInt(sizePart, 10, 64); err != nil {
+			return 0, 0, false
+		}
+	}
+	return start, size, true
+}
+
+// verifyChecksum checks the SHA-256 of f against the hex-encoded expected
+// checksum. An empty checksum is not verified.
+func verifyChecksum(f *os.File, expected string) error {
+	if expected == "" {
+		return nil
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	return compareChecksum(expected, hex.EncodeToString(h.Sum(nil)))
+}
+
+// compareChecksum returns a FileError if the hex-encoded SHA-256 actual does
+// not match expected, which may carry a "sha256:" prefix. An empty expected
+// checksum always matches.
+func compareChecksum(expected, actual string) error {
+	if expected == "" {
+		return nil
+	}
+	if !strings.EqualFold(actual, strings.TrimPrefix(expected, "sha256:")) {
+		return NewFileError(fmt.Sprintf("checksum mismatch: expected %s, got %s", expected, actual))
+	}
+	return nil
+}
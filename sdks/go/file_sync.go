@@ -0,0 +1,446 @@
+package zoptal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// RemoteFile describes a file stored in a project.
+type RemoteFile struct {
+	Path      string    `json:"path"`
+	Size      int64     `json:"size"`
+	SHA256    string    `json:"sha256"`
+	UpdatedAt Timestamp `json:"updated_at"`
+
+	// PlaintextSize and PlaintextSHA256 are the size and SHA-256 of the
+	// plaintext of an encrypted file, recorded when it was uploaded (see
+	// EncryptionOptions); Size and SHA256 are those of the ciphertext. They
+	// are empty for files that are not encrypted.
+	PlaintextSize   int64  `json:"plaintext_size,omitempty"`
+	PlaintextSHA256 string `json:"plaintext_sha256,omitempty"`
+}
+
+// ContentSize returns the size of the file's content as downloaded: the
+// plaintext size of an encrypted file, otherwise Size.
+func (f *RemoteFile) ContentSize() int64 {
+	if f.PlaintextSHA256 != "" {
+		return f.PlaintextSize
+	}
+	return f.Size
+}
+
+// ContentSHA256 returns the SHA-256 of the file's content as downloaded:
+// the plaintext hash of an encrypted file, otherwise SHA256. Local files
+// are compared with it.
+func (f *RemoteFile) ContentSHA256() string {
+	if f.PlaintextSHA256 != "" {
+		return f.PlaintextSHA256
+	}
+	return f.SHA256
+}
+
+// SyncOptions contains options for synchronizing a local directory with a project.
+type SyncOptions struct {
+	// Concurrency is the maximum number of concurrent transfers (default: 4)
+	Concurrency int
+
+	// Delete removes files from the destination that do not exist in the source (default: false)
+	Delete bool
+
+	// Ignore lists glob patterns (matched against the slash-separated relative
+	// path and the base name) of files to leave alone (optional)
+	Ignore []string
+
+	// DryRun computes the changes without transferring or deleting anything (default: false)
+	DryRun bool
+
+	// OnChange is called for every created, updated, or deleted file (optional)
+	OnChange func(path string, action SyncAction)
+}
+
+// SyncAction is the change applied to a single file during a sync.
+type SyncAction string
+
+// Sync actions.
+const (
+	SyncCreated SyncAction = "created"
+	SyncUpdated SyncAction = "updated"
+	SyncDeleted SyncAction = "deleted"
+)
+
+// SyncResult summarizes a sync.
+type SyncResult struct {
+	Created          []string
+	Updated          []string
+	Deleted          []string
+	Unchanged        int
+	BytesTransferred int64
+	Errors           []SyncError
+}
+
+// SyncError is a failure to sync a single file.
+type SyncError struct {
+	Path string
+	Err  error
+}
+
+// Error implements the error interface.
+func (e SyncError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+// Manifest lists every file in a project with its size and SHA-256 hash.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - opts: Request options (optional)
+//
+// Returns the project's files or an error if the request fails.
+func (s *FileService) Manifest(ctx context.Context, projectID string, opts ...RequestOption) ([]RemoteFile, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+
+	var result struct {
+		Files []RemoteFile `json:"files"`
+	}
+	err := s.client.Get(ctx, fmt.Sprintf("/projects/%s/files/manifest", projectID), nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file manifest: %w", err)
+	}
+	return result.Files, nil
+}
+
+// SyncUp pushes a local directory to a project, uploading only files whose
+// content differs from the project's copy.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - localDir: Local directory to push
+//   - options: Sync options (can be nil for defaults)
+//   - opts: Request options (optional)
+//
+// Returns a summary of the changes or an error if the sync could not run.
+// Failures of individual files are reported in SyncResult.Errors.
+func (s *FileService) SyncUp(ctx context.Context, projectID, localDir string, options *SyncOptions, opts ...RequestOption) (*SyncResult, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if options == nil {
+		options = &SyncOptions{}
+	}
+	local, remote, err := s.syncState(ctx, projectID, localDir, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []syncTask
+	for rel, hash := range local {
+		remoteFile, exists := remote[rel]
+		switch {
+		case !exists:
+			tasks = append(tasks, syncTask{path: rel, action: SyncCreated})
+		case !strings.EqualFold(remoteFile.ContentSHA256(), hash):
+			tasks = append(tasks, syncTask{path: rel, action: SyncUpdated})
+		}
+	}
+	if options.Delete {
+		for rel := range remote {
+			if _, exists := local[rel]; !exists {
+				tasks = append(tasks, syncTask{path: rel, action: SyncDeleted})
+			}
+		}
+	}
+
+	result := s.runSync(ctx, tasks, len(local), options, func(task syncTask) (int64, error) {
+		if task.action == SyncDeleted {
+			return 0, s.deleteRemote(ctx, projectID, task.path)
+		}
+		return s.uploadLocal(ctx, projectID, localDir, task.path)
+	})
+	return result, nil
+}
+
+// SyncDown pulls a project into a local directory, downloading only files
+// whose content differs from the local copy.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - localDir: Local directory to update (created if missing)
+//   - options: Sync options (can be nil for defaults)
+//   - opts: Request options (optional)
+//
+// Returns a summary of the changes or an error if the sync could not run.
+// Failures of individual files are reported in SyncResult.Errors.
+func (s *FileService) SyncDown(ctx context.Context, projectID, localDir string, options *SyncOptions, opts ...RequestOption) (*SyncResult, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if options == nil {
+		options = &SyncOptions{}
+	}
+	if err := os.MkdirAll(localDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", localDir, err)
+	}
+	local, remote, err := s.syncState(ctx, projectID, localDir, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []syncTask
+	for rel, remoteFile := range remote {
+		hash, exists := local[rel]
+		switch {
+		case !exists:
+			tasks = append(tasks, syncTask{path: rel, action: SyncCreated})
+		case !strings.EqualFold(remoteFile.ContentSHA256(), hash):
+			tasks = append(tasks, syncTask{path: rel, action: SyncUpdated})
+		}
+	}
+	if options.Delete {
+		for rel := range local {
+			if _, exists := remote[rel]; !exists {
+				tasks = append(tasks, syncTask{path: rel, action: SyncDeleted})
+			}
+		}
+	}
+
+	result := s.runSync(ctx, tasks, len(remote), options, func(task syncTask) (int64, error) {
+		target, err := localPath(localDir, task.path)
+		if err != nil {
+			return 0, err
+		}
+		if task.action == SyncDeleted {
+			return 0, os.Remove(target)
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return 0, err
+		}
+		downloaded, err := s.DownloadToFile(ctx, projectID, task.path, target, nil)
+		if err != nil {
+			return 0, err
+		}
+		return downloaded.Size, nil
+	})
+	return result, nil
+}
+
+// syncTask is a single file change to apply.
+type syncTask struct {
+	path   string
+	action SyncAction
+}
+
+// syncState hashes the local directory and fetches the remote manifest,
+// both filtered by the ignore patterns.
+func (s *FileService) syncState(ctx context.Context, projectID, localDir string, options *SyncOptions) (map[string]string, map[string]RemoteFile, error) {
+	if projectID == "" {
+		return nil, nil, NewValidationError("project ID is required")
+	}
+	if localDir == "" {
+		return nil, nil, NewValidationError("local directory is required")
+	}
+
+	local, err := hashDirectory(localDir, options.Ignore)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to scan %s: %w", localDir, err)
+	}
+
+	files, err := s.Manifest(ctx, projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	remote := make(map[string]RemoteFile, len(files))
+	for _, file := range files {
+		rel := strings.TrimPrefix(path.Clean("/"+file.Path), "/")
+		if !matchesAny(options.Ignore, rel) {
+			remote[rel] = file
+		}
+	}
+	return local, remote, nil
+}
+
+// runSync applies tasks with bounded concurrency and collects the result.
+func (s *FileService) runSync(ctx context.Context, tasks []syncTask, total int, options *SyncOptions, apply func(syncTask) (int64, error)) *SyncResult {
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].path < tasks[j].path })
+
+	result := &SyncResult{}
+	changed := 0
+	for _, task := range tasks {
+		if task.action != SyncDeleted {
+			changed++
+		}
+	}
+	result.Unchanged = total - changed
+
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+
+	for _, task := range tasks {
+		if ctx.Err() != nil {
+			mu.Lock()
+			result.Errors = append(result.Errors, SyncError{Path: task.path, Err: canceled(ctx)})
+			mu.Unlock()
+			continue
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(task syncTask) {
+			defer s.client.metrics.track(GoroutineWorkers)()
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			var n int64
+			var err error
+			if !options.DryRun {
+				n, err = apply(task)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result.Errors = append(result.Errors, SyncError{Path: task.path, Err: err})
+				return
+			}
+			result.BytesTransferred += n
+			switch task.action {
+			case SyncCreated:
+				result.Created = append(result.Created, task.path)
+			case SyncUpdated:
+				result.Updated = append(result.Updated, task.path)
+			case SyncDeleted:
+				result.Deleted = append(result.Deleted, task.path)
+			}
+			if options.OnChange != nil {
+				options.OnChange(task.path, task.action)
+			}
+		}(task)
+	}
+	wg.Wait()
+
+	sort.Strings(result.Created)
+	sort.Strings(result.Updated)
+	sort.Strings(result.Deleted)
+	return result
+}
+
+// uploadLocal uploads a single local file.
+func (s *FileService) uploadLocal(ctx context.Context, projectID, localDir, rel string) (int64, error) {
+	source, err := localPath(localDir, rel)
+	if err != nil {
+		return 0, err
+	}
+	f, err := os.Open(source)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	uploaded, err := s.Upload(ctx, projectID, rel, f, &UploadOptions{Overwrite: true})
+	if err != nil {
+		return 0, err
+	}
+	return uploaded.BytesSent, nil
+}
+
+// deleteRemote deletes a single file from a project.
+func (s *FileService) deleteRemote(ctx context.Context, projectID, rel string) error {
+	endpoint := fmt.Sprintf("/projects/%s/files?path=%s", projectID, url.QueryEscape(rel))
+	return s.client.Delete(ctx, endpoint, nil)
+}
+
+// hashDirectory returns the SHA-256 of every regular file under dir, keyed
+// by slash-separated relative path, skipping ignored files and interrupted
+// downloads.
+func hashDirectory(dir string, ignore []string) (map[string]string, error) {
+	hashes := make(map[string]string)
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == "." {
+			return nil
+		}
+		if matchesAny(ignore, rel) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || isPartialDownload(rel) {
+			return nil
+		}
+
+		hash, err := hashFile(p)
+		if err != nil {
+			return err
+		}
+		hashes[rel] = hash
+		return nil
+	})
+	return hashes, err
+}
+
+// hashFile returns the hex-encoded SHA-256 of a file.
+func hashFile(name string) (string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// matchesAny reports whether the slash-separated relative path rel, or its
+// base name, matches any of the glob patterns.
+func matchesAny(patterns []string, rel string) bool {
+	base := path.Base(rel)
+	for _, pattern := range patterns {
+		pattern = strings.TrimSuffix(pattern, "/")
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, base); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// localPath joins a slash-separated project path onto dir, rejecting paths
+// that would escape dir.
+func localPath(dir, rel string) (string, error) {
+	cleaned := path.Clean("/" + rel)
+	if cleaned == "/" {
+		return "", fmt.Errorf("invalid file path %q", rel)
+	}
+	return filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(cleaned, "/"))), nil
+}
@@ -0,0 +1,219 @@
+package zoptal
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"path"
+	"strconv"
+)
+
+// UploadOptions contains options for uploading a file.
+type UploadOptions struct {
+	// ContentType is the file's content type (default: detected from the
+	// file extension, then from the content)
+	ContentType string
+
+	// Overwrite replaces an existing file at the same path (default: false)
+	Overwrite bool
+
+	// Metadata is arbitrary key/value metadata stored with the file (optional)
+	Metadata map[string]string
+}
+
+// UploadResult describes an uploaded file.
+type UploadResult struct {
+	Path        string    `json:"path"`
+	Size        int64     `json:"size"`
+	ContentType string    `json:"content_type"`
+	Version     int       `json:"version,omitempty"`
+	Checksum    string    `json:"checksum,omitempty"`
+	UploadedAt  Timestamp `json:"uploaded_at"`
+
+	// BytesSent is the number of file bytes streamed to the API
+	BytesSent int64 `json:"-"`
+
+	// SHA256 is the hex-encoded SHA-256 of the streamed content
+	SHA256 string `json:"-"`
+
+	// ContentSHA256 is the hex-encoded SHA-256 of the file's content before
+	// encryption; equal to SHA256 for files that are not encrypted
+	ContentSHA256 string `json:"-"`
+
+	// KeyID is the ID of the key the file was encrypted with, or "" if it
+	// was not encrypted (see EncryptionOptions)
+	KeyID string `json:"-"`
+}
+
+// Upload streams a file to a project as multipart/form-data.
+//
+// The content is read from r and streamed to the API without being buffered
+// in memory, so arbitrarily large files can be uploaded. Because the body
+// can only be read once, uploads are not retried. With client-side
+// encryption (see EncryptionOptions), the content is encrypted as it is
+// streamed.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - filePath: Destination path of the file within the project
+//   - r: File content
+//   - options: Upload options (can be nil for defaults)
+//   - opts: Request options (optional)
+//
+// Returns the uploaded file information or an error if the upload fails.
+func (s *FileService) Upload(ctx context.Context, projectID, filePath string, r io.Reader, options *UploadOptions, opts ...RequestOption) (*UploadResult, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+	if filePath == "" {
+		return nil, NewValidationError("file path is required")
+	}
+	if r == nil {
+		return nil, NewValidationError("file content is required")
+	}
+	if options == nil {
+		options = &UploadOptions{}
+	}
+
+	content := bufio.NewReader(r)
+	contentType := detectContentType(filePath, content, options.ContentType)
+
+	body := io.Reader(content)
+	var (
+		keyID     string
+		plaintext *countingReader
+	)
+	if cipher := s.client.uploadCipher(projectID); cipher != nil {
+		keyID = cipher.KeyID()
+		plaintext = &countingReader{r: content, hash: sha256.New()}
+		encrypted, err := cipher.Encrypt(keyID, plaintext)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt %s: %w", filePath, err)
+		}
+		body = encrypted
+	}
+
+	counter := &countingReader{r: body, hash: sha256.New()}
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+
+	go func() {
+		defer s.client.metrics.track(GoroutineWorkers)()
+		pw.CloseWithError(writeUploadForm(mw, filePath, contentType, keyID, counter, plaintext, options))
+	}()
+
+	var result UploadResult
+	err := s.client.PostReader(ctx, fmt.Sprintf("/projects/%s/files/upload", projectID), mw.FormDataContentType(), pr, &result)
+	pr.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload %s: %w", filePath, err)
+	}
+
+	result.BytesSent = counter.n
+	result.SHA256 = hex.EncodeToString(counter.hash.Sum(nil))
+	result.ContentSHA256 = result.SHA256
+	if plaintext != nil {
+		result.ContentSHA256 = hex.EncodeToString(plaintext.hash.Sum(nil))
+	}
+	result.KeyID = keyID
+	if result.Path == "" {
+		result.Path = filePath
+	}
+	if result.Size == 0 {
+		result.Size = counter.n
+	}
+	if result.ContentType == "" {
+		result.ContentType = contentType
+	}
+	return &result, nil
+}
+
+// writeUploadForm writes the multipart form for an upload. For an encrypted
+// upload, plaintext counts the content before encryption; its size and hash
+// are only known once the content is written, so they follow the file part.
+func writeUploadForm(mw *multipart.Writer, filePath, contentType, keyID string, content io.Reader, plaintext *countingReader, options *UploadOptions) error {
+	if err := mw.WriteField("path", filePath); err != nil {
+		return err
+	}
+	if keyID != "" {
+		if err := mw.WriteField(encryptionKeyField, keyID); err != nil {
+			return err
+		}
+	}
+	if options.Overwrite {
+		if err := mw.WriteField("overwrite", "true"); err != nil {
+			return err
+		}
+	}
+	for key, value := range options.Metadata {
+		if err := mw.WriteField("metadata["+key+"]", value); err != nil {
+			return err
+		}
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{
+		"name":     "file",
+		"filename": path.Base(filePath),
+	}))
+	header.Set("Content-Type", contentType)
+
+	part, err := mw.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, content); err != nil {
+		return err
+	}
+	if plaintext != nil {
+		if err := mw.WriteField("plaintext_size", strconv.FormatInt(plaintext.n, 10)); err != nil {
+			return err
+		}
+		if err := mw.WriteField("plaintext_sha256", hex.EncodeToString(plaintext.hash.Sum(nil))); err != nil {
+			return err
+		}
+	}
+	return mw.Close()
+}
+
+// detectContentType returns explicit if set, otherwise the content type
+// implied by the file extension, otherwise one sniffed from the content.
+func detectContentType(filePath string, content *bufio.Reader, explicit string) string {
+	if explicit != "" {
+		return explicit
+	}
+	if ext := path.Ext(filePath); ext != "" {
+		if byExt := mime.TypeByExtension(ext); byExt != "" {
+			return byExt
+		}
+	}
+	head, _ := content.Peek(512)
+	return http.DetectContentType(head)
+}
+
+// countingReader counts and hashes the bytes read through it.
+type countingReader struct {
+	r    io.Reader
+	n    int64
+	hash hash.Hash
+}
+
+// Read implements io.Reader.
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	if n > 0 {
+		c.n += int64(n)
+		c.hash.Write(p[:n])
+	}
+	return n, err
+}
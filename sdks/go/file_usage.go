@@ -0,0 +1,64 @@
+package zoptal
+
+import (
+	"context"
+	"fmt"
+)
+
+// StorageUsage contains a breakdown of the storage used by a project.
+type StorageUsage struct {
+	ProjectID    string              `json:"project_id"`
+	TotalBytes   int64               `json:"total_bytes"`
+	FileCount    int                 `json:"file_count"`
+	QuotaBytes   int64               `json:"quota_bytes,omitempty"`
+	ByDirectory  []StorageUsageEntry `json:"by_directory"`
+	ByFileType   []StorageUsageEntry `json:"by_file_type"`
+	LargestFiles []LargestFile       `json:"largest_files"`
+}
+
+// StorageUsageEntry is the storage used by a single directory or file type.
+type StorageUsageEntry struct {
+	// Name is the directory path or file extension (e.g. "src/assets" or ".png")
+	Name      string `json:"name"`
+	Bytes     int64  `json:"bytes"`
+	FileCount int    `json:"file_count"`
+}
+
+// LargestFile describes one of the largest files in a project.
+type LargestFile struct {
+	Path      string    `json:"path"`
+	Bytes     int64     `json:"bytes"`
+	UpdatedAt Timestamp `json:"updated_at"`
+}
+
+// QuotaUsedPercent returns the percentage of the storage quota in use,
+// or 0 if the project has no storage quota.
+func (u *StorageUsage) QuotaUsedPercent() float64 {
+	if u.QuotaBytes <= 0 {
+		return 0
+	}
+	return float64(u.TotalBytes) / float64(u.QuotaBytes) * 100
+}
+
+// Usage returns the storage used by a project broken down by directory and
+// file type, along with the largest files.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - opts: Request options (optional)
+//
+// Returns the storage usage breakdown or an error if the request fails.
+func (s *FileService) Usage(ctx context.Context, projectID string, opts ...RequestOption) (*StorageUsage, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+
+	var result StorageUsage
+	err := s.client.Get(ctx, fmt.Sprintf("/projects/%s/files/usage", projectID), nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage usage: %w", err)
+	}
+	return &result, nil
+}
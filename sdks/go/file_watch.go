@@ -0,0 +1,485 @@
+package zoptal
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// ignoreFileName is the name of the file listing ignore patterns in a watched directory.
+const ignoreFileName = ".zoptalignore"
+
+// WatchOptions contains options for watching a local directory.
+type WatchOptions struct {
+	// PollInterval is how often the directory is scanned for changes where
+	// file system notifications are unavailable, and how often a failed
+	// push is retried (default: 1 second)
+	PollInterval time.Duration
+
+	// Debounce is how long the directory must be quiet before changes are pushed (default: 500 milliseconds)
+	Debounce time.Duration
+
+	// Ignore lists glob patterns of files to leave alone, in addition to
+	// those in the directory's .zoptalignore file (optional)
+	Ignore []string
+
+	// Delete removes files from the project when they are deleted locally (default: false)
+	Delete bool
+
+	// Overwrite pushes local changes even when the remote file was changed
+	// since it was last synced, instead of reporting a conflict (default: false)
+	Overwrite bool
+}
+
+// WatchEvent reports a change pushed, or failed to push, by a DirectoryWatcher.
+type WatchEvent struct {
+	Path   string
+	Action SyncAction
+
+	// Err is set if the change could not be pushed; it is a *ConflictError
+	// if the remote file was modified since it was last synced. Path is
+	// empty for errors that are not tied to a single file.
+	Err error
+}
+
+// DirectoryWatcher pushes changes in a local directory to a project.
+//
+// A DirectoryWatcher is a stream: it must be closed when no longer needed.
+type DirectoryWatcher struct {
+	*streamState
+	events chan WatchEvent
+}
+
+// Events returns the channel on which pushed changes are reported. The
+// channel is closed when the watcher stops. Events are dropped if the
+// channel is not drained.
+func (w *DirectoryWatcher) Events() <-chan WatchEvent {
+	return w.events
+}
+
+// WatchDirectory watches a local directory and pushes changes to a project
+// in near real time.
+//
+// The directory is watched with file system notifications (inotify, kqueue,
+// or ReadDirectoryChangesW); once it has been quiet for the debounce period,
+// created and modified files are uploaded and, if options.Delete is set,
+// deleted files are removed from the project. Files matching the patterns in
+// the directory's .zoptalignore file (one glob per line, # for comments) or
+// options.Ignore are skipped.
+//
+// Where notifications are unavailable, or the system's limit of watched
+// directories is reached, the watcher falls back to scanning the directory
+// every options.PollInterval. Each scan walks the whole tree and stats every
+// file, so on large trees it costs noticeable CPU; raise PollInterval or
+// ignore build output and dependency directories to reduce it.
+//
+// Before a file is pushed, its remote hash is compared with the hash seen
+// when it was last synced. If the remote file changed in the meantime, the
+// push is skipped and a *ConflictError is reported, unless options.Overwrite
+// is set.
+//
+// Parameters:
+//   - ctx: Context that stops the watcher when cancelled
+//   - projectID: ID of the project
+//   - localDir: Local directory to watch
+//   - options: Watch options (can be nil for defaults)
+//   - opts: Request options (optional)
+//
+// Returns the watcher, which must be closed, or an error if the initial
+// scan fails.
+func (s *FileService) WatchDirectory(ctx context.Context, projectID, localDir string, options *WatchOptions, opts ...RequestOption) (*DirectoryWatcher, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+	if localDir == "" {
+		return nil, NewValidationError("local directory is required")
+	}
+	if options == nil {
+		options = &WatchOptions{}
+	}
+
+	pollInterval := options.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = time.Second
+	}
+	debounce := options.Debounce
+	if debounce <= 0 {
+		debounce = 500 * time.Millisecond
+	}
+
+	state := &watchState{
+		service:   s,
+		projectID: projectID,
+		dir:       localDir,
+		options:   options,
+		debug:     s.client.debug,
+	}
+	if notify, err := fsnotify.NewWatcher(); err == nil {
+		state.notify = notify
+	} else if state.debug {
+		log.Printf("Zoptal file system notifications unavailable, polling %s: %v", localDir, err)
+	}
+	if err := state.init(ctx); err != nil {
+		state.stopNotify(nil)
+		return nil, err
+	}
+
+	w := &DirectoryWatcher{
+		streamState: newStreamState(nil, s.client.metrics),
+		events:      make(chan WatchEvent, 64),
+	}
+
+	go func() {
+		defer close(w.events)
+		defer w.Close()
+		defer state.stopNotify(nil)
+
+		if state.notify != nil && w.watch(ctx, state, pollInterval, debounce) {
+			return
+		}
+		w.poll(ctx, state, pollInterval, debounce)
+	}()
+
+	return w, nil
+}
+
+// watch pushes the changes reported by file system notifications. It
+// returns false, after stopping the notifications, if the watcher must fall
+// back to polling.
+func (w *DirectoryWatcher) watch(ctx context.Context, state *watchState, pollInterval, debounce time.Duration) bool {
+	timer := time.NewTimer(debounce)
+	resetTimer := func(d time.Duration) {
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(d)
+	}
+	defer timer.Stop()
+	resetTimer(debounce)
+
+	for state.notify != nil {
+		select {
+		case <-ctx.Done():
+			return true
+		case <-w.Done():
+			return true
+		case event, ok := <-state.notify.Events:
+			if !ok {
+				return true
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			if state.scanTree(event.Name) {
+				resetTimer(debounce)
+			}
+		case err, ok := <-state.notify.Errors:
+			if !ok {
+				return true
+			}
+			if errors.Is(err, fsnotify.ErrEventOverflow) {
+				// Events were dropped, so rescan the whole directory.
+				if state.scan() {
+					resetTimer(debounce)
+				}
+				continue
+			}
+			w.emit(WatchEvent{Err: err})
+		case <-timer.C:
+			if len(state.pending) == 0 {
+				continue
+			}
+			w.emit(state.flush(ctx)...)
+			if len(state.pending) > 0 {
+				// The manifest or a push failed; retry.
+				resetTimer(pollInterval)
+			}
+		}
+	}
+	return false
+}
+
+// poll scans the directory every pollInterval and pushes the changes.
+func (w *DirectoryWatcher) poll(ctx context.Context, state *watchState, pollInterval, debounce time.Duration) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var lastChange time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.Done():
+			return
+		case now := <-ticker.C:
+			if state.scan() {
+				lastChange = now
+			}
+			if len(state.pending) == 0 || now.Sub(lastChange) < debounce {
+				continue
+			}
+			w.emit(state.flush(ctx)...)
+		}
+	}
+}
+
+// emit reports events, dropping those the channel has no room for.
+func (w *DirectoryWatcher) emit(events ...WatchEvent) {
+	for _, event := range events {
+		select {
+		case w.events <- event:
+		default:
+		}
+	}
+}
+
+// watchedFile is the last observed state of a local file.
+type watchedFile struct {
+	size    int64
+	modTime time.Time
+	sha256  string
+}
+
+// watchState tracks the local and remote state of a watched directory.
+type watchState struct {
+	service   *FileService
+	projectID string
+	dir       string
+	options   *WatchOptions
+	debug     bool
+
+	// notify delivers file system notifications for the directories of the
+	// tree; nil when polling
+	notify *fsnotify.Watcher
+
+	ignore  []string
+	local   map[string]watchedFile
+	base    map[string]string
+	pending map[string]bool
+}
+
+// init loads the ignore patterns and records the initial local and remote state.
+func (w *watchState) init(ctx context.Context) error {
+	w.loadIgnore()
+	w.pending = make(map[string]bool)
+	w.local = make(map[string]watchedFile)
+	w.scan()
+	w.pending = make(map[string]bool)
+
+	files, err := w.service.Manifest(ctx, w.projectID)
+	if err != nil {
+		return err
+	}
+	w.base = make(map[string]string, len(files))
+	for _, file := range files {
+		w.base[strings.TrimPrefix(path.Clean("/"+file.Path), "/")] = file.ContentSHA256()
+	}
+	return nil
+}
+
+// loadIgnore reads the directory's .zoptalignore file.
+func (w *watchState) loadIgnore() {
+	w.ignore = append([]string{ignoreFileName}, w.options.Ignore...)
+
+	f, err := os.Open(filepath.Join(w.dir, ignoreFileName))
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			w.ignore = append(w.ignore, strings.TrimPrefix(line, "/"))
+		}
+	}
+}
+
+// scan walks the whole directory; see scanTree.
+func (w *watchState) scan() bool {
+	return w.scanTree(w.dir)
+}
+
+// scanTree walks root, a file or directory within the watched directory,
+// marking changed and deleted files as pending and watching new
+// directories. Files are only rehashed when their size or modification time
+// changes. A change of the .zoptalignore file rescans the whole directory.
+// It reports whether anything changed.
+func (w *watchState) scanTree(root string) bool {
+	if info, err := os.Stat(filepath.Join(w.dir, ignoreFileName)); err == nil {
+		if seen, ok := w.local[ignoreFileName]; !ok || !info.ModTime().Equal(seen.modTime) {
+			w.loadIgnore()
+			w.local[ignoreFileName] = watchedFile{modTime: info.ModTime()}
+			root = w.dir
+		}
+	}
+	prefix, err := filepath.Rel(w.dir, root)
+	if err != nil {
+		return false
+	}
+	prefix = filepath.ToSlash(prefix)
+
+	changed := false
+	seen := make(map[string]bool, len(w.local))
+	filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(w.dir, p)
+		if err != nil {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if rel != "." && matchesAny(w.ignore, rel) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() {
+			w.watchDir(p)
+			return nil
+		}
+		if !info.Mode().IsRegular() || isPartialDownload(rel) {
+			return nil
+		}
+
+		seen[rel] = true
+		prev, ok := w.local[rel]
+		if ok && prev.size == info.Size() && prev.modTime.Equal(info.ModTime()) {
+			return nil
+		}
+		hash, err := hashFile(p)
+		if err != nil {
+			return nil
+		}
+		w.local[rel] = watchedFile{size: info.Size(), modTime: info.ModTime(), sha256: hash}
+		if !ok || prev.sha256 != hash {
+			w.pending[rel] = true
+			changed = true
+		}
+		return nil
+	})
+
+	for rel := range w.local {
+		if rel == ignoreFileName || seen[rel] {
+			continue
+		}
+		if prefix != "." && rel != prefix && !strings.HasPrefix(rel, prefix+"/") {
+			continue
+		}
+		delete(w.local, rel)
+		if !matchesAny(w.ignore, rel) {
+			w.pending[rel] = true
+			changed = true
+		}
+	}
+	return changed
+}
+
+// watchDir adds dir to the file system notifications. If the system's
+// limit of watches is reached, the notifications are stopped so that the
+// watcher falls back to polling.
+func (w *watchState) watchDir(dir string) {
+	if w.notify == nil {
+		return
+	}
+	if err := w.notify.Add(dir); err != nil {
+		w.stopNotify(err)
+	}
+}
+
+// stopNotify stops the file system notifications, if any; err is the
+// reason, for the debug log.
+func (w *watchState) stopNotify(err error) {
+	if w.notify == nil {
+		return
+	}
+	w.notify.Close()
+	w.notify = nil
+	if err != nil && w.debug {
+		log.Printf("Zoptal file system notifications failed, polling %s: %v", w.dir, err)
+	}
+}
+
+// flush pushes the pending changes and returns an event for each. Changes
+// whose push fails stay pending, to be retried; conflicts do not.
+func (w *watchState) flush(ctx context.Context) []WatchEvent {
+	paths := make([]string, 0, len(w.pending))
+	for rel := range w.pending {
+		paths = append(paths, rel)
+	}
+	sort.Strings(paths)
+
+	files, err := w.service.Manifest(ctx, w.projectID)
+	if err != nil {
+		// Keep the changes pending and retry after the next debounce.
+		return []WatchEvent{{Err: err}}
+	}
+	remote := make(map[string]string, len(files))
+	for _, file := range files {
+		remote[strings.TrimPrefix(path.Clean("/"+file.Path), "/")] = file.ContentSHA256()
+	}
+
+	var events []WatchEvent
+	for _, rel := range paths {
+		delete(w.pending, rel)
+		local, exists := w.local[rel]
+		remoteHash, remoteExists := remote[rel]
+
+		if !exists && !w.options.Delete {
+			continue
+		}
+		if exists && strings.EqualFold(remoteHash, local.sha256) {
+			w.base[rel] = remoteHash
+			continue
+		}
+		if !w.options.Overwrite && remoteHash != w.base[rel] {
+			events = append(events, WatchEvent{
+				Path: rel,
+				Err:  NewConflictError(rel, local.sha256, remoteHash),
+			})
+			continue
+		}
+
+		event := WatchEvent{Path: rel}
+		switch {
+		case !exists:
+			event.Action = SyncDeleted
+			if remoteExists {
+				event.Err = w.service.deleteRemote(ctx, w.projectID, rel)
+			}
+			if event.Err == nil {
+				delete(w.base, rel)
+			}
+		default:
+			event.Action = SyncUpdated
+			if !remoteExists {
+				event.Action = SyncCreated
+			}
+			_, event.Err = w.service.uploadLocal(ctx, w.projectID, w.dir, rel)
+			if event.Err == nil {
+				w.base[rel] = local.sha256
+			}
+		}
+		if event.Err != nil {
+			w.pending[rel] = true
+		}
+		events = append(events, event)
+	}
+	return events
+}
@@ -0,0 +1,45 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// FileService handles the files of projects: uploading, downloading, and
+// synchronizing them with local directories.
+type FileService struct {
+	client *HTTPClient
+}
+
+// Get gets the metadata of a file in a project. Use DownloadTo to get its
+// content.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - filePath: Path of the file within the project
+//   - opts: Request options (optional)
+//
+// Returns the file or an error if the request fails.
+func (s *FileService) Get(ctx context.Context, projectID, filePath string, opts ...RequestOption) (*RemoteFile, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+	if filePath == "" {
+		return nil, NewValidationError("file path is required")
+	}
+
+	var raw json.RawMessage
+	params := map[string]string{"path": filePath}
+	if err := s.client.Get(ctx, fmt.Sprintf("/projects/%s/files", projectID), params, &raw); err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", filePath, err)
+	}
+
+	var file RemoteFile
+	if err := decodeJSON(raw, &file); err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", filePath, err)
+	}
+	return &file, nil
+}
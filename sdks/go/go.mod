@@ -3,11 +3,13 @@ module github.com/zoptal/zoptal-go-sdk
 go 1.19
 
 require (
+	github.com/fsnotify/fsnotify v1.7.0
 	github.com/stretchr/testify v1.8.4
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
+	golang.org/x/sys v0.13.0 // indirect
 )
\ No newline at end of file
@@ -0,0 +1,198 @@
+package zoptal
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// HedgingOptions contains options for hedged GET requests.
+//
+// When hedging is enabled, a second identical GET request is sent if the first
+// has not completed within the configured latency percentile, and whichever
+// response arrives first is used, unless the retry policy would retry it (such
+// as a 503 or 429), in which case the other attempt's response is awaited.
+// This trades a small amount of extra load for lower tail latency on read
+// endpoints such as Projects.Get and Files.Get. Streamed downloads are never
+// hedged.
+type HedgingOptions struct {
+	// Percentile is the observed GET latency percentile after which the hedge
+	// request is sent, in the range (0, 1] (default: 0.95)
+	Percentile float64
+
+	// Delay is the hedge delay used until enough latency samples have been
+	// collected (default: 100 milliseconds)
+	Delay time.Duration
+
+	// MinSamples is the number of samples required before the percentile is
+	// used instead of Delay (default: 20)
+	MinSamples int
+}
+
+// hedgeWindow is the number of recent GET latencies used to compute the percentile.
+const hedgeWindow = 100
+
+// hedger tracks GET latencies and issues hedged requests.
+type hedger struct {
+	percentile float64
+	delay      time.Duration
+	minSamples int
+	debug      bool
+	metrics    *runtimeMetrics
+
+	// retryPolicy classifies responses that must not win the race
+	retryPolicy RetryPolicy
+
+	mu      sync.Mutex
+	samples []time.Duration
+	next    int
+}
+
+// hedgeResult is the outcome of a single hedged attempt.
+type hedgeResult struct {
+	resp    *http.Response
+	err     error
+	latency time.Duration
+}
+
+// newHedger creates a hedger from the given options, applying defaults.
+func newHedger(options *HedgingOptions, retryPolicy RetryPolicy, debug bool, metrics *runtimeMetrics) *hedger {
+	h := &hedger{
+		percentile:  options.Percentile,
+		delay:       options.Delay,
+		minSamples:  options.MinSamples,
+		debug:       debug,
+		metrics:     metrics,
+		retryPolicy: retryPolicy,
+	}
+	if h.percentile <= 0 || h.percentile > 1 {
+		h.percentile = 0.95
+	}
+	if h.delay <= 0 {
+		h.delay = 100 * time.Millisecond
+	}
+	if h.minSamples <= 0 {
+		h.minSamples = 20
+	}
+	if h.minSamples > hedgeWindow {
+		h.minSamples = hedgeWindow
+	}
+	return h
+}
+
+// observe records the latency of a successful GET request.
+func (h *hedger) observe(latency time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.samples) < hedgeWindow {
+		h.samples = append(h.samples, latency)
+		return
+	}
+	h.samples[h.next] = latency
+	h.next = (h.next + 1) % hedgeWindow
+}
+
+// hedgeDelay returns how long to wait before sending the hedge request.
+func (h *hedger) hedgeDelay() time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.samples) < h.minSamples {
+		return h.delay
+	}
+
+	sorted := make([]time.Duration, len(h.samples))
+	copy(sorted, h.samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	index := int(float64(len(sorted))*h.percentile+0.5) - 1
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(sorted) {
+		index = len(sorted) - 1
+	}
+	return sorted[index]
+}
+
+// retryable reports whether the retry policy would retry a response.
+func (h *hedger) retryable(resp *http.Response) bool {
+	if resp.StatusCode < 400 {
+		return false
+	}
+	_, retry := h.retryPolicy.Backoff(0, 0, resp.StatusCode, nil)
+	return retry
+}
+
+// do sends req and, if it has not completed within the hedge delay, a second
+// copy of it. The first response the retry policy would not retry wins and
+// the other attempt is cancelled; a retryable response or an error only
+// settles the request once no other attempt is in flight, in which case a
+// response is preferred over an error. The winning response body is fully
+// buffered so that cancelling the losing attempt cannot interrupt it.
+func (h *hedger) do(client *http.Client, req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+
+	results := make(chan hedgeResult, 2)
+	send := func() {
+		defer h.metrics.track(GoroutineHedges)()
+		start := time.Now()
+		resp, err := client.Do(req.Clone(ctx))
+		if err == nil {
+			var body []byte
+			body, err = io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				resp = nil
+			} else {
+				resp.Body = io.NopCloser(bytes.NewReader(body))
+			}
+		}
+		results <- hedgeResult{resp: resp, err: err, latency: time.Since(start)}
+	}
+
+	go send()
+	inflight := 1
+	hedged := false
+
+	timer := time.NewTimer(h.hedgeDelay())
+	defer timer.Stop()
+
+	var fallback *http.Response
+	for {
+		select {
+		case <-timer.C:
+			if !hedged {
+				hedged = true
+				inflight++
+				if h.debug {
+					log.Printf("HTTP %s %s: sending hedged request", req.Method, req.URL)
+				}
+				go send()
+			}
+		case result := <-results:
+			inflight--
+			if result.err == nil && !h.retryable(result.resp) {
+				h.observe(result.latency)
+				return result.resp, nil
+			}
+			if result.err == nil {
+				fallback = result.resp
+			}
+			if inflight > 0 {
+				continue
+			}
+			if fallback != nil {
+				return fallback, nil
+			}
+			return nil, result.err
+		}
+	}
+}
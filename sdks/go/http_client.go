@@ -3,13 +3,13 @@ package zoptal
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
-	"net/url"
+	"net/http/httptrace"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -18,22 +18,73 @@ import (
 // This client handles authentication, rate limiting, retries,
 // and error response parsing for all API requests.
 type HTTPClient struct {
-	baseURL    string
-	apiKey     string
-	timeout    time.Duration
-	maxRetries int
-	debug      bool
-	client     *http.Client
+	baseURL     string
+	apiKey      string
+	org         string
+	debug       bool
+	hedger      *hedger
+	retryPolicy RetryPolicy
+	cache       ResponseCache
+	metrics     *runtimeMetrics
+	compressor  *compressor
+	mirror      *mirror
+	failover    *failover
+	swr         *swr
+	tokens      *tokenManager
+	stats       *trafficStats
+	encryption  *EncryptionOptions
+	audit       *auditor
+	policy      *policyEnforcer
+	degradation *degradation
+
+	// settings holds the settings that can be reloaded while the client is
+	// in use
+	settings *liveSettings
+
+	capabilities *capabilities
+
+	// scopes restricts the endpoints the client may call; nil allows all
+	// (see Client.WithScopes)
+	scopes *scopeSet
 }
 
 // HTTPClientConfig contains configuration for the HTTP client.
 type HTTPClientConfig struct {
-	BaseURL    string
-	APIKey     string
-	Timeout    time.Duration
-	MaxRetries int
-	Debug      bool
-	HTTPClient *http.Client
+	BaseURL     string
+	APIKey      string
+	Timeout     time.Duration
+	MaxRetries  int
+	Debug       bool
+	HTTPClient  *http.Client
+	Hedging     *HedgingOptions
+	RetryPolicy RetryPolicy
+	Cache       ResponseCache
+
+	Organization         string
+	StaleWhileRevalidate *StaleWhileRevalidateOptions
+
+	CompressRequests     bool
+	CompressionThreshold int
+
+	Transport *TransportOptions
+	Mirror    *MirrorOptions
+	Failover  *FailoverOptions
+	RateLimit *RateLimitOptions
+
+	TokenSource  TokenSource
+	TokenRefresh *TokenRefreshOptions
+
+	ModelRouting  *ModelRoutingOptions
+	ModelFallback *ModelFallbackOptions
+
+	Encryption *EncryptionOptions
+	Audit      *AuditOptions
+	Policy     *PolicyOptions
+
+	Degradation *DegradationOptions
+
+	OnRequest  func(dump []byte)
+	OnResponse func(dump []byte)
 }
 
 // NewHTTPClient creates a new HTTP client with the specified configuration.
@@ -48,16 +99,71 @@ func NewHTTPClient(config HTTPClientConfig) *HTTPClient {
 		client = &http.Client{
 			Timeout: config.Timeout,
 		}
+		if config.Transport != nil {
+			client.Transport = newTransport(config.Transport)
+		}
 	}
-
-	return &HTTPClient{
-		baseURL:    strings.TrimRight(config.BaseURL, "/"),
-		apiKey:     config.APIKey,
-		timeout:    config.Timeout,
-		maxRetries: config.MaxRetries,
-		debug:      config.Debug,
-		client:     client,
+	if config.OnRequest != nil || config.OnResponse != nil || config.Debug {
+		client = withDumps(client, config.OnRequest, config.OnResponse, config.Debug)
+	}
+	stats := newTrafficStats()
+	client = withAccounting(client, stats)
+
+	httpClient := &HTTPClient{
+		baseURL: strings.TrimRight(config.BaseURL, "/"),
+		apiKey:  config.APIKey,
+		org:     config.Organization,
+		debug:   config.Debug,
+		cache:   config.Cache,
+		metrics: newRuntimeMetrics(),
+		stats:   stats,
+
+		encryption:   config.Encryption,
+		audit:        newAuditor(config.Audit, config.Debug),
+		policy:       newPolicyEnforcer(config.Policy),
+		settings:     &liveSettings{},
+		capabilities: &capabilities{},
+	}
+	live := &clientSettings{
+		client:        client,
+		timeout:       config.Timeout,
+		maxRetries:    config.MaxRetries,
+		modelRouting:  config.ModelRouting,
+		modelFallback: config.ModelFallback,
+	}
+	httpClient.retryPolicy = config.RetryPolicy
+	if httpClient.retryPolicy == nil {
+		httpClient.retryPolicy = DefaultRetryPolicy()
+	}
+	if config.CompressRequests {
+		httpClient.compressor = newCompressor(config.CompressionThreshold)
 	}
+	if config.Hedging != nil {
+		httpClient.hedger = newHedger(config.Hedging, httpClient.retryPolicy, config.Debug, httpClient.metrics)
+	}
+	if config.Cache != nil && config.StaleWhileRevalidate != nil {
+		httpClient.swr = newSWR(config.StaleWhileRevalidate, config.Timeout)
+	}
+	if config.TokenSource != nil {
+		httpClient.tokens = newTokenManager(config.TokenSource, config.TokenRefresh, config.Debug, httpClient.metrics)
+	}
+	if config.RateLimit != nil && config.RateLimit.RequestsPerSecond > 0 {
+		live.rateLimit = config.RateLimit
+		live.limiter = newRateLimiter(config.RateLimit, config.APIKey, config.Debug)
+	}
+	httpClient.settings.base = live
+	httpClient.settings.current.Store(live)
+	if config.Failover != nil {
+		httpClient.failover = newFailover(httpClient.baseURL, config.Failover, config.Debug)
+	}
+	if config.Degradation != nil {
+		httpClient.degradation = newDegradation(config.Degradation, config.Debug)
+	}
+	if config.Mirror != nil {
+		httpClient.mirror = newMirror(config.Mirror, client, config.Timeout, httpClient.metrics)
+	}
+
+	return httpClient
 }
 
 // buildURL builds the full URL from an endpoint.
@@ -67,11 +173,14 @@ func (c *HTTPClient) buildURL(endpoint string) string {
 	}
 
 	endpoint = strings.TrimPrefix(endpoint, "/")
-	return fmt.Sprintf("%s/api/v1/%s", c.baseURL, endpoint)
+	return fmt.Sprintf("%s/api/v1/%s", c.activeBaseURL(), endpoint)
 }
 
 // createRequest creates an HTTP request with common headers.
 func (c *HTTPClient) createRequest(ctx context.Context, method, endpoint string, body io.Reader) (*http.Request, error) {
+	if err := c.checkScope(method, endpoint); err != nil {
+		return nil, err
+	}
 	url := c.buildURL(endpoint)
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
@@ -79,10 +188,21 @@ func (c *HTTPClient) createRequest(ctx context.Context, method, endpoint string,
 	}
 
 	// Set common headers
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	if err := c.authorize(req); err != nil {
+		return nil, err
+	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "zoptal-go-sdk/1.0.0")
+	req.Header.Set("User-Agent", "zoptal-go-sdk/"+Version)
 	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Zoptal-SDK-Features", sdkFeaturesHeader())
+	if c.org != "" {
+		req.Header.Set("X-Zoptal-Organization", c.org)
+	}
+	if options := requestOptionsFrom(ctx); options != nil {
+		for name, values := range options.header {
+			req.Header[name] = append([]string(nil), values...)
+		}
+	}
 
 	return req, nil
 }
@@ -91,13 +211,37 @@ func (c *HTTPClient) createRequest(ctx context.Context, method, endpoint string,
 func (c *HTTPClient) handleResponse(resp *http.Response, result interface{}) error {
 	defer resp.Body.Close()
 
-	if c.debug {
-		log.Printf("HTTP %s %s -> %d", resp.Request.Method, resp.Request.URL, resp.StatusCode)
+	body, err := readBody(resp.Body, defaultMaxResponseBytes)
+	if err != nil {
+		return err
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+	if err := responseError(resp, body); err != nil {
+		return withResponse(err, resp, body)
+	}
+
+	// Parse successful response
+	if page, ok := result.(*pageResponse); ok {
+		page.url, page.header, page.body = resp.Request.URL, resp.Header, body
+		return nil
+	}
+	if result != nil && len(body) > 0 {
+		if err := decodeJSON(body, result); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// responseError returns the error described by an error response, or nil
+// for a successful response.
+func responseError(resp *http.Response, body []byte) error {
+	if err := modelUnavailable(resp.StatusCode, body); err != nil {
+		return err
+	}
+	if err := immutableResource(resp.StatusCode, body); err != nil {
+		return err
 	}
 
 	// Handle error status codes
@@ -109,56 +253,74 @@ func (c *HTTPClient) handleResponse(resp *http.Response, result interface{}) err
 	case http.StatusNotFound:
 		return NewNotFoundError("resource not found")
 	case http.StatusUnprocessableEntity:
-		var errorData map[string]interface{}
-		validationError := "validation failed"
-		if json.Unmarshal(body, &errorData) == nil {
-			if detail, ok := errorData["detail"].(string); ok {
-				validationError = detail
-			} else if message, ok := errorData["message"].(string); ok {
-				validationError = message
-			}
-		}
-		return NewValidationError(validationError)
+		return validationError(body, "validation failed")
 	case http.StatusTooManyRequests:
-		retryAfter := resp.Header.Get("Retry-After")
-		if retryAfter == "" {
-			retryAfter = "60"
-		}
-		return NewRateLimitError(fmt.Sprintf("rate limit exceeded, retry after %s seconds", retryAfter))
+		return rateLimitError(resp.Header)
 	}
 
 	if resp.StatusCode >= 500 {
-		return NewAPIError(fmt.Sprintf("server error: %d", resp.StatusCode))
+		return NewAPIErrorWithStatus(fmt.Sprintf("server error: %d", resp.StatusCode), resp.StatusCode)
 	}
 
 	if resp.StatusCode >= 400 {
-		var errorData map[string]interface{}
-		errorMsg := fmt.Sprintf("HTTP %d", resp.StatusCode)
-		if json.Unmarshal(body, &errorData) == nil {
-			if errMsg, ok := errorData["error"].(string); ok {
-				errorMsg = errMsg
-			} else if message, ok := errorData["message"].(string); ok {
-				errorMsg = message
-			}
-		}
-		return NewAPIError(errorMsg)
+		return NewAPIErrorWithStatus(errorMessage(body, fmt.Sprintf("HTTP %d", resp.StatusCode), "error", "message"), resp.StatusCode)
 	}
 
-	// Parse successful response
-	if result != nil && len(body) > 0 {
-		if err := json.Unmarshal(body, result); err != nil {
-			return fmt.Errorf("failed to parse response JSON: %w", err)
-		}
+	return nil
+}
+
+// do sends a single HTTP request, routing it to the active endpoint and
+// mirroring GET requests to the secondary deployment when configured.
+func (c *HTTPClient) do(req *http.Request) (*http.Response, error) {
+	if c.failover != nil {
+		return c.doFailover(req)
+	}
+	if c.mirror != nil && req.Method == http.MethodGet {
+		return c.doMirrored(req)
 	}
+	return c.dispatch(req)
+}
 
-	return nil
+// dispatch sends a single HTTP request, compressing large request bodies and
+// serving GET requests through the response cache when configured.
+func (c *HTTPClient) dispatch(req *http.Request) (*http.Response, error) {
+	if c.compressor != nil && req.Body != nil && !c.compressor.disabled.Load() &&
+		req.Header.Get("Content-Type") == "application/json" && c.capabilities.allows(FeatureGzipRequests) {
+		return c.doCompressed(req)
+	}
+	if c.cache != nil && req.Method == http.MethodGet {
+		return c.doCached(req)
+	}
+	return c.send(req)
+}
+
+// streamedKey is the context key marking requests whose response body is
+// streamed to the caller.
+type streamedKey struct{}
+
+// send sends a single HTTP request, hedging GET requests when enabled.
+// Streamed requests are never hedged, since hedging buffers the body and may
+// transfer it twice.
+func (c *HTTPClient) send(req *http.Request) (*http.Response, error) {
+	if c.hedger != nil && req.Method == http.MethodGet && req.Context().Value(streamedKey{}) == nil {
+		return c.hedger.do(c.clientFor(req.Context()), req)
+	}
+	return c.clientFor(req.Context()).Do(req)
 }
 
 // executeWithRetry executes an HTTP request with retry logic.
+//
+// Whether and when a failed attempt is retried is decided by the configured
+// RetryPolicy; at most maxRetries retries are made.
 func (c *HTTPClient) executeWithRetry(ctx context.Context, req *http.Request, result interface{}) error {
 	var lastErr error
+	start := time.Now()
+	maxRetries := c.current().maxRetries
+	if options := requestOptionsFrom(ctx); options != nil && options.maxRetries >= 0 {
+		maxRetries = options.maxRetries
+	}
 
-	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+	for attempt := 0; attempt <= maxRetries; attempt++ {
 		// Clone the request body for retries
 		var bodyReader io.Reader
 		if req.Body != nil {
@@ -177,59 +339,62 @@ func (c *HTTPClient) executeWithRetry(ctx context.Context, req *http.Request, re
 			retryReq.Body = io.NopCloser(bodyReader)
 		}
 
-		resp, err := c.client.Do(retryReq)
-		if err != nil {
-			lastErr = err
-			if attempt < c.maxRetries {
-				// Wait before retrying
-				select {
-				case <-time.After(time.Duration(attempt+1) * time.Second):
-					continue
-				case <-ctx.Done():
-					return ctx.Err()
-				}
+		if err := c.throttle(ctx); err != nil {
+			if ctx.Err() != nil {
+				return canceled(ctx)
 			}
-			continue
+			return err
 		}
-
-		err = c.handleResponse(resp, result)
-		if err != nil {
-			// Don't retry on authentication errors or validation errors
-			if IsAuthenticationError(err) || IsValidationError(err) || IsNotFoundError(err) {
+		if c.tokens != nil && attempt > 0 {
+			// The token may have been refreshed since the last attempt.
+			if err := c.authorize(retryReq); err != nil {
 				return err
 			}
+		}
 
-			// Retry on rate limit errors with exponential backoff
-			if IsRateLimitError(err) && attempt < c.maxRetries {
-				select {
-				case <-time.After(time.Duration(2*(attempt+1)) * time.Second):
-					lastErr = err
-					continue
-				case <-ctx.Done():
-					return ctx.Err()
-				}
+		statusCode := 0
+		resp, err := c.do(retryReq)
+		if err == nil {
+			statusCode = resp.StatusCode
+			c.capabilities.observe(resp.Header)
+			err = c.handleResponse(resp, result)
+			if err == nil {
+				return nil // Success
 			}
+		}
+		if ctx.Err() != nil {
+			return canceled(ctx)
+		}
+		if ctx.Value(modelFallbackKey{}) != nil && isModelUnavailable(err) {
+			// Falling back to the next model beats waiting for this one.
+			return err
+		}
 
-			lastErr = err
-			if attempt < c.maxRetries {
-				// Wait before retrying
-				select {
-				case <-time.After(time.Duration(attempt+1) * time.Second):
-					continue
-				case <-ctx.Done():
-					return ctx.Err()
-				}
-			}
-		} else {
-			return nil // Success
+		lastErr = err
+		delay, retry := c.retryPolicy.Backoff(attempt, time.Since(start), statusCode, err)
+		if !retry {
+			return err
+		}
+		if attempt == maxRetries {
+			break
+		}
+
+		if c.debug {
+			log.Printf("HTTP %s %s: retrying in %v (attempt %d): %v", req.Method, req.URL, delay, attempt+1, err)
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return canceled(ctx)
 		}
 	}
 
 	if lastErr != nil {
-		return fmt.Errorf("request failed after %d attempts: %w", c.maxRetries+1, lastErr)
+		return fmt.Errorf("request failed after %d attempts: %w", maxRetries+1, lastErr)
 	}
 
-	return fmt.Errorf("request failed after %d attempts", c.maxRetries+1)
+	return fmt.Errorf("request failed after %d attempts", maxRetries+1)
 }
 
 // Get makes a GET request.
@@ -242,19 +407,24 @@ func (c *HTTPClient) executeWithRetry(ctx context.Context, req *http.Request, re
 //
 // Returns an error if the request fails.
 func (c *HTTPClient) Get(ctx context.Context, endpoint string, params map[string]string, result interface{}) error {
-	// Add query parameters
-	if params != nil && len(params) > 0 {
-		u, err := url.Parse(c.buildURL(endpoint))
-		if err != nil {
-			return fmt.Errorf("failed to parse URL: %w", err)
-		}
+	return c.GetQuery(ctx, endpoint, queryFromMap(params), result)
+}
 
-		q := u.Query()
-		for key, value := range params {
-			q.Set(key, value)
-		}
-		u.RawQuery = q.Encode()
-		endpoint = u.String()
+// GetQuery makes a GET request with a typed query, for parameters that a
+// map[string]string cannot express, such as repeated keys and nested filters.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - endpoint: API endpoint
+//   - query: Query parameters (can be nil)
+//   - result: Pointer to store the parsed response
+//
+// Returns an error if the request fails.
+func (c *HTTPClient) GetQuery(ctx context.Context, endpoint string, query *Query, result interface{}) error {
+	// Add query parameters
+	endpoint, err := query.apply(c.buildURL(endpoint))
+	if err != nil {
+		return fmt.Errorf("failed to parse URL: %w", err)
 	}
 
 	req, err := c.createRequest(ctx, http.MethodGet, endpoint, nil)
@@ -275,108 +445,283 @@ func (c *HTTPClient) Get(ctx context.Context, endpoint string, params map[string
 //
 // Returns an error if the request fails.
 func (c *HTTPClient) Post(ctx context.Context, endpoint string, data interface{}, result interface{}) error {
-	var body io.Reader
+	return c.sendJSON(ctx, http.MethodPost, endpoint, data, result)
+}
+
+// Put makes a PUT request.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - endpoint: API endpoint
+//   - data: Request body data (will be JSON encoded)
+//   - result: Pointer to store the parsed response
+//
+// Returns an error if the request fails.
+func (c *HTTPClient) Put(ctx context.Context, endpoint string, data interface{}, result interface{}) error {
+	return c.sendJSON(ctx, http.MethodPut, endpoint, data, result)
+}
+
+// Patch makes a PATCH request.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - endpoint: API endpoint
+//   - data: Request body data (will be JSON encoded)
+//   - result: Pointer to store the parsed response
+//
+// Returns an error if the request fails.
+func (c *HTTPClient) Patch(ctx context.Context, endpoint string, data interface{}, result interface{}) error {
+	return c.sendJSON(ctx, http.MethodPatch, endpoint, data, result)
+}
+
+// sendJSON makes a request with a canonical JSON body.
+func (c *HTTPClient) sendJSON(ctx context.Context, method, endpoint string, data interface{}, result interface{}) error {
+	var jsonData []byte
+	ai := false
 	if data != nil {
-		jsonData, err := json.Marshal(data)
+		var err error
+		jsonData, err = CanonicalJSON(data)
 		if err != nil {
 			return fmt.Errorf("failed to marshal request data: %w", err)
 		}
+		if method == http.MethodPost {
+			jsonData = c.routeModel(ctx, endpoint, jsonData)
+			if jsonData, err = c.attachLibraryDocs(ctx, endpoint, jsonData); err != nil {
+				return err
+			}
+			_, ai = operationClass(endpoint)
+		}
+	}
+	return c.audited(ctx, method, endpoint, func(ctx context.Context) error {
+		if err := c.checkPolicy(ctx, method, endpoint, jsonData); err != nil {
+			return err
+		}
+		if ai {
+			return c.sendAI(ctx, endpoint, jsonData, result)
+		}
+		return c.sendBody(ctx, method, endpoint, jsonData, result)
+	})
+}
+
+// sendBody makes a request with an encoded JSON body, or no body if jsonData is nil.
+func (c *HTTPClient) sendBody(ctx context.Context, method, endpoint string, jsonData []byte, result interface{}) error {
+	var body io.Reader
+	if jsonData != nil {
 		body = bytes.NewReader(jsonData)
 	}
 
-	req, err := c.createRequest(ctx, http.MethodPost, endpoint, body)
+	req, err := c.createRequest(ctx, method, endpoint, body)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set GetBody for retries
-	if data != nil {
+	if jsonData != nil {
 		req.GetBody = func() (io.ReadCloser, error) {
-			jsonData, err := json.Marshal(data)
-			if err != nil {
-				return nil, err
-			}
 			return io.NopCloser(bytes.NewReader(jsonData)), nil
 		}
 	}
 
+	// Let the server deduplicate retried non-idempotent requests
+	if (method == http.MethodPost || method == http.MethodPatch) && c.capabilities.allows(FeatureIdempotencyKeys) {
+		if key := newIdempotencyKey(); key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+	}
+
 	return c.executeWithRetry(ctx, req, result)
 }
 
-// Put makes a PUT request.
+// PostReader makes a POST request whose body is streamed from body.
+//
+// Unlike Post, the body is not buffered, so the request is sent only once
+// and is never retried.
 //
 // Parameters:
 //   - ctx: Request context for cancellation and timeouts
 //   - endpoint: API endpoint
-//   - data: Request body data (will be JSON encoded)
+//   - contentType: Content type of the request body
+//   - body: Request body
 //   - result: Pointer to store the parsed response
 //
 // Returns an error if the request fails.
-func (c *HTTPClient) Put(ctx context.Context, endpoint string, data interface{}, result interface{}) error {
-	var body io.Reader
-	if data != nil {
-		jsonData, err := json.Marshal(data)
+func (c *HTTPClient) PostReader(ctx context.Context, endpoint, contentType string, body io.Reader, result interface{}) error {
+	return c.audited(ctx, http.MethodPost, endpoint, func(ctx context.Context) error {
+		if err := c.checkPolicy(ctx, http.MethodPost, endpoint, nil); err != nil {
+			return err
+		}
+		req, err := c.createRequest(ctx, http.MethodPost, endpoint, body)
 		if err != nil {
-			return fmt.Errorf("failed to marshal request data: %w", err)
+			return fmt.Errorf("failed to create request: %w", err)
 		}
-		body = bytes.NewReader(jsonData)
+		req.Header.Set("Content-Type", contentType)
+
+		if err := c.throttle(ctx); err != nil {
+			return err
+		}
+		resp, err := c.do(req)
+		if err != nil {
+			return err
+		}
+		c.capabilities.observe(resp.Header)
+		return c.handleResponse(resp, result)
+	})
+}
+
+// GetRaw makes a GET request and returns the response for streaming.
+//
+// The request goes through the same failover, mirroring, and caching as Get,
+// but bodies too large to read into memory, such as large downloads, are
+// streamed rather than mirrored or cached, and the request is not hedged; the
+// caller must close the body. The client timeout bounds the wait for the response
+// headers only, so a long transfer is bounded by ctx. Error responses are
+// converted to errors as for Get. The request is sent only once and is
+// never retried.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - endpoint: API endpoint
+//   - params: Query parameters (can be nil)
+//   - header: Additional request headers (can be nil)
+//
+// Returns the successful response or an error if the request fails.
+func (c *HTTPClient) GetRaw(ctx context.Context, endpoint string, params map[string]string, header http.Header) (*http.Response, error) {
+	endpoint, err := queryFromMap(params).apply(c.buildURL(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
 	}
 
-	req, err := c.createRequest(ctx, http.MethodPut, endpoint, body)
+	// The client timeout would also bound reading the body, so it is
+	// disabled and replaced by a timer that only runs until the response
+	// starts to arrive; the cache may read a small body before do returns.
+	headerTimeout := c.clientFor(ctx).Timeout
+	streamCtx, cancel := context.WithCancel(context.WithValue(WithRequestOptions(ctx, WithTimeout(0)), streamedKey{}, true))
+	var (
+		timer    *time.Timer
+		timedOut atomic.Bool
+	)
+	if headerTimeout > 0 {
+		streamCtx = httptrace.WithClientTrace(streamCtx, &httptrace.ClientTrace{
+			GotFirstResponseByte: func() { timer.Stop() },
+		})
+	}
+	req, err := c.createRequest(streamCtx, http.MethodGet, endpoint, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		cancel()
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "*/*")
+	for name, values := range header {
+		req.Header[name] = values
 	}
 
-	// Set GetBody for retries
-	if data != nil {
-		req.GetBody = func() (io.ReadCloser, error) {
-			jsonData, err := json.Marshal(data)
-			if err != nil {
-				return nil, err
-			}
-			return io.NopCloser(bytes.NewReader(jsonData)), nil
+	if err := c.throttle(ctx); err != nil {
+		cancel()
+		return nil, err
+	}
+	if headerTimeout > 0 {
+		timer = time.AfterFunc(headerTimeout, func() {
+			timedOut.Store(true)
+			cancel()
+		})
+	}
+	resp, err := c.do(req)
+	if timer != nil {
+		timer.Stop()
+	}
+	if timedOut.Load() && ctx.Err() == nil {
+		if err == nil {
+			resp.Body.Close()
 		}
+		cancel()
+		return nil, fmt.Errorf("GET %s: timed out after %v awaiting response headers", req.URL.Path, headerTimeout)
+	}
+	if err != nil {
+		cancel()
+		return nil, err
 	}
+	c.capabilities.observe(resp.Header)
+	if resp.StatusCode >= 400 {
+		defer cancel()
+		return nil, c.handleResponse(resp, nil)
+	}
+	resp.Body = &cancelingBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
 
-	return c.executeWithRetry(ctx, req, result)
+// cancelingBody is a response body that cancels the request's context when
+// closed.
+type cancelingBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
 }
 
-// Patch makes a PATCH request.
+// Close implements io.Closer.
+func (b *cancelingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// PostStream makes a POST request with a JSON body and returns the response
+// for streaming, such as a server-sent event stream.
+//
+// The response body is not read, and the client timeout does not apply, so
+// a long-lived stream is bounded only by ctx; the caller must close the
+// body. Error responses are converted to errors as for Post. The request is
+// sent only once and is never retried.
 //
 // Parameters:
-//   - ctx: Request context for cancellation and timeouts
+//   - ctx: Request context for cancellation
 //   - endpoint: API endpoint
 //   - data: Request body data (will be JSON encoded)
-//   - result: Pointer to store the parsed response
+//   - accept: Accepted response content type, e.g. "text/event-stream"
 //
-// Returns an error if the request fails.
-func (c *HTTPClient) Patch(ctx context.Context, endpoint string, data interface{}, result interface{}) error {
-	var body io.Reader
-	if data != nil {
-		jsonData, err := json.Marshal(data)
-		if err != nil {
-			return fmt.Errorf("failed to marshal request data: %w", err)
-		}
-		body = bytes.NewReader(jsonData)
+// Returns the successful response or an error if the request fails.
+func (c *HTTPClient) PostStream(ctx context.Context, endpoint string, data interface{}, accept string) (*http.Response, error) {
+	jsonData, err := CanonicalJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request data: %w", err)
+	}
+	jsonData = c.routeModel(ctx, endpoint, jsonData)
+	if jsonData, err = c.attachLibraryDocs(ctx, endpoint, jsonData); err != nil {
+		return nil, err
 	}
+	if !c.fallsBack(endpoint) {
+		return c.postStreamBody(ctx, endpoint, jsonData, accept)
+	}
+
+	var resp *http.Response
+	err = c.withModelFallback(ctx, jsonData, func(ctx context.Context, jsonData []byte) error {
+		var err error
+		resp, err = c.postStreamBody(ctx, endpoint, jsonData, accept)
+		return err
+	})
+	return resp, err
+}
 
-	req, err := c.createRequest(ctx, http.MethodPatch, endpoint, body)
+// postStreamBody makes a streaming POST request with an encoded JSON body.
+func (c *HTTPClient) postStreamBody(ctx context.Context, endpoint string, jsonData []byte, accept string) (*http.Response, error) {
+	req, err := c.createRequest(ctx, http.MethodPost, endpoint, bytes.NewReader(jsonData))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	req.Header.Set("Accept", accept)
 
-	// Set GetBody for retries
-	if data != nil {
-		req.GetBody = func() (io.ReadCloser, error) {
-			jsonData, err := json.Marshal(data)
-			if err != nil {
-				return nil, err
-			}
-			return io.NopCloser(bytes.NewReader(jsonData)), nil
-		}
+	if err := c.throttle(ctx); err != nil {
+		return nil, err
 	}
-
-	return c.executeWithRetry(ctx, req, result)
+	client := *c.current().client
+	client.Timeout = 0
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	c.capabilities.observe(resp.Header)
+	if resp.StatusCode >= 400 {
+		return nil, c.handleResponse(resp, nil)
+	}
+	return resp, nil
 }
 
 // Delete makes a DELETE request.
@@ -388,21 +733,24 @@ func (c *HTTPClient) Patch(ctx context.Context, endpoint string, data interface{
 //
 // Returns an error if the request fails.
 func (c *HTTPClient) Delete(ctx context.Context, endpoint string, result interface{}) error {
-	req, err := c.createRequest(ctx, http.MethodDelete, endpoint, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
+	return c.audited(ctx, http.MethodDelete, endpoint, func(ctx context.Context) error {
+		if err := c.checkPolicy(ctx, http.MethodDelete, endpoint, nil); err != nil {
+			return err
+		}
+		req, err := c.createRequest(ctx, http.MethodDelete, endpoint, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
 
-	return c.executeWithRetry(ctx, req, result)
+		return c.executeWithRetry(ctx, req, result)
+	})
 }
 
 // Close closes the HTTP client and cleans up resources.
 func (c *HTTPClient) Close() {
-	if c.client != nil {
-		// Close idle connections
-		c.client.CloseIdleConnections()
-	}
+	// Close idle connections
+	c.current().client.CloseIdleConnections()
 	if c.debug {
 		log.Println("HTTP client closed")
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,393 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// IndexBuildStatus is the state of a semantic index build.
+type IndexBuildStatus string
+
+// Index build statuses.
+const (
+	IndexBuildScheduled IndexBuildStatus = "scheduled"
+	IndexBuildQueued    IndexBuildStatus = "queued"
+	IndexBuildRunning   IndexBuildStatus = "running"
+	IndexBuildCompleted IndexBuildStatus = "completed"
+	IndexBuildFailed    IndexBuildStatus = "failed"
+	IndexBuildCanceled  IndexBuildStatus = "canceled"
+)
+
+// Done reports whether the build has finished, successfully or not.
+func (s IndexBuildStatus) Done() bool {
+	return s == IndexBuildCompleted || s == IndexBuildFailed || s == IndexBuildCanceled
+}
+
+// IndexState is the state of the semantic index of a project.
+type IndexState string
+
+// Index states.
+const (
+	// IndexNone means the project has not been indexed; it is indexed
+	// lazily on its first search
+	IndexNone IndexState = "none"
+
+	// IndexBuilding means a build of the project's index is in progress
+	IndexBuilding IndexState = "building"
+
+	// IndexReady means the index is up to date with the project's files
+	IndexReady IndexState = "ready"
+
+	// IndexStale means files changed since the index was built; searches
+	// use the stale index until it is refreshed
+	IndexStale IndexState = "stale"
+)
+
+// IndexService manages the semantic index of the projects of a workspace:
+// the embeddings of their code that power AI.SearchCode and retrieval for AI
+// requests.
+//
+// Projects are otherwise indexed lazily, on first search and after changes,
+// which adds latency to those searches; building indexes ahead of time, for
+// example off-peak with IndexBuildOptions.StartAt, avoids it.
+type IndexService struct {
+	client *HTTPClient
+}
+
+// IndexBuildOptions contains options for building indexes.
+type IndexBuildOptions struct {
+	// Full re-embeds all files, rather than only the files changed since the
+	// last build (default: false)
+	Full bool
+
+	// StartAt schedules the build to start at a later time, such as
+	// off-peak hours (default: start now)
+	StartAt time.Time
+}
+
+// IndexBuild is a build of the semantic index of one or more projects.
+type IndexBuild struct {
+	ID         string           `json:"id"`
+	ProjectIDs []string         `json:"project_ids"`
+	Status     IndexBuildStatus `json:"status"`
+
+	// Progress is the completed fraction of the build, from 0 to 1
+	Progress float64 `json:"progress"`
+
+	// FilesIndexed and FilesTotal count the files embedded so far and in
+	// total; FilesTotal is zero until the files have been listed
+	FilesIndexed int `json:"files_indexed"`
+	FilesTotal   int `json:"files_total"`
+
+	// Error describes why a failed build failed
+	Error string `json:"error,omitempty"`
+
+	ScheduledAt Timestamp `json:"scheduled_at"`
+	StartedAt   Timestamp `json:"started_at"`
+	CompletedAt Timestamp `json:"completed_at"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// IndexStatus is the state of the semantic index of a project.
+type IndexStatus struct {
+	ProjectID string     `json:"project_id"`
+	State     IndexState `json:"state"`
+
+	// Files and Chunks count the files and code chunks in the index
+	Files  int `json:"files"`
+	Chunks int `json:"chunks"`
+
+	// IndexedAt is when the index was last updated
+	IndexedAt Timestamp `json:"indexed_at"`
+
+	// BuildID is the ID of the build in progress or scheduled, if any
+	BuildID string `json:"build_id,omitempty"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// IndexBuildStream delivers the progress of an index build as it happens.
+//
+// An IndexBuildStream is a stream: it must be closed when no longer needed.
+// Its methods must not be called concurrently, except Close.
+type IndexBuildStream struct {
+	*streamState
+	events *sseReader
+	ctx    context.Context
+	err    error
+}
+
+// Build builds the semantic index of projects, returning without waiting for
+// the build to finish; follow it with WatchBuild or GetBuild.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectIDs: IDs of the projects to index (default: all projects of
+//     the workspace)
+//   - options: Build options (can be nil for defaults)
+//   - opts: Request options (optional)
+//
+// Returns the queued or scheduled build or an error if the request fails.
+//
+// Example usage:
+//
+//	// Index all projects at 2am, before the working day.
+//	build, err := client.Index.Build(ctx, nil, &zoptal.IndexBuildOptions{
+//	    StartAt: nextTwoAM,
+//	})
+func (s *IndexService) Build(ctx context.Context, projectIDs []string, options *IndexBuildOptions, opts ...RequestOption) (*IndexBuild, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	for _, projectID := range projectIDs {
+		if strings.TrimSpace(projectID) == "" {
+			return nil, NewValidationError("project IDs must not be empty")
+		}
+	}
+	if options == nil {
+		options = &IndexBuildOptions{}
+	}
+
+	data := map[string]interface{}{}
+	if len(projectIDs) > 0 {
+		data["project_ids"] = projectIDs
+	}
+	if options.Full {
+		data["full"] = true
+	}
+	if !options.StartAt.IsZero() {
+		data["start_at"] = NewTimestamp(options.StartAt)
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Post(ctx, "/index/builds", data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to start index build: %w", err)
+	}
+	build, err := decodeIndexBuild(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start index build: %w", err)
+	}
+	return build, nil
+}
+
+// GetBuild gets an index build.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - buildID: ID of the build
+//   - opts: Request options (optional)
+//
+// Returns the build or an error if the request fails.
+func (s *IndexService) GetBuild(ctx context.Context, buildID string, opts ...RequestOption) (*IndexBuild, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if buildID == "" {
+		return nil, NewValidationError("build ID is required")
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Get(ctx, indexBuildEndpoint(buildID), nil, &raw); err != nil {
+		return nil, fmt.Errorf("failed to get index build %s: %w", buildID, err)
+	}
+	build, err := decodeIndexBuild(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get index build %s: %w", buildID, err)
+	}
+	return build, nil
+}
+
+// WatchBuild streams the progress of an index build until it finishes.
+//
+// Parameters:
+//   - ctx: Context that stops the stream
+//   - buildID: ID of the build
+//   - opts: Request options (optional)
+//
+// Returns the stream, which must be closed, or an error if the request fails.
+//
+// Example usage:
+//
+//	stream, err := client.Index.WatchBuild(ctx, build.ID)
+//	if err != nil {
+//	    return err
+//	}
+//	defer stream.Close()
+//	for {
+//	    build, err := stream.Recv()
+//	    if err == io.EOF {
+//	        break
+//	    }
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Printf("%d/%d files\n", build.FilesIndexed, build.FilesTotal)
+//	}
+func (s *IndexService) WatchBuild(ctx context.Context, buildID string, opts ...RequestOption) (*IndexBuildStream, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if buildID == "" {
+		return nil, NewValidationError("build ID is required")
+	}
+
+	header := make(http.Header)
+	header.Set("Accept", "text/event-stream")
+	resp, err := s.client.GetRaw(ctx, indexBuildEndpoint(buildID)+"/events", nil, header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch index build %s: %w", buildID, err)
+	}
+
+	return &IndexBuildStream{
+		streamState: newStreamState(resp.Body, s.client.metrics),
+		events:      newSSEReader(resp.Body),
+		ctx:         ctx,
+	}, nil
+}
+
+// Recv returns the build as of its next progress update. The update in
+// which the build finishes is returned like the others, with Status set to
+// a finished status; Recv then returns io.EOF. A failed build is not an
+// error of the stream: check Status.
+func (s *IndexBuildStream) Recv() (*IndexBuild, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	build, err := s.recv()
+	if err != nil {
+		switch {
+		case s.isClosed():
+			err = ErrStreamClosed
+		case s.ctx.Err() != nil:
+			err = canceled(s.ctx)
+		}
+		s.err = err
+		s.Close()
+		return nil, err
+	}
+	if build.Status.Done() {
+		s.err = io.EOF
+		s.Close()
+	}
+	return build, nil
+}
+
+// recv reads the next progress event.
+func (s *IndexBuildStream) recv() (*IndexBuild, error) {
+	for {
+		event, err := s.events.next()
+		if err == io.EOF {
+			return nil, NewAPIError("index build stream ended before the build finished")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read index build stream: %w", err)
+		}
+
+		switch event.Event {
+		case "error":
+			return nil, NewAPIError(errorMessage(event.Data, "index build stream failed", "message", "error"))
+		case "", "progress", "done":
+			return decodeIndexBuild(event.Data)
+		}
+		// Other events, such as keep-alives, are skipped.
+	}
+}
+
+// Status gets the state of the semantic index of a project.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - opts: Request options (optional)
+//
+// Returns the index state or an error if the request fails.
+func (s *IndexService) Status(ctx context.Context, projectID string, opts ...RequestOption) (*IndexStatus, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Get(ctx, projectIndexEndpoint(projectID), nil, &raw); err != nil {
+		return nil, fmt.Errorf("failed to get index status of project %s: %w", projectID, err)
+	}
+	var status IndexStatus
+	if err := decodeTyped(raw, &status, &status.Raw); err != nil {
+		return nil, fmt.Errorf("failed to get index status of project %s: %w", projectID, err)
+	}
+	if status.ProjectID == "" {
+		status.ProjectID = projectID
+	}
+	return &status, nil
+}
+
+// Refresh updates the semantic index of a project with the files changed
+// since it was last built, returning without waiting for the update.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - opts: Request options (optional)
+//
+// Returns the build that refreshes the index or an error if the request
+// fails.
+func (s *IndexService) Refresh(ctx context.Context, projectID string, opts ...RequestOption) (*IndexBuild, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Post(ctx, projectIndexEndpoint(projectID)+"/refresh", nil, &raw); err != nil {
+		return nil, fmt.Errorf("failed to refresh index of project %s: %w", projectID, err)
+	}
+	build, err := decodeIndexBuild(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh index of project %s: %w", projectID, err)
+	}
+	return build, nil
+}
+
+// Delete deletes the semantic index of a project, canceling any build of it
+// in progress. The project is indexed again lazily on its next search.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - opts: Request options (optional)
+//
+// Returns an error if the request fails.
+func (s *IndexService) Delete(ctx context.Context, projectID string, opts ...RequestOption) error {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return NewValidationError("project ID is required")
+	}
+
+	if err := s.client.Delete(ctx, projectIndexEndpoint(projectID), nil); err != nil {
+		return fmt.Errorf("failed to delete index of project %s: %w", projectID, err)
+	}
+	return nil
+}
+
+// indexBuildEndpoint returns the endpoint of an index build.
+func indexBuildEndpoint(buildID string) string {
+	return "/index/builds/" + url.PathEscape(buildID)
+}
+
+// projectIndexEndpoint returns the endpoint of the index of a project.
+func projectIndexEndpoint(projectID string) string {
+	return "/index/projects/" + url.PathEscape(projectID)
+}
+
+// decodeIndexBuild decodes an index build response.
+func decodeIndexBuild(raw json.RawMessage) (*IndexBuild, error) {
+	var build IndexBuild
+	if err := decodeTyped(raw, &build, &build.Raw); err != nil {
+		return nil, err
+	}
+	return &build, nil
+}
@@ -0,0 +1,120 @@
+package zoptal
+
+import (
+	"sync"
+	"time"
+)
+
+// Goroutine kinds reported in RuntimeMetrics.Goroutines.
+const (
+	GoroutineStreams = "streams"
+	GoroutinePollers = "pollers"
+	GoroutineWorkers = "workers"
+	GoroutineHedges  = "hedges"
+	GoroutineMirrors = "mirrors"
+)
+
+// RuntimeMetrics is a snapshot of the goroutines and internal queues owned by
+// the SDK, for correlating SDK resource usage with application-level runtime
+// metrics such as runtime.NumGoroutine.
+type RuntimeMetrics struct {
+	// Goroutines is the number of live SDK goroutines by kind
+	Goroutines map[string]int64
+
+	// QueueDepths is the current depth of each internal queue by name
+	QueueDepths map[string]int64
+
+	// Timestamp is when the snapshot was taken
+	Timestamp time.Time
+}
+
+// TotalGoroutines returns the total number of live SDK goroutines.
+func (m RuntimeMetrics) TotalGoroutines() int64 {
+	var total int64
+	for _, n := range m.Goroutines {
+		total += n
+	}
+	return total
+}
+
+// runtimeMetrics tracks SDK goroutines and queue depths.
+type runtimeMetrics struct {
+	mu          sync.Mutex
+	goroutines  map[string]int64
+	queueDepths map[string]int64
+}
+
+// newRuntimeMetrics creates an empty runtimeMetrics.
+func newRuntimeMetrics() *runtimeMetrics {
+	return &runtimeMetrics{
+		goroutines:  make(map[string]int64),
+		queueDepths: make(map[string]int64),
+	}
+}
+
+// track records the start of a goroutine of the given kind and returns a
+// function to call when it exits.
+func (m *runtimeMetrics) track(kind string) func() {
+	m.mu.Lock()
+	m.goroutines[kind]++
+	m.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			m.mu.Lock()
+			m.goroutines[kind]--
+			m.mu.Unlock()
+		})
+	}
+}
+
+// setQueueDepth records the current depth of an internal queue.
+func (m *runtimeMetrics) setQueueDepth(name string, depth int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.queueDepths[name] = int64(depth)
+}
+
+// snapshot returns a copy of the current metrics.
+func (m *runtimeMetrics) snapshot() RuntimeMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := RuntimeMetrics{
+		Goroutines:  make(map[string]int64, len(m.goroutines)),
+		QueueDepths: make(map[string]int64, len(m.queueDepths)),
+		Timestamp:   time.Now(),
+	}
+	for kind, n := range m.goroutines {
+		snapshot.Goroutines[kind] = n
+	}
+	for name, depth := range m.queueDepths {
+		snapshot.QueueDepths[name] = depth
+	}
+	return snapshot
+}
+
+// RuntimeMetrics returns a snapshot of the goroutines and internal queues
+// currently owned by the client.
+//
+// Returns the current runtime metrics.
+func (c *Client) RuntimeMetrics() RuntimeMetrics {
+	return c.httpClient.metrics.snapshot()
+}
+
+// reportRuntimeMetrics calls hook with a metrics snapshot every interval
+// until the client is closed.
+func (c *Client) reportRuntimeMetrics(hook func(RuntimeMetrics), interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			hook(c.RuntimeMetrics())
+		case <-c.done:
+			return
+		}
+	}
+}
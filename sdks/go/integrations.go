@@ -0,0 +1,428 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// IntegrationProvider is the third-party service of an integration.
+type IntegrationProvider string
+
+// Integration providers.
+const (
+	IntegrationGitHub IntegrationProvider = "github"
+	IntegrationSlack  IntegrationProvider = "slack"
+	IntegrationJira   IntegrationProvider = "jira"
+)
+
+// Integration statuses.
+const (
+	IntegrationStatusPendingAuthorization = "pending_authorization"
+	IntegrationStatusConnected            = "connected"
+	IntegrationStatusError                = "error"
+)
+
+// IntegrationsService manages the connectors between projects and
+// third-party services such as GitHub, Slack, and Jira, so that standard
+// integrations can be provisioned for every new project automatically.
+//
+// Connecting an integration takes two steps: Create creates it with its
+// settings, then the user authorizes the service at the returned
+// AuthorizationURL, after which the integration is connected.
+type IntegrationsService struct {
+	client *HTTPClient
+}
+
+// Integration is a connector between a project and a third-party service.
+type Integration struct {
+	ID        string              `json:"id"`
+	Provider  IntegrationProvider `json:"provider"`
+	Name      string              `json:"name,omitempty"`
+	ProjectID string              `json:"project_id"`
+
+	// Status is one of the IntegrationStatus constants
+	Status string `json:"status"`
+
+	// StatusMessage explains an error status
+	StatusMessage string `json:"status_message,omitempty"`
+
+	// AuthorizationURL is where the user authorizes the service while the
+	// integration is pending authorization
+	AuthorizationURL string `json:"authorization_url,omitempty"`
+
+	// Settings are the provider settings, such as GitHubSettings; see
+	// DecodeSettings
+	Settings map[string]interface{} `json:"settings,omitempty"`
+
+	CreatedAt Timestamp `json:"created_at"`
+	UpdatedAt Timestamp `json:"updated_at"`
+
+	// Raw is the undecoded integration, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// DecodeSettings decodes the settings of the integration into v, such as a
+// *GitHubSettings for a GitHub integration.
+//
+// Parameters:
+//   - v: Pointer to decode the settings into
+//
+// Returns an error if the settings do not match v.
+func (i *Integration) DecodeSettings(v interface{}) error {
+	data, err := json.Marshal(i.Settings)
+	if err != nil {
+		return fmt.Errorf("failed to decode settings of integration %s: %w", i.ID, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode settings of integration %s: %w", i.ID, err)
+	}
+	return nil
+}
+
+// GitHubSettings are the settings of a GitHub integration.
+type GitHubSettings struct {
+	// Repository is the repository as "owner/name" (required)
+	Repository string `json:"repository"`
+
+	// Branch is the branch the project syncs with (default: the
+	// repository's default branch)
+	Branch string `json:"branch,omitempty"`
+
+	// SyncIssues imports the repository's issues into the project
+	SyncIssues bool `json:"sync_issues,omitempty"`
+
+	// BranchMapping maps project environments to branches, e.g.
+	// {"staging": "develop"} (optional)
+	BranchMapping map[string]string `json:"branch_mapping,omitempty"`
+}
+
+// SlackSettings are the settings of a Slack integration.
+type SlackSettings struct {
+	// Channel is the channel notifications are posted to, e.g. "#deploys" (required)
+	Channel string `json:"channel"`
+
+	// Events are the notification types posted, e.g. "deployment.failed"
+	// (default: all)
+	Events []string `json:"events,omitempty"`
+
+	// ChannelMapping posts events to other channels than Channel, by event
+	// type (optional)
+	ChannelMapping map[string]string `json:"channel_mapping,omitempty"`
+}
+
+// JiraSettings are the settings of a Jira integration.
+type JiraSettings struct {
+	// SiteURL is the URL of the Jira site, e.g. "https://acme.atlassian.net" (required)
+	SiteURL string `json:"site_url"`
+
+	// ProjectKey is the key of the Jira project, e.g. "WEB" (required)
+	ProjectKey string `json:"project_key"`
+
+	// IssueType is the type of issues created from findings (default: "Bug")
+	IssueType string `json:"issue_type,omitempty"`
+
+	// StatusMapping maps Zoptal task statuses to Jira workflow statuses,
+	// e.g. {"done": "Closed"} (optional)
+	StatusMapping map[string]string `json:"status_mapping,omitempty"`
+}
+
+// CreateIntegrationRequest is a request to create an integration.
+type CreateIntegrationRequest struct {
+	// Provider is the third-party service (required)
+	Provider IntegrationProvider
+
+	// ProjectID is the ID of the project to connect (required)
+	ProjectID string
+
+	// Name is a display name (default: the provider's name)
+	Name string
+
+	// Settings are the provider settings: GitHubSettings, SlackSettings,
+	// JiraSettings, or a map (optional)
+	Settings interface{}
+
+	// RedirectURL is where the user is sent after authorizing the service
+	// (default: the integration's page in the Zoptal dashboard)
+	RedirectURL string
+}
+
+// IntegrationListOptions contains filters for listing integrations.
+type IntegrationListOptions struct {
+	// ProjectID filters by project (optional)
+	ProjectID string
+
+	// Provider filters by provider (optional)
+	Provider IntegrationProvider
+}
+
+// IntegrationCheck is one check of an integration connection test.
+type IntegrationCheck struct {
+	Name    string `json:"name"`
+	OK      bool   `json:"ok"`
+	Message string `json:"message,omitempty"`
+}
+
+// IntegrationTestResult is the result of testing an integration's connection.
+type IntegrationTestResult struct {
+	// OK is true if every check passed
+	OK      bool               `json:"ok"`
+	Checks  []IntegrationCheck `json:"checks"`
+	Latency time.Duration      `json:"-"`
+
+	// Raw is the undecoded result, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// Create creates an integration. Unless the service was already authorized
+// for the organization, the integration is pending authorization until the
+// user visits its AuthorizationURL.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - request: Integration to create
+//   - opts: Request options (optional)
+//
+// Returns the integration or an error if the request fails.
+//
+// Example usage:
+//
+//	integration, err := client.Integrations.Create(ctx, &zoptal.CreateIntegrationRequest{
+//	    Provider:  zoptal.IntegrationSlack,
+//	    ProjectID: project.ID,
+//	    Settings:  zoptal.SlackSettings{Channel: "#deploys", Events: []string{"deployment.failed"}},
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	if integration.Status == zoptal.IntegrationStatusPendingAuthorization {
+//	    fmt.Println("Authorize Slack at", integration.AuthorizationURL)
+//	}
+func (s *IntegrationsService) Create(ctx context.Context, request *CreateIntegrationRequest, opts ...RequestOption) (*Integration, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if request == nil || request.Provider == "" {
+		return nil, NewValidationError("integration provider is required")
+	}
+	if request.ProjectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+
+	data := map[string]interface{}{
+		"provider":   request.Provider,
+		"project_id": request.ProjectID,
+	}
+	if request.Name != "" {
+		data["name"] = request.Name
+	}
+	if request.Settings != nil {
+		data["settings"] = request.Settings
+	}
+	if request.RedirectURL != "" {
+		data["redirect_url"] = request.RedirectURL
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Post(ctx, "/integrations", data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to create %s integration: %w", request.Provider, err)
+	}
+	integration, err := decodeIntegration(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s integration: %w", request.Provider, err)
+	}
+	return integration, nil
+}
+
+// Get gets an integration.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - integrationID: ID of the integration
+//   - opts: Request options (optional)
+//
+// Returns the integration or an error if the request fails.
+func (s *IntegrationsService) Get(ctx context.Context, integrationID string, opts ...RequestOption) (*Integration, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if integrationID == "" {
+		return nil, NewValidationError("integration ID is required")
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Get(ctx, integrationEndpoint(integrationID), nil, &raw); err != nil {
+		return nil, fmt.Errorf("failed to get integration %s: %w", integrationID, err)
+	}
+	integration, err := decodeIntegration(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get integration %s: %w", integrationID, err)
+	}
+	return integration, nil
+}
+
+// List lists integrations.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - options: List filters (can be nil to list all integrations)
+//   - opts: Request options (optional)
+//
+// Returns the integrations or an error if the request fails.
+func (s *IntegrationsService) List(ctx context.Context, options *IntegrationListOptions, opts ...RequestOption) ([]Integration, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if options == nil {
+		options = &IntegrationListOptions{}
+	}
+
+	query := NewQuery()
+	if options.ProjectID != "" {
+		query.Set("project_id", options.ProjectID)
+	}
+	if options.Provider != "" {
+		query.Set("provider", string(options.Provider))
+	}
+
+	var response struct {
+		Integrations []json.RawMessage `json:"integrations"`
+	}
+	if err := s.client.GetQuery(ctx, "/integrations", query, &response); err != nil {
+		return nil, fmt.Errorf("failed to list integrations: %w", err)
+	}
+
+	integrations := make([]Integration, len(response.Integrations))
+	for i, raw := range response.Integrations {
+		if err := decodeTyped(raw, &integrations[i], &integrations[i].Raw); err != nil {
+			return nil, fmt.Errorf("failed to list integrations: %w", err)
+		}
+	}
+	return integrations, nil
+}
+
+// Configure replaces the settings of an integration, such as its mappings.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - integrationID: ID of the integration
+//   - settings: Provider settings: GitHubSettings, SlackSettings,
+//     JiraSettings, or a map
+//   - opts: Request options (optional)
+//
+// Returns the updated integration, or an error if the request fails;
+// invalid settings fail with a ValidationError listing them in Fields.
+func (s *IntegrationsService) Configure(ctx context.Context, integrationID string, settings interface{}, opts ...RequestOption) (*Integration, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if integrationID == "" {
+		return nil, NewValidationError("integration ID is required")
+	}
+	if settings == nil {
+		return nil, NewValidationError("settings are required")
+	}
+
+	data := map[string]interface{}{"settings": settings}
+	var raw json.RawMessage
+	if err := s.client.Put(ctx, integrationEndpoint(integrationID)+"/settings", data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to configure integration %s: %w", integrationID, err)
+	}
+	integration, err := decodeIntegration(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure integration %s: %w", integrationID, err)
+	}
+	return integration, nil
+}
+
+// AuthorizationURL starts a new authorization of an integration's service,
+// for example after the user revoked access or the integration reported
+// an error.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - integrationID: ID of the integration
+//   - redirectURL: Where the user is sent after authorizing (optional)
+//   - opts: Request options (optional)
+//
+// Returns the URL to send the user to, or an error if the request fails.
+func (s *IntegrationsService) AuthorizationURL(ctx context.Context, integrationID, redirectURL string, opts ...RequestOption) (string, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if integrationID == "" {
+		return "", NewValidationError("integration ID is required")
+	}
+
+	data := map[string]interface{}{}
+	if redirectURL != "" {
+		data["redirect_url"] = redirectURL
+	}
+	var response struct {
+		AuthorizationURL string `json:"authorization_url"`
+	}
+	if err := s.client.Post(ctx, integrationEndpoint(integrationID)+"/authorize", data, &response); err != nil {
+		return "", fmt.Errorf("failed to authorize integration %s: %w", integrationID, err)
+	}
+	return response.AuthorizationURL, nil
+}
+
+// Test tests the connection of an integration, checking its authorization
+// and settings against the third-party service, e.g. that a Slack channel
+// exists and the bot can post to it.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - integrationID: ID of the integration
+//   - opts: Request options (optional)
+//
+// Returns the test result, whose OK is false if a check failed, or an error
+// if the request fails.
+func (s *IntegrationsService) Test(ctx context.Context, integrationID string, opts ...RequestOption) (*IntegrationTestResult, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if integrationID == "" {
+		return nil, NewValidationError("integration ID is required")
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Post(ctx, integrationEndpoint(integrationID)+"/test", nil, &raw); err != nil {
+		return nil, fmt.Errorf("failed to test integration %s: %w", integrationID, err)
+	}
+
+	var response struct {
+		IntegrationTestResult
+		LatencyMS int64 `json:"latency_ms"`
+	}
+	if err := decodeTyped(raw, &response, &response.Raw); err != nil {
+		return nil, fmt.Errorf("failed to test integration %s: %w", integrationID, err)
+	}
+	result := response.IntegrationTestResult
+	result.Latency = time.Duration(response.LatencyMS) * time.Millisecond
+	return &result, nil
+}
+
+// Delete deletes an integration and revokes its authorization.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - integrationID: ID of the integration
+//   - opts: Request options (optional)
+//
+// Returns an error if the request fails.
+func (s *IntegrationsService) Delete(ctx context.Context, integrationID string, opts ...RequestOption) error {
+	ctx = WithRequestOptions(ctx, opts...)
+	if integrationID == "" {
+		return NewValidationError("integration ID is required")
+	}
+
+	if err := s.client.Delete(ctx, integrationEndpoint(integrationID), nil); err != nil {
+		return fmt.Errorf("failed to delete integration %s: %w", integrationID, err)
+	}
+	return nil
+}
+
+// integrationEndpoint returns the endpoint of an integration.
+func integrationEndpoint(integrationID string) string {
+	return "/integrations/" + url.PathEscape(integrationID)
+}
+
+// decodeIntegration decodes an integration response.
+func decodeIntegration(raw json.RawMessage) (*Integration, error) {
+	var integration Integration
+	if err := decodeTyped(raw, &integration, &integration.Raw); err != nil {
+		return nil, err
+	}
+	return &integration, nil
+}
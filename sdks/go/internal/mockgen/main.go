@@ -0,0 +1,445 @@
+// Command mockgen generates the service interfaces of the Zoptal SDK and
+// their fakes in the zoptalmock package.
+//
+// Each service interface lists every exported method of the service whose
+// first parameter is a context.Context, in source order, so the interfaces
+// always cover the full service surface. For each interface, a fake is
+// written to zoptalmock with a function field per method.
+//
+// Usage, from the root of the module:
+//
+//	go generate ./...
+//
+// which runs
+//
+//	go run ./internal/mockgen
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	sdkImportPath  = "github.com/zoptal/zoptal-go-sdk"
+	interfacesFile = "interfaces.go"
+	mockDir        = "zoptalmock"
+	header         = "// Code generated by go run ./internal/mockgen; DO NOT EDIT.\n\n"
+)
+
+// services lists the services, by the name of their fake, and the type
+// implementing each; the interface is the name followed by "API".
+var services = []struct {
+	name, typ string
+}{
+	{"Auth", "AuthService"},
+	{"Projects", "ProjectService"},
+	{"AI", "AIService"},
+	{"Collaboration", "CollaborationService"},
+	{"Files", "FileService"},
+	{"Templates", "TemplateService"},
+	{"Insights", "InsightsService"},
+	{"Extensions", "ExtensionsService"},
+	{"Notifications", "NotificationsService"},
+	{"Security", "SecurityService"},
+	{"Jobs", "JobsService"},
+	{"Marketplace", "MarketplaceService"},
+	{"Integrations", "IntegrationsService"},
+	{"Experiments", "ExperimentsService"},
+	{"Orgs", "OrgsService"},
+	{"Audit", "AuditService"},
+	{"Index", "IndexService"},
+	{"Usage", "UsageService"},
+	{"Quotas", "QuotasService"},
+}
+
+func main() {
+	dir := flag.String("dir", ".", "root directory of the SDK module")
+	flag.Parse()
+
+	if err := generate(*dir); err != nil {
+		fmt.Fprintf(os.Stderr, "mockgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// generate writes the interfaces and fakes for the SDK in dir.
+func generate(dir string) error {
+	pkg, err := loadSDK(dir)
+	if err != nil {
+		return err
+	}
+
+	var apis []*api
+	for _, service := range services {
+		a, err := newAPI(pkg, service.name, service.typ)
+		if err != nil {
+			return err
+		}
+		apis = append(apis, a)
+	}
+
+	src, err := interfacesSource(pkg, apis)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, interfacesFile), src, 0o644); err != nil {
+		return err
+	}
+	for _, a := range apis {
+		src, err := mockSource(pkg, a)
+		if err != nil {
+			return err
+		}
+		name := filepath.Join(dir, mockDir, strings.ToLower(a.name)+".go")
+		if err := os.WriteFile(name, src, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadSDK type-checks the SDK package in dir, without the interfaces this
+// command generates.
+func loadSDK(dir string) (*types.Package, error) {
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range bp.GoFiles {
+		if name == interfacesFile {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check(sdkImportPath, fset, files, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to type-check the SDK: %w", err)
+	}
+	return pkg, nil
+}
+
+// api is a service interface.
+type api struct {
+	name    string
+	typ     string
+	methods []*types.Func
+}
+
+// newAPI collects the methods of the service typ.
+func newAPI(pkg *types.Package, name, typ string) (*api, error) {
+	obj, ok := pkg.Scope().Lookup(typ).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("service %s not found", typ)
+	}
+
+	a := &api{name: name, typ: typ}
+	set := types.NewMethodSet(types.NewPointer(obj.Type()))
+	for i := 0; i < set.Len(); i++ {
+		fn := set.At(i).Obj().(*types.Func)
+		if fn.Exported() && takesContext(fn) {
+			a.methods = append(a.methods, fn)
+		}
+	}
+	sort.Slice(a.methods, func(i, j int) bool {
+		return a.methods[i].Pos() < a.methods[j].Pos()
+	})
+	if len(a.methods) == 0 {
+		return nil, fmt.Errorf("service %s has no methods", typ)
+	}
+	return a, nil
+}
+
+// takesContext reports whether the first parameter of fn is a context.Context.
+func takesContext(fn *types.Func) bool {
+	params := fn.Type().(*types.Signature).Params()
+	if params.Len() == 0 {
+		return false
+	}
+	named, ok := params.At(0).Type().(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "context" && named.Obj().Name() == "Context"
+}
+
+// imports qualifies the types of generated code, collecting the imports
+// they require.
+type imports struct {
+	self  *types.Package
+	alias map[string]string
+	paths map[string]string
+}
+
+func newImports(self *types.Package, alias map[string]string) *imports {
+	return &imports{self: self, alias: alias, paths: make(map[string]string)}
+}
+
+// qualify implements types.Qualifier.
+func (im *imports) qualify(pkg *types.Package) string {
+	if pkg == im.self {
+		return ""
+	}
+	name := pkg.Name()
+	if alias, ok := im.alias[pkg.Path()]; ok {
+		name = alias
+	}
+	im.paths[pkg.Path()] = name
+	return name
+}
+
+// write writes the import declaration.
+func (im *imports) write(buf *bytes.Buffer) {
+	var std, other []string
+	for path, name := range im.paths {
+		spec := fmt.Sprintf("%q", path)
+		if name != pathBase(path) {
+			spec = name + " " + spec
+		}
+		if strings.Contains(strings.SplitN(path, "/", 2)[0], ".") {
+			other = append(other, spec)
+		} else {
+			std = append(std, spec)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+
+	buf.WriteString("import (\n")
+	for _, spec := range std {
+		fmt.Fprintf(buf, "\t%s\n", spec)
+	}
+	if len(std) > 0 && len(other) > 0 {
+		buf.WriteString("\n")
+	}
+	for _, spec := range other {
+		fmt.Fprintf(buf, "\t%s\n", spec)
+	}
+	buf.WriteString(")\n\n")
+}
+
+// pathBase returns the last element of an import path.
+func pathBase(path string) string {
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+// param is a parameter of a method.
+type param struct {
+	name     string
+	typ      string
+	variadic bool
+}
+
+// params returns the parameters of sig, naming unnamed ones.
+func params(sig *types.Signature, qualify types.Qualifier) []param {
+	var ps []param
+	for i := 0; i < sig.Params().Len(); i++ {
+		v := sig.Params().At(i)
+		p := param{name: v.Name(), typ: types.TypeString(v.Type(), qualify)}
+		if p.name == "" || p.name == "_" {
+			p.name = fmt.Sprintf("arg%d", i)
+		}
+		if sig.Variadic() && i == sig.Params().Len()-1 {
+			p.variadic = true
+			p.typ = "..." + types.TypeString(v.Type().(*types.Slice).Elem(), qualify)
+		}
+		ps = append(ps, p)
+	}
+	return ps
+}
+
+// paramList formats parameters, grouping consecutive ones of the same type.
+func paramList(ps []param) string {
+	var parts []string
+	for i, p := range ps {
+		if i+1 < len(ps) && ps[i+1].typ == p.typ {
+			parts = append(parts, p.name)
+			continue
+		}
+		parts = append(parts, p.name+" "+p.typ)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// resultList formats the results of sig.
+func resultList(sig *types.Signature, qualify types.Qualifier) string {
+	results := sig.Results()
+	var ts []string
+	for i := 0; i < results.Len(); i++ {
+		ts = append(ts, types.TypeString(results.At(i).Type(), qualify))
+	}
+	switch len(ts) {
+	case 0:
+		return ""
+	case 1:
+		return " " + ts[0]
+	default:
+		return " (" + strings.Join(ts, ", ") + ")"
+	}
+}
+
+// isRequestOptions reports whether p is the trailing opts ...RequestOption
+// parameter of the SDK's methods.
+func isRequestOptions(p param, sdkName string) bool {
+	prefix := "..."
+	if sdkName != "" {
+		prefix += sdkName + "."
+	}
+	return p.variadic && p.typ == prefix+"RequestOption"
+}
+
+// interfacesSource returns the source of the interfaces file.
+func interfacesSource(pkg *types.Package, apis []*api) ([]byte, error) {
+	im := newImports(pkg, nil)
+	var body bytes.Buffer
+	body.WriteString(`// Service interfaces
+//
+// Each service on Client is described by an interface so that code depending
+// on the SDK can accept the interface and be unit tested without HTTP, using
+// the fakes in the zoptalmock package or its own implementations.
+
+`)
+	for _, a := range apis {
+		fmt.Fprintf(&body, "// %sAPI is the interface implemented by %s.\n", a.name, a.typ)
+		fmt.Fprintf(&body, "type %sAPI interface {\n", a.name)
+		for _, fn := range a.methods {
+			sig := fn.Type().(*types.Signature)
+			fmt.Fprintf(&body, "\t%s(%s)%s\n", fn.Name(), paramList(params(sig, im.qualify)), resultList(sig, im.qualify))
+		}
+		body.WriteString("}\n\n")
+	}
+	body.WriteString("// Compile-time checks that the services implement their interfaces.\nvar (\n")
+	for _, a := range apis {
+		fmt.Fprintf(&body, "\t_ %sAPI = (*%s)(nil)\n", a.name, a.typ)
+	}
+	body.WriteString(")\n")
+
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	fmt.Fprintf(&buf, "package %s\n\n", pkg.Name())
+	buf.WriteString("//go:generate go run ./internal/mockgen\n\n")
+	im.write(&buf)
+	buf.Write(body.Bytes())
+	return format.Source(buf.Bytes())
+}
+
+// mockSource returns the source of the fake of a.
+func mockSource(pkg *types.Package, a *api) ([]byte, error) {
+	const sdkName = "zoptal"
+	im := newImports(nil, map[string]string{sdkImportPath: sdkName})
+
+	recv := string(unicode.ToLower(rune(a.name[0])))
+	iface := sdkName + "." + a.name + "API"
+
+	var fields, methods bytes.Buffer
+	for _, fn := range a.methods {
+		sig := fn.Type().(*types.Signature)
+		ps := params(sig, im.qualify)
+		for i := range ps {
+			if ps[i].name == recv {
+				ps[i].name += "_"
+			}
+		}
+		results := resultList(sig, im.qualify)
+
+		// The function field takes the parameters without the request options.
+		fps := ps
+		if n := len(ps); n > 0 && isRequestOptions(ps[n-1], sdkName) {
+			fps = ps[:n-1]
+		}
+		fmt.Fprintf(&fields, "\t%sFunc func(%s)%s\n", fn.Name(), paramList(fps), results)
+
+		var recorded, passed []string
+		for i, p := range fps {
+			name := p.name
+			if p.variadic {
+				name += "..."
+			}
+			passed = append(passed, name)
+			if i > 0 {
+				recorded = append(recorded, p.name)
+			}
+		}
+		record := fmt.Sprintf("%q", fn.Name())
+		if len(recorded) > 0 {
+			record += ", " + strings.Join(recorded, ", ")
+		}
+
+		var zeros []string
+		for i := 0; i < sig.Results().Len(); i++ {
+			t := sig.Results().At(i).Type()
+			if i == sig.Results().Len()-1 && types.Identical(t, types.Universe.Lookup("error").Type()) {
+				zeros = append(zeros, fmt.Sprintf("notImplemented(%q)", a.name+"."+fn.Name()))
+				continue
+			}
+			zeros = append(zeros, zero(t, im.qualify))
+		}
+		call := fmt.Sprintf("%s.%sFunc(%s)", recv, fn.Name(), strings.Join(passed, ", "))
+		if sig.Results().Len() > 0 {
+			call = "return " + call
+		}
+
+		fmt.Fprintf(&methods, "// %s implements %s.\n", fn.Name(), iface)
+		fmt.Fprintf(&methods, "func (%s *%s) %s(%s)%s {\n", recv, a.name, fn.Name(), paramList(ps), results)
+		fmt.Fprintf(&methods, "\t%s.record(%s)\n", recv, record)
+		fmt.Fprintf(&methods, "\tif %s.%sFunc == nil {\n", recv, fn.Name())
+		if len(zeros) > 0 {
+			fmt.Fprintf(&methods, "\t\treturn %s\n", strings.Join(zeros, ", "))
+		} else {
+			methods.WriteString("\t\treturn\n")
+		}
+		methods.WriteString("\t}\n")
+		fmt.Fprintf(&methods, "\t%s\n}\n\n", call)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	fmt.Fprintf(&buf, "package %s\n\n", mockDir)
+	im.qualify(pkg)
+	im.write(&buf)
+	fmt.Fprintf(&buf, "// %s is a fake implementation of %s.\n", a.name, iface)
+	fmt.Fprintf(&buf, "type %s struct {\n\trecorder\n\n", a.name)
+	buf.Write(fields.Bytes())
+	buf.WriteString("}\n\n")
+	fmt.Fprintf(&buf, "var _ %s = (*%s)(nil)\n\n", iface, a.name)
+	buf.Write(methods.Bytes())
+	return format.Source(bytes.TrimRight(buf.Bytes(), "\n"))
+}
+
+// zero returns the zero value of t.
+func zero(t types.Type, qualify types.Qualifier) string {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsString != 0:
+			return `""`
+		case u.Info()&types.IsBoolean != 0:
+			return "false"
+		default:
+			return "0"
+		}
+	case *types.Struct, *types.Array:
+		return types.TypeString(t, qualify) + "{}"
+	default:
+		return "nil"
+	}
+}
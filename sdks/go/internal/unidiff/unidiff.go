@@ -0,0 +1,299 @@
+// Package unidiff parses unified diffs and applies them to text.
+package unidiff
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Line kinds of a hunk.
+const (
+	Context = ' '
+	Delete  = '-'
+	Insert  = '+'
+)
+
+// Line is a line of a hunk. Text includes its line ending, except for the
+// last line of a file without one.
+type Line struct {
+	Kind byte
+	Text string
+}
+
+// Hunk is a contiguous change of a file.
+type Hunk struct {
+	// OldStart and NewStart are the 1-based first lines of the hunk in the
+	// old and new file; OldLines and NewLines are the line counts
+	OldStart, OldLines int
+	NewStart, NewLines int
+
+	Lines []Line
+}
+
+// Old returns the lines the hunk expects in the old file.
+func (h *Hunk) Old() []string {
+	return h.side(Delete)
+}
+
+// New returns the lines the hunk produces in the new file.
+func (h *Hunk) New() []string {
+	return h.side(Insert)
+}
+
+// Start returns the 0-based index of the hunk's old lines in the old file.
+// Pure insertions, with no old lines, start after line OldStart.
+func (h *Hunk) Start() int {
+	if h.OldLines == 0 {
+		return h.OldStart
+	}
+	return h.OldStart - 1
+}
+
+// side returns the context lines and the lines of kind.
+func (h *Hunk) side(kind byte) []string {
+	var lines []string
+	for _, line := range h.Lines {
+		if line.Kind == Context || line.Kind == kind {
+			lines = append(lines, line.Text)
+		}
+	}
+	return lines
+}
+
+// FileDiff is the change of one file.
+type FileDiff struct {
+	// OldPath and NewPath are the paths of the --- and +++ headers, without
+	// the a/ and b/ prefixes; "/dev/null" for created and deleted files
+	OldPath, NewPath string
+
+	Hunks []Hunk
+}
+
+// Parse parses a unified diff of one or more files, as produced by diff -u
+// and git diff. A diff consisting only of hunks, without file headers, is
+// returned as a single FileDiff without paths.
+func Parse(diff string) ([]FileDiff, error) {
+	lines := SplitLines(diff)
+	var files []FileDiff
+	var file *FileDiff
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case strings.HasPrefix(line, "--- ") && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "+++ "):
+			files = append(files, FileDiff{
+				OldPath: headerPath(line[4:]),
+				NewPath: headerPath(lines[i+1][4:]),
+			})
+			file = &files[len(files)-1]
+			i += 2
+
+		case strings.HasPrefix(line, "@@ "):
+			if file == nil {
+				files = append(files, FileDiff{})
+				file = &files[len(files)-1]
+			}
+			hunk, next, err := parseHunk(lines, i)
+			if err != nil {
+				return nil, err
+			}
+			file.Hunks = append(file.Hunks, hunk)
+			i = next
+
+		default:
+			// git headers ("diff --git", "index", mode lines) and commentary
+			i++
+		}
+	}
+
+	if len(files) == 0 {
+		return nil, errors.New("diff contains no hunks")
+	}
+	return files, nil
+}
+
+// headerPath returns the path of a --- or +++ header.
+func headerPath(header string) string {
+	header = strings.TrimRight(header, "\r\n")
+	if i := strings.IndexByte(header, '\t'); i >= 0 {
+		header = header[:i]
+	}
+	if header == "/dev/null" {
+		return header
+	}
+	if strings.HasPrefix(header, "a/") || strings.HasPrefix(header, "b/") {
+		return header[2:]
+	}
+	return header
+}
+
+// parseHunk parses the hunk whose header is lines[start], returning the
+// index of the line after it.
+func parseHunk(lines []string, start int) (Hunk, int, error) {
+	var hunk Hunk
+	header := strings.TrimRight(lines[start], "\r\n")
+	fields := strings.Fields(header)
+	if len(fields) < 3 || !strings.HasPrefix(fields[1], "-") || !strings.HasPrefix(fields[2], "+") {
+		return hunk, 0, fmt.Errorf("malformed hunk header %q", header)
+	}
+	var err error
+	if hunk.OldStart, hunk.OldLines, err = parseRange(fields[1][1:]); err != nil {
+		return hunk, 0, fmt.Errorf("malformed hunk header %q: %w", header, err)
+	}
+	if hunk.NewStart, hunk.NewLines, err = parseRange(fields[2][1:]); err != nil {
+		return hunk, 0, fmt.Errorf("malformed hunk header %q: %w", header, err)
+	}
+
+	oldLeft, newLeft := hunk.OldLines, hunk.NewLines
+	i := start + 1
+	for ; i < len(lines) && (oldLeft > 0 || newLeft > 0); i++ {
+		line := lines[i]
+		if strings.HasPrefix(line, `\`) {
+			// "\ No newline at end of file" applies to the previous line.
+			trimNewline(&hunk)
+			continue
+		}
+		kind := byte(Context)
+		text := line
+		if line != "" && line != "\n" && line != "\r\n" {
+			kind, text = line[0], line[1:]
+		}
+		switch kind {
+		case Context:
+			oldLeft--
+			newLeft--
+		case Delete:
+			oldLeft--
+		case Insert:
+			newLeft--
+		default:
+			return hunk, 0, fmt.Errorf("unexpected line %q in hunk %q", strings.TrimRight(line, "\r\n"), header)
+		}
+		hunk.Lines = append(hunk.Lines, Line{Kind: kind, Text: text})
+	}
+	if oldLeft != 0 || newLeft != 0 {
+		return hunk, 0, fmt.Errorf("hunk %q is truncated", header)
+	}
+	if i < len(lines) && strings.HasPrefix(lines[i], `\`) {
+		trimNewline(&hunk)
+		i++
+	}
+	return hunk, i, nil
+}
+
+// trimNewline removes the line ending of the last line of a hunk.
+func trimNewline(hunk *Hunk) {
+	if n := len(hunk.Lines); n > 0 {
+		text := hunk.Lines[n-1].Text
+		text = strings.TrimSuffix(text, "\n")
+		hunk.Lines[n-1].Text = strings.TrimSuffix(text, "\r")
+	}
+}
+
+// parseRange parses a hunk range, "start,count" or "start".
+func parseRange(s string) (int, int, error) {
+	startText, countText, hasCount := strings.Cut(s, ",")
+	start, err := strconv.Atoi(startText)
+	if err != nil {
+		return 0, 0, err
+	}
+	count := 1
+	if hasCount {
+		if count, err = strconv.Atoi(countText); err != nil {
+			return 0, 0, err
+		}
+	}
+	return start, count, nil
+}
+
+// MismatchError reports a hunk whose old lines were not found in the text.
+type MismatchError struct {
+	// Hunk is the index of the hunk
+	Hunk int
+
+	// Line is the 1-based line where the hunk was expected
+	Line int
+}
+
+// Error implements the error interface.
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("hunk %d does not match at line %d", e.Hunk+1, e.Line)
+}
+
+// Locate finds where the old lines of a hunk occur in lines, searching
+// outward from the hunk's expected position (adjusted by offset, the shift
+// caused by earlier hunks) but not before from. It returns the 0-based index
+// or -1.
+func Locate(lines []string, hunk *Hunk, from, offset int) int {
+	old := hunk.Old()
+	expected := hunk.Start() + offset
+	for delta := 0; ; delta++ {
+		before, after := expected-delta, expected+delta
+		if before < from && after > len(lines)-len(old) {
+			return -1
+		}
+		if before >= from && matches(lines, before, old) {
+			return before
+		}
+		if delta > 0 && after >= from && matches(lines, after, old) {
+			return after
+		}
+	}
+}
+
+// matches reports whether want occurs in lines at index at.
+func matches(lines []string, at int, want []string) bool {
+	if at < 0 || at+len(want) > len(lines) {
+		return false
+	}
+	for i, line := range want {
+		if lines[at+i] != line {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply applies the hunks of a file diff to text. Hunks are located at
+// their expected lines or, if the text has shifted, at the nearest place
+// their context matches; a hunk that matches nowhere fails with a
+// MismatchError.
+func Apply(text string, hunks []Hunk) (string, error) {
+	lines := SplitLines(text)
+	var out strings.Builder
+	pos, offset := 0, 0
+
+	for i := range hunks {
+		hunk := &hunks[i]
+		at := Locate(lines, hunk, pos, offset)
+		if at < 0 {
+			return "", &MismatchError{Hunk: i, Line: hunk.OldStart + offset}
+		}
+		for _, line := range lines[pos:at] {
+			out.WriteString(line)
+		}
+		for _, line := range hunk.New() {
+			out.WriteString(line)
+		}
+		pos = at + len(hunk.Old())
+		offset = at - hunk.Start()
+	}
+	for _, line := range lines[pos:] {
+		out.WriteString(line)
+	}
+	return out.String(), nil
+}
+
+// SplitLines splits s into lines, keeping their line endings.
+func SplitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,379 @@
+package zoptal
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// jobSignatureHeader carries the HMAC-SHA256 signature of a job webhook
+// request, as "sha256=<hex>", computed over the timestamp, a ".", and the
+// body.
+const jobSignatureHeader = "X-Zoptal-Signature"
+
+// jobTimestampHeader carries the Unix time in seconds at which a job webhook
+// request was signed.
+const jobTimestampHeader = "X-Zoptal-Timestamp"
+
+// jobWebhookTolerance is how far the signing time of a job webhook request
+// may be from the current time, bounding how long a captured request can be
+// replayed.
+const jobWebhookTolerance = 5 * time.Minute
+
+// JobsService runs long-running operations, such as large analyses, project
+// imports, and security scans, as asynchronous jobs.
+type JobsService struct {
+	client *HTTPClient
+}
+
+// JobType is the kind of operation a job runs.
+type JobType string
+
+// Job types.
+const (
+	JobTypeAnalysis      JobType = "analysis"
+	JobTypeProjectImport JobType = "project-import"
+	JobTypeSecurityScan  JobType = "security-scan"
+	JobTypeAIBatch       JobType = "ai-batch"
+)
+
+// JobStatus is the status of a job.
+type JobStatus string
+
+// Job statuses.
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+	JobCanceled  JobStatus = "canceled"
+)
+
+// Done reports whether the job has finished, successfully or not.
+func (s JobStatus) Done() bool {
+	return s == JobSucceeded || s == JobFailed || s == JobCanceled
+}
+
+// Job is an asynchronous operation.
+type Job struct {
+	ID     string    `json:"id"`
+	Type   JobType   `json:"type"`
+	Status JobStatus `json:"status"`
+
+	// Progress is the completed percentage, in the range [0, 100]
+	Progress float64 `json:"progress"`
+
+	// Message describes the current step, such as "scanning 120 of 480 files"
+	Message string `json:"message,omitempty"`
+
+	// Result is the output of a succeeded job, whose shape depends on Type
+	Result json.RawMessage `json:"result,omitempty"`
+
+	// Error describes why the job failed
+	Error string `json:"error,omitempty"`
+
+	CreatedAt   Timestamp `json:"created_at"`
+	UpdatedAt   Timestamp `json:"updated_at"`
+	CompletedAt Timestamp `json:"completed_at,omitempty"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// DecodeResult decodes the result of a succeeded job into v.
+func (j *Job) DecodeResult(v interface{}) error {
+	if j.Status != JobSucceeded {
+		return NewAPIError(fmt.Sprintf("job %s has no result: status %s", j.ID, j.Status))
+	}
+	return decodeJSON(j.Result, v)
+}
+
+// JobRequest submits a job.
+type JobRequest struct {
+	// Type is the operation to run (required)
+	Type JobType `json:"type"`
+
+	// ProjectID is the project the job operates on (optional)
+	ProjectID string `json:"project_id,omitempty"`
+
+	// Params are the parameters of the operation, whose shape depends on Type (optional)
+	Params interface{} `json:"params,omitempty"`
+
+	// Webhook is notified when the job finishes (optional)
+	Webhook *JobWebhook `json:"webhook,omitempty"`
+}
+
+// JobWebhook is an HTTPS endpoint that receives the finished job as a JSON
+// POST request, instead of or in addition to polling with Wait. Requests are
+// signed with Secret together with their signing time; verify them with
+// ParseJobWebhook.
+type JobWebhook struct {
+	URL string `json:"url"`
+
+	// Secret signs webhook requests; it is write-only and never returned (required)
+	Secret string `json:"secret"`
+}
+
+// JobWaitOptions contains options for waiting for a job.
+type JobWaitOptions struct {
+	// PollInterval is the initial interval between checks of the job
+	// (default: 1 second)
+	PollInterval time.Duration
+
+	// MaxPollInterval bounds the interval, which doubles after each check
+	// (default: 30 seconds)
+	MaxPollInterval time.Duration
+
+	// OnProgress is called with the job each time it is checked (optional)
+	OnProgress func(*Job)
+}
+
+// Submit submits a job and returns without waiting for it to run.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - request: The job to run
+//   - opts: Request options (optional)
+//
+// Returns the queued job or an error if the request fails.
+//
+// Example usage:
+//
+//	job, err := client.Jobs.Submit(ctx, &zoptal.JobRequest{
+//	    Type:      zoptal.JobTypeSecurityScan,
+//	    ProjectID: projectID,
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	job, err = client.Jobs.Wait(ctx, job.ID, &zoptal.JobWaitOptions{
+//	    OnProgress: func(job *zoptal.Job) { fmt.Printf("%.0f%%\n", job.Progress) },
+//	})
+func (s *JobsService) Submit(ctx context.Context, request *JobRequest, opts ...RequestOption) (*Job, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if request == nil || request.Type == "" {
+		return nil, NewValidationError("job type is required")
+	}
+	if webhook := request.Webhook; webhook != nil {
+		u, err := url.Parse(webhook.URL)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return nil, NewValidationError("job webhook URL must be an absolute HTTPS URL")
+		}
+		if webhook.Secret == "" {
+			return nil, NewValidationError("job webhook secret is required")
+		}
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Post(ctx, "/jobs", request, &raw); err != nil {
+		return nil, fmt.Errorf("failed to submit job: %w", err)
+	}
+	job, err := decodeJob(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit job: %w", err)
+	}
+	return job, nil
+}
+
+// Get gets a job.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - jobID: ID of the job
+//   - opts: Request options (optional)
+//
+// Returns the job or an error if the request fails.
+func (s *JobsService) Get(ctx context.Context, jobID string, opts ...RequestOption) (*Job, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if jobID == "" {
+		return nil, NewValidationError("job ID is required")
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Get(ctx, "/jobs/"+url.PathEscape(jobID), nil, &raw); err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	job, err := decodeJob(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	return job, nil
+}
+
+// Cancel asks the server to stop a job. Cancellation is asynchronous: the
+// returned job may still be running; Wait reports it as canceled once it
+// has stopped. Cancelling a finished job has no effect.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - jobID: ID of the job
+//   - opts: Request options (optional)
+//
+// Returns the job or an error if the request fails.
+func (s *JobsService) Cancel(ctx context.Context, jobID string, opts ...RequestOption) (*Job, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if jobID == "" {
+		return nil, NewValidationError("job ID is required")
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Post(ctx, "/jobs/"+url.PathEscape(jobID)+"/cancel", map[string]interface{}{}, &raw); err != nil {
+		return nil, fmt.Errorf("failed to cancel job: %w", err)
+	}
+	job, err := decodeJob(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel job: %w", err)
+	}
+	return job, nil
+}
+
+// Wait polls a job until it finishes, backing off exponentially between
+// checks. Cancelling ctx stops the polling but not the job; the error names
+// the job, which can later be fetched with Get.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - jobID: ID of the job
+//   - options: Wait options (can be nil for defaults)
+//   - opts: Request options (optional)
+//
+// Returns the succeeded job, or an error if the job fails or is canceled,
+// or a request fails.
+func (s *JobsService) Wait(ctx context.Context, jobID string, options *JobWaitOptions, opts ...RequestOption) (*Job, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if jobID == "" {
+		return nil, NewValidationError("job ID is required")
+	}
+	if options == nil {
+		options = &JobWaitOptions{}
+	}
+
+	job, err := WaitFor(ctx, func(ctx context.Context) (*Job, bool, error) {
+		job, err := s.Get(ctx, jobID)
+		if err != nil {
+			return nil, false, err
+		}
+		if options.OnProgress != nil {
+			options.OnProgress(job)
+		}
+		switch job.Status {
+		case JobFailed:
+			return job, true, NewAPIError(fmt.Sprintf("job %s failed: %s", job.ID, job.Error))
+		case JobCanceled:
+			return job, true, NewAPIError(fmt.Sprintf("job %s was canceled", job.ID))
+		}
+		return job, job.Status.Done(), nil
+	}, &WaitOptions{PollInterval: options.PollInterval, MaxPollInterval: options.MaxPollInterval})
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("job %s still running: %w", jobID, err)
+		}
+		return nil, err
+	}
+	return job, nil
+}
+
+// ParseJobWebhook verifies the signature of a job webhook request and
+// returns the finished job it carries. Use it in the handler of the URL
+// given in JobRequest.Webhook.
+//
+// The signature covers the request's signing time, which must be within
+// five minutes of the current time, so a captured request cannot be
+// replayed later.
+//
+// Parameters:
+//   - r: The webhook request; its body is consumed
+//   - secret: The secret given in JobRequest.Webhook
+//
+// Returns the job, a ValidationError if secret is empty, or an
+// AuthenticationError if the signature or signing time is missing or
+// invalid.
+func ParseJobWebhook(r *http.Request, secret string) (*Job, error) {
+	if secret == "" {
+		return nil, NewValidationError("job webhook secret is required")
+	}
+	body, err := readBody(r.Body, defaultMaxResponseBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read job webhook: %w", err)
+	}
+
+	signature := r.Header.Get(jobSignatureHeader)
+	if !strings.HasPrefix(signature, "sha256=") {
+		return nil, NewAuthenticationError("job webhook signature is missing")
+	}
+	timestamp := r.Header.Get(jobTimestampHeader)
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, NewAuthenticationError("job webhook timestamp is missing or invalid")
+	}
+	if age := time.Since(time.Unix(seconds, 0)); age > jobWebhookTolerance || age < -jobWebhookTolerance {
+		return nil, NewAuthenticationError("job webhook timestamp is outside the tolerance")
+	}
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	if err != nil || !hmac.Equal(got, mac.Sum(nil)) {
+		return nil, NewAuthenticationError("job webhook signature is invalid")
+	}
+
+	job, err := decodeJob(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode job webhook: %w", err)
+	}
+	return job, nil
+}
+
+// decodeJob decodes a job response.
+func decodeJob(raw []byte) (*Job, error) {
+	var job Job
+	if err := decodeTyped(raw, &job, &job.Raw); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// pollBackoff spaces the checks of a long-running operation, doubling the
+// interval after each check up to a maximum.
+type pollBackoff struct {
+	interval time.Duration
+	max      time.Duration
+}
+
+// newPollBackoff creates a poll backoff starting at interval, or at
+// fallback if interval is not positive, and growing to max (default: 30
+// seconds).
+func newPollBackoff(interval, max, fallback time.Duration) *pollBackoff {
+	if interval <= 0 {
+		interval = fallback
+	}
+	if max <= 0 {
+		max = 30 * time.Second
+	}
+	if max < interval {
+		max = interval
+	}
+	return &pollBackoff{interval: interval, max: max}
+}
+
+// wait sleeps for the current interval, with jitter, and grows it. It
+// returns early with the cancellation error of ctx.
+func (b *pollBackoff) wait(ctx context.Context) error {
+	select {
+	case <-time.After(jitter(b.interval)):
+	case <-ctx.Done():
+		return canceled(ctx)
+	}
+	if b.interval *= 2; b.interval > b.max {
+		b.interval = b.max
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// immutableCodes are the error codes of responses rejecting a change to a
+// resource under legal hold or marked immutable.
+var immutableCodes = map[string]bool{
+	"legal_hold":         true,
+	"resource_immutable": true,
+	"immutable":          true,
+}
+
+// LegalHold is the legal hold state of a project. While a project is on
+// hold, its files and settings cannot be changed or deleted.
+type LegalHold struct {
+	ProjectID string `json:"project_id"`
+	Enabled   bool   `json:"enabled"`
+
+	// Reason is the reason given when the hold was placed, such as a case number
+	Reason string `json:"reason,omitempty"`
+
+	// PlacedBy is the ID of the user who placed the hold
+	PlacedBy string `json:"placed_by,omitempty"`
+
+	PlacedAt Timestamp `json:"placed_at"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// FileImmutability is the immutability state of a file. An immutable file
+// cannot be overwritten or deleted.
+type FileImmutability struct {
+	Path      string `json:"path"`
+	Immutable bool   `json:"immutable"`
+
+	// LegalHold reports whether the file is also held by a legal hold on its
+	// project, which keeps it immutable regardless of Immutable
+	LegalHold bool `json:"legal_hold"`
+
+	UpdatedAt Timestamp `json:"updated_at"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// SetLegalHold places or removes a legal hold on a project. While the hold
+// is in place, changes to the project and its files fail with an
+// ImmutableResourceError. Placing and removing holds requires compliance
+// permissions.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - enabled: True to place the hold, false to remove it
+//   - reason: Reason for the hold, such as a case number (optional)
+//   - opts: Request options (optional)
+//
+// Returns the legal hold state or an error if the request fails.
+func (s *ProjectService) SetLegalHold(ctx context.Context, projectID string, enabled bool, reason string, opts ...RequestOption) (*LegalHold, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+
+	data := map[string]interface{}{"enabled": enabled}
+	if reason != "" {
+		data["reason"] = reason
+	}
+	var raw json.RawMessage
+	if err := s.client.Put(ctx, fmt.Sprintf("/projects/%s/legal-hold", url.PathEscape(projectID)), data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to set legal hold: %w", err)
+	}
+
+	var hold LegalHold
+	if err := decodeTyped(raw, &hold, &hold.Raw); err != nil {
+		return nil, fmt.Errorf("failed to set legal hold: %w", err)
+	}
+	if hold.ProjectID == "" {
+		hold.ProjectID = projectID
+	}
+	return &hold, nil
+}
+
+// SetImmutable marks a file as immutable, or makes it mutable again. Changes
+// to an immutable file fail with an ImmutableResourceError.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - filePath: Path of the file within the project
+//   - immutable: True to make the file immutable, false to allow changes
+//   - opts: Request options (optional)
+//
+// Returns the immutability state or an error if the request fails.
+func (s *FileService) SetImmutable(ctx context.Context, projectID, filePath string, immutable bool, opts ...RequestOption) (*FileImmutability, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+	if filePath == "" {
+		return nil, NewValidationError("file path is required")
+	}
+
+	data := map[string]interface{}{
+		"path":      strings.TrimPrefix(filePath, "/"),
+		"immutable": immutable,
+	}
+	var raw json.RawMessage
+	if err := s.client.Put(ctx, fmt.Sprintf("/projects/%s/files/immutable", url.PathEscape(projectID)), data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to set immutability of %s: %w", filePath, err)
+	}
+
+	var state FileImmutability
+	if err := decodeTyped(raw, &state, &state.Raw); err != nil {
+		return nil, fmt.Errorf("failed to set immutability of %s: %w", filePath, err)
+	}
+	if state.Path == "" {
+		state.Path = filePath
+	}
+	return &state, nil
+}
+
+// immutableResource returns an ImmutableResourceError for an error response
+// rejecting a change to a held or immutable resource, or nil for other
+// responses.
+func immutableResource(statusCode int, body []byte) error {
+	if statusCode < 400 {
+		return nil
+	}
+	code := errorMessage(body, "", "code", "error_code", "type")
+	if statusCode != http.StatusLocked && !immutableCodes[code] {
+		return nil
+	}
+
+	return NewImmutableResourceError(
+		errorMessage(body, "", "resource", "resource_type"),
+		errorMessage(body, "", "resource_id", "path", "id"),
+		errorMessage(body, "resource is immutable", "message", "error", "detail"),
+		code == "legal_hold",
+	)
+}
@@ -0,0 +1,219 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// MarketplaceService discovers the community templates published on the
+// Zoptal marketplace and installs them into organizations, where they can be
+// used like the organization's private templates (see TemplateService).
+type MarketplaceService struct {
+	client *HTTPClient
+}
+
+// MarketplaceAuthor is the publisher of a marketplace template.
+type MarketplaceAuthor struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Verified bool   `json:"verified"`
+}
+
+// MarketplaceTemplate is a community template on the marketplace.
+type MarketplaceTemplate struct {
+	Template
+
+	Author  MarketplaceAuthor `json:"author"`
+	License string            `json:"license,omitempty"`
+
+	// Rating is the average user rating from 1 to 5, or 0 if unrated
+	Rating float64 `json:"rating"`
+
+	// RatingCount is the number of ratings Rating averages
+	RatingCount int64 `json:"rating_count"`
+
+	// WeeklyDownloads is the number of installs in the last 7 days;
+	// Downloads is the total
+	WeeklyDownloads int64 `json:"weekly_downloads"`
+
+	// Installed is true if the template is installed in the caller's
+	// organization
+	Installed bool `json:"installed"`
+
+	PublishedAt Timestamp `json:"published_at"`
+}
+
+// MarketplaceSearchOptions contains filters for searching the marketplace.
+type MarketplaceSearchOptions struct {
+	// Query is free text matched against template names and descriptions (optional)
+	Query string
+
+	// Category filters by category, e.g. "web" (optional)
+	Category string
+
+	// Language filters by programming language, e.g. "go" (optional)
+	Language string
+
+	// Framework filters by framework, e.g. "nextjs" (optional)
+	Framework string
+
+	// Tags filters to templates having all of the given tags (optional)
+	Tags []string
+
+	// MinRating filters to templates rated at least this, from 0 to 5 (optional)
+	MinRating float64
+
+	// VerifiedOnly filters to templates of verified authors (default: false)
+	VerifiedOnly bool
+
+	// Sort orders the results: "popularity", "rating", "downloads",
+	// "updated", or "name" (default: "popularity")
+	Sort string
+
+	// Pagination selects the page of results (optional)
+	Pagination *Pagination
+}
+
+// MarketplaceSearchResult is a page of marketplace search results.
+type MarketplaceSearchResult struct {
+	Templates []MarketplaceTemplate `json:"-"`
+	Total     int                   `json:"total"`
+	Page      int                   `json:"page"`
+	Pages     int                   `json:"pages"`
+}
+
+// Search searches the community templates on the marketplace.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - options: Search filters (can be nil to list all templates)
+//   - opts: Request options (optional)
+//
+// Returns a page of matching templates or an error if the request fails.
+//
+// Example usage:
+//
+//	result, err := client.Marketplace.Search(ctx, &zoptal.MarketplaceSearchOptions{
+//	    Language:  "go",
+//	    MinRating: 4,
+//	    Sort:      "rating",
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	for _, template := range result.Templates {
+//	    fmt.Printf("%s by %s: %.1f (%d downloads)\n", template.Name, template.Author.Name, template.Rating, template.Downloads)
+//	}
+func (s *MarketplaceService) Search(ctx context.Context, options *MarketplaceSearchOptions, opts ...RequestOption) (*MarketplaceSearchResult, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if options == nil {
+		options = &MarketplaceSearchOptions{}
+	}
+	switch options.Sort {
+	case "", "popularity", "rating", "downloads", "updated", "name":
+	default:
+		return nil, NewValidationError("sort must be 'popularity', 'rating', 'downloads', 'updated', or 'name'")
+	}
+	if options.MinRating < 0 || options.MinRating > 5 {
+		return nil, NewValidationError("minimum rating must be between 0 and 5")
+	}
+
+	query := NewQuery()
+	if options.Query != "" {
+		query.Set("q", options.Query)
+	}
+	if options.Category != "" {
+		query.Set("category", options.Category)
+	}
+	if options.Language != "" {
+		query.Set("language", options.Language)
+	}
+	if options.Framework != "" {
+		query.Set("framework", options.Framework)
+	}
+	query.Add("tag", options.Tags...)
+	if options.MinRating > 0 {
+		query.Set("min_rating", strconv.FormatFloat(options.MinRating, 'g', -1, 64))
+	}
+	if options.VerifiedOnly {
+		query.SetBool("verified", true)
+	}
+	if options.Sort != "" {
+		query.Set("sort", options.Sort)
+	}
+	options.Pagination.apply(query)
+
+	var response struct {
+		MarketplaceSearchResult
+		Templates []json.RawMessage `json:"templates"`
+	}
+	if err := s.client.GetQuery(ctx, "/marketplace/templates", query, &response); err != nil {
+		return nil, fmt.Errorf("failed to search marketplace: %w", err)
+	}
+
+	result := response.MarketplaceSearchResult
+	result.Templates = make([]MarketplaceTemplate, len(response.Templates))
+	for i, raw := range response.Templates {
+		if err := decodeTyped(raw, &result.Templates[i], &result.Templates[i].Raw); err != nil {
+			return nil, fmt.Errorf("failed to search marketplace: %w", err)
+		}
+	}
+	return &result, nil
+}
+
+// GetTemplate gets a community template.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - templateID: ID of the template
+//   - opts: Request options (optional)
+//
+// Returns the template or an error if the request fails.
+func (s *MarketplaceService) GetTemplate(ctx context.Context, templateID string, opts ...RequestOption) (*MarketplaceTemplate, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if templateID == "" {
+		return nil, NewValidationError("template ID is required")
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Get(ctx, fmt.Sprintf("/marketplace/templates/%s", templateID), nil, &raw); err != nil {
+		return nil, fmt.Errorf("failed to get marketplace template %s: %w", templateID, err)
+	}
+
+	var template MarketplaceTemplate
+	if err := decodeTyped(raw, &template, &template.Raw); err != nil {
+		return nil, fmt.Errorf("failed to get marketplace template %s: %w", templateID, err)
+	}
+	return &template, nil
+}
+
+// Install installs a community template into an organization, making it
+// available to the organization's projects like its private templates.
+// Installing a template that is already installed returns the installed
+// template.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - templateID: ID of the marketplace template
+//   - orgID: ID of the organization to install it into
+//   - opts: Request options (optional)
+//
+// Returns the organization's template or an error if the request fails.
+func (s *MarketplaceService) Install(ctx context.Context, templateID, orgID string, opts ...RequestOption) (*Template, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if templateID == "" {
+		return nil, NewValidationError("template ID is required")
+	}
+	if orgID == "" {
+		return nil, NewValidationError("organization ID is required")
+	}
+
+	data := map[string]interface{}{"org_id": orgID}
+	template, err := postTemplate(ctx, s.client, fmt.Sprintf("/marketplace/templates/%s/install", templateID), data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to install marketplace template %s: %w", templateID, err)
+	}
+	return template, nil
+}
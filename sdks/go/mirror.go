@@ -0,0 +1,258 @@
+package zoptal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MirrorOptions contains options for mirroring GET requests to a secondary
+// deployment.
+//
+// Mirroring is intended for validating a self-hosted deployment against the
+// cloud API before cutover: every mirrored GET is sent again to the secondary
+// base URL in the background, the two responses are compared, and any
+// difference is reported to OnDiff. The primary response is always the one
+// returned to the caller, and mirror failures never affect it.
+type MirrorOptions struct {
+	// BaseURL is the base URL of the secondary deployment (required)
+	BaseURL string
+
+	// APIKey is the API key used for mirrored requests (default: the client's API key)
+	APIKey string
+
+	// SampleRate is the fraction of GET requests that are mirrored, in the
+	// range (0, 1] (default: 1)
+	SampleRate float64
+
+	// MaxInFlight is the maximum number of concurrent mirrored requests;
+	// requests beyond it are not mirrored (default: 10)
+	MaxInFlight int
+
+	// IgnoreFields lists JSON object keys, at any depth, that are expected to
+	// differ between deployments and are left out of the comparison
+	// (optional, e.g. "id", "created_at", "request_id")
+	IgnoreFields []string
+
+	// OnDiff is called, from a background goroutine, when the mirrored
+	// response differs from the primary response or the mirrored request fails (required)
+	OnDiff func(MirrorDiff)
+
+	// HTTPClient is the HTTP client used for mirrored requests (default: the client's HTTP client)
+	HTTPClient *http.Client
+}
+
+// MirrorDiff describes a difference between a primary and a mirrored response.
+type MirrorDiff struct {
+	// Path is the request path and query, relative to the base URL
+	Path string
+
+	PrimaryStatus int
+	MirrorStatus  int
+
+	// Differences lists the JSON paths whose values differ, such as
+	// "$.items[2].name"; it is empty when the bodies are not both JSON
+	Differences []string
+
+	PrimaryBody []byte
+	MirrorBody  []byte
+
+	// Err is set if the mirrored request failed
+	Err error
+}
+
+// maxMirrorDifferences is the maximum number of differences reported per response.
+const maxMirrorDifferences = 50
+
+// mirror duplicates GET requests to a secondary base URL.
+type mirror struct {
+	baseURL    string
+	apiKey     string
+	sampleRate float64
+	ignore     map[string]bool
+	onDiff     func(MirrorDiff)
+	client     *http.Client
+	timeout    time.Duration
+	metrics    *runtimeMetrics
+	slots      chan struct{}
+}
+
+// newMirror creates a mirror from the given options, applying defaults.
+func newMirror(options *MirrorOptions, client *http.Client, timeout time.Duration, metrics *runtimeMetrics) *mirror {
+	m := &mirror{
+		baseURL:    strings.TrimRight(options.BaseURL, "/"),
+		apiKey:     options.APIKey,
+		sampleRate: options.SampleRate,
+		ignore:     make(map[string]bool, len(options.IgnoreFields)),
+		onDiff:     options.OnDiff,
+		client:     options.HTTPClient,
+		timeout:    timeout,
+		metrics:    metrics,
+	}
+	if m.sampleRate <= 0 || m.sampleRate > 1 {
+		m.sampleRate = 1
+	}
+	if m.client == nil {
+		m.client = client
+	}
+	if m.timeout <= 0 {
+		m.timeout = 30 * time.Second
+	}
+	maxInFlight := options.MaxInFlight
+	if maxInFlight <= 0 {
+		maxInFlight = 10
+	}
+	m.slots = make(chan struct{}, maxInFlight)
+	for _, field := range options.IgnoreFields {
+		m.ignore[field] = true
+	}
+	return m
+}
+
+// doMirrored sends a GET request to the primary API and, if sampled, sends
+// a copy to the mirror once the primary response has been read.
+func (c *HTTPClient) doMirrored(req *http.Request) (*http.Response, error) {
+	path := strings.TrimPrefix(req.URL.String(), c.activeBaseURL())
+	if c.mirror.baseURL == "" || c.mirror.onDiff == nil || len(path) == len(req.URL.String()) ||
+		rand.Float64() >= c.mirror.sampleRate {
+		return c.dispatch(req)
+	}
+
+	// Capture the request before the cache adds conditional headers.
+	mirrorReq, err := http.NewRequest(http.MethodGet, c.mirror.baseURL+path, nil)
+	if err != nil {
+		return c.dispatch(req)
+	}
+	mirrorReq.Header = req.Header.Clone()
+	if c.mirror.apiKey != "" {
+		mirrorReq.Header.Set("Authorization", "Bearer "+c.mirror.apiKey)
+	}
+
+	resp, err := c.dispatch(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, buffered, err := bufferBody(resp, defaultMaxResponseBytes)
+	if err != nil {
+		return nil, err
+	}
+	if !buffered {
+		// Streamed responses, such as downloads, are too large to compare.
+		return resp, nil
+	}
+
+	select {
+	case c.mirror.slots <- struct{}{}:
+	default:
+		if c.debug {
+			log.Printf("HTTP GET %s: mirror saturated, not mirroring", req.URL)
+		}
+		return resp, nil
+	}
+
+	c.mirror.metrics.setQueueDepth("mirror", len(c.mirror.slots))
+	go c.mirror.compare(mirrorReq, path, resp.StatusCode, body)
+	return resp, nil
+}
+
+// compare sends the mirrored request and reports any difference from the
+// primary response.
+func (m *mirror) compare(req *http.Request, path string, primaryStatus int, primaryBody []byte) {
+	defer m.metrics.track(GoroutineMirrors)()
+	defer func() {
+		<-m.slots
+		m.metrics.setQueueDepth("mirror", len(m.slots))
+	}()
+
+	// Mirrored requests are detached from the caller's context, which is
+	// usually done by the time the mirror runs.
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	diff := MirrorDiff{
+		Path:          path,
+		PrimaryStatus: primaryStatus,
+		PrimaryBody:   primaryBody,
+	}
+
+	resp, err := m.client.Do(req.WithContext(ctx))
+	if err != nil {
+		diff.Err = fmt.Errorf("mirrored request failed: %w", err)
+		m.onDiff(diff)
+		return
+	}
+	body, err := readBody(resp.Body, defaultMaxResponseBytes)
+	resp.Body.Close()
+	if err != nil {
+		diff.Err = fmt.Errorf("failed to read mirrored response: %w", err)
+		m.onDiff(diff)
+		return
+	}
+	diff.MirrorStatus = resp.StatusCode
+	diff.MirrorBody = body
+
+	var primaryValue, mirrorValue interface{}
+	if json.Unmarshal(primaryBody, &primaryValue) == nil && json.Unmarshal(body, &mirrorValue) == nil {
+		m.diffJSON("$", primaryValue, mirrorValue, &diff.Differences)
+		if len(diff.Differences) == 0 && diff.PrimaryStatus == diff.MirrorStatus {
+			return
+		}
+	} else if diff.PrimaryStatus == diff.MirrorStatus && bytes.Equal(primaryBody, body) {
+		return
+	}
+
+	m.onDiff(diff)
+}
+
+// diffJSON appends the paths at which two decoded JSON values differ.
+func (m *mirror) diffJSON(path string, a, b interface{}, out *[]string) {
+	if len(*out) >= maxMirrorDifferences {
+		return
+	}
+
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok {
+			*out = append(*out, path)
+			return
+		}
+		keys := make([]string, 0, len(av)+len(bv))
+		for key := range av {
+			keys = append(keys, key)
+		}
+		for key := range bv {
+			if _, ok := av[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if !m.ignore[key] {
+				m.diffJSON(path+"."+key, av[key], bv[key], out)
+			}
+		}
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			*out = append(*out, path)
+			return
+		}
+		for i := range av {
+			m.diffJSON(fmt.Sprintf("%s[%d]", path, i), av[i], bv[i], out)
+		}
+	default:
+		if !reflect.DeepEqual(a, b) {
+			*out = append(*out, path)
+		}
+	}
+}
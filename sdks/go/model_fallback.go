@@ -0,0 +1,167 @@
+package zoptal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+)
+
+// modelUnavailableCodes are the error codes of responses for models that
+// are over capacity or temporarily unavailable.
+var modelUnavailableCodes = map[string]bool{
+	"model_unavailable": true,
+	"model_overloaded":  true,
+	"model_capacity":    true,
+	"overloaded_error":  true,
+}
+
+// statusOverloaded is the non-standard status of providers at capacity.
+const statusOverloaded = 529
+
+// ModelFallbackOptions configures the fallback of AI requests to other
+// models while the requested model is over capacity or unavailable.
+//
+// A request that fails with a ModelUnavailableError, or a 503 response, is
+// sent again with the next model of the list, without first retrying the
+// unavailable model. The model that served a request is reported in
+// ResponseMetadata.Model. Requests that fail for other reasons are not
+// retried with another model.
+type ModelFallbackOptions struct {
+	// Models are tried in order after the requested model (required)
+	Models []string
+
+	// OnFallback is called each time a request falls back to another model,
+	// for example to record model incidents (optional)
+	OnFallback func(ModelFallbackEvent)
+}
+
+// ModelFallbackEvent describes the fallback of a request to another model.
+type ModelFallbackEvent struct {
+	// From is the unavailable model, or "" for the server's default model
+	From string
+
+	// To is the model the request is sent to next
+	To string
+
+	// Err is the error returned for From
+	Err error
+}
+
+// modelFallbackKey is the context key marking a request that has a fallback
+// model left, so that its unavailable model is not retried.
+type modelFallbackKey struct{}
+
+// modelUnavailable returns a ModelUnavailableError for an error response
+// about an unavailable model, or nil for other responses.
+func modelUnavailable(statusCode int, body []byte) error {
+	if statusCode < 400 {
+		return nil
+	}
+	code := errorMessage(body, "", "code", "error_code", "type")
+	if statusCode != statusOverloaded && !modelUnavailableCodes[code] {
+		return nil
+	}
+
+	return NewModelUnavailableError(
+		errorMessage(body, "", "model"),
+		errorMessage(body, "model is unavailable", "message", "error", "detail"),
+		statusCode,
+	)
+}
+
+// isModelUnavailable reports whether err means the requested model could
+// not serve the request.
+func isModelUnavailable(err error) bool {
+	var unavailable *ModelUnavailableError
+	if errors.As(err, &unavailable) {
+		return true
+	}
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusServiceUnavailable
+}
+
+// fallsBack reports whether POST requests to endpoint fall back to other models.
+func (c *HTTPClient) fallsBack(endpoint string) bool {
+	fallback := c.current().modelFallback
+	if fallback == nil || len(fallback.Models) == 0 {
+		return false
+	}
+	_, ok := operationClass(endpoint)
+	return ok
+}
+
+// withModelFallback sends an AI request body, falling back to the configured
+// models while the requested model is unavailable.
+func (c *HTTPClient) withModelFallback(ctx context.Context, body []byte, send func(ctx context.Context, body []byte) error) error {
+	fallback := c.current().modelFallback
+	requested := requestModel(body)
+	chain := []string{requested}
+	for _, model := range fallback.Models {
+		if model != "" && model != requested {
+			chain = append(chain, model)
+		}
+	}
+
+	for i, model := range chain {
+		attemptBody := body
+		if i > 0 {
+			attemptBody = withRequestModel(body, model)
+		}
+		attemptCtx := ctx
+		if i < len(chain)-1 {
+			attemptCtx = context.WithValue(ctx, modelFallbackKey{}, true)
+		}
+
+		err := send(attemptCtx, attemptBody)
+		if err == nil {
+			if recorder, ok := ctx.Value(metadataKey{}).(*metadataRecorder); ok {
+				recorder.servedBy(model)
+			}
+			return nil
+		}
+		if i == len(chain)-1 || ctx.Err() != nil || !isModelUnavailable(err) {
+			return err
+		}
+
+		event := ModelFallbackEvent{From: model, To: chain[i+1], Err: err}
+		if c.debug {
+			log.Printf("Model %q unavailable, falling back to %q: %v", event.From, event.To, err)
+		}
+		if fallback.OnFallback != nil {
+			fallback.OnFallback(event)
+		}
+	}
+	return nil
+}
+
+// requestModel returns the model named by a JSON request body, or "".
+func requestModel(body []byte) string {
+	var request struct {
+		Model string `json:"model"`
+	}
+	if json.Unmarshal(body, &request) != nil {
+		return ""
+	}
+	return request.Model
+}
+
+// withRequestModel returns a JSON request body with its model replaced.
+func withRequestModel(body []byte, model string) []byte {
+	var fields map[string]json.RawMessage
+	if !bytes.HasPrefix(bytes.TrimSpace(body), []byte("{")) || json.Unmarshal(body, &fields) != nil {
+		return body
+	}
+	encoded, err := json.Marshal(model)
+	if err != nil {
+		return body
+	}
+	fields["model"] = encoded
+	replaced, err := CanonicalJSON(fields)
+	if err != nil {
+		return body
+	}
+	return replaced
+}
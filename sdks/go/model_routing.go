@@ -0,0 +1,166 @@
+package zoptal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+)
+
+// OperationClass is a kind of AI request, for model routing.
+type OperationClass string
+
+// Operation classes.
+const (
+	// OperationChat is a conversational request, such as /ai/chat
+	OperationChat OperationClass = "chat"
+
+	// OperationCompletion produces code or text, such as /ai/generate-code
+	OperationCompletion OperationClass = "completion"
+
+	// OperationAnalysis examines existing code, such as reviews, explanations, and scans
+	OperationAnalysis OperationClass = "analysis"
+)
+
+// ModelRoutingOptions picks the model of AI requests that do not name one,
+// so that small requests can go to fast, inexpensive models and large or
+// demanding ones to accurate models, without changing every call site.
+//
+// Example usage:
+//
+//	ModelRouting: &zoptal.ModelRoutingOptions{
+//	    Routes: []zoptal.ModelRoute{
+//	        {Class: zoptal.OperationChat, MaxRequestTokens: 2000, Model: "gpt-4o-mini"},
+//	        {Class: zoptal.OperationAnalysis, Model: "claude-3-5-sonnet-latest"},
+//	    },
+//	    Default: "gpt-4o",
+//	}
+type ModelRoutingOptions struct {
+	// Routes are tried in order; the first matching route picks the model
+	Routes []ModelRoute
+
+	// Default is the model of AI requests no route matches; "" leaves the
+	// choice to the server (optional)
+	Default string
+}
+
+// ModelRoute maps a class and size of AI requests to a model.
+type ModelRoute struct {
+	// Class is the operation class the route applies to; "" matches all classes
+	Class OperationClass
+
+	// MaxRequestTokens limits the route to requests of at most this many
+	// tokens, estimated from the size of the request body; zero means no limit
+	MaxRequestTokens int
+
+	// Model is the model used for matching requests (required)
+	Model string
+}
+
+// modelKey is the context key of a per-request model override.
+type modelKey struct{}
+
+// WithModel returns a context whose AI requests use model, overriding the
+// client's model routing.
+//
+// Parameters:
+//   - ctx: Parent context
+//   - model: Model ID, as listed by AI.ListModels
+//
+// Returns the derived context.
+func WithModel(ctx context.Context, model string) context.Context {
+	return context.WithValue(ctx, modelKey{}, model)
+}
+
+// modellessAIEndpoints are the endpoints under the AI root that retrieve or
+// record data rather than run a model, and so are not AI requests.
+var modellessAIEndpoints = map[string]bool{
+	"docs/fetch": true,
+	"feedback":   true,
+}
+
+// operationClass classifies an endpoint, returning false for endpoints that
+// are not AI requests.
+func operationClass(endpoint string) (OperationClass, bool) {
+	path := strings.Trim(endpoint, "/")
+	if !strings.HasPrefix(path, "ai/") {
+		return "", false
+	}
+	path = strings.TrimPrefix(path, "ai/")
+	if modellessAIEndpoints[path] {
+		return "", false
+	}
+
+	switch {
+	case strings.HasPrefix(path, "chat"):
+		return OperationChat, true
+	case strings.Contains(path, "analy"), strings.Contains(path, "review"), strings.Contains(path, "explain"),
+		strings.Contains(path, "scan"), strings.Contains(path, "search"), strings.Contains(path, "metric"):
+		return OperationAnalysis, true
+	default:
+		return OperationCompletion, true
+	}
+}
+
+// route returns the model for a request of the given class and body size,
+// or "" to leave the choice to the server.
+func (o *ModelRoutingOptions) route(class OperationClass, bodySize int) string {
+	// Roughly four bytes of JSON per token.
+	tokens := bodySize / 4
+	for _, route := range o.Routes {
+		if route.Model == "" || (route.Class != "" && route.Class != class) {
+			continue
+		}
+		if route.MaxRequestTokens > 0 && tokens > route.MaxRequestTokens {
+			continue
+		}
+		return route.Model
+	}
+	return o.Default
+}
+
+// routeModel sets the model of an AI request body that does not name one,
+// from the context override or the client's model routing. Bodies that are
+// not JSON objects are returned unchanged.
+func (c *HTTPClient) routeModel(ctx context.Context, endpoint string, body []byte) []byte {
+	override, _ := ctx.Value(modelKey{}).(string)
+	routing := c.current().modelRouting
+	if override == "" && routing == nil {
+		return body
+	}
+	class, ok := operationClass(endpoint)
+	if !ok || !bytes.HasPrefix(bytes.TrimSpace(body), []byte("{")) {
+		return body
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return body
+	}
+	if model, ok := fields["model"]; ok && !bytes.Equal(model, []byte(`""`)) && !bytes.Equal(model, []byte("null")) {
+		return body
+	}
+
+	model := override
+	if model == "" {
+		model = routing.route(class, len(body))
+	}
+	if model == "" {
+		return body
+	}
+
+	encoded, err := json.Marshal(model)
+	if err != nil {
+		return body
+	}
+	fields["model"] = encoded
+	routed, err := CanonicalJSON(fields)
+	if err != nil {
+		return body
+	}
+	if c.debug {
+		log.Printf("Routing %s request %s to model %s", class, endpoint, model)
+	}
+	return routed
+}
@@ -0,0 +1,389 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// notificationPollWait is how long the server holds a poll for new notifications open.
+const notificationPollWait = 25 * time.Second
+
+// NotificationsService handles notifications and their delivery preferences.
+type NotificationsService struct {
+	client *HTTPClient
+}
+
+// Notification is a notification sent to the authenticated user.
+type Notification struct {
+	ID        string                 `json:"id"`
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Body      string                 `json:"body,omitempty"`
+	ProjectID string                 `json:"project_id,omitempty"`
+	URL       string                 `json:"url,omitempty"`
+	Read      bool                   `json:"read"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+	CreatedAt Timestamp              `json:"created_at"`
+
+	// Raw is the undecoded notification, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// NotificationListOptions contains filters for listing notifications.
+type NotificationListOptions struct {
+	// Unread lists only unread notifications (default: false)
+	Unread bool
+
+	// Types filters to the given notification types, e.g. "project.shared" (optional)
+	Types []string
+
+	// Since lists only notifications created at or after this time (optional)
+	Since time.Time
+
+	// Pagination selects the page of results (optional)
+	Pagination *Pagination
+}
+
+// NotificationList is a page of notifications, newest first.
+type NotificationList struct {
+	Notifications []Notification `json:"-"`
+	Total         int            `json:"total"`
+	Unread        int            `json:"unread"`
+	Page          int            `json:"page"`
+	Pages         int            `json:"pages"`
+}
+
+// NotificationChannelType is a notification delivery channel.
+type NotificationChannelType string
+
+const (
+	// NotificationChannelEmail delivers notifications by email.
+	NotificationChannelEmail NotificationChannelType = "email"
+
+	// NotificationChannelWebhook delivers notifications as HTTP POST requests.
+	NotificationChannelWebhook NotificationChannelType = "webhook"
+
+	// NotificationChannelInApp delivers notifications in the Zoptal app and API.
+	NotificationChannelInApp NotificationChannelType = "in_app"
+)
+
+// NotificationChannel is the configuration of a delivery channel.
+type NotificationChannel struct {
+	Type    NotificationChannelType `json:"type"`
+	Enabled bool                    `json:"enabled"`
+
+	// Types lists the notification types delivered on the channel; empty
+	// delivers all types that are not muted
+	Types []string `json:"types,omitempty"`
+
+	// Address is the email address for the email channel (default: the account email)
+	Address string `json:"address,omitempty"`
+
+	// WebhookURL is the HTTPS URL notifications are posted to, for the webhook channel
+	WebhookURL string `json:"webhook_url,omitempty"`
+
+	// WebhookSecret signs webhook requests; it is write-only and never returned
+	WebhookSecret string `json:"webhook_secret,omitempty"`
+}
+
+// NotificationPreferences are the notification delivery preferences of the
+// authenticated user.
+type NotificationPreferences struct {
+	Channels []NotificationChannel `json:"channels"`
+
+	// Muted lists notification types that are not delivered on any channel
+	Muted []string `json:"muted,omitempty"`
+}
+
+// SubscribeOptions contains options for subscribing to notifications.
+type SubscribeOptions struct {
+	// Types filters to the given notification types (optional)
+	Types []string
+
+	// MarkRead marks notifications as read once they are delivered to the
+	// subscription (default: false)
+	MarkRead bool
+}
+
+// NotificationSubscription delivers new notifications in near real time.
+//
+// A NotificationSubscription is a stream: it must be closed when no longer needed.
+type NotificationSubscription struct {
+	*streamState
+	notifications chan Notification
+	errors        chan error
+}
+
+// Notifications returns the channel on which new notifications are
+// delivered, oldest first. The channel is closed when the subscription stops.
+func (s *NotificationSubscription) Notifications() <-chan Notification {
+	return s.notifications
+}
+
+// Errors returns the channel on which polling errors are reported; polling
+// continues with backoff after an error. Errors are dropped if the channel
+// is not drained.
+func (s *NotificationSubscription) Errors() <-chan error {
+	return s.errors
+}
+
+// List lists the notifications of the authenticated user, newest first.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - options: List filters (can be nil to list all notifications)
+//   - opts: Request options (optional)
+//
+// Returns a page of notifications or an error if the request fails.
+func (s *NotificationsService) List(ctx context.Context, options *NotificationListOptions, opts ...RequestOption) (*NotificationList, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if options == nil {
+		options = &NotificationListOptions{}
+	}
+
+	query := NewQuery().Add("type", options.Types...).SetTime("since", options.Since)
+	if options.Unread {
+		query.SetBool("unread", true)
+	}
+	options.Pagination.apply(query)
+
+	var response struct {
+		NotificationList
+		Notifications []json.RawMessage `json:"notifications"`
+	}
+	if err := s.client.GetQuery(ctx, "/notifications", query, &response); err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	result := response.NotificationList
+	notifications, err := decodeNotifications(response.Notifications)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	result.Notifications = notifications
+	return &result, nil
+}
+
+// MarkRead marks notifications as read.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - ids: IDs of the notifications
+//
+// Returns an error if the request fails.
+func (s *NotificationsService) MarkRead(ctx context.Context, ids ...string) error {
+	if len(ids) == 0 {
+		return NewValidationError("at least one notification ID is required")
+	}
+
+	data := map[string]interface{}{"ids": ids}
+	if err := s.client.Post(ctx, "/notifications/read", data, nil); err != nil {
+		return fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+	return nil
+}
+
+// MarkAllRead marks all notifications of the authenticated user as read.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - opts: Request options (optional)
+//
+// Returns an error if the request fails.
+func (s *NotificationsService) MarkAllRead(ctx context.Context, opts ...RequestOption) error {
+	ctx = WithRequestOptions(ctx, opts...)
+	if err := s.client.Post(ctx, "/notifications/read-all", nil, nil); err != nil {
+		return fmt.Errorf("failed to mark all notifications as read: %w", err)
+	}
+	return nil
+}
+
+// GetPreferences gets the notification delivery preferences of the
+// authenticated user.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - opts: Request options (optional)
+//
+// Returns the preferences or an error if the request fails.
+func (s *NotificationsService) GetPreferences(ctx context.Context, opts ...RequestOption) (*NotificationPreferences, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	var preferences NotificationPreferences
+	if err := s.client.Get(ctx, "/notifications/preferences", nil, &preferences); err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	return &preferences, nil
+}
+
+// UpdatePreferences replaces the notification delivery preferences of the
+// authenticated user.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - preferences: New preferences
+//   - opts: Request options (optional)
+//
+// Returns the updated preferences or an error if the request fails.
+func (s *NotificationsService) UpdatePreferences(ctx context.Context, preferences *NotificationPreferences, opts ...RequestOption) (*NotificationPreferences, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if preferences == nil {
+		return nil, NewValidationError("preferences are required")
+	}
+	for _, channel := range preferences.Channels {
+		switch channel.Type {
+		case NotificationChannelEmail, NotificationChannelInApp:
+		case NotificationChannelWebhook:
+			if !channel.Enabled {
+				continue
+			}
+			u, err := url.Parse(channel.WebhookURL)
+			if err != nil || u.Scheme != "https" || u.Host == "" {
+				return nil, NewValidationError("webhook channel requires an HTTPS webhook URL")
+			}
+		default:
+			return nil, NewValidationError(fmt.Sprintf("unknown notification channel %q", channel.Type))
+		}
+	}
+
+	var updated NotificationPreferences
+	if err := s.client.Put(ctx, "/notifications/preferences", preferences, &updated); err != nil {
+		return nil, fmt.Errorf("failed to update notification preferences: %w", err)
+	}
+	return &updated, nil
+}
+
+// Subscribe delivers new notifications in near real time by long-polling
+// the API, for bots and other consumers that cannot receive webhooks.
+//
+// Parameters:
+//   - ctx: Context that stops the subscription when cancelled
+//   - options: Subscription options (can be nil for defaults)
+//   - opts: Request options (optional)
+//
+// Returns the subscription, which must be closed, or an error if it cannot
+// be started.
+func (s *NotificationsService) Subscribe(ctx context.Context, options *SubscribeOptions, opts ...RequestOption) (*NotificationSubscription, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if options == nil {
+		options = &SubscribeOptions{}
+	}
+
+	// A poll without a cursor returns the current position immediately, so
+	// that only notifications created from now on are delivered.
+	start, err := s.pollPage(ctx, "", options.Types, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to notifications: %w", err)
+	}
+
+	sub := &NotificationSubscription{
+		streamState:   newStreamState(nil, s.client.metrics),
+		notifications: make(chan Notification, 64),
+		errors:        make(chan error, 8),
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-runCtx.Done():
+		case <-sub.Done():
+		}
+		cancel()
+	}()
+
+	go func() {
+		defer close(sub.notifications)
+		defer sub.Close()
+
+		cursor := start.cursor
+		delay := time.Second
+		for {
+			page, err := s.pollPage(runCtx, cursor, options.Types, notificationPollWait)
+			if runCtx.Err() != nil {
+				return
+			}
+			if err != nil {
+				select {
+				case sub.errors <- err:
+				default:
+				}
+				select {
+				case <-time.After(jitter(delay)):
+				case <-runCtx.Done():
+					return
+				}
+				if delay < 30*time.Second {
+					delay *= 2
+				}
+				continue
+			}
+			delay = time.Second
+			cursor = page.cursor
+
+			var delivered []string
+			for _, notification := range page.notifications {
+				select {
+				case sub.notifications <- notification:
+					delivered = append(delivered, notification.ID)
+				case <-runCtx.Done():
+					return
+				}
+			}
+			if options.MarkRead && len(delivered) > 0 {
+				if err := s.MarkRead(runCtx, delivered...); err != nil && runCtx.Err() == nil {
+					select {
+					case sub.errors <- err:
+					default:
+					}
+				}
+			}
+		}
+	}()
+
+	return sub, nil
+}
+
+// notificationPage is the result of a notification poll.
+type notificationPage struct {
+	cursor        string
+	notifications []Notification
+}
+
+// pollPage waits up to wait for notifications after cursor.
+func (s *NotificationsService) pollPage(ctx context.Context, cursor string, types []string, wait time.Duration) (*notificationPage, error) {
+	query := NewQuery().Add("type", types...).Set("wait", strconv.Itoa(int(wait/time.Second)))
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+
+	var response struct {
+		Cursor        string            `json:"cursor"`
+		Notifications []json.RawMessage `json:"notifications"`
+	}
+	if err := s.client.GetQuery(ctx, "/notifications/poll", query, &response); err != nil {
+		return nil, fmt.Errorf("failed to poll notifications: %w", err)
+	}
+
+	notifications, err := decodeNotifications(response.Notifications)
+	if err != nil {
+		return nil, fmt.Errorf("failed to poll notifications: %w", err)
+	}
+	if response.Cursor == "" {
+		response.Cursor = cursor
+	}
+	return &notificationPage{cursor: response.Cursor, notifications: notifications}, nil
+}
+
+// decodeNotifications decodes raw notifications into typed ones.
+func decodeNotifications(raws []json.RawMessage) ([]Notification, error) {
+	notifications := make([]Notification, len(raws))
+	for i, raw := range raws {
+		if err := decodeTyped(raw, &notifications[i], &notifications[i].Raw); err != nil {
+			return nil, err
+		}
+	}
+	return notifications, nil
+}
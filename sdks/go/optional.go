@@ -0,0 +1,186 @@
+package zoptal
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Optional is a value that may or may not be set, for request fields where
+// "not given" differs from the zero value.
+//
+// The request structs of the SDK omit unset Optional fields from the
+// request, so the server applies its default. On its own, an unset Optional
+// encodes as JSON null, which clears a field in a merge patch (see Patch);
+// in structs of your own, omit unset fields with the `json:",omitzero"` tag
+// (Go 1.24 and later), for which IsZero reports true. Decoding sets the
+// Optional when its key is present with a non-null value. The zero value is
+// unset.
+//
+// Example usage:
+//
+//	var limit zoptal.Optional[int]
+//	limit.Set(5)
+//	if n, ok := limit.Get(); ok {
+//	    fmt.Println(n)
+//	}
+type Optional[T any] struct {
+	value T
+	set   bool
+}
+
+// NewOptional creates a set Optional holding v.
+func NewOptional[T any](v T) Optional[T] {
+	return Optional[T]{value: v, set: true}
+}
+
+// Set sets the value.
+func (o *Optional[T]) Set(v T) {
+	o.value, o.set = v, true
+}
+
+// Unset clears the value.
+func (o *Optional[T]) Unset() {
+	var zero T
+	o.value, o.set = zero, false
+}
+
+// IsSet reports whether the value is set.
+func (o Optional[T]) IsSet() bool {
+	return o.set
+}
+
+// IsZero reports whether the value is unset, for the omitzero JSON tag option.
+func (o Optional[T]) IsZero() bool {
+	return !o.set
+}
+
+// Get returns the value and whether it is set.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.set
+}
+
+// ValueOr returns the value if it is set, and fallback otherwise.
+func (o Optional[T]) ValueOr(fallback T) T {
+	if !o.set {
+		return fallback
+	}
+	return o.value
+}
+
+// Ptr returns a pointer to a copy of the value, or nil if it is unset.
+func (o Optional[T]) Ptr() *T {
+	if !o.set {
+		return nil
+	}
+	v := o.value
+	return &v
+}
+
+// MarshalJSON implements json.Marshaler.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.set {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		o.Unset()
+		return nil
+	}
+
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	o.Set(v)
+	return nil
+}
+
+// optional is implemented by every Optional.
+type optional interface {
+	IsSet() bool
+}
+
+// marshalOmittingUnset encodes v, a struct, as JSON without the members of
+// its unset Optional fields, as the omitzero tag option would on Go 1.24.
+// Request structs call it from MarshalJSON with a conversion of themselves
+// to a type without the method.
+func marshalOmittingUnset(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	var unset []string
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if o, ok := rv.Field(i).Interface().(optional); !ok || o.IsSet() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		unset = append(unset, name)
+	}
+	if len(unset) == 0 {
+		return data, nil
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+	for _, name := range unset {
+		delete(members, name)
+	}
+	return json.Marshal(members)
+}
+
+// String returns a pointer to s, for optional string fields of request structs.
+func String(s string) *string {
+	return &s
+}
+
+// Int returns a pointer to i, for optional int fields of request structs.
+func Int(i int) *int {
+	return &i
+}
+
+// Int64 returns a pointer to i, for optional int64 fields of request structs.
+func Int64(i int64) *int64 {
+	return &i
+}
+
+// Float64 returns a pointer to f, for optional float64 fields of request structs.
+func Float64(f float64) *float64 {
+	return &f
+}
+
+// Bool returns a pointer to b, for optional bool fields of request structs.
+func Bool(b bool) *bool {
+	return &b
+}
+
+// Time returns a pointer to t, for optional time fields of request structs.
+func Time(t time.Time) *time.Time {
+	return &t
+}
+
+// Ptr returns a pointer to v, for optional fields of other types.
+func Ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,284 @@
+package zoptal
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Option configures a client created with New.
+type Option interface {
+	applyClient(options *ClientOptions) error
+}
+
+// optionFunc is an Option implemented by a function.
+type optionFunc func(options *ClientOptions) error
+
+// applyClient implements Option.
+func (f optionFunc) applyClient(options *ClientOptions) error {
+	return f(options)
+}
+
+// ClientRequestOption is an option that configures either a whole client, as
+// an Option of New, or a single call, as a RequestOption, such as
+// WithTimeout.
+type ClientRequestOption interface {
+	Option
+	RequestOption
+}
+
+// clientRequestOption is a ClientRequestOption implemented by functions.
+type clientRequestOption struct {
+	client  optionFunc
+	request requestOptionFunc
+}
+
+// applyClient implements Option.
+func (o clientRequestOption) applyClient(options *ClientOptions) error {
+	return o.client(options)
+}
+
+// applyRequest implements RequestOption.
+func (o clientRequestOption) applyRequest(options *requestOptions) {
+	o.request(options)
+}
+
+// New creates a new Zoptal client, validating its configuration.
+//
+// Unlike NewClientWithOptions, which panics without an API key, New reports
+// every configuration problem as an error, so it is the constructor to use
+// in libraries and with configuration from untrusted sources.
+//
+// Parameters:
+//   - apiKey: Your Zoptal API key; may be empty with WithCredentials or
+//     WithTokenSource
+//   - opts: Client options, applied in order
+//
+// Returns a new Client instance, or a ValidationError if the configuration
+// is invalid.
+//
+// Example usage:
+//
+//	client, err := zoptal.New(apiKey,
+//	    zoptal.WithBaseURL("https://eu.api.zoptal.com"),
+//	    zoptal.WithTimeout(10*time.Second),
+//	)
+//	if err != nil {
+//	    return err
+//	}
+//	defer client.Close()
+func New(apiKey string, opts ...Option) (*Client, error) {
+	options := &ClientOptions{}
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt.applyClient(options); err != nil {
+			return nil, err
+		}
+	}
+	apiKey, err := resolveAPIKey(apiKey, options)
+	if err != nil {
+		return nil, err
+	}
+	if apiKey == "" && options.TokenSource == nil {
+		return nil, NewValidationError("API key is required")
+	}
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	return NewClientWithOptions(apiKey, options), nil
+}
+
+// WithClientOptions configures a client created with New from options, for
+// settings that have no Option of their own. It replaces the settings of
+// the options before it, so it should come first.
+//
+// Parameters:
+//   - options: Client options
+//
+// Returns the option.
+func WithClientOptions(options *ClientOptions) Option {
+	return optionFunc(func(o *ClientOptions) error {
+		if options == nil {
+			return NewValidationError("client options are required")
+		}
+		*o = *options
+		return nil
+	})
+}
+
+// WithBaseURL sets the base URL of the Zoptal API.
+//
+// Parameters:
+//   - baseURL: Base URL, e.g. "https://eu.api.zoptal.com"
+//
+// Returns the option.
+func WithBaseURL(baseURL string) Option {
+	return optionFunc(func(o *ClientOptions) error {
+		o.BaseURL = baseURL
+		return nil
+	})
+}
+
+// WithHTTPClient sets the HTTP client requests are sent with.
+//
+// Parameters:
+//   - client: HTTP client
+//
+// Returns the option.
+func WithHTTPClient(client *http.Client) Option {
+	return optionFunc(func(o *ClientOptions) error {
+		o.HTTPClient = client
+		return nil
+	})
+}
+
+// WithRetryPolicy sets the policy deciding which failed requests are retried.
+//
+// Parameters:
+//   - policy: Retry policy
+//
+// Returns the option.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return optionFunc(func(o *ClientOptions) error {
+		o.RetryPolicy = policy
+		return nil
+	})
+}
+
+// WithTokenSource authenticates with OAuth access tokens instead of an API
+// key.
+//
+// Parameters:
+//   - source: Token source
+//
+// Returns the option.
+func WithTokenSource(source TokenSource) Option {
+	return optionFunc(func(o *ClientOptions) error {
+		o.TokenSource = source
+		return nil
+	})
+}
+
+// WithRateLimit enables the client-side rate limiter.
+//
+// Parameters:
+//   - options: Rate limit, e.g. &zoptal.RateLimitOptions{RequestsPerSecond: 5}
+//
+// Returns the option.
+func WithRateLimit(options *RateLimitOptions) Option {
+	return optionFunc(func(o *ClientOptions) error {
+		if options == nil {
+			return NewValidationError("rate limit options are required")
+		}
+		if err := options.validate(); err != nil {
+			return err
+		}
+		o.RateLimit = options
+		return nil
+	})
+}
+
+// WithDebug enables debug logging, including request and response dumps.
+//
+// Returns the option.
+func WithDebug() Option {
+	return optionFunc(func(o *ClientOptions) error {
+		o.Debug = true
+		return nil
+	})
+}
+
+// Validate checks the options for values NewClientWithOptions cannot use.
+//
+// Returns a ValidationError for the first invalid value, or nil.
+func (o *ClientOptions) Validate() error {
+	if o.BaseURL != "" {
+		if err := validateBaseURL(o.BaseURL); err != nil {
+			return NewValidationError(fmt.Sprintf("base URL: %v", err))
+		}
+	}
+	if o.Timeout < 0 {
+		return NewValidationError("timeout must not be negative")
+	}
+	if o.CompressionThreshold < 0 {
+		return NewValidationError("compression threshold must not be negative")
+	}
+	if o.MetricsInterval < 0 {
+		return NewValidationError("metrics interval must not be negative")
+	}
+	if o.StaleWhileRevalidate != nil && o.Cache == nil {
+		return NewValidationError("stale-while-revalidate requires a cache")
+	}
+	if o.RateLimit != nil {
+		if err := o.RateLimit.validate(); err != nil {
+			return err
+		}
+	}
+	if f := o.Failover; f != nil {
+		if len(f.Endpoints) == 0 {
+			return NewValidationError("failover endpoints are required")
+		}
+		for i, endpoint := range f.Endpoints {
+			if err := validateBaseURL(endpoint); err != nil {
+				return NewValidationError(fmt.Sprintf("failover endpoint %d: %v", i, err))
+			}
+		}
+	}
+	if m := o.Mirror; m != nil {
+		if err := validateBaseURL(m.BaseURL); err != nil {
+			return NewValidationError(fmt.Sprintf("mirror base URL: %v", err))
+		}
+		if m.SampleRate < 0 || m.SampleRate > 1 {
+			return NewValidationError("mirror sample rate must be in the range (0, 1]")
+		}
+		if m.OnDiff == nil {
+			return NewValidationError("mirror diff handler is required")
+		}
+	}
+	return nil
+}
+
+// validateBaseURL checks that a base URL is an absolute HTTP or HTTPS URL.
+func validateBaseURL(baseURL string) error {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%q is not an http or https URL", baseURL)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%q has no host", baseURL)
+	}
+	return nil
+}
+
+// clientTimeout returns the client part of WithTimeout.
+func clientTimeout(timeout time.Duration) optionFunc {
+	return func(o *ClientOptions) error {
+		if timeout <= 0 {
+			return NewValidationError("timeout must be positive")
+		}
+		o.Timeout = timeout
+		return nil
+	}
+}
+
+// clientMaxRetries returns the client part of WithMaxRetries.
+func clientMaxRetries(maxRetries int) optionFunc {
+	return func(o *ClientOptions) error {
+		switch {
+		case maxRetries < 0:
+			return NewValidationError("max retries must not be negative")
+		case maxRetries == 0:
+			// Zero means the default in ClientOptions.
+			o.MaxRetries = -1
+		default:
+			o.MaxRetries = maxRetries
+		}
+		return nil
+	}
+}
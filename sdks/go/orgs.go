@@ -0,0 +1,231 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"net/url"
+)
+
+// OrgRole is the role of a member of an organization.
+type OrgRole string
+
+// Organization roles.
+const (
+	OrgRoleOwner  OrgRole = "owner"
+	OrgRoleAdmin  OrgRole = "admin"
+	OrgRoleMember OrgRole = "member"
+	OrgRoleViewer OrgRole = "viewer"
+)
+
+// Invitation statuses.
+const (
+	InvitationStatusPending  = "pending"
+	InvitationStatusAccepted = "accepted"
+	InvitationStatusExpired  = "expired"
+	InvitationStatusRevoked  = "revoked"
+)
+
+// OrgsService manages organizations and their members, such as inviting
+// new members, so that onboarding can be automated.
+type OrgsService struct {
+	client *HTTPClient
+}
+
+// Invitation is an invitation of a person to join an organization.
+type Invitation struct {
+	// ID is the ID of the invitation, set by the server
+	ID string `json:"id,omitempty"`
+
+	// Email is the email address the invitation is sent to (required)
+	Email string `json:"email"`
+
+	// Role is the role of the member once the invitation is accepted
+	// (default: OrgRoleMember)
+	Role OrgRole `json:"role,omitempty"`
+
+	// Message is a personal note included in the invitation email (optional)
+	Message string `json:"message,omitempty"`
+
+	// Status is one of the InvitationStatus constants, set by the server
+	Status string `json:"status,omitempty"`
+
+	// InvitedBy is the ID of the user who sent the invitation, set by the server
+	InvitedBy string `json:"invited_by,omitempty"`
+
+	CreatedAt Timestamp `json:"created_at"`
+	ExpiresAt Timestamp `json:"expires_at"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// InvitationListOptions contains filters for listing invitations.
+type InvitationListOptions struct {
+	// Status filters by status, e.g. InvitationStatusPending (optional)
+	Status string
+}
+
+// Invite invites a person to join an organization, sending them an email
+// with a link to accept the invitation.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - orgID: ID of the organization
+//   - invitation: Invitation to send; only Email, Role, and Message are used
+//   - opts: Request options (optional)
+//
+// Returns the created invitation or an error if the request fails.
+//
+// Example usage:
+//
+//	invitation, err := client.Orgs.Invite(ctx, orgID, &zoptal.Invitation{
+//	    Email: "new.hire@example.com",
+//	    Role:  zoptal.OrgRoleMember,
+//	})
+func (s *OrgsService) Invite(ctx context.Context, orgID string, invitation *Invitation, opts ...RequestOption) (*Invitation, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if orgID == "" {
+		return nil, NewValidationError("organization ID is required")
+	}
+	if invitation == nil || invitation.Email == "" {
+		return nil, NewValidationError("email is required")
+	}
+	if _, err := mail.ParseAddress(invitation.Email); err != nil {
+		return nil, NewValidationError(fmt.Sprintf("invalid email %q", invitation.Email))
+	}
+
+	data := map[string]interface{}{
+		"email": invitation.Email,
+	}
+	if invitation.Role != "" {
+		data["role"] = invitation.Role
+	}
+	if invitation.Message != "" {
+		data["message"] = invitation.Message
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Post(ctx, invitationsEndpoint(orgID), data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to invite %s: %w", invitation.Email, err)
+	}
+	created, err := decodeInvitation(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to invite %s: %w", invitation.Email, err)
+	}
+	return created, nil
+}
+
+// ListInvitations lists the invitations of an organization.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - orgID: ID of the organization
+//   - options: List filters (can be nil to list all invitations)
+//   - opts: Request options (optional)
+//
+// Returns the invitations or an error if the request fails.
+func (s *OrgsService) ListInvitations(ctx context.Context, orgID string, options *InvitationListOptions, opts ...RequestOption) ([]Invitation, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if orgID == "" {
+		return nil, NewValidationError("organization ID is required")
+	}
+	if options == nil {
+		options = &InvitationListOptions{}
+	}
+
+	query := NewQuery()
+	if options.Status != "" {
+		query.Set("status", options.Status)
+	}
+
+	var response struct {
+		Invitations []json.RawMessage `json:"invitations"`
+	}
+	if err := s.client.GetQuery(ctx, invitationsEndpoint(orgID), query, &response); err != nil {
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+
+	invitations := make([]Invitation, len(response.Invitations))
+	for i, raw := range response.Invitations {
+		if err := decodeTyped(raw, &invitations[i], &invitations[i].Raw); err != nil {
+			return nil, fmt.Errorf("failed to list invitations: %w", err)
+		}
+	}
+	return invitations, nil
+}
+
+// ResendInvitation sends the email of a pending or expired invitation
+// again, extending its expiry.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - orgID: ID of the organization
+//   - invitationID: ID of the invitation
+//   - opts: Request options (optional)
+//
+// Returns the updated invitation or an error if the request fails.
+func (s *OrgsService) ResendInvitation(ctx context.Context, orgID, invitationID string, opts ...RequestOption) (*Invitation, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if orgID == "" {
+		return nil, NewValidationError("organization ID is required")
+	}
+	if invitationID == "" {
+		return nil, NewValidationError("invitation ID is required")
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Post(ctx, invitationEndpoint(orgID, invitationID)+"/resend", nil, &raw); err != nil {
+		return nil, fmt.Errorf("failed to resend invitation %s: %w", invitationID, err)
+	}
+	invitation, err := decodeInvitation(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resend invitation %s: %w", invitationID, err)
+	}
+	return invitation, nil
+}
+
+// RevokeInvitation revokes a pending invitation, so that it can no longer
+// be accepted.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - orgID: ID of the organization
+//   - invitationID: ID of the invitation
+//   - opts: Request options (optional)
+//
+// Returns an error if the request fails.
+func (s *OrgsService) RevokeInvitation(ctx context.Context, orgID, invitationID string, opts ...RequestOption) error {
+	ctx = WithRequestOptions(ctx, opts...)
+	if orgID == "" {
+		return NewValidationError("organization ID is required")
+	}
+	if invitationID == "" {
+		return NewValidationError("invitation ID is required")
+	}
+
+	if err := s.client.Delete(ctx, invitationEndpoint(orgID, invitationID), nil); err != nil {
+		return fmt.Errorf("failed to revoke invitation %s: %w", invitationID, err)
+	}
+	return nil
+}
+
+// invitationsEndpoint returns the endpoint of the invitations of an organization.
+func invitationsEndpoint(orgID string) string {
+	return "/orgs/" + url.PathEscape(orgID) + "/invitations"
+}
+
+// invitationEndpoint returns the endpoint of an invitation.
+func invitationEndpoint(orgID, invitationID string) string {
+	return invitationsEndpoint(orgID) + "/" + url.PathEscape(invitationID)
+}
+
+// decodeInvitation decodes an invitation response.
+func decodeInvitation(raw json.RawMessage) (*Invitation, error) {
+	var invitation Invitation
+	if err := decodeTyped(raw, &invitation, &invitation.Raw); err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
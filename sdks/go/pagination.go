@@ -0,0 +1,27 @@
+package zoptal
+
+// Pagination selects a page of a paginated list.
+type Pagination struct {
+	// Page is the 1-based page number (default: 1)
+	Page int
+
+	// Limit is the number of items per page (default: 20, max: 100)
+	Limit int
+}
+
+// apply adds the page and limit query parameters to query.
+func (p *Pagination) apply(query *Query) {
+	page, limit := 1, 20
+	if p != nil {
+		if p.Page > 0 {
+			page = p.Page
+		}
+		if p.Limit > 0 {
+			limit = p.Limit
+		}
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	query.SetInt("page", page).SetInt("limit", limit)
+}
@@ -0,0 +1,283 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// PageIterator iterates over the items of a paginated list endpoint,
+// fetching pages as needed.
+//
+// The next page is found, in order of preference, from:
+//   - an RFC 8288 Link response header with rel="next"
+//   - a "next_cursor" field in the response body, sent back as the "cursor" parameter
+//   - "page" and "pages" fields in the response body, requesting page+1
+//
+// A next link or next_cursor that was already requested stops the iteration
+// with a *DecodeError rather than looping over the same pages forever. A
+// next link must have the scheme and host of the page it was returned with.
+//
+// Items are read from a JSON array body, or from the "data", "items", or
+// "results" field of an object body (or its only array field), so endpoints
+// can be iterated without SDK support for their response shape.
+//
+// Example usage:
+//
+//	it := zoptal.Paginate[zoptal.Project](client, "/projects", nil)
+//	defer it.Close()
+//	for it.Next(ctx) {
+//	    fmt.Println(it.Item().Name)
+//	}
+//	if err := it.Err(); err != nil {
+//	    log.Fatal(err)
+//	}
+type PageIterator[T any] struct {
+	client   *HTTPClient
+	endpoint string
+	query    *Query
+
+	// cursors are the cursors requested so far, to detect a server
+	// repeating one, which would otherwise loop forever
+	cursors map[string]bool
+
+	// links are the page URLs requested so far, for the same purpose
+	links map[string]bool
+
+	items  []T
+	index  int
+	item   T
+	err    error
+	done   bool
+	closed bool
+}
+
+// pageResponse receives a raw response from handleResponse, keeping the
+// headers needed to find the next page.
+type pageResponse struct {
+	url    *url.URL
+	header http.Header
+	body   []byte
+}
+
+// itemKeys are the object fields searched for the items of a page.
+var itemKeys = []string{"data", "items", "results"}
+
+// Paginate returns an iterator over the items of a paginated list endpoint.
+//
+// Parameters:
+//   - client: Client to send the requests with
+//   - endpoint: API endpoint of the list
+//   - query: Query parameters of the first page (can be nil)
+//
+// Returns a new iterator; no request is sent until Next is called.
+func Paginate[T any](client *Client, endpoint string, query *Query) *PageIterator[T] {
+	return &PageIterator[T]{client: client.httpClient, endpoint: endpoint, query: query.Clone()}
+}
+
+// Next advances to the next item, fetching the next page if needed. It
+// returns false when there are no more items or an error occurred.
+func (it *PageIterator[T]) Next(ctx context.Context) bool {
+	for !it.closed && it.err == nil {
+		if it.index < len(it.items) {
+			it.item = it.items[it.index]
+			it.index++
+			return true
+		}
+		if it.done {
+			return false
+		}
+		if err := it.fetch(ctx); err != nil {
+			it.err = err
+		}
+	}
+	return false
+}
+
+// Item returns the current item.
+func (it *PageIterator[T]) Item() T {
+	return it.item
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *PageIterator[T]) Err() error {
+	if it.closed && it.err == nil {
+		return ErrStreamClosed
+	}
+	return it.err
+}
+
+// Close stops the iteration. It is idempotent.
+func (it *PageIterator[T]) Close() error {
+	it.closed = true
+	return nil
+}
+
+// fetch fetches the next page and works out where the page after it is.
+func (it *PageIterator[T]) fetch(ctx context.Context) error {
+	var page pageResponse
+	if err := it.client.GetQuery(ctx, it.endpoint, it.query, &page); err != nil {
+		return fmt.Errorf("failed to list %s: %w", it.endpoint, err)
+	}
+
+	items, fields, err := decodePage[T](page.body)
+	if err != nil {
+		return fmt.Errorf("failed to list %s: %w", it.endpoint, err)
+	}
+	it.items, it.index = items, 0
+
+	if next := parseLinkHeader(page.header.Values("Link"))["next"]; next != "" {
+		nextURL, err := page.url.Parse(next)
+		if err != nil {
+			return NewDecodeError(fmt.Sprintf("invalid next page link %q", next), err)
+		}
+		// The link is followed with the client's credentials, so it must not
+		// lead to another host or downgrade to plain HTTP.
+		if nextURL.Scheme != page.url.Scheme || nextURL.Host != page.url.Host {
+			return NewDecodeError(fmt.Sprintf("next page link %q points to another origin", next), nil)
+		}
+		if nextURL.String() == page.url.String() {
+			it.done = true
+			return nil
+		}
+		if it.links == nil {
+			it.links = make(map[string]bool)
+		}
+		it.links[page.url.String()] = true
+		if it.links[nextURL.String()] {
+			return NewDecodeError(fmt.Sprintf("next page link %q does not advance the list", next), nil)
+		}
+		it.endpoint, it.query = nextURL.String(), NewQuery()
+		return nil
+	}
+
+	var cursor string
+	if decodeJSON(fields["next_cursor"], &cursor) == nil && cursor != "" {
+		if it.cursors == nil {
+			it.cursors = map[string]bool{it.query.Get("cursor"): true}
+		}
+		if it.cursors[cursor] {
+			return NewDecodeError(fmt.Sprintf("next cursor %q does not advance the list", cursor), nil)
+		}
+		it.cursors[cursor] = true
+		it.query.Set("cursor", cursor)
+		return nil
+	}
+
+	var current, pages int
+	if decodeJSON(fields["page"], &current) == nil && decodeJSON(fields["pages"], &pages) == nil &&
+		current > 0 && current < pages && len(items) > 0 {
+		it.query.SetInt("page", current+1)
+		return nil
+	}
+
+	it.done = true
+	return nil
+}
+
+// decodePage decodes the items of a page, returning the fields of an object
+// body for finding the next page.
+func decodePage[T any](body []byte) ([]T, map[string]json.RawMessage, error) {
+	body = []byte(strings.TrimSpace(string(body)))
+	if len(body) == 0 {
+		return nil, nil, nil
+	}
+
+	var items []T
+	if body[0] == '[' {
+		if err := decodeJSON(body, &items); err != nil {
+			return nil, nil, err
+		}
+		return items, nil, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := decodeJSON(body, &fields); err != nil {
+		return nil, nil, err
+	}
+
+	list, ok := []byte(nil), false
+	for _, key := range itemKeys {
+		if value, found := fields[key]; found {
+			list, ok = value, true
+			break
+		}
+	}
+	if !ok {
+		for _, value := range fields {
+			if trimmed := strings.TrimSpace(string(value)); strings.HasPrefix(trimmed, "[") {
+				if ok {
+					return nil, nil, NewDecodeError("paginated response has more than one list field", nil)
+				}
+				list, ok = value, true
+			}
+		}
+	}
+	if !ok {
+		return nil, nil, NewDecodeError("paginated response has no list field", nil)
+	}
+
+	if string(list) != "null" {
+		if err := decodeJSON(list, &items); err != nil {
+			return nil, nil, err
+		}
+	}
+	return items, fields, nil
+}
+
+// parseLinkHeader parses RFC 8288 Link header values, returning the target
+// of each link relation.
+func parseLinkHeader(values []string) map[string]string {
+	links := map[string]string{}
+	for _, value := range values {
+		for _, link := range splitLinks(value) {
+			link = strings.TrimSpace(link)
+			if !strings.HasPrefix(link, "<") {
+				continue
+			}
+			end := strings.Index(link, ">")
+			if end < 0 {
+				continue
+			}
+			target := link[1:end]
+
+			for _, param := range strings.Split(link[end+1:], ";") {
+				name, val, found := strings.Cut(strings.TrimSpace(param), "=")
+				if !found || !strings.EqualFold(strings.TrimSpace(name), "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(val), `"`)) {
+					rel = strings.ToLower(rel)
+					if _, exists := links[rel]; !exists {
+						links[rel] = target
+					}
+				}
+			}
+		}
+	}
+	return links
+}
+
+// splitLinks splits a Link header value into links at the commas outside
+// of URI references and quoted strings.
+func splitLinks(value string) []string {
+	var links []string
+	start, inURI, inQuote := 0, false, false
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case c == '<' && !inQuote:
+			inURI = true
+		case c == '>' && !inQuote:
+			inURI = false
+		case c == '"' && !inURI:
+			inQuote = !inQuote
+		case c == ',' && !inURI && !inQuote:
+			links = append(links, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(links, value[start:])
+}
@@ -0,0 +1,323 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Patch is a partial update of a resource, following JSON Merge Patch
+// (RFC 7396) semantics.
+//
+// Each field of a patch is in one of three states: unset fields are left
+// untouched, cleared fields are sent as an explicit null and reset by the
+// server, and set fields are replaced with the given value. This makes a
+// partial update safe to send: fields that were not mentioned can never be
+// wiped by accident, as they can when an update struct is sent whole.
+//
+// ProjectPatch and TemplatePatch are typed patches for their resources.
+type Patch struct {
+	fields map[string]interface{}
+}
+
+// NewPatch creates an empty patch.
+func NewPatch() *Patch {
+	return &Patch{fields: make(map[string]interface{})}
+}
+
+// Set sets a field to a value. Nested fields are given as a dotted path:
+// "settings.theme" replaces only the theme key of the settings object.
+func (p *Patch) Set(field string, value interface{}) *Patch {
+	p.put(field, value)
+	return p
+}
+
+// Clear clears a field, sending an explicit null.
+func (p *Patch) Clear(field string) *Patch {
+	p.put(field, nil)
+	return p
+}
+
+// Unset removes a field from the patch, leaving it untouched by the update.
+func (p *Patch) Unset(field string) *Patch {
+	unsetPatchField(p.fields, strings.Split(field, "."))
+	return p
+}
+
+// IsEmpty reports whether the patch changes nothing.
+func (p *Patch) IsEmpty() bool {
+	return len(p.fields) == 0
+}
+
+// Fields returns the merge patch document: set fields with their values and
+// cleared fields with nil.
+func (p *Patch) Fields() map[string]interface{} {
+	return copyPatchFields(p.fields)
+}
+
+// MarshalJSON implements json.Marshaler, encoding the merge patch document.
+func (p *Patch) MarshalJSON() ([]byte, error) {
+	if p.fields == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(p.fields)
+}
+
+// put stores a value, or nil for a cleared field, at a dotted field path.
+func (p *Patch) put(field string, value interface{}) {
+	if p.fields == nil {
+		p.fields = make(map[string]interface{})
+	}
+	parts := strings.Split(field, ".")
+	fields := p.fields
+	for _, part := range parts[:len(parts)-1] {
+		nested, ok := fields[part].(map[string]interface{})
+		if !ok {
+			nested = make(map[string]interface{})
+			fields[part] = nested
+		}
+		fields = nested
+	}
+	fields[parts[len(parts)-1]] = value
+}
+
+// unsetPatchField removes a field at a path, along with nested objects
+// left empty. It reports whether fields is empty afterwards.
+func unsetPatchField(fields map[string]interface{}, path []string) bool {
+	if fields == nil {
+		return true
+	}
+	if len(path) == 1 {
+		delete(fields, path[0])
+	} else if nested, ok := fields[path[0]].(map[string]interface{}); ok && unsetPatchField(nested, path[1:]) {
+		delete(fields, path[0])
+	}
+	return len(fields) == 0
+}
+
+// copyPatchFields deep-copies the nested objects of a patch document.
+func copyPatchFields(fields map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		if nested, ok := value.(map[string]interface{}); ok {
+			value = copyPatchFields(nested)
+		}
+		copied[key] = value
+	}
+	return copied
+}
+
+// ProjectPatch is a partial update of a project.
+//
+// Example usage:
+//
+//	// Rename the project and remove its description, leaving everything else as is
+//	patch := zoptal.NewProjectPatch().SetName("api-v2").ClearDescription()
+//	project, err := client.Projects.Patch(ctx, projectID, patch)
+type ProjectPatch struct {
+	patch Patch
+}
+
+// NewProjectPatch creates an empty project patch.
+func NewProjectPatch() *ProjectPatch {
+	return &ProjectPatch{}
+}
+
+// SetName renames the project.
+func (p *ProjectPatch) SetName(name string) *ProjectPatch {
+	p.patch.Set("name", name)
+	return p
+}
+
+// SetDescription replaces the description of the project.
+func (p *ProjectPatch) SetDescription(description string) *ProjectPatch {
+	p.patch.Set("description", description)
+	return p
+}
+
+// ClearDescription removes the description of the project.
+func (p *ProjectPatch) ClearDescription() *ProjectPatch {
+	p.patch.Clear("description")
+	return p
+}
+
+// SetVisibility changes the visibility of the project: "private", "public", or "team".
+func (p *ProjectPatch) SetVisibility(visibility string) *ProjectPatch {
+	p.patch.Set("visibility", visibility)
+	return p
+}
+
+// SetTags replaces the tags of the project. A nil slice sets no tags; it
+// does not clear the field.
+func (p *ProjectPatch) SetTags(tags []string) *ProjectPatch {
+	if tags == nil {
+		tags = []string{}
+	}
+	p.patch.Set("tags", tags)
+	return p
+}
+
+// ClearTags removes all tags of the project.
+func (p *ProjectPatch) ClearTags() *ProjectPatch {
+	p.patch.Clear("tags")
+	return p
+}
+
+// SetSetting sets one project setting, leaving the other settings untouched.
+func (p *ProjectPatch) SetSetting(key string, value interface{}) *ProjectPatch {
+	p.patch.Set("settings."+key, value)
+	return p
+}
+
+// ClearSetting resets one project setting to its default.
+func (p *ProjectPatch) ClearSetting(key string) *ProjectPatch {
+	p.patch.Clear("settings." + key)
+	return p
+}
+
+// Patch returns the underlying generic patch, for fields without a typed setter.
+func (p *ProjectPatch) Patch() *Patch {
+	return &p.patch
+}
+
+// MarshalJSON implements json.Marshaler, encoding the merge patch document.
+func (p *ProjectPatch) MarshalJSON() ([]byte, error) {
+	return p.patch.MarshalJSON()
+}
+
+// TemplatePatch is a partial update of a template.
+type TemplatePatch struct {
+	patch Patch
+}
+
+// NewTemplatePatch creates an empty template patch.
+func NewTemplatePatch() *TemplatePatch {
+	return &TemplatePatch{}
+}
+
+// SetName renames the template.
+func (p *TemplatePatch) SetName(name string) *TemplatePatch {
+	p.patch.Set("name", name)
+	return p
+}
+
+// SetDescription replaces the description of the template.
+func (p *TemplatePatch) SetDescription(description string) *TemplatePatch {
+	p.patch.Set("description", description)
+	return p
+}
+
+// ClearDescription removes the description of the template.
+func (p *TemplatePatch) ClearDescription() *TemplatePatch {
+	p.patch.Clear("description")
+	return p
+}
+
+// SetTags replaces the tags of the template. A nil slice sets no tags; it
+// does not clear the field.
+func (p *TemplatePatch) SetTags(tags []string) *TemplatePatch {
+	if tags == nil {
+		tags = []string{}
+	}
+	p.patch.Set("tags", tags)
+	return p
+}
+
+// ClearTags removes all tags of the template.
+func (p *TemplatePatch) ClearTags() *TemplatePatch {
+	p.patch.Clear("tags")
+	return p
+}
+
+// SetPreviewURL replaces the preview URL of the template.
+func (p *TemplatePatch) SetPreviewURL(previewURL string) *TemplatePatch {
+	p.patch.Set("preview_url", previewURL)
+	return p
+}
+
+// ClearPreviewURL removes the preview URL of the template.
+func (p *TemplatePatch) ClearPreviewURL() *TemplatePatch {
+	p.patch.Clear("preview_url")
+	return p
+}
+
+// Patch returns the underlying generic patch, for fields without a typed setter.
+func (p *TemplatePatch) Patch() *Patch {
+	return &p.patch
+}
+
+// MarshalJSON implements json.Marshaler, encoding the merge patch document.
+func (p *TemplatePatch) MarshalJSON() ([]byte, error) {
+	return p.patch.MarshalJSON()
+}
+
+// Patch applies a partial update to a project. Fields not mentioned in the
+// patch are left untouched.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - patch: Fields to set or clear
+//   - opts: Request options (optional)
+//
+// Returns the updated project or an error if the request fails.
+func (s *ProjectService) Patch(ctx context.Context, projectID string, patch *ProjectPatch, opts ...RequestOption) (*Project, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+	if patch == nil || patch.patch.IsEmpty() {
+		return nil, NewValidationError("patch has no changes")
+	}
+	if visibility, ok := patch.patch.fields["visibility"]; ok {
+		if visibility != "private" && visibility != "public" && visibility != "team" {
+			return nil, NewValidationError("visibility must be 'private', 'public', or 'team'")
+		}
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Patch(ctx, fmt.Sprintf("/projects/%s", projectID), patch.patch.Fields(), &raw); err != nil {
+		return nil, fmt.Errorf("failed to update project %s: %w", projectID, err)
+	}
+
+	var project Project
+	if err := decodeTyped(raw, &project, &project.Raw); err != nil {
+		return nil, fmt.Errorf("failed to update project %s: %w", projectID, err)
+	}
+	return &project, nil
+}
+
+// Update applies a partial update to a template. Fields not mentioned in
+// the patch are left untouched.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - templateID: ID of the template
+//   - patch: Fields to set or clear
+//   - opts: Request options (optional)
+//
+// Returns the updated template or an error if the request fails.
+func (s *TemplateService) Update(ctx context.Context, templateID string, patch *TemplatePatch, opts ...RequestOption) (*Template, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if templateID == "" {
+		return nil, NewValidationError("template ID is required")
+	}
+	if patch == nil || patch.patch.IsEmpty() {
+		return nil, NewValidationError("patch has no changes")
+	}
+	if name, ok := patch.patch.fields["name"].(string); ok && strings.TrimSpace(name) == "" {
+		return nil, NewValidationError("template name cannot be empty")
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Patch(ctx, fmt.Sprintf("/templates/%s", templateID), patch.patch.Fields(), &raw); err != nil {
+		return nil, fmt.Errorf("failed to update template %s: %w", templateID, err)
+	}
+
+	var template Template
+	if err := decodeTyped(raw, &template, &template.Raw); err != nil {
+		return nil, fmt.Errorf("failed to update template %s: %w", templateID, err)
+	}
+	return &template, nil
+}
@@ -0,0 +1,226 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// PolicyAction is a guarded operation, for which the PolicyEngine is
+// consulted before the request is sent.
+type PolicyAction string
+
+// Guarded operations.
+const (
+	// PolicyProjectCreate is the creation of a project, including by clone,
+	// fork, and import
+	PolicyProjectCreate PolicyAction = "project.create"
+
+	// PolicyProjectDelete is the deletion of a project
+	PolicyProjectDelete PolicyAction = "project.delete"
+
+	// PolicyProjectVisibility is a change of the visibility of a project
+	PolicyProjectVisibility PolicyAction = "project.visibility"
+
+	// PolicyProjectEnv is a change of the environment variables of a project
+	PolicyProjectEnv PolicyAction = "project.env"
+
+	// PolicyAIAction is the execution of the action of a code lens (see
+	// AIService.ExecuteAction)
+	PolicyAIAction PolicyAction = "ai.action"
+)
+
+// PolicyInput describes a guarded operation for a PolicyEngine.
+type PolicyInput struct {
+	Action PolicyAction `json:"action"`
+
+	// Method and Path identify the request, e.g. "DELETE" and
+	// "/projects/proj_123"
+	Method string `json:"method"`
+	Path   string `json:"path"`
+
+	// ProjectID is the ID of the project acted on; for a clone or fork, the
+	// ID of the source project; empty for a new project
+	ProjectID string `json:"project_id,omitempty"`
+
+	// Body is the decoded JSON body of the request, if any, e.g. the name
+	// and visibility of a new project
+	Body interface{} `json:"body,omitempty"`
+
+	// Actor is the actor of the call, as recorded in the audit trail (see
+	// WithAuditActor and AuditOptions.Actor)
+	Actor string `json:"actor,omitempty"`
+
+	// Credential identifies the credential of the client, as in AuditRecord
+	Credential string `json:"credential"`
+
+	// Scopes are the scopes of a client derived with WithScopes
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// PolicyDecision is the decision of a PolicyEngine on an operation.
+type PolicyDecision struct {
+	Allowed bool `json:"allowed"`
+
+	// Rule names the rule a denied operation violates, e.g.
+	// "projects.no_public_visibility"
+	Rule string `json:"rule,omitempty"`
+
+	// Reason explains the denial to the user
+	Reason string `json:"reason,omitempty"`
+}
+
+// PolicyEngine decides whether guarded operations are allowed, so that
+// platform teams can enforce guardrails, such as "projects are never made
+// public" or "only the platform service deletes projects", in one place for
+// every service embedding the SDK. The zoptalpolicy package adapts Open
+// Policy Agent.
+//
+// Evaluate is called before each guarded request, possibly concurrently.
+// Returning an error, rather than a denial, means the policy could not be
+// evaluated; see PolicyOptions.FailOpen.
+type PolicyEngine interface {
+	Evaluate(ctx context.Context, input *PolicyInput) (*PolicyDecision, error)
+}
+
+// PolicyEngineFunc adapts a function to PolicyEngine.
+type PolicyEngineFunc func(ctx context.Context, input *PolicyInput) (*PolicyDecision, error)
+
+// Evaluate implements PolicyEngine.
+func (f PolicyEngineFunc) Evaluate(ctx context.Context, input *PolicyInput) (*PolicyDecision, error) {
+	return f(ctx, input)
+}
+
+// PolicyOptions enables the enforcement of a policy on guarded operations
+// (see PolicyAction). An operation the engine denies is not sent and fails
+// with a *PolicyDeniedError; it is recorded in the audit trail as failed.
+type PolicyOptions struct {
+	// Engine decides on the operations (required)
+	Engine PolicyEngine
+
+	// Actions restricts enforcement to these operations (default: all
+	// guarded operations)
+	Actions []PolicyAction
+
+	// FailOpen allows operations when the engine returns an error, e.g.
+	// because a policy server is down (default: false, such operations
+	// fail with the error)
+	FailOpen bool
+}
+
+// policyEnforcer consults the engine of a client's PolicyOptions.
+type policyEnforcer struct {
+	options PolicyOptions
+	actions map[PolicyAction]bool
+}
+
+// newPolicyEnforcer creates an enforcer, or returns nil if no policy is
+// enforced.
+func newPolicyEnforcer(options *PolicyOptions) *policyEnforcer {
+	if options == nil || options.Engine == nil {
+		return nil
+	}
+	enforcer := &policyEnforcer{options: *options}
+	if len(options.Actions) > 0 {
+		enforcer.actions = make(map[PolicyAction]bool, len(options.Actions))
+		for _, action := range options.Actions {
+			enforcer.actions[action] = true
+		}
+	}
+	return enforcer
+}
+
+// checkPolicy returns a PolicyDeniedError if the request is a guarded
+// operation that the client's policy denies.
+func (c *HTTPClient) checkPolicy(ctx context.Context, method, endpoint string, jsonData []byte) error {
+	if c.policy == nil {
+		return nil
+	}
+	var body interface{}
+	if len(jsonData) > 0 {
+		if err := json.Unmarshal(jsonData, &body); err != nil {
+			body = nil
+		}
+	}
+	action, projectID := policyAction(method, endpoint, body)
+	if action == "" || (c.policy.actions != nil && !c.policy.actions[action]) {
+		return nil
+	}
+
+	input := &PolicyInput{
+		Action:     action,
+		Method:     method,
+		Path:       "/" + endpointPath(endpoint),
+		ProjectID:  projectID,
+		Body:       body,
+		Credential: c.credentialID(),
+	}
+	if actor, _ := ctx.Value(auditActorKey{}).(string); actor != "" {
+		input.Actor = actor
+	} else if c.audit != nil {
+		input.Actor = c.audit.options.Actor
+	}
+	if c.scopes != nil {
+		input.Scopes = c.scopes.names
+	}
+
+	decision, err := c.policy.options.Engine.Evaluate(ctx, input)
+	if err != nil {
+		if c.policy.options.FailOpen {
+			if c.debug {
+				log.Printf("Zoptal policy evaluation failed for %s, allowing: %v", action, err)
+			}
+			return nil
+		}
+		return fmt.Errorf("failed to evaluate policy for %s: %w", action, err)
+	}
+	if decision == nil || !decision.Allowed {
+		if decision == nil {
+			decision = &PolicyDecision{}
+		}
+		return NewPolicyDeniedError(action, decision.Rule, decision.Reason)
+	}
+	return nil
+}
+
+// policyAction classifies a request as a guarded operation, returning ""
+// for other requests, and the ID of the project it acts on.
+func policyAction(method, endpoint string, body interface{}) (PolicyAction, string) {
+	if method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions {
+		return "", ""
+	}
+	if isAIAction(endpoint) {
+		return PolicyAIAction, ""
+	}
+	parts := strings.Split(endpointPath(endpoint), "/")
+	if parts[0] != "projects" {
+		return "", ""
+	}
+	if len(parts) > 1 {
+		if id, err := url.PathUnescape(parts[1]); err == nil {
+			parts[1] = id
+		}
+	}
+
+	switch {
+	case len(parts) == 1 && method == http.MethodPost:
+		return PolicyProjectCreate, ""
+	case len(parts) == 2 && method == http.MethodDelete:
+		return PolicyProjectDelete, parts[1]
+	case len(parts) == 2 && (method == http.MethodPatch || method == http.MethodPut):
+		if fields, ok := body.(map[string]interface{}); ok {
+			if _, ok := fields["visibility"]; ok {
+				return PolicyProjectVisibility, parts[1]
+			}
+		}
+	case len(parts) == 3 && method == http.MethodPost && (parts[2] == "clone" || parts[2] == "fork"):
+		return PolicyProjectCreate, parts[1]
+	case len(parts) >= 3 && parts[2] == "env":
+		return PolicyProjectEnv, parts[1]
+	}
+	return "", ""
+}
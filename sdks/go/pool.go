@@ -0,0 +1,276 @@
+package zoptal
+
+import (
+	"container/list"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ClientPoolOptions contains options for a pool of per-tenant clients.
+type ClientPoolOptions struct {
+	// APIKey returns the API key of a tenant, for example from a secrets
+	// store; it is called when a tenant's client is first needed and again
+	// after it was evicted (required)
+	APIKey func(tenantID string) (string, error)
+
+	// Options configures every tenant's client. Each tenant gets its own
+	// rate limiter from Options.RateLimit and its own stats; HTTPClient, or
+	// a transport built from Options.Transport, is shared by all tenants.
+	// TokenSource is ignored (optional)
+	Options *ClientOptions
+
+	// MaxClients is the number of tenant clients kept; the least recently
+	// used one is evicted beyond it (default: 1000)
+	MaxClients int
+
+	// OnEvict is called with a tenant's final stats when its client is
+	// evicted or removed (optional)
+	OnEvict func(tenantID string, stats ClientStats)
+}
+
+// ClientPool manages one client per tenant for services that multiplex many
+// customers' API keys. Tenants are isolated from each other: each client
+// authenticates with its tenant's key and has its own rate limiter, caches
+// keyed by that key, and stats. Clients share one connection pool, and the
+// least recently used ones are closed once MaxClients is reached, so the
+// number of tenants served is unbounded while memory stays bounded.
+type ClientPool struct {
+	apiKey     func(tenantID string) (string, error)
+	options    ClientOptions
+	maxClients int
+	onEvict    func(tenantID string, stats ClientStats)
+	shared     *http.Client
+
+	mu      sync.Mutex
+	clients map[string]*list.Element
+	order   *list.List
+	closed  bool
+}
+
+// poolEntry is a tenant's client in a ClientPool.
+type poolEntry struct {
+	tenantID string
+	client   *Client
+}
+
+// NewClientPool creates a pool of per-tenant clients.
+//
+// Parameters:
+//   - options: Pool options
+//
+// Returns a new ClientPool, or a ValidationError if APIKey is not set or
+// Options is invalid.
+//
+// Example usage:
+//
+//	pool, err := zoptal.NewClientPool(zoptal.ClientPoolOptions{
+//	    APIKey: func(tenantID string) (string, error) { return secrets.ZoptalKey(tenantID) },
+//	    Options: &zoptal.ClientOptions{
+//	        RateLimit: &zoptal.RateLimitOptions{RequestsPerSecond: 5},
+//	    },
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	defer pool.Close()
+//
+//	client, err := pool.ForTenant(tenantID)
+//	if err != nil {
+//	    return err
+//	}
+//	project, err := client.Projects.Clone(ctx, templateID, nil)
+func NewClientPool(options ClientPoolOptions) (*ClientPool, error) {
+	if options.APIKey == nil {
+		return nil, NewValidationError("client pool API key function is required")
+	}
+
+	p := &ClientPool{
+		apiKey:     options.APIKey,
+		maxClients: options.MaxClients,
+		onEvict:    options.OnEvict,
+		clients:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+	if options.Options != nil {
+		p.options = *options.Options
+	}
+	p.options.TokenSource = nil
+	p.options.TokenRefresh = nil
+	if err := p.options.Validate(); err != nil {
+		return nil, err
+	}
+	if p.maxClients <= 0 {
+		p.maxClients = 1000
+	}
+
+	p.shared = p.options.HTTPClient
+	if p.shared == nil {
+		timeout := p.options.Timeout
+		if timeout == 0 {
+			timeout = 30 * time.Second
+		}
+		transport := p.options.Transport
+		if transport == nil {
+			transport = &TransportOptions{}
+		}
+		p.shared = &http.Client{Timeout: timeout, Transport: newTransport(transport)}
+		p.options.HTTPClient = p.shared
+	}
+	return p, nil
+}
+
+// ForTenant returns the client of a tenant, creating it on first use.
+//
+// The client must not be closed by the caller; it is closed by the pool when
+// evicted. A client evicted while in use keeps working, but without its
+// background work, such as preconnecting and metrics reporting.
+//
+// Parameters:
+//   - tenantID: ID of the tenant
+//
+// Returns the tenant's client, or an error if its API key cannot be looked
+// up, its client cannot be created, or the pool is closed.
+func (p *ClientPool) ForTenant(tenantID string) (*Client, error) {
+	if tenantID == "" {
+		return nil, NewValidationError("tenant ID is required")
+	}
+	if client := p.lookup(tenantID); client != nil {
+		return client, nil
+	}
+
+	apiKey, err := p.apiKey(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API key of tenant %s: %w", tenantID, err)
+	}
+	if apiKey == "" {
+		return nil, NewValidationError(fmt.Sprintf("tenant %s has no API key", tenantID))
+	}
+	options := p.options
+	client, err := New(apiKey, WithClientOptions(&options))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client of tenant %s: %w", tenantID, err)
+	}
+	client.pooled = true
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		client.Close()
+		return nil, NewValidationError("client pool is closed")
+	}
+	if elem, ok := p.clients[tenantID]; ok {
+		// Another caller created the tenant's client first.
+		p.order.MoveToFront(elem)
+		existing := elem.Value.(*poolEntry).client
+		p.mu.Unlock()
+		client.Close()
+		return existing, nil
+	}
+	p.clients[tenantID] = p.order.PushFront(&poolEntry{tenantID: tenantID, client: client})
+	var evicted []*poolEntry
+	for p.order.Len() > p.maxClients {
+		oldest := p.order.Back()
+		p.order.Remove(oldest)
+		entry := oldest.Value.(*poolEntry)
+		delete(p.clients, entry.tenantID)
+		evicted = append(evicted, entry)
+	}
+	p.mu.Unlock()
+
+	for _, entry := range evicted {
+		p.evict(entry)
+	}
+	return client, nil
+}
+
+// lookup returns the client of a tenant if it is in the pool, marking it
+// recently used.
+func (p *ClientPool) lookup(tenantID string) *Client {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	elem, ok := p.clients[tenantID]
+	if !ok {
+		return nil
+	}
+	p.order.MoveToFront(elem)
+	return elem.Value.(*poolEntry).client
+}
+
+// Remove closes and removes the client of a tenant, for example after its
+// API key was rotated or the tenant offboarded. The next ForTenant call
+// creates a new client.
+//
+// Parameters:
+//   - tenantID: ID of the tenant
+func (p *ClientPool) Remove(tenantID string) {
+	p.mu.Lock()
+	elem, ok := p.clients[tenantID]
+	if ok {
+		p.order.Remove(elem)
+		delete(p.clients, tenantID)
+	}
+	p.mu.Unlock()
+
+	if ok {
+		p.evict(elem.Value.(*poolEntry))
+	}
+}
+
+// evict closes a tenant's client and reports its final stats.
+func (p *ClientPool) evict(entry *poolEntry) {
+	entry.client.Close()
+	if p.onEvict != nil {
+		p.onEvict(entry.tenantID, entry.client.Stats())
+	}
+}
+
+// Len returns the number of tenant clients in the pool.
+func (p *ClientPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.order.Len()
+}
+
+// Stats returns the traffic of each tenant whose client is in the pool.
+//
+// Returns the stats by tenant ID.
+func (p *ClientPool) Stats() map[string]ClientStats {
+	p.mu.Lock()
+	entries := make([]*poolEntry, 0, p.order.Len())
+	for elem := p.order.Front(); elem != nil; elem = elem.Next() {
+		entries = append(entries, elem.Value.(*poolEntry))
+	}
+	p.mu.Unlock()
+
+	stats := make(map[string]ClientStats, len(entries))
+	for _, entry := range entries {
+		stats[entry.tenantID] = entry.client.Stats()
+	}
+	return stats
+}
+
+// Close closes every tenant client and the shared connections. ForTenant
+// fails after Close.
+func (p *ClientPool) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	entries := make([]*poolEntry, 0, p.order.Len())
+	for elem := p.order.Front(); elem != nil; elem = elem.Next() {
+		entries = append(entries, elem.Value.(*poolEntry))
+	}
+	p.clients = make(map[string]*list.Element)
+	p.order.Init()
+	p.mu.Unlock()
+
+	for _, entry := range entries {
+		p.evict(entry)
+	}
+	p.shared.CloseIdleConnections()
+	return nil
+}
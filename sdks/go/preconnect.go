@@ -0,0 +1,61 @@
+package zoptal
+
+import (
+	"context"
+	"sync"
+)
+
+// readiness is the result of the client warm-up, completed once.
+type readiness struct {
+	once sync.Once
+	done chan struct{}
+	err  error
+}
+
+// startWarmup starts the client warm-up in the background if it has not
+// been started yet. The warm-up resolves DNS, establishes the TLS connection
+// that later requests reuse, and validates the API key.
+func (c *Client) startWarmup() {
+	c.ready.once.Do(func() {
+		c.ready.done = make(chan struct{})
+		go func() {
+			defer c.httpClient.metrics.track(GoroutineWorkers)()
+			defer close(c.ready.done)
+
+			ctx, cancel := context.WithTimeout(context.Background(), c.GetTimeout())
+			defer cancel()
+			go func() {
+				select {
+				case <-c.done:
+					cancel()
+				case <-ctx.Done():
+				}
+			}()
+
+			_, c.ready.err = c.GetUserInfo(ctx)
+		}()
+	})
+}
+
+// Ready waits until the client has connected to the API and validated its
+// credentials.
+//
+// With ClientOptions.Preconnect the warm-up starts when the client is
+// created, so by the time the first real request is made the connection is
+// usually already established. Without it, the first call to Ready starts
+// the warm-up.
+//
+// Parameters:
+//   - ctx: Context bounding how long to wait
+//
+// Returns nil once the client is ready, the warm-up error (for example an
+// AuthenticationError for an invalid API key), or the context error.
+func (c *Client) Ready(ctx context.Context) error {
+	c.startWarmup()
+	select {
+	case <-c.ready.done:
+		return c.ready.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
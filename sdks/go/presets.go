@@ -0,0 +1,95 @@
+package zoptal
+
+import "time"
+
+// Presets provides tuned client options for common workloads, as a starting
+// point instead of hand-picked timeouts and retry settings.
+//
+// Each preset returns a new ClientOptions, so fields can be overridden
+// before the client is created:
+//
+//	options := zoptal.Presets.Batch()
+//	options.RateLimit.RequestsPerSecond = 50
+//	client := zoptal.NewClientWithOptions(apiKey, options)
+var Presets presets
+
+// presets is the type of Presets.
+type presets struct{}
+
+// Production returns options for long-running services: standard timeouts
+// and retries, a larger connection pool, and compressed uploads.
+func (presets) Production() *ClientOptions {
+	return &ClientOptions{
+		Timeout:     30 * time.Second,
+		MaxRetries:  3,
+		RetryPolicy: DefaultRetryPolicy(),
+		Transport: &TransportOptions{
+			MaxIdleConns:        100,
+			MaxIdleConnsPerHost: 16,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CompressRequests: true,
+	}
+}
+
+// Interactive returns options for requests a user is waiting on, such as
+// editor integrations and CLIs: short timeouts, few quickly spaced retries,
+// and hedged reads to cut tail latency.
+func (presets) Interactive() *ClientOptions {
+	retryPolicy := DefaultRetryPolicy()
+	retryPolicy.BaseDelay = 200 * time.Millisecond
+	retryPolicy.MaxDelay = 2 * time.Second
+	retryPolicy.MaxElapsedTime = 10 * time.Second
+
+	return &ClientOptions{
+		Timeout:     10 * time.Second,
+		MaxRetries:  1,
+		RetryPolicy: retryPolicy,
+		Hedging:     &HedgingOptions{},
+		Transport: &TransportOptions{
+			MaxIdleConnsPerHost: 4,
+			DialTimeout:         5 * time.Second,
+			TLSHandshakeTimeout: 5 * time.Second,
+		},
+	}
+}
+
+// Batch returns options for background jobs that make many requests: long
+// timeouts for slow operations, patient retries, compressed uploads, and a
+// client-side rate limit of 10 requests per second so jobs queue instead of
+// running into 429 responses. Adjust RateLimit to the organization's quota.
+func (presets) Batch() *ClientOptions {
+	retryPolicy := DefaultRetryPolicy()
+	retryPolicy.BaseDelay = 2 * time.Second
+	retryPolicy.MaxDelay = time.Minute
+	retryPolicy.MaxElapsedTime = 30 * time.Minute
+
+	return &ClientOptions{
+		Timeout:     5 * time.Minute,
+		MaxRetries:  8,
+		RetryPolicy: retryPolicy,
+		Transport: &TransportOptions{
+			MaxIdleConns:        100,
+			MaxIdleConnsPerHost: 32,
+		},
+		CompressRequests: true,
+		RateLimit: &RateLimitOptions{
+			RequestsPerSecond: 10,
+		},
+	}
+}
+
+// Development returns options for local development against a staging or
+// development deployment: debug logging, a generous timeout for stepping
+// through code, and a single retry so failures surface quickly.
+//
+// Parameters:
+//   - baseURL: Base URL of the deployment, or "" for the default API
+func (presets) Development(baseURL string) *ClientOptions {
+	return &ClientOptions{
+		BaseURL:    baseURL,
+		Timeout:    time.Minute,
+		MaxRetries: 1,
+		Debug:      true,
+	}
+}
@@ -0,0 +1,310 @@
+package zoptal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Environment variables read by NewClientFromEnv and LoadProfile.
+const (
+	// EnvAPIKey is the API key, overriding the profile's
+	EnvAPIKey = "ZOPTAL_API_KEY"
+
+	// EnvBaseURL is the base URL of the API, overriding the profile's
+	EnvBaseURL = "ZOPTAL_BASE_URL"
+
+	// EnvOrganization is the ID of the organization, overriding the profile's
+	EnvOrganization = "ZOPTAL_ORG"
+
+	// EnvTimeout is the request timeout, in seconds or as a duration such as
+	// "1m", overriding the profile's
+	EnvTimeout = "ZOPTAL_TIMEOUT"
+
+	// EnvMaxRetries is the maximum number of retries, overriding the profile's
+	EnvMaxRetries = "ZOPTAL_MAX_RETRIES"
+
+	// EnvDebug enables debug logging if "true" or "1" (read by
+	// NewClientFromEnv only)
+	EnvDebug = "ZOPTAL_DEBUG"
+
+	// EnvProfile is the name of the profile to use (default: "default")
+	EnvProfile = "ZOPTAL_PROFILE"
+
+	// EnvConfigFile is the path of the configuration file (default:
+	// ~/.zoptal/config.yaml)
+	EnvConfigFile = "ZOPTAL_CONFIG_FILE"
+)
+
+// DefaultProfile is the name of the profile used when none is selected.
+const DefaultProfile = "default"
+
+// ConfigFile is the contents of the shared configuration file,
+// ~/.zoptal/config.yaml, holding named profiles:
+//
+//	profiles:
+//	  default:
+//	    api_key: zk_live_...
+//	  staging:
+//	    api_key: zk_test_...
+//	    base_url: https://staging.api.zoptal.com
+//	    org: org_123
+//	    timeout: 60s
+//
+// Unknown fields are rejected so that misspelled settings do not go
+// unnoticed.
+type ConfigFile struct {
+	Profiles map[string]*Profile `yaml:"profiles"`
+}
+
+// Profile is a named set of credentials and settings in a ConfigFile.
+// Besides the fields below, a profile may set those of FileConfig, such as
+// timeout and rate_limit.
+type Profile struct {
+	// APIKey is the API key (optional if ZOPTAL_API_KEY is set)
+	APIKey string `yaml:"api_key"`
+
+	// BaseURL is the base URL of the API (default: "https://api.zoptal.com")
+	BaseURL string `yaml:"base_url"`
+
+	// Organization is the ID of the organization requests act on (optional)
+	Organization string `yaml:"org"`
+
+	FileConfig `yaml:",inline"`
+}
+
+// LoadConfigFile reads and validates a configuration file of profiles.
+//
+// Parameters:
+//   - path: Path of the configuration file
+//
+// Returns the configuration, or an error if the file cannot be read, is
+// not valid YAML, has unknown fields, or has invalid values.
+func LoadConfigFile(path string) (*ConfigFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	config := &ConfigFile{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	for name, profile := range config.Profiles {
+		if profile == nil {
+			config.Profiles[name] = &Profile{}
+			continue
+		}
+		if err := profile.Validate(); err != nil {
+			var validationErr *ValidationError
+			if errors.As(err, &validationErr) {
+				return nil, NewValidationError(fmt.Sprintf("profile %q in %s: %s", name, path, validationErr.Message))
+			}
+			return nil, err
+		}
+	}
+	return config, nil
+}
+
+// Validate checks the values of the profile.
+//
+// Returns a ValidationError for the first invalid value, or nil.
+func (p *Profile) Validate() error {
+	if p.BaseURL != "" {
+		if err := validateBaseURL(p.BaseURL); err != nil {
+			return NewValidationError(fmt.Sprintf("base_url: %v", err))
+		}
+	}
+	return p.FileConfig.Validate()
+}
+
+// ConfigFilePath returns the path of the configuration file: the value of
+// ZOPTAL_CONFIG_FILE if set, or ~/.zoptal/config.yaml.
+//
+// Returns the path, or an error if the home directory is unknown.
+func ConfigFilePath() (string, error) {
+	if path := os.Getenv(EnvConfigFile); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config file: %w", err)
+	}
+	return filepath.Join(home, ".zoptal", "config.yaml"), nil
+}
+
+// LoadProfile loads a profile from the configuration file (see
+// ConfigFilePath) and applies the ZOPTAL_API_KEY, ZOPTAL_BASE_URL,
+// ZOPTAL_ORG, ZOPTAL_TIMEOUT, and ZOPTAL_MAX_RETRIES environment variables
+// over it.
+//
+// A missing configuration file is not an error unless ZOPTAL_CONFIG_FILE
+// names it, so that the environment or the OS keychain alone is enough; a
+// profile selected by name must be in the file if there is one.
+//
+// Parameters:
+//   - name: Name of the profile (default: ZOPTAL_PROFILE, or "default")
+//
+// Returns the profile, or an error if the configuration file or an
+// environment variable is invalid, or the file does not have the selected
+// profile.
+//
+// Example usage:
+//
+//	profile, err := zoptal.LoadProfile("staging")
+//	if err != nil {
+//	    return err
+//	}
+//	client, err := zoptal.New(profile.APIKey, profile.Option(), zoptal.WithDebug())
+func LoadProfile(name string) (*Profile, error) {
+	explicit := name != "" || os.Getenv(EnvProfile) != ""
+	explicitFile := os.Getenv(EnvConfigFile) != ""
+	name = profileName(name)
+
+	profile := &Profile{}
+	path, err := ConfigFilePath()
+	if err != nil && explicit {
+		return nil, err
+	}
+	if err == nil {
+		config, err := LoadConfigFile(path)
+		switch {
+		case err == nil:
+			selected, ok := config.Profiles[name]
+			if !ok && explicit {
+				return nil, NewValidationError(fmt.Sprintf("profile %q not found in %s", name, path))
+			}
+			if ok {
+				copied := *selected
+				profile = &copied
+			}
+		case errors.Is(err, fs.ErrNotExist) && !explicitFile:
+		default:
+			return nil, err
+		}
+	}
+
+	if apiKey := os.Getenv(EnvAPIKey); apiKey != "" {
+		profile.APIKey = apiKey
+	}
+	if baseURL := os.Getenv(EnvBaseURL); baseURL != "" {
+		profile.BaseURL = baseURL
+	}
+	if org := os.Getenv(EnvOrganization); org != "" {
+		profile.Organization = org
+	}
+	if value := os.Getenv(EnvTimeout); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if seconds, convErr := strconv.Atoi(value); convErr == nil {
+			timeout, err = time.Duration(seconds)*time.Second, nil
+		}
+		if err != nil || timeout <= 0 {
+			return nil, NewValidationError(fmt.Sprintf("%s must be a positive number of seconds or duration, got %q", EnvTimeout, value))
+		}
+		profile.Timeout = &timeout
+	}
+	if value := os.Getenv(EnvMaxRetries); value != "" {
+		maxRetries, err := strconv.Atoi(value)
+		if err != nil || maxRetries < 0 {
+			return nil, NewValidationError(fmt.Sprintf("%s must be a non-negative integer, got %q", EnvMaxRetries, value))
+		}
+		profile.MaxRetries = &maxRetries
+	}
+	return profile, nil
+}
+
+// Option returns an Option of New that applies the profile's base URL,
+// organization, and settings. The API key is passed to New separately.
+//
+// Returns the option.
+func (p *Profile) Option() Option {
+	return optionFunc(func(o *ClientOptions) error {
+		if p.BaseURL != "" {
+			o.BaseURL = p.BaseURL
+		}
+		if p.Organization != "" {
+			o.Organization = p.Organization
+		}
+		p.FileConfig.applyOptions(o)
+		return nil
+	})
+}
+
+// NewClientFromEnv creates a new Zoptal client from the environment and the
+// configuration file, as cloud SDKs do: the profile named by ZOPTAL_PROFILE
+// (default: "default") in ~/.zoptal/config.yaml, overridden by the
+// environment variables (see LoadProfile, and ZOPTAL_DEBUG), overridden in
+// turn by opts. The API key is looked up with DefaultCredentials, so it may
+// also come from the OS keychain, unless opts include WithCredentials.
+//
+// Parameters:
+//   - opts: Client options, applied after the profile's
+//
+// Returns a new Client instance, or an error if the configuration file is
+// invalid or no API key is configured.
+//
+// Example usage:
+//
+//	client, err := zoptal.NewClientFromEnv()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer client.Close()
+func NewClientFromEnv(opts ...Option) (*Client, error) {
+	profile, err := LoadProfile("")
+	if err != nil {
+		return nil, err
+	}
+	options := []Option{profile.Option(), WithCredentials(DefaultCredentials(""))}
+	if value := os.Getenv(EnvDebug); value != "" {
+		debug, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, NewValidationError(fmt.Sprintf("%s must be true or false, got %q", EnvDebug, value))
+		}
+		if debug {
+			options = append(options, WithDebug())
+		}
+	}
+	return New("", append(options, opts...)...)
+}
+
+// applyOptions sets the client options the configuration sets.
+func (f *FileConfig) applyOptions(o *ClientOptions) {
+	if f.Timeout != nil {
+		o.Timeout = *f.Timeout
+	}
+	if f.MaxRetries != nil {
+		o.MaxRetries = *f.MaxRetries
+		if o.MaxRetries == 0 {
+			// Zero means the default in ClientOptions.
+			o.MaxRetries = -1
+		}
+	}
+	if r := f.RateLimit; r != nil {
+		o.RateLimit = &RateLimitOptions{
+			RequestsPerSecond: r.RequestsPerSecond,
+			Burst:             r.Burst,
+			MaxWait:           r.MaxWait,
+		}
+	}
+	if r := f.ModelRouting; r != nil {
+		routing := &ModelRoutingOptions{Default: r.Default}
+		for _, route := range r.Routes {
+			routing.Routes = append(routing.Routes, ModelRoute(route))
+		}
+		o.ModelRouting = routing
+	}
+	if f.ModelFallback != nil {
+		o.ModelFallback = &ModelFallbackOptions{Models: f.ModelFallback}
+	}
+}
@@ -0,0 +1,134 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Project is a Zoptal project.
+type Project struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Template    string                 `json:"template,omitempty"`
+	Status      string                 `json:"status,omitempty"`
+	Visibility  string                 `json:"visibility,omitempty"`
+	OrgID       string                 `json:"org_id,omitempty"`
+	ForkedFrom  string                 `json:"forked_from,omitempty"`
+	Settings    map[string]interface{} `json:"settings,omitempty"`
+	CreatedAt   Timestamp              `json:"created_at"`
+	UpdatedAt   Timestamp              `json:"updated_at"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// CloneOptions contains options for cloning a project.
+type CloneOptions struct {
+	// Name is the name of the new project (default: the source name with " (copy)" appended)
+	Name string
+
+	// Description is the description of the new project (default: the source description)
+	Description string
+
+	// Visibility is the visibility of the new project: "private", "public",
+	// or "team" (default: the source visibility)
+	Visibility string
+
+	// IncludeCollaborators copies the source project's collaborators and
+	// their roles (default: false)
+	IncludeCollaborators bool
+}
+
+// Clone creates a copy of a project in the same organization, including its
+// files and environment configuration.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project to clone
+//   - options: Clone options (can be nil for defaults)
+//   - opts: Request options (optional)
+//
+// Returns the new project or an error if cloning fails.
+func (s *ProjectService) Clone(ctx context.Context, projectID string, options *CloneOptions, opts ...RequestOption) (*Project, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+	if options == nil {
+		options = &CloneOptions{}
+	}
+	if options.Visibility != "" && options.Visibility != "private" && options.Visibility != "public" && options.Visibility != "team" {
+		return nil, NewValidationError("visibility must be 'private', 'public', or 'team'")
+	}
+
+	data := map[string]interface{}{
+		"include_files":         true,
+		"include_environment":   true,
+		"include_collaborators": options.IncludeCollaborators,
+	}
+	if name := strings.TrimSpace(options.Name); name != "" {
+		data["name"] = name
+	}
+	if options.Description != "" {
+		data["description"] = options.Description
+	}
+	if options.Visibility != "" {
+		data["visibility"] = options.Visibility
+	}
+
+	project, err := s.createFrom(ctx, fmt.Sprintf("/projects/%s/clone", projectID), data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone project %s: %w", projectID, err)
+	}
+	return project, nil
+}
+
+// Fork creates a copy of a project in another organization, including its
+// files and environment configuration. The fork records the source project
+// in ForkedFrom; collaborators are not copied.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project to fork
+//   - targetOrg: ID of the organization that will own the fork
+//   - opts: Request options (optional)
+//
+// Returns the new project or an error if forking fails.
+func (s *ProjectService) Fork(ctx context.Context, projectID, targetOrg string, opts ...RequestOption) (*Project, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+	if targetOrg == "" {
+		return nil, NewValidationError("target organization is required")
+	}
+
+	data := map[string]interface{}{
+		"org_id":              targetOrg,
+		"include_files":       true,
+		"include_environment": true,
+	}
+
+	project, err := s.createFrom(ctx, fmt.Sprintf("/projects/%s/fork", projectID), data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fork project %s: %w", projectID, err)
+	}
+	return project, nil
+}
+
+// createFrom posts to an endpoint that creates a project and decodes it.
+func (s *ProjectService) createFrom(ctx context.Context, endpoint string, data interface{}) (*Project, error) {
+	var raw json.RawMessage
+	if err := s.client.Post(ctx, endpoint, data, &raw); err != nil {
+		return nil, err
+	}
+
+	var project Project
+	if err := decodeTyped(raw, &project, &project.Raw); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
@@ -0,0 +1,266 @@
+package zoptal
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// ArchiveFormat is the format of a project archive.
+type ArchiveFormat string
+
+// Supported archive formats.
+const (
+	ArchiveTarGz ArchiveFormat = "tar.gz"
+	ArchiveZip   ArchiveFormat = "zip"
+)
+
+// archiveManifestName is the name of the manifest entry in a project archive.
+const archiveManifestName = "zoptal-project.json"
+
+// archiveFilesDir is the directory holding project files in a project archive.
+const archiveFilesDir = "files/"
+
+// archiveManifestVersion is the current project archive manifest version.
+const archiveManifestVersion = 1
+
+// ArchiveManifest describes the contents of a project archive. It is stored
+// as zoptal-project.json at the root of the archive, with the project's
+// files under files/.
+type ArchiveManifest struct {
+	Version    int             `json:"version"`
+	ProjectID  string          `json:"project_id"`
+	Project    json.RawMessage `json:"project"`
+	Files      []RemoteFile    `json:"files"`
+	ExportedAt time.Time       `json:"exported_at"`
+}
+
+// ExportResult summarizes an exported project archive.
+type ExportResult struct {
+	Format ArchiveFormat
+	Files  int
+	Bytes  int64
+}
+
+// Export streams a full archive of a project, with its metadata and files,
+// for backup or offline use.
+//
+// The archive is written to w as it is built, so large projects are never
+// held in memory. Each file's content is verified against the SHA-256 in the
+// project's file manifest. Encrypted files are archived decrypted and
+// verified against their plaintext size and hash (see EncryptionOptions);
+// encrypted files without them are spooled to a temporary file to learn
+// their size. Archives can be restored with Projects.Import.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - format: Archive format (ArchiveTarGz or ArchiveZip)
+//   - w: Destination for the archive
+//   - opts: Request options (optional)
+//
+// Returns a summary of the archive or an error if the export fails. On
+// error, w holds an incomplete archive.
+func (s *ProjectService) Export(ctx context.Context, projectID string, format ArchiveFormat, w io.Writer, opts ...RequestOption) (*ExportResult, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+	if w == nil {
+		return nil, NewValidationError("writer is required")
+	}
+
+	var archive archiveWriter
+	switch format {
+	case ArchiveTarGz:
+		archive = newTarGzWriter(w)
+	case ArchiveZip:
+		archive = &zipArchiveWriter{zw: zip.NewWriter(w)}
+	default:
+		return nil, NewValidationError(fmt.Sprintf("unsupported archive format %q", format))
+	}
+
+	manifest := ArchiveManifest{
+		Version:    archiveManifestVersion,
+		ProjectID:  projectID,
+		ExportedAt: time.Now().UTC(),
+	}
+	err := s.client.Get(ctx, fmt.Sprintf("/projects/%s", projectID), nil, &manifest.Project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export project: %w", err)
+	}
+
+	files := &FileService{client: s.client}
+	manifest.Files, err = files.Manifest(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export project: %w", err)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode archive manifest: %w", err)
+	}
+	entry, err := archive.create(archiveManifestName, int64(len(data)), manifest.ExportedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	if _, err := entry.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	result := &ExportResult{Format: format}
+	for _, file := range manifest.Files {
+		name := strings.TrimPrefix(path.Clean("/"+file.Path), "/")
+		modTime := file.UpdatedAt.Time
+		if modTime.IsZero() {
+			modTime = manifest.ExportedAt
+		}
+
+		var n int64
+		if file.PlaintextSHA256 == "" && s.client.encryption != nil {
+			n, err = exportSpooled(ctx, files, projectID, file, archive, archiveFilesDir+name, modTime)
+		} else {
+			n, err = exportFile(ctx, files, projectID, file, archive, archiveFilesDir+name, modTime)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		result.Files++
+		result.Bytes += n
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	return result, nil
+}
+
+// exportFile downloads a file into a new archive entry, returning its size.
+// Archive entries are sized up front, so the content must match the size
+// and hash in the manifest: those of the plaintext of encrypted files.
+func exportFile(ctx context.Context, files *FileService, projectID string, file RemoteFile, archive archiveWriter, name string, modTime time.Time) (int64, error) {
+	entry, err := archive.create(name, file.ContentSize(), modTime)
+	if err != nil {
+		return 0, fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	h := sha256.New()
+	downloaded, err := files.DownloadTo(ctx, projectID, file.Path, io.MultiWriter(entry, h), nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to export project: %w", err)
+	}
+	if downloaded.Size != file.ContentSize() {
+		return 0, NewFileError(fmt.Sprintf("%s changed during export: expected %d bytes, got %d", file.Path, file.ContentSize(), downloaded.Size))
+	}
+	if err := compareChecksum(file.ContentSHA256(), hex.EncodeToString(h.Sum(nil))); err != nil {
+		return 0, err
+	}
+	return downloaded.Size, nil
+}
+
+// exportSpooled downloads a file that may be encrypted without a recorded
+// plaintext size to a temporary file, then copies it into a new archive
+// entry of its decrypted size, returning the size.
+func exportSpooled(ctx context.Context, files *FileService, projectID string, file RemoteFile, archive archiveWriter, name string, modTime time.Time) (int64, error) {
+	tmp, err := os.CreateTemp("", "zoptal-export-*")
+	if err != nil {
+		return 0, fmt.Errorf("failed to export project: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	h := sha256.New()
+	downloaded, err := files.DownloadTo(ctx, projectID, file.Path, io.MultiWriter(tmp, h), nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to export project: %w", err)
+	}
+	if downloaded.KeyID == "" {
+		if downloaded.Size != file.Size {
+			return 0, NewFileError(fmt.Sprintf("%s changed during export: expected %d bytes, got %d", file.Path, file.Size, downloaded.Size))
+		}
+		if err := compareChecksum(file.SHA256, hex.EncodeToString(h.Sum(nil))); err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("failed to export project: %w", err)
+	}
+	entry, err := archive.create(name, downloaded.Size, modTime)
+	if err != nil {
+		return 0, fmt.Errorf("failed to write archive: %w", err)
+	}
+	if _, err := io.CopyN(entry, tmp, downloaded.Size); err != nil {
+		return 0, fmt.Errorf("failed to write archive: %w", err)
+	}
+	return downloaded.Size, nil
+}
+
+// archiveWriter writes entries to a project archive.
+type archiveWriter interface {
+	// create starts a new entry; its content must be exactly size bytes.
+	create(name string, size int64, modTime time.Time) (io.Writer, error)
+	Close() error
+}
+
+// tarGzWriter writes a gzip-compressed tar archive.
+type tarGzWriter struct {
+	gz *gzip.Writer
+	tw *tar.Writer
+}
+
+// newTarGzWriter creates a tarGzWriter writing to w.
+func newTarGzWriter(w io.Writer) *tarGzWriter {
+	gz := gzip.NewWriter(w)
+	return &tarGzWriter{gz: gz, tw: tar.NewWriter(gz)}
+}
+
+// create implements archiveWriter.
+func (t *tarGzWriter) create(name string, size int64, modTime time.Time) (io.Writer, error) {
+	err := t.tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0o644,
+		Size:     size,
+		ModTime:  modTime,
+		Typeflag: tar.TypeReg,
+	})
+	return t.tw, err
+}
+
+// Close implements archiveWriter.
+func (t *tarGzWriter) Close() error {
+	if err := t.tw.Close(); err != nil {
+		return err
+	}
+	return t.gz.Close()
+}
+
+// zipArchiveWriter writes a zip archive.
+type zipArchiveWriter struct {
+	zw *zip.Writer
+}
+
+// create implements archiveWriter.
+func (z *zipArchiveWriter) create(name string, size int64, modTime time.Time) (io.Writer, error) {
+	return z.zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: modTime,
+	})
+}
+
+// Close implements archiveWriter.
+func (z *zipArchiveWriter) Close() error {
+	return z.zw.Close()
+}
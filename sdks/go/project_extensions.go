@@ -0,0 +1,561 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"sort"
+)
+
+// ExtensionKind is the kind of a platform extension.
+type ExtensionKind string
+
+// Extension kinds.
+const (
+	ExtensionKindLinter       ExtensionKind = "linter"
+	ExtensionKindDeployTarget ExtensionKind = "deploy_target"
+	ExtensionKindIntegration  ExtensionKind = "integration"
+)
+
+// Extension setting types, the JSON types of ExtensionSetting.Type.
+const (
+	SettingTypeString  = "string"
+	SettingTypeInteger = "integer"
+	SettingTypeNumber  = "number"
+	SettingTypeBoolean = "boolean"
+	SettingTypeArray   = "array"
+	SettingTypeObject  = "object"
+)
+
+// ExtensionsService manages the platform extensions of projects, such as
+// linters, deploy targets, and integrations: which are enabled and how they
+// are configured.
+type ExtensionsService struct {
+	client *HTTPClient
+}
+
+// Extensions returns the project extensions service.
+func (s *ProjectService) Extensions() *ExtensionsService {
+	return &ExtensionsService{client: s.client}
+}
+
+// Extension is a platform extension that can be enabled on projects.
+type Extension struct {
+	ID          string        `json:"id"`
+	Name        string        `json:"name"`
+	Description string        `json:"description,omitempty"`
+	Kind        ExtensionKind `json:"kind"`
+	Publisher   string        `json:"publisher,omitempty"`
+	Version     string        `json:"version,omitempty"`
+
+	// Schema describes the settings of the extension
+	Schema ExtensionSchema `json:"schema"`
+
+	// Raw is the undecoded extension, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// ExtensionSchema describes the settings of an extension, as a subset of
+// JSON Schema.
+type ExtensionSchema struct {
+	// Properties are the settings by name
+	Properties map[string]ExtensionSetting `json:"properties,omitempty"`
+
+	// Required are the names of the settings that must be set
+	Required []string `json:"required,omitempty"`
+}
+
+// ExtensionSetting describes one setting of an extension.
+type ExtensionSetting struct {
+	// Type is one of the SettingType constants
+	Type        string        `json:"type"`
+	Description string        `json:"description,omitempty"`
+	Default     interface{}   `json:"default,omitempty"`
+	Enum        []interface{} `json:"enum,omitempty"`
+
+	// Secret settings, such as tokens, are returned masked
+	Secret bool `json:"secret,omitempty"`
+}
+
+// ProjectExtension is an extension enabled on a project.
+type ProjectExtension struct {
+	ExtensionID string                 `json:"extension_id"`
+	Enabled     bool                   `json:"enabled"`
+	Settings    map[string]interface{} `json:"settings,omitempty"`
+	UpdatedAt   Timestamp              `json:"updated_at"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// DecodeSettings decodes the settings of the extension into v, typically a
+// struct with json tags matching the extension's schema.
+//
+// Parameters:
+//   - v: Pointer to decode the settings into
+//
+// Returns an error if the settings do not match v.
+func (e *ProjectExtension) DecodeSettings(v interface{}) error {
+	data, err := json.Marshal(e.Settings)
+	if err != nil {
+		return fmt.Errorf("failed to decode settings of extension %s: %w", e.ExtensionID, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode settings of extension %s: %w", e.ExtensionID, err)
+	}
+	return nil
+}
+
+// ExtensionListOptions contains filters for listing extensions.
+type ExtensionListOptions struct {
+	// Kind filters by kind (optional)
+	Kind ExtensionKind
+
+	// Query is free text matched against extension names and descriptions (optional)
+	Query string
+}
+
+// ExtensionConfig is the desired state of an extension on a project, for
+// ExtensionsService.Apply.
+type ExtensionConfig struct {
+	// ExtensionID is the ID of the extension (required)
+	ExtensionID string `json:"extension_id" yaml:"extension_id"`
+
+	// Settings are the settings of the extension: a map or a struct with
+	// json tags (optional)
+	Settings interface{} `json:"settings,omitempty" yaml:"settings,omitempty"`
+}
+
+// ApplyExtensionsOptions contains options for ExtensionsService.Apply.
+type ApplyExtensionsOptions struct {
+	// DryRun computes the changes without making them (default: false)
+	DryRun bool
+}
+
+// ApplyExtensionsResult lists the changes made by ExtensionsService.Apply,
+// by extension ID.
+type ApplyExtensionsResult struct {
+	Enabled    []string
+	Configured []string
+	Disabled   []string
+	Unchanged  []string
+}
+
+// Validate checks settings against the schema, as the API does when they
+// are saved, so that configuration files can be checked before they are
+// applied.
+//
+// Parameters:
+//   - settings: Settings to check: a map or a struct with json tags
+//
+// Returns nil if the settings are valid, or a ValidationError listing each
+// invalid setting in Fields.
+func (s *ExtensionSchema) Validate(settings interface{}) error {
+	values, err := settingsMap(settings)
+	if err != nil {
+		return NewValidationError(err.Error())
+	}
+
+	var fields []FieldError
+	for _, name := range s.Required {
+		if _, ok := values[name]; !ok {
+			fields = append(fields, FieldError{Field: name, Code: "required", Message: "setting is required"})
+		}
+	}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		setting, ok := s.Properties[name]
+		if !ok {
+			fields = append(fields, FieldError{Field: name, Code: "unknown", Message: "unknown setting"})
+			continue
+		}
+		value := values[name]
+		if !hasSettingType(value, setting.Type) {
+			fields = append(fields, FieldError{Field: name, Code: "invalid_type", Message: "must be of type " + setting.Type})
+			continue
+		}
+		if len(setting.Enum) > 0 && !inEnum(value, setting.Enum) {
+			fields = append(fields, FieldError{Field: name, Code: "invalid_value", Message: fmt.Sprintf("must be one of %v", setting.Enum)})
+		}
+	}
+	if len(fields) > 0 {
+		return NewValidationErrorWithFields("invalid extension settings", fields)
+	}
+	return nil
+}
+
+// hasSettingType reports whether a decoded JSON value has a setting type.
+// Unknown types accept any value.
+func hasSettingType(value interface{}, settingType string) bool {
+	switch settingType {
+	case SettingTypeString:
+		_, ok := value.(string)
+		return ok
+	case SettingTypeInteger:
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	case SettingTypeNumber:
+		_, ok := value.(float64)
+		return ok
+	case SettingTypeBoolean:
+		_, ok := value.(bool)
+		return ok
+	case SettingTypeArray:
+		_, ok := value.([]interface{})
+		return ok
+	case SettingTypeObject:
+		_, ok := value.(map[string]interface{})
+		return ok
+	default:
+		return true
+	}
+}
+
+// inEnum reports whether a decoded JSON value is one of the allowed values.
+func inEnum(value interface{}, enum []interface{}) bool {
+	for _, allowed := range enum {
+		if reflect.DeepEqual(value, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// settingsMap converts settings given as a map or struct to decoded JSON.
+func settingsMap(settings interface{}) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	if settings == nil {
+		return values, nil
+	}
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode settings: %w", err)
+	}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("settings must be an object: %w", err)
+	}
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	return values, nil
+}
+
+// List lists the platform extensions that can be enabled on projects.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - options: List filters (can be nil to list all extensions)
+//   - opts: Request options (optional)
+//
+// Returns the extensions or an error if the request fails.
+func (s *ExtensionsService) List(ctx context.Context, options *ExtensionListOptions, opts ...RequestOption) ([]Extension, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if options == nil {
+		options = &ExtensionListOptions{}
+	}
+
+	query := NewQuery()
+	if options.Kind != "" {
+		query.Set("kind", string(options.Kind))
+	}
+	if options.Query != "" {
+		query.Set("q", options.Query)
+	}
+
+	var response struct {
+		Extensions []json.RawMessage `json:"extensions"`
+	}
+	if err := s.client.GetQuery(ctx, "/extensions", query, &response); err != nil {
+		return nil, fmt.Errorf("failed to list extensions: %w", err)
+	}
+
+	extensions := make([]Extension, len(response.Extensions))
+	for i, raw := range response.Extensions {
+		if err := decodeTyped(raw, &extensions[i], &extensions[i].Raw); err != nil {
+			return nil, fmt.Errorf("failed to list extensions: %w", err)
+		}
+	}
+	return extensions, nil
+}
+
+// Get gets a platform extension, including the schema of its settings.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - extensionID: ID of the extension
+//   - opts: Request options (optional)
+//
+// Returns the extension or an error if the request fails.
+func (s *ExtensionsService) Get(ctx context.Context, extensionID string, opts ...RequestOption) (*Extension, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if extensionID == "" {
+		return nil, NewValidationError("extension ID is required")
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Get(ctx, fmt.Sprintf("/extensions/%s", url.PathEscape(extensionID)), nil, &raw); err != nil {
+		return nil, fmt.Errorf("failed to get extension %s: %w", extensionID, err)
+	}
+
+	var extension Extension
+	if err := decodeTyped(raw, &extension, &extension.Raw); err != nil {
+		return nil, fmt.Errorf("failed to get extension %s: %w", extensionID, err)
+	}
+	return &extension, nil
+}
+
+// ListEnabled lists the extensions enabled on a project.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - opts: Request options (optional)
+//
+// Returns the project's extensions or an error if the request fails.
+func (s *ExtensionsService) ListEnabled(ctx context.Context, projectID string, opts ...RequestOption) ([]ProjectExtension, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+
+	var response struct {
+		Extensions []json.RawMessage `json:"extensions"`
+	}
+	if err := s.client.Get(ctx, fmt.Sprintf("/projects/%s/extensions", url.PathEscape(projectID)), nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to list extensions of project %s: %w", projectID, err)
+	}
+
+	extensions := make([]ProjectExtension, len(response.Extensions))
+	for i, raw := range response.Extensions {
+		if err := decodeTyped(raw, &extensions[i], &extensions[i].Raw); err != nil {
+			return nil, fmt.Errorf("failed to list extensions of project %s: %w", projectID, err)
+		}
+	}
+	return extensions, nil
+}
+
+// Enable enables an extension on a project. Enabling an extension that is
+// already enabled replaces its settings.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - extensionID: ID of the extension
+//   - settings: Settings of the extension: a map or a struct with json tags
+//     (can be nil for the defaults)
+//   - opts: Request options (optional)
+//
+// Returns the project's extension, or an error if the request fails; invalid
+// settings fail with a ValidationError listing them in Fields.
+//
+// Example usage:
+//
+//	type eslintSettings struct {
+//	    Config   string `json:"config"`
+//	    MaxWarns int    `json:"max_warnings"`
+//	}
+//	_, err := client.Projects.Extensions().Enable(ctx, projectID, "eslint",
+//	    eslintSettings{Config: ".eslintrc.json", MaxWarns: 0})
+func (s *ExtensionsService) Enable(ctx context.Context, projectID, extensionID string, settings interface{}, opts ...RequestOption) (*ProjectExtension, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+	if extensionID == "" {
+		return nil, NewValidationError("extension ID is required")
+	}
+
+	data := map[string]interface{}{"enabled": true}
+	if settings != nil {
+		data["settings"] = settings
+	}
+	extension, err := s.put(ctx, projectID, extensionID, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enable extension %s: %w", extensionID, err)
+	}
+	return extension, nil
+}
+
+// Configure replaces the settings of an extension enabled on a project.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - extensionID: ID of the extension
+//   - settings: Settings of the extension: a map or a struct with json tags
+//   - opts: Request options (optional)
+//
+// Returns the project's extension, or an error if the request fails; invalid
+// settings fail with a ValidationError listing them in Fields.
+func (s *ExtensionsService) Configure(ctx context.Context, projectID, extensionID string, settings interface{}, opts ...RequestOption) (*ProjectExtension, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+	if extensionID == "" {
+		return nil, NewValidationError("extension ID is required")
+	}
+	if settings == nil {
+		return nil, NewValidationError("settings are required")
+	}
+
+	extension, err := s.put(ctx, projectID, extensionID, map[string]interface{}{"settings": settings})
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure extension %s: %w", extensionID, err)
+	}
+	return extension, nil
+}
+
+// Disable disables an extension on a project.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - extensionID: ID of the extension
+//   - opts: Request options (optional)
+//
+// Returns an error if the request fails.
+func (s *ExtensionsService) Disable(ctx context.Context, projectID, extensionID string, opts ...RequestOption) error {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return NewValidationError("project ID is required")
+	}
+	if extensionID == "" {
+		return NewValidationError("extension ID is required")
+	}
+
+	endpoint := fmt.Sprintf("/projects/%s/extensions/%s", url.PathEscape(projectID), url.PathEscape(extensionID))
+	if err := s.client.Delete(ctx, endpoint, nil); err != nil {
+		return fmt.Errorf("failed to disable extension %s: %w", extensionID, err)
+	}
+	return nil
+}
+
+// Apply makes the extensions of a project match a declared configuration,
+// for keeping project tooling as code: extensions in configs are enabled or
+// reconfigured as needed, and the others are disabled. Settings are
+// compared after JSON encoding; secret settings are returned masked and so
+// are always reconfigured.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - configs: Desired extensions of the project
+//   - options: Apply options (can be nil for defaults)
+//   - opts: Request options (optional)
+//
+// Returns the changes, or an error if a request fails; changes made before
+// the failure are kept and reported in the result.
+//
+// Example usage:
+//
+//	var configs []zoptal.ExtensionConfig
+//	if err := yaml.Unmarshal(data, &configs); err != nil {
+//	    return err
+//	}
+//	result, err := client.Projects.Extensions().Apply(ctx, projectID, configs, nil)
+func (s *ExtensionsService) Apply(ctx context.Context, projectID string, configs []ExtensionConfig, options *ApplyExtensionsOptions, opts ...RequestOption) (*ApplyExtensionsResult, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+	if options == nil {
+		options = &ApplyExtensionsOptions{}
+	}
+
+	desired := make(map[string]map[string]interface{}, len(configs))
+	for _, config := range configs {
+		if config.ExtensionID == "" {
+			return nil, NewValidationError("extension ID is required")
+		}
+		if _, ok := desired[config.ExtensionID]; ok {
+			return nil, NewValidationError(fmt.Sprintf("extension %s is configured more than once", config.ExtensionID))
+		}
+		settings, err := settingsMap(config.Settings)
+		if err != nil {
+			return nil, NewValidationError(fmt.Sprintf("invalid settings of extension %s: %v", config.ExtensionID, err))
+		}
+		desired[config.ExtensionID] = settings
+	}
+
+	current, err := s.ListEnabled(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	enabled := make(map[string]ProjectExtension, len(current))
+	for _, extension := range current {
+		if extension.Enabled {
+			enabled[extension.ExtensionID] = extension
+		}
+	}
+
+	result := &ApplyExtensionsResult{}
+	for _, config := range configs {
+		settings := desired[config.ExtensionID]
+		existing, ok := enabled[config.ExtensionID]
+		switch {
+		case !ok:
+			if !options.DryRun {
+				if _, err := s.Enable(ctx, projectID, config.ExtensionID, settings); err != nil {
+					return result, err
+				}
+			}
+			result.Enabled = append(result.Enabled, config.ExtensionID)
+		case !sameSettings(existing.Settings, settings):
+			if !options.DryRun {
+				if _, err := s.Configure(ctx, projectID, config.ExtensionID, settings); err != nil {
+					return result, err
+				}
+			}
+			result.Configured = append(result.Configured, config.ExtensionID)
+		default:
+			result.Unchanged = append(result.Unchanged, config.ExtensionID)
+		}
+	}
+	for _, extension := range current {
+		if _, ok := desired[extension.ExtensionID]; ok || !extension.Enabled {
+			continue
+		}
+		if !options.DryRun {
+			if err := s.Disable(ctx, projectID, extension.ExtensionID); err != nil {
+				return result, err
+			}
+		}
+		result.Disabled = append(result.Disabled, extension.ExtensionID)
+	}
+	return result, nil
+}
+
+// sameSettings reports whether two settings maps are equal, treating nil and
+// empty as equal.
+func sameSettings(a, b map[string]interface{}) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// put updates the extension of a project.
+func (s *ExtensionsService) put(ctx context.Context, projectID, extensionID string, data map[string]interface{}) (*ProjectExtension, error) {
+	endpoint := fmt.Sprintf("/projects/%s/extensions/%s", url.PathEscape(projectID), url.PathEscape(extensionID))
+	var raw json.RawMessage
+	if err := s.client.Put(ctx, endpoint, data, &raw); err != nil {
+		return nil, err
+	}
+
+	var extension ProjectExtension
+	if err := decodeTyped(raw, &extension, &extension.Raw); err != nil {
+		return nil, err
+	}
+	if extension.ExtensionID == "" {
+		extension.ExtensionID = extensionID
+	}
+	return &extension, nil
+}
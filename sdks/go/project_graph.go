@@ -0,0 +1,337 @@
+package zoptal
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// DependencyKind is a kind of dependency between projects.
+type DependencyKind string
+
+// Dependency kinds.
+const (
+	// DependencySharedLibrary means the project uses a library published by
+	// the other project
+	DependencySharedLibrary DependencyKind = "shared_library"
+
+	// DependencyTemplate means the project was created from a template
+	// published from the other project
+	DependencyTemplate DependencyKind = "template"
+
+	// DependencyDeploy means the project must be deployed after the other
+	// project, e.g. a frontend after its API
+	DependencyDeploy DependencyKind = "deploy"
+)
+
+// GraphFormat is the format of an exported project graph.
+type GraphFormat string
+
+// Supported graph formats.
+const (
+	// GraphDOT is the Graphviz DOT language, for rendering with dot(1)
+	GraphDOT GraphFormat = "dot"
+
+	// GraphJSON is a JSON object with "nodes" and "edges" arrays
+	GraphJSON GraphFormat = "json"
+)
+
+// dotEdgeStyles are the DOT styles of the edges of each dependency kind.
+var dotEdgeStyles = map[DependencyKind]string{
+	DependencyTemplate: "dashed",
+	DependencyDeploy:   "bold",
+}
+
+// GraphRequest selects the part of the project dependency graph to get.
+type GraphRequest struct {
+	// Scope is the ID of the project to center the graph on (default: all
+	// projects of the workspace)
+	Scope string
+
+	// Depth limits a graph with a Scope to the projects at most this many
+	// dependencies away from it, in either direction (default: unlimited)
+	Depth int
+
+	// Kinds restricts the graph to these kinds of dependencies (default: all)
+	Kinds []DependencyKind
+}
+
+// GraphNode is a project in a dependency graph.
+type GraphNode struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+
+	// Depth is the number of dependencies between the project and the scope
+	// of the graph, or 0 for a graph of the whole workspace
+	Depth int `json:"depth"`
+}
+
+// GraphEdge is a dependency of one project on another.
+type GraphEdge struct {
+	// From is the ID of the dependent project, and To the ID of the project
+	// it depends on
+	From string `json:"from"`
+	To   string `json:"to"`
+
+	Kind DependencyKind `json:"kind"`
+
+	// Label names what the dependency is on, e.g. "auth-lib@2.3.0" for a
+	// shared library, or the name of a template
+	Label string `json:"label,omitempty"`
+}
+
+// ProjectGraph is a graph of the dependencies between projects.
+type ProjectGraph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// Node returns the node of a project, or nil if the project is not in the
+// graph.
+func (g *ProjectGraph) Node(projectID string) *GraphNode {
+	for i := range g.Nodes {
+		if g.Nodes[i].ID == projectID {
+			return &g.Nodes[i]
+		}
+	}
+	return nil
+}
+
+// DependenciesOf returns the dependencies of a project: the edges from it.
+func (g *ProjectGraph) DependenciesOf(projectID string) []GraphEdge {
+	var edges []GraphEdge
+	for _, edge := range g.Edges {
+		if edge.From == projectID {
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
+
+// DependentsOf returns the dependents of a project: the edges to it.
+func (g *ProjectGraph) DependentsOf(projectID string) []GraphEdge {
+	var edges []GraphEdge
+	for _, edge := range g.Edges {
+		if edge.To == projectID {
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
+
+// Cycles returns the groups of projects that depend on each other, directly
+// or indirectly, such as two services each deploying after the other. Each
+// group lists its project IDs in sorted order, and the groups are sorted by
+// their first ID. A graph that satisfies layering constraints has none.
+func (g *ProjectGraph) Cycles() [][]string {
+	successors := make(map[string][]string)
+	selfLoops := make(map[string]bool)
+	for _, edge := range g.Edges {
+		successors[edge.From] = append(successors[edge.From], edge.To)
+		if edge.From == edge.To {
+			selfLoops[edge.From] = true
+		}
+	}
+
+	// Tarjan's algorithm: each strongly connected component with more than
+	// one project, or with a project depending on itself, is a cycle.
+	var (
+		index    = make(map[string]int)
+		lowlink  = make(map[string]int)
+		onStack  = make(map[string]bool)
+		stack    []string
+		cycles   [][]string
+		visit    func(id string)
+		nextIdx  int
+		projects = g.projectIDs()
+	)
+	visit = func(id string) {
+		index[id], lowlink[id] = nextIdx, nextIdx
+		nextIdx++
+		stack = append(stack, id)
+		onStack[id] = true
+
+		for _, next := range successors[id] {
+			if _, seen := index[next]; !seen {
+				visit(next)
+				if lowlink[next] < lowlink[id] {
+					lowlink[id] = lowlink[next]
+				}
+			} else if onStack[next] && index[next] < lowlink[id] {
+				lowlink[id] = index[next]
+			}
+		}
+
+		if lowlink[id] != index[id] {
+			return
+		}
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == id {
+				break
+			}
+		}
+		if len(component) > 1 || selfLoops[id] {
+			sort.Strings(component)
+			cycles = append(cycles, component)
+		}
+	}
+	for _, id := range projects {
+		if _, seen := index[id]; !seen {
+			visit(id)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+// Export writes the graph to w.
+//
+// Parameters:
+//   - w: Destination for the graph
+//   - format: Graph format (GraphDOT or GraphJSON)
+//
+// Returns an error if the format is not supported or writing fails.
+//
+// Example usage:
+//
+//	// Render with: dot -Tsvg projects.dot -o projects.svg
+//	f, err := os.Create("projects.dot")
+//	if err != nil {
+//	    return err
+//	}
+//	defer f.Close()
+//	err = graph.Export(f, zoptal.GraphDOT)
+func (g *ProjectGraph) Export(w io.Writer, format GraphFormat) error {
+	switch format {
+	case GraphDOT:
+		return g.writeDOT(w)
+	case GraphJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(struct {
+			Nodes []GraphNode `json:"nodes"`
+			Edges []GraphEdge `json:"edges"`
+		}{g.Nodes, g.Edges})
+	default:
+		return NewValidationError(fmt.Sprintf("unsupported graph format %q", format))
+	}
+}
+
+// writeDOT writes the graph in the Graphviz DOT language.
+func (g *ProjectGraph) writeDOT(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	b.WriteString("digraph projects {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+	for _, node := range g.Nodes {
+		label := node.Name
+		if label == "" {
+			label = node.ID
+		}
+		fmt.Fprintf(b, "  %s [label=%s];\n", dotQuote(node.ID), dotQuote(label))
+	}
+	for _, edge := range g.Edges {
+		label := string(edge.Kind)
+		if edge.Label != "" {
+			label += ": " + edge.Label
+		}
+		fmt.Fprintf(b, "  %s -> %s [label=%s", dotQuote(edge.From), dotQuote(edge.To), dotQuote(label))
+		if style := dotEdgeStyles[edge.Kind]; style != "" {
+			fmt.Fprintf(b, ", style=%s", style)
+		}
+		b.WriteString("];\n")
+	}
+	b.WriteString("}\n")
+	return b.Flush()
+}
+
+// projectIDs returns the IDs of the projects of the graph, including those
+// only named by edges, in sorted order.
+func (g *ProjectGraph) projectIDs() []string {
+	seen := make(map[string]bool)
+	for _, node := range g.Nodes {
+		seen[node.ID] = true
+	}
+	for _, edge := range g.Edges {
+		seen[edge.From] = true
+		seen[edge.To] = true
+	}
+	ids := make([]string, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// dotQuote quotes a string as a DOT ID.
+func dotQuote(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+	return `"` + s + `"`
+}
+
+// Graph gets the graph of the dependencies between projects, such as shared
+// libraries, template lineage, and deploy order, for visualizing the
+// architecture of a workspace or checking it against constraints.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - request: Part of the graph to get (can be nil for the whole workspace)
+//   - opts: Request options (optional)
+//
+// Returns the graph or an error if the request fails.
+//
+// Example usage:
+//
+//	graph, err := client.Projects.Graph(ctx, &zoptal.GraphRequest{
+//	    Kinds: []zoptal.DependencyKind{zoptal.DependencyDeploy},
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	if cycles := graph.Cycles(); len(cycles) > 0 {
+//	    return fmt.Errorf("deploy order has cycles: %v", cycles)
+//	}
+func (s *ProjectService) Graph(ctx context.Context, request *GraphRequest, opts ...RequestOption) (*ProjectGraph, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if request == nil {
+		request = &GraphRequest{}
+	}
+	if request.Depth < 0 {
+		return nil, NewValidationError("depth must not be negative")
+	}
+
+	query := NewQuery()
+	if request.Scope != "" {
+		query.Set("scope", request.Scope)
+		if request.Depth > 0 {
+			query.SetInt("depth", request.Depth)
+		}
+	}
+	for _, kind := range request.Kinds {
+		query.Add("kind", string(kind))
+	}
+
+	var raw json.RawMessage
+	if err := s.client.GetQuery(ctx, "/projects/graph", query, &raw); err != nil {
+		return nil, fmt.Errorf("failed to get project graph: %w", err)
+	}
+	var graph ProjectGraph
+	if err := decodeTyped(raw, &graph, &graph.Raw); err != nil {
+		return nil, fmt.Errorf("failed to get project graph: %w", err)
+	}
+	return &graph, nil
+}
@@ -0,0 +1,365 @@
+package zoptal
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+// ImportOptions contains options for importing a project archive.
+type ImportOptions struct {
+	// Name is the name of the new project (default: the name recorded in
+	// the archive, or "Imported project" for plain archives)
+	Name string
+
+	// Description is the description of the new project (default: the
+	// description recorded in the archive)
+	Description string
+
+	// Visibility is the visibility of the new project: "private", "public",
+	// or "team" (default: the visibility recorded in the archive, or "private")
+	Visibility string
+}
+
+// ImportResult summarizes an imported project archive.
+type ImportResult struct {
+	// ProjectID is the ID of the created project
+	ProjectID string
+
+	// Imported lists the paths of the files that were imported
+	Imported []string
+
+	// Failures lists the files that could not be imported
+	Failures []ImportFailure
+
+	// Bytes is the total size of the imported files
+	Bytes int64
+}
+
+// ImportFailure is a file that could not be imported.
+type ImportFailure struct {
+	Path string
+	Err  error
+}
+
+// Error implements the error interface.
+func (f ImportFailure) Error() string {
+	return fmt.Sprintf("%s: %v", f.Path, f.Err)
+}
+
+// archivedProject is the subset of the archived project metadata used to
+// recreate it.
+type archivedProject struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Template    string                 `json:"template"`
+	Visibility  string                 `json:"visibility"`
+	Settings    map[string]interface{} `json:"settings,omitempty"`
+}
+
+// Import creates a new project from an archive.
+//
+// The archive may be one written by Projects.Export, in either format, or a
+// plain zip or tar.gz of a source tree. For archives written by Export the
+// project's name, description, template, and settings are restored and each
+// file is verified against its recorded SHA-256. For plain zip archives a
+// single top-level directory shared by all files is stripped.
+//
+// Files that cannot be imported do not stop the import; they are reported
+// in ImportResult.Failures.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - r: Archive content
+//   - options: Import options (can be nil for defaults)
+//   - opts: Request options (optional)
+//
+// Returns a summary of the import or an error if the archive cannot be read
+// or the project cannot be created. If reading fails after the project was
+// created, the partial summary is returned along with the error.
+func (s *ProjectService) Import(ctx context.Context, r io.Reader, options *ImportOptions, opts ...RequestOption) (*ImportResult, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if r == nil {
+		return nil, NewValidationError("archive is required")
+	}
+	if options == nil {
+		options = &ImportOptions{}
+	}
+	if options.Visibility != "" && options.Visibility != "private" && options.Visibility != "public" && options.Visibility != "team" {
+		return nil, NewValidationError("visibility must be 'private', 'public', or 'team'")
+	}
+
+	br := bufio.NewReader(r)
+	magic, _ := br.Peek(4)
+	switch {
+	case bytes.HasPrefix(magic, []byte{0x1f, 0x8b}):
+		return s.importTarGz(ctx, br, options)
+	case bytes.HasPrefix(magic, []byte("PK\x03\x04")):
+		return s.importZip(ctx, br, options)
+	default:
+		return nil, NewValidationError("archive must be a zip or tar.gz file")
+	}
+}
+
+// importTarGz imports a gzip-compressed tar archive, streaming each entry.
+func (s *ProjectService) importTarGz(ctx context.Context, r io.Reader, options *ImportOptions) (*ImportResult, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, NewValidationError(fmt.Sprintf("invalid tar.gz archive: %v", err))
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+
+	var imp *archiveImport
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return imp.partial(fmt.Errorf("failed to read archive: %w", err))
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		// Archives written by Export start with the manifest.
+		if imp == nil {
+			var manifest *ArchiveManifest
+			if path.Clean(header.Name) == archiveManifestName {
+				manifest, err = readArchiveManifest(tr)
+				if err != nil {
+					return nil, err
+				}
+			}
+			imp, err = s.startImport(ctx, manifest, options)
+			if err != nil {
+				return nil, err
+			}
+			if manifest != nil {
+				continue
+			}
+		}
+
+		if err := imp.add(ctx, imp.entryPath(header.Name), tr); err != nil {
+			return imp.partial(err)
+		}
+	}
+
+	if imp == nil {
+		if imp, err = s.startImport(ctx, nil, options); err != nil {
+			return nil, err
+		}
+	}
+	return imp.result, nil
+}
+
+// importZip imports a zip archive. The archive is spooled to a temporary
+// file because zip archives cannot be read sequentially.
+func (s *ProjectService) importZip(ctx context.Context, r io.Reader, options *ImportOptions) (*ImportResult, error) {
+	tmp, err := os.CreateTemp("", "zoptal-import-*.zip")
+	if err != nil {
+		return nil, fmt.Errorf("failed to buffer archive: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	size, err := io.Copy(tmp, r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to buffer archive: %w", err)
+	}
+	zr, err := zip.NewReader(tmp, size)
+	if err != nil {
+		return nil, NewValidationError(fmt.Sprintf("invalid zip archive: %v", err))
+	}
+
+	var manifest *ArchiveManifest
+	var entries []*zip.File
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !f.Mode().IsRegular() {
+			continue
+		}
+		if path.Clean(f.Name) == archiveManifestName {
+			rc, err := f.Open()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read archive: %w", err)
+			}
+			manifest, err = readArchiveManifest(rc)
+			rc.Close()
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		entries = append(entries, f)
+	}
+
+	imp, err := s.startImport(ctx, manifest, options)
+	if err != nil {
+		return nil, err
+	}
+	if manifest == nil {
+		names := make([]string, len(entries))
+		for i, f := range entries {
+			names[i] = f.Name
+		}
+		imp.stripPrefix = commonTopLevelDir(names)
+	}
+
+	for _, f := range entries {
+		name := imp.entryPath(f.Name)
+		rc, err := f.Open()
+		if err != nil {
+			imp.fail(name, err)
+			continue
+		}
+		err = imp.add(ctx, name, rc)
+		rc.Close()
+		if err != nil {
+			return imp.partial(err)
+		}
+	}
+	return imp.result, nil
+}
+
+// archiveImport tracks an import in progress.
+type archiveImport struct {
+	files       *FileService
+	result      *ImportResult
+	hashes      map[string]string
+	fromExport  bool
+	stripPrefix string
+}
+
+// startImport creates the project an archive is imported into.
+func (s *ProjectService) startImport(ctx context.Context, manifest *ArchiveManifest, options *ImportOptions) (*archiveImport, error) {
+	project := archivedProject{Name: "Imported project", Template: "blank", Visibility: "private"}
+	imp := &archiveImport{
+		files:  &FileService{client: s.client},
+		result: &ImportResult{},
+		hashes: make(map[string]string),
+	}
+	if manifest != nil {
+		if len(manifest.Project) > 0 {
+			if err := json.Unmarshal(manifest.Project, &project); err != nil {
+				return nil, NewValidationError(fmt.Sprintf("invalid archive manifest: %v", err))
+			}
+		}
+		for _, file := range manifest.Files {
+			imp.hashes[strings.TrimPrefix(path.Clean("/"+file.Path), "/")] = file.ContentSHA256()
+		}
+		imp.fromExport = true
+	}
+	if options.Name != "" {
+		project.Name = options.Name
+	}
+	if options.Description != "" {
+		project.Description = options.Description
+	}
+	if options.Visibility != "" {
+		project.Visibility = options.Visibility
+	}
+	if strings.TrimSpace(project.Name) == "" {
+		return nil, NewValidationError("project name is required")
+	}
+	project.Name = strings.TrimSpace(project.Name)
+
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := s.client.Post(ctx, "/projects", project, &created); err != nil {
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+	if created.ID == "" {
+		return nil, NewProjectError("failed to create project: response did not include a project ID")
+	}
+	imp.result.ProjectID = created.ID
+	return imp, nil
+}
+
+// entryPath returns the project path for an archive entry name.
+func (i *archiveImport) entryPath(name string) string {
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+	if i.fromExport {
+		return strings.TrimPrefix(name, archiveFilesDir)
+	}
+	return strings.TrimPrefix(name, i.stripPrefix)
+}
+
+// add uploads a single file. Failures of the file are recorded in the
+// result; only context errors are returned.
+func (i *archiveImport) add(ctx context.Context, name string, r io.Reader) error {
+	if name == "" {
+		return nil
+	}
+
+	uploaded, err := i.files.Upload(ctx, i.result.ProjectID, name, r, &UploadOptions{Overwrite: true})
+	if err != nil {
+		if ctx.Err() != nil {
+			return canceled(ctx)
+		}
+		i.fail(name, err)
+		return nil
+	}
+	if err := compareChecksum(i.hashes[name], uploaded.ContentSHA256); err != nil {
+		i.fail(name, err)
+		return nil
+	}
+
+	i.result.Imported = append(i.result.Imported, name)
+	i.result.Bytes += uploaded.BytesSent
+	return nil
+}
+
+// fail records a file that could not be imported.
+func (i *archiveImport) fail(name string, err error) {
+	i.result.Failures = append(i.result.Failures, ImportFailure{Path: name, Err: err})
+}
+
+// partial returns the result so far, if the project was created, with err.
+func (i *archiveImport) partial(err error) (*ImportResult, error) {
+	if i == nil {
+		return nil, err
+	}
+	return i.result, err
+}
+
+// readArchiveManifest decodes an archive manifest.
+func readArchiveManifest(r io.Reader) (*ArchiveManifest, error) {
+	var manifest ArchiveManifest
+	if err := json.NewDecoder(r).Decode(&manifest); err != nil {
+		return nil, NewValidationError(fmt.Sprintf("invalid archive manifest: %v", err))
+	}
+	if manifest.Version > archiveManifestVersion {
+		return nil, NewValidationError(fmt.Sprintf("unsupported archive manifest version %d", manifest.Version))
+	}
+	return &manifest, nil
+}
+
+// commonTopLevelDir returns the top-level directory, with a trailing slash,
+// shared by all names, or "" if there is none.
+func commonTopLevelDir(names []string) string {
+	prefix := ""
+	for _, name := range names {
+		name = strings.TrimPrefix(path.Clean("/"+name), "/")
+		dir, _, ok := strings.Cut(name, "/")
+		if !ok {
+			return ""
+		}
+		if prefix == "" {
+			prefix = dir + "/"
+		} else if prefix != dir+"/" {
+			return ""
+		}
+	}
+	return prefix
+}
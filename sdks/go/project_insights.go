@@ -0,0 +1,112 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// InsightsPeriod is the time window of a project insights report.
+type InsightsPeriod string
+
+// Insights periods.
+const (
+	InsightsPeriodWeek    InsightsPeriod = "7d"
+	InsightsPeriodMonth   InsightsPeriod = "30d"
+	InsightsPeriodQuarter InsightsPeriod = "90d"
+	InsightsPeriodYear    InsightsPeriod = "365d"
+)
+
+// InsightsService provides analyses of a project's history, built from the
+// data Zoptal already collects for its code analysis.
+type InsightsService struct {
+	client *HTTPClient
+}
+
+// Insights returns the project insights service.
+func (s *ProjectService) Insights() *InsightsService {
+	return &InsightsService{client: s.client}
+}
+
+// Hotspot is a file that changes often and is hard to change: a candidate
+// for refactoring.
+type Hotspot struct {
+	Path string `json:"path"`
+
+	// Score ranks the hotspot: change frequency multiplied by complexity,
+	// normalized to the range [0, 1] within the report
+	Score float64 `json:"score"`
+
+	// Changes is the number of commits that changed the file in the period
+	Changes int `json:"changes"`
+
+	// Authors is the number of people who changed the file in the period
+	Authors int `json:"authors"`
+
+	// Complexity is the cyclomatic complexity of the file at the end of the period
+	Complexity float64 `json:"complexity"`
+
+	// LinesAdded and LinesDeleted are the lines churned in the period
+	LinesAdded   int `json:"lines_added"`
+	LinesDeleted int `json:"lines_deleted"`
+
+	LastChanged Timestamp `json:"last_changed"`
+
+	// Trend is "rising", "falling", or "stable", comparing the second half
+	// of the period with the first
+	Trend string `json:"trend"`
+
+	// History breaks the period down into intervals, oldest first
+	History []HotspotInterval `json:"history"`
+}
+
+// HotspotInterval is the activity on a hotspot during one interval of a report.
+type HotspotInterval struct {
+	Start      Timestamp `json:"start"`
+	Changes    int       `json:"changes"`
+	Complexity float64   `json:"complexity"`
+}
+
+// HotspotReport ranks the hotspots of a project, highest score first.
+type HotspotReport struct {
+	ProjectID   string         `json:"project_id"`
+	Period      InsightsPeriod `json:"period"`
+	Hotspots    []Hotspot      `json:"hotspots"`
+	GeneratedAt Timestamp      `json:"generated_at"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// Hotspots ranks the files of a project by change frequency multiplied by
+// complexity over a period, with their trend, to show where refactoring
+// pays off most.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - period: Time window of the analysis (default: InsightsPeriodQuarter)
+//   - opts: Request options (optional)
+//
+// Returns the hotspot report or an error if the request fails.
+func (s *InsightsService) Hotspots(ctx context.Context, projectID string, period InsightsPeriod, opts ...RequestOption) (*HotspotReport, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+	if period == "" {
+		period = InsightsPeriodQuarter
+	}
+
+	query := NewQuery().Set("period", string(period))
+	var raw json.RawMessage
+	if err := s.client.GetQuery(ctx, fmt.Sprintf("/projects/%s/insights/hotspots", projectID), query, &raw); err != nil {
+		return nil, fmt.Errorf("failed to get hotspots: %w", err)
+	}
+
+	var report HotspotReport
+	if err := decodeTyped(raw, &report, &report.Raw); err != nil {
+		return nil, fmt.Errorf("failed to get hotspots: %w", err)
+	}
+	return &report, nil
+}
@@ -0,0 +1,172 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ProjectService handles projects: creating, listing, and deleting them,
+// and operations on a project as a whole, such as cloning and exporting.
+type ProjectService struct {
+	client *HTTPClient
+}
+
+// ProjectCreateRequest contains the fields of a new project.
+type ProjectCreateRequest struct {
+	// Name is the name of the project (required)
+	Name string `json:"name"`
+
+	// Template is the ID of the template the project is created from (optional)
+	Template string `json:"template,omitempty"`
+
+	// Description is the description of the project (optional)
+	Description string `json:"description,omitempty"`
+
+	// Visibility is "private", "public", or "team" (default: "private")
+	Visibility string `json:"visibility,omitempty"`
+}
+
+// ProjectListOptions contains options for listing projects.
+type ProjectListOptions struct {
+	// Search filters to projects whose name or description contains the text (optional)
+	Search string
+
+	// Status filters by status, e.g. "ready" (optional)
+	Status string
+
+	// Pagination selects the page of results (optional)
+	Pagination *Pagination
+}
+
+// ProjectList is a page of projects.
+type ProjectList struct {
+	Projects []Project `json:"-"`
+	Total    int       `json:"total"`
+	Page     int       `json:"page"`
+	Pages    int       `json:"pages"`
+}
+
+// Get gets a project.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - opts: Request options (optional)
+//
+// Returns the project or an error if the request fails.
+func (s *ProjectService) Get(ctx context.Context, projectID string, opts ...RequestOption) (*Project, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Get(ctx, fmt.Sprintf("/projects/%s", projectID), nil, &raw); err != nil {
+		return nil, fmt.Errorf("failed to get project %s: %w", projectID, err)
+	}
+	project, err := decodeProject(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project %s: %w", projectID, err)
+	}
+	return project, nil
+}
+
+// List lists the projects the client can access.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - options: List options (can be nil for defaults)
+//   - opts: Request options (optional)
+//
+// Returns a page of projects or an error if the request fails. Use
+// Paginate to iterate over all of them.
+func (s *ProjectService) List(ctx context.Context, options *ProjectListOptions, opts ...RequestOption) (*ProjectList, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if options == nil {
+		options = &ProjectListOptions{}
+	}
+
+	query := NewQuery()
+	if options.Search != "" {
+		query.Set("search", options.Search)
+	}
+	if options.Status != "" {
+		query.Set("status", options.Status)
+	}
+	options.Pagination.apply(query)
+
+	var response struct {
+		ProjectList
+		Projects []json.RawMessage `json:"projects"`
+	}
+	if err := s.client.GetQuery(ctx, "/projects", query, &response); err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	result := response.ProjectList
+	for _, raw := range response.Projects {
+		project, err := decodeProject(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list projects: %w", err)
+		}
+		result.Projects = append(result.Projects, *project)
+	}
+	return &result, nil
+}
+
+// Create creates a project.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - request: The project to create
+//   - opts: Request options (optional)
+//
+// Returns the created project, which may still be provisioning (see
+// WaitUntilReady), or an error if the request fails.
+func (s *ProjectService) Create(ctx context.Context, request *ProjectCreateRequest, opts ...RequestOption) (*Project, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if request == nil || strings.TrimSpace(request.Name) == "" {
+		return nil, NewValidationError("project name is required")
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Post(ctx, "/projects", request, &raw); err != nil {
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+	project, err := decodeProject(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+	return project, nil
+}
+
+// Delete deletes a project and its files.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - opts: Request options (optional)
+//
+// Returns an error if the request fails.
+func (s *ProjectService) Delete(ctx context.Context, projectID string, opts ...RequestOption) error {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return NewValidationError("project ID is required")
+	}
+
+	if err := s.client.Delete(ctx, fmt.Sprintf("/projects/%s", projectID), nil); err != nil {
+		return fmt.Errorf("failed to delete project %s: %w", projectID, err)
+	}
+	return nil
+}
+
+// decodeProject decodes a project response.
+func decodeProject(raw []byte) (*Project, error) {
+	var project Project
+	if err := decodeTyped(raw, &project, &project.Raw); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
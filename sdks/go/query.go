@@ -0,0 +1,204 @@
+package zoptal
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query builds the query parameters of a request.
+//
+// Unlike a map[string]string, a Query can repeat keys (tag=a&tag=b), encodes
+// times as RFC 3339 in UTC, and supports the nested filter syntax used by
+// list endpoints (filter[status][eq]=active). The zero value is an empty
+// query, and all methods can be chained:
+//
+//	query := zoptal.NewQuery().
+//	    Add("tag", "go", "cli").
+//	    Filter("status", "eq", "active").
+//	    TimeRange("created_at", since, time.Time{})
+type Query struct {
+	values url.Values
+}
+
+// NewQuery creates an empty query.
+func NewQuery() *Query {
+	return &Query{}
+}
+
+// queryFromMap creates a query from single-valued parameters.
+func queryFromMap(params map[string]string) *Query {
+	q := NewQuery()
+	for key, value := range params {
+		q.Set(key, value)
+	}
+	return q
+}
+
+// Set sets a parameter, replacing any existing values.
+func (q *Query) Set(key, value string) *Query {
+	q.init()
+	q.values.Set(key, value)
+	return q
+}
+
+// Add adds values to a parameter, repeating the key for each value.
+func (q *Query) Add(key string, values ...string) *Query {
+	q.init()
+	for _, value := range values {
+		q.values.Add(key, value)
+	}
+	return q
+}
+
+// SetInt sets an integer parameter.
+func (q *Query) SetInt(key string, value int) *Query {
+	return q.Set(key, strconv.Itoa(value))
+}
+
+// SetBool sets a boolean parameter to "true" or "false".
+func (q *Query) SetBool(key string, value bool) *Query {
+	return q.Set(key, strconv.FormatBool(value))
+}
+
+// SetTime sets a time parameter, encoded as RFC 3339 in UTC. A zero time
+// is skipped.
+func (q *Query) SetTime(key string, t time.Time) *Query {
+	if t.IsZero() {
+		return q
+	}
+	return q.Set(key, formatQueryTime(t))
+}
+
+// Filter sets a nested filter parameter, filter[field][operator]=value.
+// Nested fields are given as a dotted path: "owner.id" is encoded as
+// filter[owner][id][operator].
+//
+// Parameters:
+//   - field: Field to filter on
+//   - operator: Comparison, e.g. "eq", "ne", "in", "gte", or "lt"
+//   - values: Values to compare with; several values repeat the key
+func (q *Query) Filter(field, operator string, values ...string) *Query {
+	key := filterKey(field, operator)
+	q.init()
+	q.values.Del(key)
+	return q.Add(key, values...)
+}
+
+// TimeRange filters a time field to the half-open range [from, to), encoded
+// as filter[field][gte] and filter[field][lt] in RFC 3339 UTC. A zero time
+// leaves that end of the range open.
+func (q *Query) TimeRange(field string, from, to time.Time) *Query {
+	if !from.IsZero() {
+		q.Filter(field, "gte", formatQueryTime(from))
+	}
+	if !to.IsZero() {
+		q.Filter(field, "lt", formatQueryTime(to))
+	}
+	return q
+}
+
+// Get returns the first value of a parameter, or "" if it is not set.
+func (q *Query) Get(key string) string {
+	if q == nil {
+		return ""
+	}
+	return q.values.Get(key)
+}
+
+// Del removes a parameter.
+func (q *Query) Del(key string) *Query {
+	if q != nil && q.values != nil {
+		q.values.Del(key)
+	}
+	return q
+}
+
+// Values returns a copy of the parameters.
+func (q *Query) Values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+	for key, vs := range q.values {
+		values[key] = append([]string(nil), vs...)
+	}
+	return values
+}
+
+// Clone returns a copy of the query.
+func (q *Query) Clone() *Query {
+	return &Query{values: q.Values()}
+}
+
+// Encode encodes the query in URL form, sorted by key.
+func (q *Query) Encode() string {
+	if q == nil {
+		return ""
+	}
+	return q.values.Encode()
+}
+
+// init allocates the parameter map of a zero Query.
+func (q *Query) init() {
+	if q.values == nil {
+		q.values = url.Values{}
+	}
+}
+
+// apply adds the query to a URL, keeping any parameters already in it.
+func (q *Query) apply(rawURL string) (string, error) {
+	if q.Encode() == "" {
+		return rawURL, nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	values := u.Query()
+	for key, vs := range q.values {
+		values[key] = append([]string(nil), vs...)
+	}
+	u.RawQuery = values.Encode()
+	return u.String(), nil
+}
+
+// filterKey returns the key of a nested filter parameter.
+func filterKey(field, operator string) string {
+	var b strings.Builder
+	b.WriteString("filter")
+	for _, part := range strings.Split(field, ".") {
+		b.WriteString("[" + part + "]")
+	}
+	if operator != "" {
+		b.WriteString("[" + operator + "]")
+	}
+	return b.String()
+}
+
+// formatQueryTime formats a time as RFC 3339 in UTC.
+func formatQueryTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// TimeRange is the half-open time range [From, To). A zero time leaves that
+// end of the range open.
+type TimeRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// Contains reports whether a time is in the range.
+func (r TimeRange) Contains(t time.Time) bool {
+	return (r.From.IsZero() || !t.Before(r.From)) && (r.To.IsZero() || t.Before(r.To))
+}
+
+// validate checks that a range with both ends set ends after it starts.
+func (r TimeRange) validate() error {
+	if !r.From.IsZero() && !r.To.IsZero() && !r.From.Before(r.To) {
+		return NewValidationError("time range must end after it starts")
+	}
+	return nil
+}
@@ -0,0 +1,429 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// QuotaResource is a resource with a quota or budget.
+type QuotaResource string
+
+// Quota resources.
+const (
+	QuotaAPIRequests  QuotaResource = "api_requests"
+	QuotaAITokens     QuotaResource = "ai_tokens"
+	QuotaStorageBytes QuotaResource = "storage_bytes"
+	QuotaProjects     QuotaResource = "projects"
+	QuotaSeats        QuotaResource = "seats"
+)
+
+// BudgetPeriod is the period after which the usage counted against a quota
+// or budget resets.
+type BudgetPeriod string
+
+// Budget periods.
+const (
+	BudgetDaily   BudgetPeriod = "day"
+	BudgetWeekly  BudgetPeriod = "week"
+	BudgetMonthly BudgetPeriod = "month"
+)
+
+// AlertTarget is the limit an alert threshold is relative to.
+type AlertTarget string
+
+// Alert targets.
+const (
+	// AlertOnQuota alerts relative to the quota of the plan
+	AlertOnQuota AlertTarget = "quota"
+
+	// AlertOnBudget alerts relative to the budget set with SetBudget
+	AlertOnBudget AlertTarget = "budget"
+)
+
+// NotificationQuotaAlert is the notification type of triggered quota alerts.
+const NotificationQuotaAlert = "quota.alert"
+
+// QuotasService reads the quotas of the organization's plan and manages
+// soft budgets and the alerts raised as usage approaches them, so that
+// spend can be controlled before a hard quota stops work.
+type QuotasService struct {
+	client *HTTPClient
+}
+
+// Quota is a hard limit of the organization's plan. Requests beyond it fail
+// until the quota resets or the plan is changed.
+type Quota struct {
+	Resource QuotaResource `json:"resource"`
+
+	// Limit is the quota, or 0 if the resource is unlimited
+	Limit int64 `json:"limit"`
+
+	// Used is the usage counted against the quota in the current period
+	Used int64 `json:"used"`
+
+	// Period is the period of the quota, or "" for a quota that does not
+	// reset, such as storage
+	Period BudgetPeriod `json:"period,omitempty"`
+
+	// ResetsAt is when the current period ends; zero without a Period
+	ResetsAt Timestamp `json:"resets_at"`
+}
+
+// Remaining returns the usage left before the quota is reached, or -1 if
+// the resource is unlimited.
+func (q *Quota) Remaining() int64 {
+	if q.Limit <= 0 {
+		return -1
+	}
+	if q.Used >= q.Limit {
+		return 0
+	}
+	return q.Limit - q.Used
+}
+
+// UsedFraction returns the fraction of the quota used, or 0 if the resource
+// is unlimited.
+func (q *Quota) UsedFraction() float64 {
+	if q.Limit <= 0 {
+		return 0
+	}
+	return float64(q.Used) / float64(q.Limit)
+}
+
+// Budget is a soft limit on a resource, set by the organization below its
+// quota. Exceeding a budget does not fail requests; it triggers the alerts
+// on the budget.
+type Budget struct {
+	// Resource is the budgeted resource (required); an organization has at
+	// most one budget per resource
+	Resource QuotaResource `json:"resource"`
+
+	// Limit is the budget, e.g. 5000000 AI tokens (required)
+	Limit int64 `json:"limit"`
+
+	// Period is the period of the budget (default: BudgetMonthly)
+	Period BudgetPeriod `json:"period,omitempty"`
+
+	// Used is the usage in the current period, set by the server
+	Used int64 `json:"used,omitempty"`
+
+	// ResetsAt is when the current period ends, set by the server
+	ResetsAt  Timestamp `json:"resets_at"`
+	UpdatedAt Timestamp `json:"updated_at"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// UsedFraction returns the fraction of the budget used.
+func (b *Budget) UsedFraction() float64 {
+	if b.Limit <= 0 {
+		return 0
+	}
+	return float64(b.Used) / float64(b.Limit)
+}
+
+// QuotaAlert raises a notification when the usage of a resource reaches a
+// threshold of its quota or budget, once per period.
+type QuotaAlert struct {
+	// ID is the ID of the alert, set by the server
+	ID string `json:"id,omitempty"`
+
+	// Resource is the watched resource (required)
+	Resource QuotaResource `json:"resource"`
+
+	// Target is the limit Threshold is relative to (default: AlertOnQuota)
+	Target AlertTarget `json:"target,omitempty"`
+
+	// Threshold is the fraction of the limit that triggers the alert, e.g.
+	// 0.8 for 80%; budget alerts may exceed 1 to alert on overspend (required)
+	Threshold float64 `json:"threshold"`
+
+	// Channels deliver the alert as a NotificationQuotaAlert notification,
+	// to the addresses of the notification preferences (default: in-app)
+	Channels []NotificationChannelType `json:"channels,omitempty"`
+
+	// WebhookURL is an HTTPS URL the alert is posted to, in addition to the
+	// Channels, e.g. to page the on-call engineer (optional)
+	WebhookURL string `json:"webhook_url,omitempty"`
+
+	// WebhookSecret signs webhook requests; it is write-only and never
+	// returned (required with WebhookURL)
+	WebhookSecret string `json:"webhook_secret,omitempty"`
+
+	// LastTriggeredAt is when the alert last fired, set by the server
+	LastTriggeredAt Timestamp `json:"last_triggered_at"`
+	CreatedAt       Timestamp `json:"created_at"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// List lists the quotas of the organization's plan with their current usage.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - opts: Request options (optional)
+//
+// Returns the quotas or an error if the request fails.
+//
+// Example usage:
+//
+//	quotas, err := client.Quotas.List(ctx)
+//	if err != nil {
+//	    return err
+//	}
+//	for _, quota := range quotas {
+//	    if quota.UsedFraction() > 0.9 {
+//	        log.Printf("%s quota is %.0f%% used", quota.Resource, quota.UsedFraction()*100)
+//	    }
+//	}
+func (s *QuotasService) List(ctx context.Context, opts ...RequestOption) ([]Quota, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	var response struct {
+		Quotas []Quota `json:"quotas"`
+	}
+	if err := s.client.Get(ctx, "/quotas", nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to list quotas: %w", err)
+	}
+	return response.Quotas, nil
+}
+
+// SetBudget sets the budget of a resource, replacing any existing budget
+// of the resource.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - budget: Budget to set; only Resource, Limit, and Period are used
+//   - opts: Request options (optional)
+//
+// Returns the budget with its current usage or an error if the request fails.
+//
+// Example usage:
+//
+//	// Cap AI spend at 5M tokens a month, alerting at 80%.
+//	_, err := client.Quotas.SetBudget(ctx, &zoptal.Budget{
+//	    Resource: zoptal.QuotaAITokens,
+//	    Limit:    5_000_000,
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	_, err = client.Quotas.CreateAlert(ctx, &zoptal.QuotaAlert{
+//	    Resource:  zoptal.QuotaAITokens,
+//	    Target:    zoptal.AlertOnBudget,
+//	    Threshold: 0.8,
+//	    Channels:  []zoptal.NotificationChannelType{zoptal.NotificationChannelEmail},
+//	})
+func (s *QuotasService) SetBudget(ctx context.Context, budget *Budget, opts ...RequestOption) (*Budget, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if budget == nil || budget.Resource == "" {
+		return nil, NewValidationError("resource is required")
+	}
+	if budget.Limit <= 0 {
+		return nil, NewValidationError("budget limit must be positive")
+	}
+	switch budget.Period {
+	case "", BudgetDaily, BudgetWeekly, BudgetMonthly:
+	default:
+		return nil, NewValidationError(fmt.Sprintf("unsupported budget period %q", budget.Period))
+	}
+
+	period := budget.Period
+	if period == "" {
+		period = BudgetMonthly
+	}
+	data := map[string]interface{}{
+		"limit":  budget.Limit,
+		"period": period,
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Put(ctx, budgetEndpoint(budget.Resource), data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to set %s budget: %w", budget.Resource, err)
+	}
+	updated, err := decodeBudget(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set %s budget: %w", budget.Resource, err)
+	}
+	return updated, nil
+}
+
+// ListBudgets lists the budgets of the organization with their current usage.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - opts: Request options (optional)
+//
+// Returns the budgets or an error if the request fails.
+func (s *QuotasService) ListBudgets(ctx context.Context, opts ...RequestOption) ([]Budget, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	var response struct {
+		Budgets []json.RawMessage `json:"budgets"`
+	}
+	if err := s.client.Get(ctx, "/quotas/budgets", nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to list budgets: %w", err)
+	}
+
+	budgets := make([]Budget, len(response.Budgets))
+	for i, raw := range response.Budgets {
+		if err := decodeTyped(raw, &budgets[i], &budgets[i].Raw); err != nil {
+			return nil, fmt.Errorf("failed to list budgets: %w", err)
+		}
+	}
+	return budgets, nil
+}
+
+// DeleteBudget deletes the budget of a resource. Its budget alerts no
+// longer fire until a budget is set again.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - resource: Budgeted resource
+//   - opts: Request options (optional)
+//
+// Returns an error if the request fails.
+func (s *QuotasService) DeleteBudget(ctx context.Context, resource QuotaResource, opts ...RequestOption) error {
+	ctx = WithRequestOptions(ctx, opts...)
+	if resource == "" {
+		return NewValidationError("resource is required")
+	}
+
+	if err := s.client.Delete(ctx, budgetEndpoint(resource), nil); err != nil {
+		return fmt.Errorf("failed to delete %s budget: %w", resource, err)
+	}
+	return nil
+}
+
+// CreateAlert registers an alert on the usage of a resource.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - alert: Alert to create
+//   - opts: Request options (optional)
+//
+// Returns the created alert or an error if the request fails.
+func (s *QuotasService) CreateAlert(ctx context.Context, alert *QuotaAlert, opts ...RequestOption) (*QuotaAlert, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if alert == nil || alert.Resource == "" {
+		return nil, NewValidationError("resource is required")
+	}
+	switch alert.Target {
+	case "", AlertOnQuota:
+		if alert.Threshold <= 0 || alert.Threshold > 1 {
+			return nil, NewValidationError("quota alert threshold must be in the range (0, 1]")
+		}
+	case AlertOnBudget:
+		if alert.Threshold <= 0 {
+			return nil, NewValidationError("budget alert threshold must be positive")
+		}
+	default:
+		return nil, NewValidationError(fmt.Sprintf("unsupported alert target %q", alert.Target))
+	}
+	if alert.WebhookURL != "" {
+		u, err := url.Parse(alert.WebhookURL)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return nil, NewValidationError("alert webhook URL must be an absolute HTTPS URL")
+		}
+		if alert.WebhookSecret == "" {
+			return nil, NewValidationError("alert webhook secret is required")
+		}
+	}
+
+	target := alert.Target
+	if target == "" {
+		target = AlertOnQuota
+	}
+	data := map[string]interface{}{
+		"resource":  alert.Resource,
+		"target":    target,
+		"threshold": alert.Threshold,
+	}
+	if len(alert.Channels) > 0 {
+		data["channels"] = alert.Channels
+	}
+	if alert.WebhookURL != "" {
+		data["webhook_url"] = alert.WebhookURL
+		data["webhook_secret"] = alert.WebhookSecret
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Post(ctx, "/quotas/alerts", data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to create %s alert: %w", alert.Resource, err)
+	}
+	created, err := decodeQuotaAlert(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s alert: %w", alert.Resource, err)
+	}
+	return created, nil
+}
+
+// ListAlerts lists the alerts of the organization.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - opts: Request options (optional)
+//
+// Returns the alerts or an error if the request fails.
+func (s *QuotasService) ListAlerts(ctx context.Context, opts ...RequestOption) ([]QuotaAlert, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	var response struct {
+		Alerts []json.RawMessage `json:"alerts"`
+	}
+	if err := s.client.Get(ctx, "/quotas/alerts", nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to list quota alerts: %w", err)
+	}
+
+	alerts := make([]QuotaAlert, len(response.Alerts))
+	for i, raw := range response.Alerts {
+		if err := decodeTyped(raw, &alerts[i], &alerts[i].Raw); err != nil {
+			return nil, fmt.Errorf("failed to list quota alerts: %w", err)
+		}
+	}
+	return alerts, nil
+}
+
+// DeleteAlert deletes an alert.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - alertID: ID of the alert
+//   - opts: Request options (optional)
+//
+// Returns an error if the request fails.
+func (s *QuotasService) DeleteAlert(ctx context.Context, alertID string, opts ...RequestOption) error {
+	ctx = WithRequestOptions(ctx, opts...)
+	if alertID == "" {
+		return NewValidationError("alert ID is required")
+	}
+
+	if err := s.client.Delete(ctx, "/quotas/alerts/"+url.PathEscape(alertID), nil); err != nil {
+		return fmt.Errorf("failed to delete quota alert %s: %w", alertID, err)
+	}
+	return nil
+}
+
+// budgetEndpoint returns the endpoint of the budget of a resource.
+func budgetEndpoint(resource QuotaResource) string {
+	return "/quotas/budgets/" + url.PathEscape(string(resource))
+}
+
+// decodeBudget decodes a budget response.
+func decodeBudget(raw json.RawMessage) (*Budget, error) {
+	var budget Budget
+	if err := decodeTyped(raw, &budget, &budget.Raw); err != nil {
+		return nil, err
+	}
+	return &budget, nil
+}
+
+// decodeQuotaAlert decodes a quota alert response.
+func decodeQuotaAlert(raw json.RawMessage) (*QuotaAlert, error) {
+	var alert QuotaAlert
+	if err := decodeTyped(raw, &alert, &alert.Raw); err != nil {
+		return nil, err
+	}
+	return &alert, nil
+}
@@ -0,0 +1,239 @@
+package zoptal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimitOptions contains options for the client-side rate limiter.
+//
+// The limiter is a token bucket that delays requests so the client stays
+// within its quota instead of running into 429 responses. By default the
+// bucket is held in process; with a Backend, all replicas of a service that
+// use the same Key draw from one shared bucket, so together they respect the
+// organization's quota instead of each assuming it owns the full budget.
+type RateLimitOptions struct {
+	// RequestsPerSecond is the sustained request rate (required)
+	RequestsPerSecond float64
+
+	// Burst is the maximum number of requests sent at once after a quiet
+	// period (default: RequestsPerSecond rounded up, at least 1)
+	Burst int
+
+	// Backend shares the bucket between replicas, e.g. zoptalcache.NewRedis
+	// (default: an in-process bucket)
+	Backend RateLimitBackend
+
+	// Key identifies the shared bucket; replicas sharing a quota must use
+	// the same key, such as the organization ID (default: derived from the API key)
+	Key string
+
+	// MaxWait is the longest a request waits for a token before failing
+	// with a RateLimitError; zero waits until the request context is done (default: 0)
+	MaxWait time.Duration
+}
+
+// validate checks the options; RequestsPerSecond must be positive and
+// finite, as a zero rate would never refill the bucket.
+func (o *RateLimitOptions) validate() error {
+	if !(o.RequestsPerSecond > 0) || math.IsInf(o.RequestsPerSecond, 1) {
+		return NewValidationError("rate limit requests per second must be positive and finite")
+	}
+	if o.Burst < 0 || o.MaxWait < 0 {
+		return NewValidationError("rate limit burst and max wait must not be negative")
+	}
+	return nil
+}
+
+// RateLimitBackend holds token buckets shared between replicas.
+type RateLimitBackend interface {
+	// Take takes a token from the bucket stored under key, which refills at
+	// rate tokens per second up to burst tokens. It returns zero if a token
+	// was taken, or how long to wait before one will be available.
+	Take(ctx context.Context, key string, rate float64, burst int) (time.Duration, error)
+}
+
+// tokenBucket is an in-process token bucket.
+type tokenBucket struct {
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// take takes a token from the bucket, returning zero if one was taken or
+// how long to wait before one will be available.
+func (b *tokenBucket) take(rate float64, burst int) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if b.last.IsZero() {
+		b.tokens = float64(burst)
+	} else {
+		b.tokens = math.Min(float64(burst), b.tokens+now.Sub(b.last).Seconds()*rate)
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / rate * float64(time.Second))
+}
+
+// available returns the number of tokens in the bucket, without taking one.
+func (b *tokenBucket) available(rate float64, burst int) float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.last.IsZero() {
+		return float64(burst)
+	}
+	return math.Min(float64(burst), b.tokens+time.Since(b.last).Seconds()*rate)
+}
+
+// rateLimiter delays requests to stay within a request rate.
+type rateLimiter struct {
+	rate    float64
+	burst   int
+	backend RateLimitBackend
+	key     string
+	maxWait time.Duration
+	debug   bool
+	local   tokenBucket
+}
+
+// newRateLimiter creates a rate limiter from the given options, applying defaults.
+func newRateLimiter(options *RateLimitOptions, apiKey string, debug bool) *rateLimiter {
+	l := &rateLimiter{
+		rate:    options.RequestsPerSecond,
+		burst:   options.Burst,
+		backend: options.Backend,
+		key:     options.Key,
+		maxWait: options.MaxWait,
+		debug:   debug,
+	}
+	if l.burst <= 0 {
+		l.burst = int(math.Ceil(l.rate))
+		if l.burst < 1 {
+			l.burst = 1
+		}
+	}
+	if l.key == "" {
+		sum := sha256.Sum256([]byte(apiKey))
+		l.key = "zoptal:ratelimit:" + hex.EncodeToString(sum[:8])
+	}
+	return l
+}
+
+// wait blocks until a request may be sent. If the shared backend fails, the
+// in-process bucket is used so that requests are never blocked by it.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	start := time.Now()
+	for {
+		var delay time.Duration
+		if l.backend != nil {
+			var err error
+			delay, err = l.backend.Take(ctx, l.key, l.rate, l.burst)
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				if l.debug {
+					log.Printf("Zoptal rate limit backend failed, using local limiter: %v", err)
+				}
+				delay = l.local.take(l.rate, l.burst)
+			}
+		} else {
+			delay = l.local.take(l.rate, l.burst)
+		}
+		if delay <= 0 {
+			return nil
+		}
+
+		if l.maxWait > 0 && time.Since(start)+delay > l.maxWait {
+			err := NewRateLimitError(fmt.Sprintf("client-side rate limit of %g requests per second exceeded", l.rate))
+			err.RetryAfter = delay
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// throttle waits for the client-side rate limiter, if configured.
+func (c *HTTPClient) throttle(ctx context.Context) error {
+	limiter := c.current().limiter
+	if limiter == nil {
+		return nil
+	}
+	return limiter.wait(ctx)
+}
+
+// rateLimitError creates the error of a 429 response from its rate limit
+// headers.
+func rateLimitError(header http.Header) *RateLimitError {
+	now := time.Now()
+	err := NewRateLimitError("rate limit exceeded")
+
+	if value := strings.TrimSpace(header.Get("Retry-After")); value != "" {
+		if seconds, parseErr := strconv.ParseInt(value, 10, 64); parseErr == nil && seconds >= 0 {
+			err.RetryAfter = time.Duration(seconds) * time.Second
+		} else if at, parseErr := http.ParseTime(value); parseErr == nil && at.After(now) {
+			err.RetryAfter = at.Sub(now)
+		}
+	}
+	if limit, parseErr := strconv.Atoi(header.Get("X-RateLimit-Limit")); parseErr == nil {
+		err.Limit = limit
+	}
+	if remaining, parseErr := strconv.Atoi(header.Get("X-RateLimit-Remaining")); parseErr == nil {
+		err.Remaining = remaining
+	}
+	if reset, parseErr := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); parseErr == nil && reset >= 0 {
+		// Servers report either a Unix time or the seconds until the reset.
+		if reset > 1e9 {
+			err.Reset = time.Unix(reset, 0)
+		} else {
+			err.Reset = now.Add(time.Duration(reset) * time.Second)
+		}
+	}
+
+	switch {
+	case err.RetryAfter > 0:
+		err.Message = fmt.Sprintf("rate limit exceeded, retry after %s", err.RetryAfter.Round(time.Second))
+	case !err.Reset.IsZero():
+		err.Message = fmt.Sprintf("rate limit exceeded, quota resets at %s", err.Reset.UTC().Format(time.RFC3339))
+	}
+	if err.Limit >= 0 {
+		err.Message += fmt.Sprintf(" (limit %d requests)", err.Limit)
+	}
+	return err
+}
+
+// wait returns how long a rate limit error asks to wait before retrying, or
+// zero if it does not say.
+func (e *RateLimitError) wait() time.Duration {
+	if e.RetryAfter > 0 {
+		return e.RetryAfter
+	}
+	if e.Remaining == 0 && !e.Reset.IsZero() {
+		if until := time.Until(e.Reset); until > 0 {
+			return until
+		}
+	}
+	return 0
+}
@@ -0,0 +1,163 @@
+package zoptal
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RequestOption overrides a client default for a single call, without
+// building a second client. Every service method accepts request options
+// after its other arguments; methods that cannot, such as
+// NotificationsService.MarkRead, take them from a context returned by
+// WithRequestOptions.
+//
+// Example usage:
+//
+//	models, err := client.AI.ListModels(ctx,
+//	    zoptal.WithHeader("X-Trace-ID", traceID),
+//	    zoptal.WithTimeout(5*time.Second),
+//	    zoptal.WithNoRetry(),
+//	)
+type RequestOption interface {
+	applyRequest(options *requestOptions)
+}
+
+// requestOptionFunc is a RequestOption implemented by a function.
+type requestOptionFunc func(options *requestOptions)
+
+// applyRequest implements RequestOption.
+func (f requestOptionFunc) applyRequest(options *requestOptions) {
+	f(options)
+}
+
+// requestOptions are the overrides of a call.
+type requestOptions struct {
+	header  http.Header
+	timeout time.Duration
+
+	// maxRetries is the maximum number of retries, or -1 for the client's
+	maxRetries int
+
+	// libraryDocs is the documentation attached to code generation requests
+	libraryDocs []*DocsRequest
+}
+
+// requestOptionsKey is the context key of requestOptions.
+type requestOptionsKey struct{}
+
+// WithHeader sets a header on the requests of a call, replacing any value
+// the SDK would send.
+//
+// Parameters:
+//   - name: Header name
+//   - value: Header value
+//
+// Returns the request option.
+func WithHeader(name, value string) RequestOption {
+	return requestOptionFunc(func(options *requestOptions) {
+		if options.header == nil {
+			options.header = make(http.Header)
+		}
+		options.header.Set(name, value)
+	})
+}
+
+// WithTimeout sets the timeout of each request attempt, of a client created
+// with New or of a single call, overriding ClientOptions.Timeout. It does not
+// apply to streams, which are bounded by their context only.
+//
+// Parameters:
+//   - timeout: Timeout of each attempt; for a call, zero disables the
+//     timeout, while a client's timeout must be positive
+//
+// Returns the option.
+func WithTimeout(timeout time.Duration) ClientRequestOption {
+	return clientRequestOption{
+		client: clientTimeout(timeout),
+		request: func(options *requestOptions) {
+			options.timeout = timeout
+			if timeout == 0 {
+				// Distinguish no timeout from the client's.
+				options.timeout = -1
+			}
+		},
+	}
+}
+
+// WithMaxRetries sets the maximum number of retries, of a client created
+// with New or of a single call, overriding ClientOptions.MaxRetries. Retries
+// are still subject to the RetryPolicy.
+//
+// Parameters:
+//   - maxRetries: Maximum number of retries
+//
+// Returns the option.
+func WithMaxRetries(maxRetries int) ClientRequestOption {
+	return clientRequestOption{
+		client: clientMaxRetries(maxRetries),
+		request: func(options *requestOptions) {
+			options.maxRetries = maxRetries
+			if maxRetries < 0 {
+				options.maxRetries = 0
+			}
+		},
+	}
+}
+
+// WithNoRetry sends requests only once, for a client created with New or a
+// single call, for example when the caller retries on its own or a late
+// answer is useless.
+//
+// Returns the option.
+func WithNoRetry() ClientRequestOption {
+	return WithMaxRetries(0)
+}
+
+// WithRequestOptions returns a context whose calls use the given request
+// options, in addition to those of ctx; later options take precedence.
+//
+// Parameters:
+//   - ctx: Parent context
+//   - opts: Request options
+//
+// Returns the derived context.
+func WithRequestOptions(ctx context.Context, opts ...RequestOption) context.Context {
+	if len(opts) == 0 {
+		return ctx
+	}
+	options := requestOptions{maxRetries: -1}
+	if parent := requestOptionsFrom(ctx); parent != nil {
+		options = *parent
+		options.header = parent.header.Clone()
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt.applyRequest(&options)
+		}
+	}
+	return context.WithValue(ctx, requestOptionsKey{}, &options)
+}
+
+// requestOptionsFrom returns the request options of ctx, or nil if it has
+// none.
+func requestOptionsFrom(ctx context.Context) *requestOptions {
+	options, _ := ctx.Value(requestOptionsKey{}).(*requestOptions)
+	return options
+}
+
+// clientFor returns the HTTP client to send a request of ctx with: the
+// current client, with the timeout of the request options if any.
+func (c *HTTPClient) clientFor(ctx context.Context) *http.Client {
+	client := c.current().client
+	options := requestOptionsFrom(ctx)
+	if options == nil || options.timeout == 0 {
+		return client
+	}
+	timed := *client
+	timed.Timeout = options.timeout
+	if timed.Timeout < 0 {
+		timed.Timeout = 0
+	}
+	return &timed
+}
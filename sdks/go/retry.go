@@ -0,0 +1,122 @@
+package zoptal
+
+import (
+	"errors"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RetryPolicy decides whether a failed request should be retried and how long
+// to wait before the next attempt.
+//
+// Backoff is called after every failed attempt with the zero-based attempt
+// number, the time elapsed since the first attempt, the HTTP status code of the
+// response (0 if no response was received), and the error. It returns the
+// delay before the next attempt and whether a retry should be made at all.
+// The client never makes more than ClientOptions.MaxRetries retries regardless
+// of the policy.
+type RetryPolicy interface {
+	Backoff(attempt int, elapsed time.Duration, statusCode int, err error) (time.Duration, bool)
+}
+
+// ExponentialBackoff is a RetryPolicy using capped exponential backoff with
+// full jitter: the delay before retry n is a random duration between zero and
+// min(MaxDelay, BaseDelay * 2^n). A rate limited request is instead retried
+// after the delay the server asks for with Retry-After, or when its quota
+// resets, and not at all if that is past MaxElapsedTime.
+type ExponentialBackoff struct {
+	// BaseDelay is the backoff ceiling for the first retry (default: 1 second)
+	BaseDelay time.Duration
+
+	// MaxDelay caps the backoff ceiling for any single retry (default: 30 seconds)
+	MaxDelay time.Duration
+
+	// MaxElapsedTime stops retrying once this much time has passed since the
+	// first attempt; zero means no limit (default: 2 minutes)
+	MaxElapsedTime time.Duration
+
+	// RetryableStatuses is the set of HTTP status codes that are retried.
+	// Transport errors (no response) are always retried.
+	RetryableStatuses map[int]bool
+}
+
+// DefaultRetryPolicy returns the retry policy used when none is configured.
+//
+// Returns an ExponentialBackoff retrying 408, 429, 500, 502, 503, and 504
+// responses as well as transport errors.
+func DefaultRetryPolicy() *ExponentialBackoff {
+	return &ExponentialBackoff{
+		BaseDelay:      time.Second,
+		MaxDelay:       30 * time.Second,
+		MaxElapsedTime: 2 * time.Minute,
+		RetryableStatuses: map[int]bool{
+			http.StatusRequestTimeout:      true,
+			http.StatusTooManyRequests:     true,
+			http.StatusInternalServerError: true,
+			http.StatusBadGateway:          true,
+			http.StatusServiceUnavailable:  true,
+			http.StatusGatewayTimeout:      true,
+		},
+	}
+}
+
+// Backoff implements RetryPolicy.
+func (b *ExponentialBackoff) Backoff(attempt int, elapsed time.Duration, statusCode int, err error) (time.Duration, bool) {
+	if statusCode != 0 && !b.RetryableStatuses[statusCode] {
+		return 0, false
+	}
+	if b.MaxElapsedTime > 0 && elapsed >= b.MaxElapsedTime {
+		return 0, false
+	}
+
+	// Wait as long as the server asks, or give up if that is too long.
+	var rateLimited *RateLimitError
+	if errors.As(err, &rateLimited) {
+		if wait := rateLimited.wait(); wait > 0 {
+			if b.MaxElapsedTime > 0 && elapsed+wait > b.MaxElapsedTime {
+				return 0, false
+			}
+			return wait, true
+		}
+	}
+
+	base := b.BaseDelay
+	if base <= 0 {
+		base = time.Second
+	}
+	maxDelay := b.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = 30 * time.Second
+	}
+
+	ceiling := base
+	for i := 0; i < attempt && ceiling < maxDelay; i++ {
+		ceiling *= 2
+	}
+	if ceiling > maxDelay {
+		ceiling = maxDelay
+	}
+
+	delay := jitter(ceiling)
+	if b.MaxElapsedTime > 0 && elapsed+delay > b.MaxElapsedTime {
+		delay = b.MaxElapsedTime - elapsed
+	}
+	return delay, true
+}
+
+var (
+	jitterMu   sync.Mutex
+	jitterRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// jitter returns a random duration in [0, d].
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	jitterMu.Lock()
+	defer jitterMu.Unlock()
+	return time.Duration(jitterRand.Int63n(int64(d) + 1))
+}
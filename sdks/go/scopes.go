@@ -0,0 +1,321 @@
+package zoptal
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Scope is an API permission scope. Scopes have the form "resource:read",
+// granting GET and HEAD requests to the resource's endpoints, or
+// "resource:write", granting all other methods; the bare "resource" grants
+// both. They are the scope names of the Zoptal OAuth server.
+type Scope string
+
+// Scopes of the Zoptal API.
+const (
+	ScopeProjectsRead       Scope = "projects:read"
+	ScopeProjectsWrite      Scope = "projects:write"
+	ScopeFilesRead          Scope = "files:read"
+	ScopeFilesWrite         Scope = "files:write"
+	ScopeCollaborationRead  Scope = "collaboration:read"
+	ScopeCollaborationWrite Scope = "collaboration:write"
+	ScopeTemplatesRead      Scope = "templates:read"
+	ScopeTemplatesWrite     Scope = "templates:write"
+	ScopeNotificationsRead  Scope = "notifications:read"
+	ScopeNotificationsWrite Scope = "notifications:write"
+	ScopeSecurityRead       Scope = "security:read"
+	ScopeSecurityWrite      Scope = "security:write"
+	ScopeUserRead           Scope = "user:read"
+	ScopeUserWrite          Scope = "user:write"
+	ScopeJobsRead           Scope = "jobs:read"
+	ScopeJobsWrite          Scope = "jobs:write"
+	ScopeMarketplaceRead    Scope = "marketplace:read"
+	ScopeMarketplaceWrite   Scope = "marketplace:write"
+	ScopeExtensionsRead     Scope = "extensions:read"
+	ScopeIntegrationsRead   Scope = "integrations:read"
+	ScopeIntegrationsWrite  Scope = "integrations:write"
+	ScopeExperimentsRead    Scope = "experiments:read"
+	ScopeExperimentsWrite   Scope = "experiments:write"
+	ScopeOrgsRead           Scope = "orgs:read"
+	ScopeOrgsWrite          Scope = "orgs:write"
+	ScopeAuditRead          Scope = "audit:read"
+	ScopeIndexRead          Scope = "index:read"
+	ScopeIndexWrite         Scope = "index:write"
+	ScopeUsageRead          Scope = "usage:read"
+	ScopeQuotasRead         Scope = "quotas:read"
+	ScopeQuotasWrite        Scope = "quotas:write"
+
+	// ScopeAI grants the AI endpoints, which have no read and write levels
+	ScopeAI Scope = "ai"
+)
+
+// unscopedResources are the endpoints every client may call, such as the
+// health check, server-side cancellation of the client's own requests, and
+// introspection of its own permissions.
+var unscopedResources = map[string]bool{
+	"":            true,
+	"health":      true,
+	"requests":    true,
+	"permissions": true,
+}
+
+// levellessResources are the resources whose scope has no read and write
+// levels.
+var levellessResources = map[string]bool{
+	"ai": true,
+}
+
+// projectResources are the resources nested under a project's endpoints,
+// by path segment, that have their own scope.
+var projectResources = map[string]string{
+	"files":     "files",
+	"documents": "collaboration",
+	"security":  "security",
+}
+
+// ScopedTokenSource is a TokenSource that can obtain access tokens limited
+// to a subset of the scopes of its grant. Clients derived with WithScopes
+// use it to request down-scoped tokens.
+//
+// ScopedToken may be called concurrently with Token.
+type ScopedTokenSource interface {
+	TokenSource
+	ScopedToken(ctx context.Context, scopes []string) (*Token, error)
+}
+
+// scopeSet is the set of scopes a client was granted.
+type scopeSet struct {
+	scopes map[string]bool
+	names  []string
+}
+
+// newScopeSet creates a scope set, ignoring duplicates.
+func newScopeSet(scopes []Scope) *scopeSet {
+	set := &scopeSet{scopes: make(map[string]bool, len(scopes))}
+	for _, scope := range scopes {
+		name := strings.TrimSpace(string(scope))
+		if name == "" || set.scopes[name] {
+			continue
+		}
+		set.scopes[name] = true
+		set.names = append(set.names, name)
+	}
+	sort.Strings(set.names)
+	return set
+}
+
+// grants reports whether the set grants scope.
+func (s *scopeSet) grants(scope string) bool {
+	if s.scopes[scope] {
+		return true
+	}
+	resource, _, ok := strings.Cut(scope, ":")
+	return ok && s.scopes[resource]
+}
+
+// narrow returns the scopes of a client derived from one granted s: those
+// of scopes that s grants.
+func (s *scopeSet) narrow(scopes []Scope) []Scope {
+	if s == nil {
+		return scopes
+	}
+	var granted []Scope
+	for _, scope := range scopes {
+		if s.grants(string(scope)) {
+			granted = append(granted, scope)
+		}
+	}
+	return granted
+}
+
+// requiredScope returns the scope a request requires, or "" if any client
+// may make it.
+func requiredScope(method, endpoint string) string {
+	segments := strings.Split(endpointPath(endpoint), "/")
+	resource := segments[0]
+	if unscopedResources[resource] {
+		return ""
+	}
+	if resource == "projects" && len(segments) >= 3 {
+		if nested, ok := projectResources[segments[2]]; ok {
+			resource = nested
+		}
+	}
+	if levellessResources[resource] {
+		return resource
+	}
+	if method == http.MethodGet || method == http.MethodHead {
+		return resource + ":read"
+	}
+	return resource + ":write"
+}
+
+// endpointPath returns the path of an endpoint or URL relative to the API
+// root, without query or surrounding slashes, e.g. "projects/p1/files".
+func endpointPath(endpoint string) string {
+	path := endpoint
+	if strings.Contains(endpoint, "://") {
+		if u, err := url.Parse(endpoint); err == nil {
+			path = u.Path
+		}
+	}
+	path, _, _ = strings.Cut(path, "?")
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "/"), "api/v1/")
+	return strings.Trim(path, "/")
+}
+
+// checkScope returns a ScopeError if the client was not granted the scope a
+// request requires.
+func (c *HTTPClient) checkScope(method, endpoint string) error {
+	if c.scopes == nil {
+		return nil
+	}
+	scope := requiredScope(method, endpoint)
+	if scope == "" || c.scopes.grants(scope) {
+		return nil
+	}
+	return NewScopeError(scope, method, endpoint)
+}
+
+// withScopes returns a client sharing c's connections, settings, and
+// caches, limited to scopes.
+func (c *HTTPClient) withScopes(scopes *scopeSet) *HTTPClient {
+	derived := &HTTPClient{
+		baseURL:      c.baseURL,
+		apiKey:       c.apiKey,
+		org:          c.org,
+		debug:        c.debug,
+		hedger:       c.hedger,
+		retryPolicy:  c.retryPolicy,
+		cache:        c.cache,
+		metrics:      c.metrics,
+		compressor:   c.compressor,
+		mirror:       c.mirror,
+		failover:     c.failover,
+		swr:          c.swr,
+		tokens:       c.tokens,
+		stats:        c.stats,
+		encryption:   c.encryption,
+		audit:        c.audit,
+		policy:       c.policy,
+		degradation:  c.degradation,
+		settings:     c.settings,
+		capabilities: c.capabilities,
+		scopes:       scopes,
+	}
+	if c.tokens != nil {
+		if source, ok := c.tokens.source.(ScopedTokenSource); ok {
+			derived.tokens = c.tokens.withSource(&scopedTokenSource{source: source, scopes: scopes.names})
+		}
+	}
+	return derived
+}
+
+// withSource returns a token manager with m's refresh options that obtains
+// tokens from source.
+func (m *tokenManager) withSource(source TokenSource) *tokenManager {
+	return &tokenManager{
+		source:    source,
+		refreshAt: m.refreshAt,
+		jitter:    m.jitter,
+		onRefresh: m.onRefresh,
+		debug:     m.debug,
+		metrics:   m.metrics,
+		changed:   make(chan struct{}, 1),
+	}
+}
+
+// scopedTokenSource obtains tokens limited to scopes from a
+// ScopedTokenSource.
+type scopedTokenSource struct {
+	source ScopedTokenSource
+	scopes []string
+}
+
+// Token implements TokenSource.
+func (s *scopedTokenSource) Token(ctx context.Context) (*Token, error) {
+	return s.source.ScopedToken(ctx, s.scopes)
+}
+
+// ScopedToken implements ScopedTokenSource, for clients derived from a
+// derived client.
+func (s *scopedTokenSource) ScopedToken(ctx context.Context, scopes []string) (*Token, error) {
+	return s.source.ScopedToken(ctx, scopes)
+}
+
+// WithScopes returns a client limited to the given scopes, for proving
+// least-privilege use of the API. The client refuses requests to endpoints
+// outside its scopes with a ScopeError, without sending them. When the
+// client authenticates with OAuth and its TokenSource implements
+// ScopedTokenSource, as RefreshTokenSource does, the derived client also
+// uses its own access tokens limited to the scopes, so that the server
+// enforces them too; with an API key, or another TokenSource, they are
+// enforced by the client only.
+//
+// The derived client shares its connections, settings, and caches with c.
+// A client derived from a derived client only keeps the scopes that its
+// parent was granted. Closing c stops the derived client's background work.
+//
+// Parameters:
+//   - scopes: Scopes the client may use
+//
+// Returns the derived client.
+//
+// Example usage:
+//
+//	reader := client.WithScopes(zoptal.ScopeProjectsRead, zoptal.ScopeSecurityRead)
+//	defer reader.Close()
+//
+//	projects, err := reader.Projects.List(ctx, nil) // allowed
+//	_, err = reader.Security.ScanProject(ctx, projectID, nil)
+//	var scopeErr *zoptal.ScopeError
+//	if errors.As(err, &scopeErr) {
+//	    log.Printf("not granted: %s", scopeErr.Scope) // "security:write"
+//	}
+func (c *Client) WithScopes(scopes ...Scope) *Client {
+	set := newScopeSet(c.httpClient.scopes.narrow(scopes))
+	root := c
+	if c.root != nil {
+		root = c.root
+	}
+
+	derived := &Client{
+		httpClient: c.httpClient.withScopes(set),
+		apiKey:     c.apiKey,
+		baseURL:    c.baseURL,
+		debug:      c.debug,
+		done:       make(chan struct{}),
+		root:       root,
+	}
+	derived.initServices()
+
+	if derived.httpClient.tokens != nil && derived.httpClient.tokens != c.httpClient.tokens {
+		go derived.refreshTokens()
+	}
+	return derived
+}
+
+// Scopes returns the scopes of a client derived with WithScopes, sorted, or
+// nil if the client is not limited to scopes.
+func (c *Client) Scopes() []Scope {
+	if c.httpClient.scopes == nil {
+		return nil
+	}
+	scopes := make([]Scope, len(c.httpClient.scopes.names))
+	for i, name := range c.httpClient.scopes.names {
+		scopes[i] = Scope(name)
+	}
+	return scopes
+}
+
+// rootDone returns the done channel of the client c was derived from, or
+// nil if c was not derived.
+func (c *Client) rootDone() <-chan struct{} {
+	if c.root == nil {
+		return nil
+	}
+	return c.root.done
+}
@@ -0,0 +1,288 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SecurityService scans projects and code for security vulnerabilities.
+type SecurityService struct {
+	client *HTTPClient
+}
+
+// SecuritySeverity is the severity of a security finding.
+type SecuritySeverity string
+
+// Security finding severities, from least to most severe.
+const (
+	SecuritySeverityLow      SecuritySeverity = "low"
+	SecuritySeverityMedium   SecuritySeverity = "medium"
+	SecuritySeverityHigh     SecuritySeverity = "high"
+	SecuritySeverityCritical SecuritySeverity = "critical"
+)
+
+// securitySeverityRanks orders the severities; unknown severities rank lowest.
+var securitySeverityRanks = map[SecuritySeverity]int{
+	SecuritySeverityLow:      1,
+	SecuritySeverityMedium:   2,
+	SecuritySeverityHigh:     3,
+	SecuritySeverityCritical: 4,
+}
+
+// AtLeast reports whether s is at least as severe as min.
+func (s SecuritySeverity) AtLeast(min SecuritySeverity) bool {
+	return securitySeverityRanks[s] >= securitySeverityRanks[min]
+}
+
+// SecurityFinding is a vulnerability found by a security scan.
+type SecurityFinding struct {
+	ID          string           `json:"id"`
+	RuleID      string           `json:"rule_id,omitempty"`
+	Title       string           `json:"title"`
+	Description string           `json:"description,omitempty"`
+	Severity    SecuritySeverity `json:"severity"`
+
+	// CWE is the Common Weakness Enumeration ID of the weakness, such as "CWE-89"
+	CWE string `json:"cwe_id,omitempty"`
+
+	// Path, Line, and EndLine locate the finding; Path is empty for ScanCode
+	Path    string `json:"path,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	EndLine int    `json:"end_line,omitempty"`
+
+	// Snippet is the vulnerable code
+	Snippet string `json:"snippet,omitempty"`
+
+	// SuggestedFix is replacement code or a description of the fix
+	SuggestedFix string `json:"suggested_fix,omitempty"`
+
+	// References are links to advisories and documentation
+	References []string `json:"references,omitempty"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// SecurityScanStatus is the state of a project security scan.
+type SecurityScanStatus string
+
+// Security scan statuses.
+const (
+	SecurityScanQueued    SecurityScanStatus = "queued"
+	SecurityScanRunning   SecurityScanStatus = "running"
+	SecurityScanCompleted SecurityScanStatus = "completed"
+	SecurityScanFailed    SecurityScanStatus = "failed"
+)
+
+// Done reports whether the scan has finished, successfully or not.
+func (s SecurityScanStatus) Done() bool {
+	return s == SecurityScanCompleted || s == SecurityScanFailed
+}
+
+// SecurityScan is a security scan of a project.
+type SecurityScan struct {
+	ID        string             `json:"id"`
+	ProjectID string             `json:"project_id"`
+	Status    SecurityScanStatus `json:"status"`
+
+	// Progress is the completed fraction of the scan, from 0 to 1
+	Progress float64 `json:"progress"`
+
+	// FilesScanned is the number of files scanned so far
+	FilesScanned int `json:"files_scanned"`
+
+	// Findings are the vulnerabilities found; complete once the scan has completed
+	Findings []SecurityFinding `json:"-"`
+
+	// Error describes why a failed scan failed
+	Error string `json:"error,omitempty"`
+
+	CreatedAt   Timestamp `json:"created_at"`
+	CompletedAt Timestamp `json:"completed_at"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// FindingsAtLeast returns the findings at least as severe as min.
+func (s *SecurityScan) FindingsAtLeast(min SecuritySeverity) []SecurityFinding {
+	var findings []SecurityFinding
+	for _, finding := range s.Findings {
+		if finding.Severity.AtLeast(min) {
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// SecurityScanOptions contains options for scanning a project.
+type SecurityScanOptions struct {
+	// Paths limits the scan to files under these paths (optional)
+	Paths []string
+
+	// MinSeverity omits findings less severe than this (optional)
+	MinSeverity SecuritySeverity
+
+	// PollInterval is the initial interval between checks of a running
+	// scan, which doubles after each check up to 30 seconds (default: 2 seconds)
+	PollInterval time.Duration
+
+	// OnProgress is called with the scan each time it is checked (optional)
+	OnProgress func(*SecurityScan)
+}
+
+// ScanProject scans the files of a project for security vulnerabilities.
+//
+// Large projects are scanned asynchronously: ScanProject starts the scan and
+// polls it until it completes, so it may take minutes; use ctx to bound it.
+// Cancelling ctx stops the polling but not the scan; the error names the
+// scan, whose results can later be fetched with GetScan.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - options: Scan options (can be nil for defaults)
+//   - opts: Request options (optional)
+//
+// Returns the completed scan with its findings, or an error if the scan
+// fails or a request fails.
+func (s *SecurityService) ScanProject(ctx context.Context, projectID string, options *SecurityScanOptions, opts ...RequestOption) (*SecurityScan, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+	if options == nil {
+		options = &SecurityScanOptions{}
+	}
+	backoff := newPollBackoff(options.PollInterval, 0, 2*time.Second)
+
+	data := map[string]interface{}{}
+	if len(options.Paths) > 0 {
+		data["paths"] = options.Paths
+	}
+	if options.MinSeverity != "" {
+		data["min_severity"] = options.MinSeverity
+	}
+
+	var raw json.RawMessage
+	endpoint := fmt.Sprintf("/projects/%s/security/scans", url.PathEscape(projectID))
+	if err := s.client.Post(ctx, endpoint, data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to start security scan: %w", err)
+	}
+	scan, err := decodeSecurityScan(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start security scan: %w", err)
+	}
+
+	for {
+		if options.OnProgress != nil {
+			options.OnProgress(scan)
+		}
+		switch scan.Status {
+		case SecurityScanCompleted:
+			return scan, nil
+		case SecurityScanFailed:
+			return nil, NewAPIError(fmt.Sprintf("security scan %s failed: %s", scan.ID, scan.Error))
+		}
+
+		if err := backoff.wait(ctx); err != nil {
+			return nil, fmt.Errorf("security scan %s still running: %w", scan.ID, err)
+		}
+		if scan, err = s.GetScan(ctx, scan.ID); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// GetScan gets a project security scan, with its findings so far.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - scanID: ID of the scan
+//   - opts: Request options (optional)
+//
+// Returns the scan or an error if the request fails.
+func (s *SecurityService) GetScan(ctx context.Context, scanID string, opts ...RequestOption) (*SecurityScan, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if scanID == "" {
+		return nil, NewValidationError("scan ID is required")
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Get(ctx, "/security/scans/"+url.PathEscape(scanID), nil, &raw); err != nil {
+		return nil, fmt.Errorf("failed to get security scan: %w", err)
+	}
+	scan, err := decodeSecurityScan(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get security scan: %w", err)
+	}
+	return scan, nil
+}
+
+// ScanCode scans a snippet of code for security vulnerabilities.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - code: Code to scan
+//   - language: Programming language of the code, e.g. "go" (optional)
+//   - opts: Request options (optional)
+//
+// Returns the findings or an error if the request fails.
+func (s *SecurityService) ScanCode(ctx context.Context, code, language string, opts ...RequestOption) ([]SecurityFinding, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if strings.TrimSpace(code) == "" {
+		return nil, NewValidationError("code is required")
+	}
+
+	data := map[string]interface{}{"code": code}
+	if language != "" {
+		data["language"] = language
+	}
+	var response struct {
+		Findings []json.RawMessage `json:"findings"`
+	}
+	if err := s.client.Post(ctx, "/security/scan-code", data, &response); err != nil {
+		return nil, fmt.Errorf("failed to scan code: %w", err)
+	}
+
+	findings, err := decodeSecurityFindings(response.Findings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan code: %w", err)
+	}
+	return findings, nil
+}
+
+// decodeSecurityScan decodes a scan and its findings.
+func decodeSecurityScan(raw json.RawMessage) (*SecurityScan, error) {
+	var scan SecurityScan
+	if err := decodeTyped(raw, &scan, &scan.Raw); err != nil {
+		return nil, err
+	}
+	var response struct {
+		Findings []json.RawMessage `json:"findings"`
+	}
+	if err := decodeJSON(raw, &response); err != nil {
+		return nil, err
+	}
+	findings, err := decodeSecurityFindings(response.Findings)
+	if err != nil {
+		return nil, err
+	}
+	scan.Findings = findings
+	return &scan, nil
+}
+
+// decodeSecurityFindings decodes findings, keeping their raw form.
+func decodeSecurityFindings(raws []json.RawMessage) ([]SecurityFinding, error) {
+	findings := make([]SecurityFinding, len(raws))
+	for i, raw := range raws {
+		if err := decodeTyped(raw, &findings[i], &findings[i].Raw); err != nil {
+			return nil, err
+		}
+	}
+	return findings, nil
+}
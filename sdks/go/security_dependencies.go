@@ -0,0 +1,148 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// Dependency is a dependency of a project, as declared in a manifest such as
+// go.mod, package.json, requirements.txt, or Cargo.toml.
+type Dependency struct {
+	Name string `json:"name"`
+
+	// Ecosystem is the package ecosystem, such as "go", "npm", or "pypi"
+	Ecosystem string `json:"ecosystem"`
+
+	// Version is the version in use; LatestVersion is the newest release
+	Version       string `json:"version"`
+	LatestVersion string `json:"latest_version,omitempty"`
+
+	// Manifest is the path of the manifest declaring the dependency
+	Manifest string `json:"manifest"`
+
+	// Direct is false for dependencies pulled in by other dependencies
+	Direct bool `json:"direct"`
+
+	// Outdated reports whether a newer release is available
+	Outdated bool `json:"outdated"`
+
+	// License is the SPDX identifier of the dependency's license, or "" if unknown
+	License string `json:"license,omitempty"`
+
+	// LicenseIssue describes why the license is a problem for the project,
+	// such as "copyleft", "unknown", or "denied by policy"; "" if it is not
+	LicenseIssue string `json:"license_issue,omitempty"`
+
+	// Vulnerabilities are the known vulnerabilities of the version in use
+	Vulnerabilities []DependencyVulnerability `json:"vulnerabilities,omitempty"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// Vulnerable reports whether the version in use has known vulnerabilities.
+func (d *Dependency) Vulnerable() bool {
+	return len(d.Vulnerabilities) > 0
+}
+
+// DependencyVulnerability is a known vulnerability of a dependency.
+type DependencyVulnerability struct {
+	// ID is the advisory ID, such as a GHSA or CVE ID
+	ID string `json:"id"`
+
+	// Aliases are other IDs of the same advisory
+	Aliases []string `json:"aliases,omitempty"`
+
+	Severity SecuritySeverity `json:"severity"`
+	Summary  string           `json:"summary,omitempty"`
+
+	// FixedVersion is the first version without the vulnerability, or "" if none is fixed
+	FixedVersion string `json:"fixed_version,omitempty"`
+
+	URL string `json:"url,omitempty"`
+}
+
+// DependencyReport is the result of a dependency analysis of a project.
+type DependencyReport struct {
+	ProjectID string `json:"project_id"`
+
+	// Manifests are the paths of the manifests that were analyzed
+	Manifests []string `json:"manifests"`
+
+	Dependencies []Dependency `json:"-"`
+
+	AnalyzedAt Timestamp `json:"analyzed_at"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// Outdated returns the dependencies with a newer release available.
+func (r *DependencyReport) Outdated() []Dependency {
+	return r.filter(func(d *Dependency) bool { return d.Outdated })
+}
+
+// Vulnerable returns the dependencies with known vulnerabilities.
+func (r *DependencyReport) Vulnerable() []Dependency {
+	return r.filter((*Dependency).Vulnerable)
+}
+
+// LicenseProblems returns the dependencies whose license is a problem for the project.
+func (r *DependencyReport) LicenseProblems() []Dependency {
+	return r.filter(func(d *Dependency) bool { return d.LicenseIssue != "" })
+}
+
+// filter returns the dependencies matching keep.
+func (r *DependencyReport) filter(keep func(*Dependency) bool) []Dependency {
+	var dependencies []Dependency
+	for i := range r.Dependencies {
+		if keep(&r.Dependencies[i]) {
+			dependencies = append(dependencies, r.Dependencies[i])
+		}
+	}
+	return dependencies
+}
+
+// AnalyzeDependencies inspects the dependency manifests of a project, such
+// as go.mod and package.json, and reports outdated dependencies, those with
+// known vulnerabilities, and those whose license is a problem for the
+// project under the organization's license policy.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - opts: Request options (optional)
+//
+// Returns the dependency report or an error if the request fails.
+func (s *SecurityService) AnalyzeDependencies(ctx context.Context, projectID string, opts ...RequestOption) (*DependencyReport, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+
+	var raw json.RawMessage
+	endpoint := fmt.Sprintf("/projects/%s/security/dependencies", url.PathEscape(projectID))
+	if err := s.client.Get(ctx, endpoint, nil, &raw); err != nil {
+		return nil, fmt.Errorf("failed to analyze dependencies: %w", err)
+	}
+
+	var report DependencyReport
+	if err := decodeTyped(raw, &report, &report.Raw); err != nil {
+		return nil, fmt.Errorf("failed to analyze dependencies: %w", err)
+	}
+	var response struct {
+		Dependencies []json.RawMessage `json:"dependencies"`
+	}
+	if err := decodeJSON(raw, &response); err != nil {
+		return nil, fmt.Errorf("failed to analyze dependencies: %w", err)
+	}
+	report.Dependencies = make([]Dependency, len(response.Dependencies))
+	for i, dependency := range response.Dependencies {
+		if err := decodeTyped(dependency, &report.Dependencies[i], &report.Dependencies[i].Raw); err != nil {
+			return nil, fmt.Errorf("failed to analyze dependencies: %w", err)
+		}
+	}
+	return &report, nil
+}
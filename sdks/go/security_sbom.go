@@ -0,0 +1,80 @@
+package zoptal
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// SBOMFormat is the format of a software bill of materials.
+type SBOMFormat string
+
+// Supported SBOM formats.
+const (
+	// SBOMCycloneDX is CycloneDX JSON
+	SBOMCycloneDX SBOMFormat = "cyclonedx-json"
+
+	// SBOMSPDX is SPDX JSON
+	SBOMSPDX SBOMFormat = "spdx-json"
+)
+
+// sbomMediaTypes are the media types requested for each SBOM format.
+var sbomMediaTypes = map[SBOMFormat]string{
+	SBOMCycloneDX: "application/vnd.cyclonedx+json",
+	SBOMSPDX:      "application/spdx+json",
+}
+
+// SBOMResult summarizes a generated SBOM.
+type SBOMResult struct {
+	Format      SBOMFormat
+	ContentType string
+	Bytes       int64
+}
+
+// GenerateSBOM generates a software bill of materials for a project, listing
+// its dependencies with versions, licenses, and package URLs, and streams it
+// to w for compliance pipelines.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - format: SBOM format (SBOMCycloneDX or SBOMSPDX)
+//   - w: Destination for the SBOM document
+//   - opts: Request options (optional)
+//
+// Returns a summary of the SBOM or an error if the request fails. On error,
+// w may hold an incomplete document.
+func (s *SecurityService) GenerateSBOM(ctx context.Context, projectID string, format SBOMFormat, w io.Writer, opts ...RequestOption) (*SBOMResult, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+	mediaType, ok := sbomMediaTypes[format]
+	if !ok {
+		return nil, NewValidationError(fmt.Sprintf("unsupported SBOM format %q", format))
+	}
+	if w == nil {
+		return nil, NewValidationError("writer is required")
+	}
+
+	header := make(http.Header)
+	header.Set("Accept", mediaType+", application/json")
+	endpoint := fmt.Sprintf("/projects/%s/security/sbom", url.PathEscape(projectID))
+	resp, err := s.client.GetRaw(ctx, endpoint, map[string]string{"format": string(format)}, header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate SBOM: %w", err)
+	}
+	defer resp.Body.Close()
+
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate SBOM: %w", err)
+	}
+	return &SBOMResult{
+		Format:      format,
+		ContentType: resp.Header.Get("Content-Type"),
+		Bytes:       n,
+	}, nil
+}
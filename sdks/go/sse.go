@@ -0,0 +1,77 @@
+package zoptal
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+)
+
+// maxEventBytes is the largest server-sent event the SDK will read.
+const maxEventBytes = 4 << 20
+
+// serverEvent is a single server-sent event.
+type serverEvent struct {
+	ID    string
+	Event string
+	Data  []byte
+}
+
+// sseReader reads server-sent events (the text/event-stream format) from a
+// response body.
+type sseReader struct {
+	scanner *bufio.Scanner
+}
+
+// newSSEReader creates an event reader for r.
+func newSSEReader(r io.Reader) *sseReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxEventBytes)
+	return &sseReader{scanner: scanner}
+}
+
+// next reads the next event. It returns io.EOF at the end of the stream.
+func (r *sseReader) next() (*serverEvent, error) {
+	var event serverEvent
+	var data bytes.Buffer
+	hasData := false
+
+	for r.scanner.Scan() {
+		line := r.scanner.Text()
+		if line == "" {
+			if !hasData {
+				// Comments and events without data are not dispatched.
+				event = serverEvent{}
+				continue
+			}
+			event.Data = data.Bytes()
+			return &event, nil
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event.Event = value
+		case "id":
+			event.ID = value
+		case "data":
+			if hasData {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+			hasData = true
+		}
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	if hasData {
+		event.Data = data.Bytes()
+		return &event, nil
+	}
+	return nil, io.EOF
+}
@@ -0,0 +1,274 @@
+package zoptal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SSEWriter writes server-sent events to an HTTP response, flushing each
+// event so that it reaches the browser immediately.
+//
+// An SSEWriter must not be used concurrently.
+type SSEWriter struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+	nextID  int
+}
+
+// NewSSEWriter prepares w for server-sent events: it sets the
+// text/event-stream headers, disables caching and proxy buffering, and
+// sends the response headers.
+//
+// Parameters:
+//   - w: Response to write the events to
+//
+// Returns the writer, or an error if w does not support flushing, in which
+// case nothing has been written.
+func NewSSEWriter(w http.ResponseWriter) (*SSEWriter, error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, errors.New("response writer does not support flushing")
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	// Disables response buffering in nginx and compatible proxies.
+	header.Set("X-Accel-Buffering", "no")
+	header.Del("Content-Length")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	return &SSEWriter{w: w, flusher: flusher}, nil
+}
+
+// Send writes an event and flushes it. Data is encoded as JSON unless it is
+// a string or []byte, which are sent as is; multi-line data is split across
+// data fields. Events are numbered with increasing IDs.
+//
+// Parameters:
+//   - event: Event name, or "" for the default "message" event
+//   - data: Event payload
+//
+// Returns an error if the data cannot be encoded or the client has gone away.
+func (s *SSEWriter) Send(event string, data interface{}) error {
+	var payload string
+	switch v := data.(type) {
+	case string:
+		payload = v
+	case []byte:
+		payload = string(v)
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("failed to encode event: %w", err)
+		}
+		payload = string(encoded)
+	}
+
+	var b strings.Builder
+	b.WriteString("id: ")
+	b.WriteString(strconv.Itoa(s.nextID))
+	b.WriteByte('\n')
+	if event != "" {
+		b.WriteString("event: ")
+		b.WriteString(event)
+		b.WriteByte('\n')
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(payload, "\r\n", "\n"), "\n") {
+		b.WriteString("data: ")
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	b.WriteByte('\n')
+	s.nextID++
+
+	return s.write(b.String())
+}
+
+// Comment writes a comment line, which browsers ignore. Comments keep idle
+// connections open through proxies that close silent connections.
+func (s *SSEWriter) Comment(text string) error {
+	return s.write(": " + strings.ReplaceAll(text, "\n", " ") + "\n\n")
+}
+
+// write writes raw event stream text and flushes it.
+func (s *SSEWriter) write(text string) error {
+	if _, err := io.WriteString(s.w, text); err != nil {
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+	s.flusher.Flush()
+	return nil
+}
+
+// SSEProxyOptions configures the forwarding of an SDK stream to a browser.
+type SSEProxyOptions struct {
+	// Event is the event name of stream items (default: "chunk")
+	Event string
+
+	// DoneEvent is the event sent after the last item, so the browser can
+	// close its EventSource instead of reconnecting (default: "done")
+	DoneEvent string
+
+	// ErrorEvent is the event sent when the stream fails (default: "error")
+	ErrorEvent string
+
+	// ExposeErrors sends the stream error message to the browser; otherwise
+	// a generic message is sent, so internal details do not leak (default: false)
+	ExposeErrors bool
+
+	// Heartbeat is the interval of keep-alive comments sent while the stream
+	// is idle; negative disables them (default: 15 seconds)
+	Heartbeat time.Duration
+}
+
+// ProxySSE forwards the items of an SDK stream to a browser as server-sent
+// events, until the stream ends, fails, or the browser disconnects.
+//
+// Each item is sent as a JSON event. At the end of the stream a done event
+// with data "[DONE]" is sent; if the stream fails, an error event with a
+// JSON {"error": message} payload is sent instead. Keep-alive comments are
+// sent while the stream is idle. When the browser disconnects, stop is
+// called to release the stream, so that generation is not billed for
+// nobody.
+//
+// Parameters:
+//   - w: Response to write the events to
+//   - r: Request being served, whose context signals the browser disconnecting
+//   - recv: Receives the next item, returning io.EOF after the last one
+//   - stop: Stops the stream, unblocking recv; typically the stream's Close
+//   - options: Proxy options (can be nil for defaults)
+//
+// Returns nil when the stream ended, the stream error if it failed, or the
+// request context's error if the browser disconnected.
+//
+// Example usage:
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//	    stream, err := client.AI.GenerateCodeStream(r.Context(), request)
+//	    if err != nil {
+//	        http.Error(w, "generation failed", http.StatusBadGateway)
+//	        return
+//	    }
+//	    defer stream.Close()
+//	    zoptal.ProxySSE(w, r, stream.Recv, stream.Close, nil)
+//	}
+func ProxySSE[T any](w http.ResponseWriter, r *http.Request, recv func() (T, error), stop func() error, options *SSEProxyOptions) error {
+	if options == nil {
+		options = &SSEProxyOptions{}
+	}
+	event := options.Event
+	if event == "" {
+		event = "chunk"
+	}
+	doneEvent := options.DoneEvent
+	if doneEvent == "" {
+		doneEvent = "done"
+	}
+	errorEvent := options.ErrorEvent
+	if errorEvent == "" {
+		errorEvent = "error"
+	}
+	heartbeat := options.Heartbeat
+	if heartbeat == 0 {
+		heartbeat = 15 * time.Second
+	}
+
+	sse, err := NewSSEWriter(w)
+	if err != nil {
+		return err
+	}
+
+	type received struct {
+		item T
+		err  error
+	}
+	items := make(chan received)
+	next := make(chan struct{}, 1)
+	done := make(chan struct{})
+	defer close(done)
+
+	// recv blocks, so it runs on its own goroutine, one item at a time, while
+	// this one watches for heartbeats and the browser disconnecting.
+	go func() {
+		for range next {
+			item, err := recv()
+			select {
+			case items <- received{item: item, err: err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	defer close(next)
+	next <- struct{}{}
+
+	var ticks <-chan time.Time
+	if heartbeat > 0 {
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
+
+	for {
+		select {
+		case <-r.Context().Done():
+			if stop != nil {
+				stop()
+			}
+			return r.Context().Err()
+
+		case <-ticks:
+			if err := sse.Comment("ping"); err != nil {
+				if stop != nil {
+					stop()
+				}
+				return err
+			}
+
+		case result := <-items:
+			if result.err == io.EOF {
+				return sse.Send(doneEvent, "[DONE]")
+			}
+			if result.err != nil {
+				message := "stream failed"
+				if options.ExposeErrors {
+					message = result.err.Error()
+				}
+				sse.Send(errorEvent, map[string]string{"error": message})
+				return result.err
+			}
+			if err := sse.Send(event, result.item); err != nil {
+				if stop != nil {
+					stop()
+				}
+				return err
+			}
+			next <- struct{}{}
+		}
+	}
+}
+
+// ServeSSE forwards the code chunks of the stream to a browser as
+// server-sent events and closes the stream; see ProxySSE.
+//
+// Parameters:
+//   - w: Response to write the events to
+//   - r: Request being served
+//   - options: Proxy options (can be nil for defaults)
+//
+// Returns nil when generation completed, or the error that ended it.
+func (s *CodeStream) ServeSSE(w http.ResponseWriter, r *http.Request, options *SSEProxyOptions) error {
+	defer s.Close()
+	return ProxySSE(w, r, s.Recv, s.Close, options)
+}
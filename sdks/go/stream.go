@@ -0,0 +1,74 @@
+package zoptal
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// Stream ownership contract
+//
+// Every stream, session, watcher, and iterator returned by the SDK implements
+// io.Closer, and the caller owns it: it must be closed when no longer needed,
+// typically with defer. Close is idempotent, safe to call from any goroutine,
+// and unblocks any pending reads, which then return ErrStreamClosed.
+//
+// Methods returning such values follow a naming convention so that the
+// zoptal-streamcheck tool (cmd/zoptal-streamcheck) can find un-closed streams
+// in user code: the method name ends in "Stream" or starts with "Open",
+// "Watch", or "Subscribe".
+
+// ErrStreamClosed is returned by reads on a stream that has been closed.
+var ErrStreamClosed = errors.New("zoptal: stream closed")
+
+// streamState implements the stream ownership contract and is embedded by all
+// SDK stream types.
+type streamState struct {
+	once    sync.Once
+	done    chan struct{}
+	closer  io.Closer
+	err     error
+	release func()
+}
+
+// newStreamState creates a streamState that closes closer (which may be nil)
+// on Close and reports its goroutine as a stream in the runtime metrics.
+func newStreamState(closer io.Closer, metrics *runtimeMetrics) *streamState {
+	s := &streamState{
+		done:   make(chan struct{}),
+		closer: closer,
+	}
+	if metrics != nil {
+		s.release = metrics.track(GoroutineStreams)
+	}
+	return s
+}
+
+// Close closes the stream. It is idempotent and unblocks pending reads.
+func (s *streamState) Close() error {
+	s.once.Do(func() {
+		close(s.done)
+		if s.closer != nil {
+			s.err = s.closer.Close()
+		}
+		if s.release != nil {
+			s.release()
+		}
+	})
+	return s.err
+}
+
+// Done returns a channel that is closed when the stream is closed.
+func (s *streamState) Done() <-chan struct{} {
+	return s.done
+}
+
+// isClosed reports whether Close has been called.
+func (s *streamState) isClosed() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,207 @@
+package zoptal
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StaleWhileRevalidateOptions contains options for serving cached GET
+// responses while they are refreshed in the background.
+//
+// With stale-while-revalidate, a cached response younger than the staleness
+// bound is returned immediately, without waiting for the network, and a
+// background request refreshes the cache for the next call. This makes
+// repeated reads of slowly changing data, such as templates and project
+// metadata, effectively instant. It requires ClientOptions.Cache.
+type StaleWhileRevalidateOptions struct {
+	// MaxStale is how old a cached response may be and still be served
+	// without waiting for the network (default: 5 minutes)
+	MaxStale time.Duration
+
+	// FreshFor is how long a cached response is served without starting a
+	// background refresh (default: 0, refresh on every hit)
+	FreshFor time.Duration
+
+	// Endpoints sets MaxStale per endpoint, keyed by endpoint path prefix
+	// relative to the API root, such as "/templates" or "/projects/". When
+	// set, only matching endpoints are served stale; the longest matching
+	// prefix wins and a zero duration disables stale serving for that
+	// prefix (optional)
+	Endpoints map[string]time.Duration
+}
+
+// swr serves cached GET responses while revalidating them in the background.
+type swr struct {
+	maxStale  time.Duration
+	freshFor  time.Duration
+	endpoints map[string]time.Duration
+	timeout   time.Duration
+
+	mu       sync.Mutex
+	inflight map[string]bool
+}
+
+// newSWR creates an swr from the given options, applying defaults.
+func newSWR(options *StaleWhileRevalidateOptions, timeout time.Duration) *swr {
+	s := &swr{
+		maxStale:  options.MaxStale,
+		freshFor:  options.FreshFor,
+		endpoints: make(map[string]time.Duration, len(options.Endpoints)),
+		timeout:   timeout,
+		inflight:  make(map[string]bool),
+	}
+	if s.maxStale <= 0 {
+		s.maxStale = 5 * time.Minute
+	}
+	if s.timeout <= 0 {
+		s.timeout = 30 * time.Second
+	}
+	for prefix, maxStale := range options.Endpoints {
+		s.endpoints[strings.TrimPrefix(prefix, "/")] = maxStale
+	}
+	return s
+}
+
+// maxStaleFor returns the staleness bound for an endpoint, or zero if stale
+// responses must not be served for it. ok is false if the request is not
+// under the API root, in which case only the default bound applies.
+func (s *swr) maxStaleFor(endpoint string, ok bool) time.Duration {
+	if len(s.endpoints) == 0 {
+		return s.maxStale
+	}
+	if !ok {
+		return 0
+	}
+
+	best, maxStale := -1, time.Duration(0)
+	for prefix, d := range s.endpoints {
+		if strings.HasPrefix(endpoint, prefix) && len(prefix) > best {
+			best, maxStale = len(prefix), d
+		}
+	}
+	return maxStale
+}
+
+// startRefresh marks key as being refreshed, reporting false if a refresh
+// is already in flight.
+func (s *swr) startRefresh(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.inflight[key] {
+		return false
+	}
+	s.inflight[key] = true
+	return true
+}
+
+// endRefresh clears the in-flight mark for key.
+func (s *swr) endRefresh(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.inflight, key)
+}
+
+// endpointOf returns the endpoint of a request URL relative to the API root
+// of the active base URL, reporting false if the URL is not under it.
+func (c *HTTPClient) endpointOf(u *url.URL) (string, bool) {
+	target := *u
+	target.RawQuery, target.Fragment = "", ""
+	full := target.String()
+	endpoint := strings.TrimPrefix(full, c.buildURL(""))
+	return endpoint, len(endpoint) < len(full)
+}
+
+// serveStale returns the cached response for req if it is within the
+// staleness bound, refreshing it in the background when it is no longer
+// fresh. It returns nil if the request must go to the network.
+func (c *HTTPClient) serveStale(req *http.Request, key string, cached *CachedResponse) *http.Response {
+	maxStale := c.swr.maxStaleFor(c.endpointOf(req.URL))
+	age := time.Since(cached.StoredAt)
+	if maxStale <= 0 || age > maxStale {
+		return nil
+	}
+
+	if age > c.swr.freshFor && c.swr.startRefresh(key) {
+		refresh := req.Clone(context.Background())
+		go c.refreshCached(refresh, key, cached)
+	}
+
+	header := make(http.Header)
+	if cached.ContentType != "" {
+		header.Set("Content-Type", cached.ContentType)
+	}
+	if cached.Link != "" {
+		header.Set("Link", cached.Link)
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(cached.Body)),
+		ContentLength: int64(len(cached.Body)),
+		Request:       req,
+	}
+}
+
+// refreshCached revalidates a cached response in the background.
+func (c *HTTPClient) refreshCached(req *http.Request, key string, cached *CachedResponse) {
+	defer c.metrics.track(GoroutineWorkers)()
+	defer c.swr.endRefresh(key)
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.swr.timeout)
+	defer cancel()
+	req = req.WithContext(ctx)
+
+	if cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+	if cached.LastModified != "" {
+		req.Header.Set("If-Modified-Since", cached.LastModified)
+	}
+
+	resp, err := c.send(req)
+	if err != nil {
+		if c.debug {
+			log.Printf("HTTP GET %s: background refresh failed: %v", req.URL, err)
+		}
+		return
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		refreshed := *cached
+		refreshed.StoredAt = time.Now()
+		c.cache.Set(key, &refreshed)
+	case http.StatusOK:
+		body, err := readBody(resp.Body, defaultMaxResponseBytes)
+		if err != nil {
+			return
+		}
+		c.cache.Set(key, &CachedResponse{
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			ContentType:  resp.Header.Get("Content-Type"),
+			Link:         strings.Join(resp.Header.Values("Link"), ", "),
+			Body:         body,
+			StoredAt:     time.Now(),
+		})
+	default:
+		if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+			c.cache.Delete(key)
+		}
+		if c.debug {
+			log.Printf("HTTP GET %s: background refresh returned %d", req.URL, resp.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Template is a project template.
+type Template struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Category    string    `json:"category,omitempty"`
+	Language    string    `json:"language,omitempty"`
+	Framework   string    `json:"framework,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+	Features    []string  `json:"features,omitempty"`
+	PreviewURL  string    `json:"preview_url,omitempty"`
+	Version     string    `json:"version,omitempty"`
+	Visibility  string    `json:"visibility,omitempty"`
+	Status      string    `json:"status,omitempty"`
+	OrgID       string    `json:"org_id,omitempty"`
+	Downloads   int64     `json:"downloads"`
+	Stars       int64     `json:"stars"`
+	Popularity  float64   `json:"popularity"`
+	UpdatedAt   Timestamp `json:"updated_at"`
+
+	// Raw is the undecoded template, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// TemplateSearchOptions contains filters for searching project templates.
+type TemplateSearchOptions struct {
+	// Query is free text matched against template names and descriptions (optional)
+	Query string
+
+	// Language filters by programming language, e.g. "typescript" (optional)
+	Language string
+
+	// Framework filters by framework, e.g. "react" (optional)
+	Framework string
+
+	// Tags filters to templates having all of the given tags (optional)
+	Tags []string
+
+	// Sort orders the results: "popularity", "updated", or "name" (default: "popularity")
+	Sort string
+
+	// Pagination selects the page of results (optional)
+	Pagination *Pagination
+}
+
+// TemplateSearchResult is a page of template search results.
+type TemplateSearchResult struct {
+	Templates []Template `json:"-"`
+	Total     int        `json:"total"`
+	Page      int        `json:"page"`
+	Pages     int        `json:"pages"`
+}
+
+// SearchTemplates searches and filters the available project templates.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - options: Search filters (can be nil to list all templates)
+//   - opts: Request options (optional)
+//
+// Returns a page of matching templates or an error if the request fails.
+func (s *ProjectService) SearchTemplates(ctx context.Context, options *TemplateSearchOptions, opts ...RequestOption) (*TemplateSearchResult, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if options == nil {
+		options = &TemplateSearchOptions{}
+	}
+	switch options.Sort {
+	case "", "popularity", "updated", "name":
+	default:
+		return nil, NewValidationError("sort must be 'popularity', 'updated', or 'name'")
+	}
+
+	query := NewQuery()
+	if options.Query != "" {
+		query.Set("q", options.Query)
+	}
+	if options.Language != "" {
+		query.Set("language", options.Language)
+	}
+	if options.Framework != "" {
+		query.Set("framework", options.Framework)
+	}
+	query.Add("tag", options.Tags...)
+	if options.Sort != "" {
+		query.Set("sort", options.Sort)
+	}
+	options.Pagination.apply(query)
+
+	var response struct {
+		TemplateSearchResult
+		Templates []json.RawMessage `json:"templates"`
+	}
+	if err := s.client.GetQuery(ctx, "/projects/templates", query, &response); err != nil {
+		return nil, fmt.Errorf("failed to search templates: %w", err)
+	}
+
+	result := response.TemplateSearchResult
+	result.Templates = make([]Template, len(response.Templates))
+	for i, raw := range response.Templates {
+		if err := decodeTyped(raw, &result.Templates[i], &result.Templates[i].Raw); err != nil {
+			return nil, fmt.Errorf("failed to search templates: %w", err)
+		}
+	}
+	return &result, nil
+}
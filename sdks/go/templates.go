@@ -0,0 +1,241 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// TemplateService handles private organization templates.
+//
+// Templates are created from existing projects with Projects.PublishAsTemplate
+// and then versioned and published here, so teams can standardize their
+// project scaffolds.
+type TemplateService struct {
+	client *HTTPClient
+}
+
+// TemplateVersion is a version of a template.
+type TemplateVersion struct {
+	Version         string    `json:"version"`
+	SourceProjectID string    `json:"source_project_id,omitempty"`
+	Changelog       string    `json:"changelog,omitempty"`
+	Published       bool      `json:"published"`
+	CreatedAt       Timestamp `json:"created_at"`
+}
+
+// PublishTemplateOptions contains options for creating a template from a project.
+type PublishTemplateOptions struct {
+	// Name is the name of the template (required)
+	Name string
+
+	// Description is the description of the template (optional)
+	Description string
+
+	// Version is the first version of the template (default: "1.0.0")
+	Version string
+
+	// Tags are used to find the template in searches (optional)
+	Tags []string
+
+	// Publish makes the template available to the organization immediately
+	// instead of creating it as a draft (default: false)
+	Publish bool
+}
+
+// TemplateVersionOptions contains options for creating a template version.
+type TemplateVersionOptions struct {
+	// Version is the new version, which must be greater than the latest
+	// version (required)
+	Version string
+
+	// Changelog describes the changes in the version (optional)
+	Changelog string
+}
+
+// PublishAsTemplate creates a private organization template from a project.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project to create the template from
+//   - options: Template options
+//   - opts: Request options (optional)
+//
+// Returns the created template or an error if the request fails.
+func (s *ProjectService) PublishAsTemplate(ctx context.Context, projectID string, options *PublishTemplateOptions, opts ...RequestOption) (*Template, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+	if options == nil || strings.TrimSpace(options.Name) == "" {
+		return nil, NewValidationError("template name is required")
+	}
+
+	version := options.Version
+	if version == "" {
+		version = "1.0.0"
+	}
+	data := map[string]interface{}{
+		"name":        strings.TrimSpace(options.Name),
+		"description": options.Description,
+		"version":     version,
+		"visibility":  "org",
+		"publish":     options.Publish,
+	}
+	if len(options.Tags) > 0 {
+		data["tags"] = options.Tags
+	}
+
+	template, err := postTemplate(ctx, s.client, fmt.Sprintf("/projects/%s/publish-template", projectID), data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish project %s as template: %w", projectID, err)
+	}
+	return template, nil
+}
+
+// Get gets a template.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - templateID: ID of the template
+//   - opts: Request options (optional)
+//
+// Returns the template or an error if the request fails.
+func (s *TemplateService) Get(ctx context.Context, templateID string, opts ...RequestOption) (*Template, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if templateID == "" {
+		return nil, NewValidationError("template ID is required")
+	}
+
+	var raw json.RawMessage
+	if err := s.client.Get(ctx, fmt.Sprintf("/templates/%s", templateID), nil, &raw); err != nil {
+		return nil, fmt.Errorf("failed to get template %s: %w", templateID, err)
+	}
+
+	var template Template
+	if err := decodeTyped(raw, &template, &template.Raw); err != nil {
+		return nil, fmt.Errorf("failed to get template %s: %w", templateID, err)
+	}
+	return &template, nil
+}
+
+// ListVersions lists the versions of a template, newest first.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - templateID: ID of the template
+//   - opts: Request options (optional)
+//
+// Returns the template versions or an error if the request fails.
+func (s *TemplateService) ListVersions(ctx context.Context, templateID string, opts ...RequestOption) ([]TemplateVersion, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if templateID == "" {
+		return nil, NewValidationError("template ID is required")
+	}
+
+	var result struct {
+		Versions []TemplateVersion `json:"versions"`
+	}
+	if err := s.client.Get(ctx, fmt.Sprintf("/templates/%s/versions", templateID), nil, &result); err != nil {
+		return nil, fmt.Errorf("failed to list versions of template %s: %w", templateID, err)
+	}
+	return result.Versions, nil
+}
+
+// CreateVersion creates a new draft version of a template from the current
+// state of a project.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - templateID: ID of the template
+//   - projectID: ID of the project to snapshot
+//   - options: Version options
+//   - opts: Request options (optional)
+//
+// Returns the created version or an error if the request fails.
+func (s *TemplateService) CreateVersion(ctx context.Context, templateID, projectID string, options *TemplateVersionOptions, opts ...RequestOption) (*TemplateVersion, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if templateID == "" {
+		return nil, NewValidationError("template ID is required")
+	}
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+	if options == nil || options.Version == "" {
+		return nil, NewValidationError("version is required")
+	}
+
+	data := map[string]interface{}{
+		"project_id": projectID,
+		"version":    options.Version,
+		"changelog":  options.Changelog,
+	}
+
+	var version TemplateVersion
+	if err := s.client.Post(ctx, fmt.Sprintf("/templates/%s/versions", templateID), data, &version); err != nil {
+		return nil, fmt.Errorf("failed to create version of template %s: %w", templateID, err)
+	}
+	return &version, nil
+}
+
+// Publish makes a version of a template the one used for new projects in
+// the organization.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - templateID: ID of the template
+//   - version: Version to publish
+//   - opts: Request options (optional)
+//
+// Returns the updated template or an error if the request fails.
+func (s *TemplateService) Publish(ctx context.Context, templateID, version string, opts ...RequestOption) (*Template, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if templateID == "" {
+		return nil, NewValidationError("template ID is required")
+	}
+	if version == "" {
+		return nil, NewValidationError("version is required")
+	}
+
+	data := map[string]interface{}{"version": version}
+	template, err := postTemplate(ctx, s.client, fmt.Sprintf("/templates/%s/publish", templateID), data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish template %s: %w", templateID, err)
+	}
+	return template, nil
+}
+
+// Delete deletes a template. Projects created from it are not affected.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - templateID: ID of the template
+//   - opts: Request options (optional)
+//
+// Returns an error if the request fails.
+func (s *TemplateService) Delete(ctx context.Context, templateID string, opts ...RequestOption) error {
+	ctx = WithRequestOptions(ctx, opts...)
+	if templateID == "" {
+		return NewValidationError("template ID is required")
+	}
+
+	if err := s.client.Delete(ctx, fmt.Sprintf("/templates/%s", templateID), nil); err != nil {
+		return fmt.Errorf("failed to delete template %s: %w", templateID, err)
+	}
+	return nil
+}
+
+// postTemplate sends a POST request and decodes the template response.
+func postTemplate(ctx context.Context, client *HTTPClient, endpoint string, data interface{}) (*Template, error) {
+	var raw json.RawMessage
+	if err := client.Post(ctx, endpoint, data, &raw); err != nil {
+		return nil, err
+	}
+
+	var template Template
+	if err := decodeTyped(raw, &template, &template.Raw); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
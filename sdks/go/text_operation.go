@@ -0,0 +1,313 @@
+package zoptal
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// EditComponent is one component of a TextOperation. Exactly one of its
+// fields is set.
+type EditComponent struct {
+	// Retain skips over this many characters, leaving them unchanged
+	Retain int `json:"retain,omitempty"`
+
+	// Insert inserts this text at the current position
+	Insert string `json:"insert,omitempty"`
+
+	// Delete deletes this many characters at the current position
+	Delete int `json:"delete,omitempty"`
+}
+
+// TextOperation is an operational-transform edit of a text document.
+//
+// An operation walks the whole document from start to end: its components
+// retain, insert, or delete characters, and the characters retained and
+// deleted must add up to the length of the document it applies to. Lengths
+// and positions count Unicode code points, not bytes.
+//
+// Operations are built by chaining Retain, Insert, and Delete:
+//
+//	// Replace "world" with "Go" in "hello world"
+//	op := zoptal.NewTextOperation().Retain(6).Delete(5).Insert("Go")
+type TextOperation struct {
+	Components []EditComponent `json:"components"`
+
+	// BaseLength is the length of the document the operation applies to
+	BaseLength int `json:"base_length"`
+
+	// TargetLength is the length of the document after the operation
+	TargetLength int `json:"target_length"`
+}
+
+// NewTextOperation creates an empty text operation.
+func NewTextOperation() *TextOperation {
+	return &TextOperation{}
+}
+
+// Retain appends a component skipping n characters.
+func (o *TextOperation) Retain(n int) *TextOperation {
+	if n <= 0 {
+		return o
+	}
+	o.BaseLength += n
+	o.TargetLength += n
+	if last := o.last(0); last != nil && last.Retain > 0 {
+		last.Retain += n
+		return o
+	}
+	o.Components = append(o.Components, EditComponent{Retain: n})
+	return o
+}
+
+// Insert appends a component inserting text.
+//
+// An insert directly following a delete is moved before it, so that equal
+// edits always have the same components.
+func (o *TextOperation) Insert(text string) *TextOperation {
+	if text == "" {
+		return o
+	}
+	o.TargetLength += utf8.RuneCountInString(text)
+
+	last := o.last(0)
+	switch {
+	case last != nil && last.Insert != "":
+		last.Insert += text
+	case last != nil && last.Delete > 0:
+		if prev := o.last(1); prev != nil && prev.Insert != "" {
+			prev.Insert += text
+		} else {
+			o.Components = append(o.Components, *last)
+			o.Components[len(o.Components)-2] = EditComponent{Insert: text}
+		}
+	default:
+		o.Components = append(o.Components, EditComponent{Insert: text})
+	}
+	return o
+}
+
+// Delete appends a component deleting n characters.
+func (o *TextOperation) Delete(n int) *TextOperation {
+	if n <= 0 {
+		return o
+	}
+	o.BaseLength += n
+	if last := o.last(0); last != nil && last.Delete > 0 {
+		last.Delete += n
+		return o
+	}
+	o.Components = append(o.Components, EditComponent{Delete: n})
+	return o
+}
+
+// IsNoop reports whether the operation leaves the document unchanged.
+func (o *TextOperation) IsNoop() bool {
+	return len(o.Components) == 0 || (len(o.Components) == 1 && o.Components[0].Retain > 0)
+}
+
+// Apply applies the operation to a document.
+//
+// Parameters:
+//   - text: Document to edit, whose length must equal the operation's BaseLength
+//
+// Returns the edited document or an error if the operation does not fit it.
+func (o *TextOperation) Apply(text string) (string, error) {
+	runes := []rune(text)
+	if len(runes) != o.BaseLength {
+		return "", NewCollaborationError(fmt.Sprintf("operation base length %d does not match document length %d", o.BaseLength, len(runes)))
+	}
+
+	result := make([]rune, 0, o.TargetLength)
+	pos := 0
+	for _, c := range o.Components {
+		switch {
+		case c.Retain > 0:
+			if pos+c.Retain > len(runes) {
+				return "", NewCollaborationError("operation retains past the end of the document")
+			}
+			result = append(result, runes[pos:pos+c.Retain]...)
+			pos += c.Retain
+		case c.Insert != "":
+			result = append(result, []rune(c.Insert)...)
+		case c.Delete > 0:
+			pos += c.Delete
+		}
+	}
+	if pos != len(runes) {
+		return "", NewCollaborationError("operation does not cover the whole document")
+	}
+	return string(result), nil
+}
+
+// last returns the component skip places from the end, or nil.
+func (o *TextOperation) last(skip int) *EditComponent {
+	if i := len(o.Components) - 1 - skip; i >= 0 {
+		return &o.Components[i]
+	}
+	return nil
+}
+
+// componentCursor walks the components of an operation, allowing the
+// current component to be partially consumed.
+type componentCursor struct {
+	components []EditComponent
+	index      int
+	current    *EditComponent
+}
+
+// newComponentCursor creates a cursor positioned at the first component.
+func newComponentCursor(o *TextOperation) *componentCursor {
+	c := &componentCursor{components: o.Components}
+	c.next()
+	return c
+}
+
+// next advances to the next component, setting current to nil at the end.
+func (c *componentCursor) next() {
+	if c.index >= len(c.components) {
+		c.current = nil
+		return
+	}
+	component := c.components[c.index]
+	c.index++
+	c.current = &component
+}
+
+// consume consumes n characters of the current retain, delete, or insert.
+func (c *componentCursor) consume(n int) {
+	switch {
+	case c.current.Retain > 0:
+		c.current.Retain -= n
+		if c.current.Retain == 0 {
+			c.next()
+		}
+	case c.current.Delete > 0:
+		c.current.Delete -= n
+		if c.current.Delete == 0 {
+			c.next()
+		}
+	default:
+		runes := []rune(c.current.Insert)
+		c.current.Insert = string(runes[n:])
+		if c.current.Insert == "" {
+			c.next()
+		}
+	}
+}
+
+// componentLength returns the number of characters a component spans.
+func componentLength(c *EditComponent) int {
+	switch {
+	case c.Retain > 0:
+		return c.Retain
+	case c.Delete > 0:
+		return c.Delete
+	default:
+		return utf8.RuneCountInString(c.Insert)
+	}
+}
+
+// ComposeTextOperations combines two consecutive operations into one with
+// the same effect as applying a and then b.
+//
+// Parameters:
+//   - a: First operation
+//   - b: Second operation, whose BaseLength must equal a's TargetLength
+//
+// Returns the combined operation or an error if the operations are not consecutive.
+func ComposeTextOperations(a, b *TextOperation) (*TextOperation, error) {
+	if a.TargetLength != b.BaseLength {
+		return nil, NewCollaborationError("operations to compose are not consecutive")
+	}
+
+	result := NewTextOperation()
+	c1, c2 := newComponentCursor(a), newComponentCursor(b)
+	for c1.current != nil || c2.current != nil {
+		if c1.current != nil && c1.current.Delete > 0 {
+			result.Delete(c1.current.Delete)
+			c1.next()
+			continue
+		}
+		if c2.current != nil && c2.current.Insert != "" {
+			result.Insert(c2.current.Insert)
+			c2.next()
+			continue
+		}
+		if c1.current == nil || c2.current == nil {
+			return nil, NewCollaborationError("operations to compose have mismatched components")
+		}
+
+		n := componentLength(c1.current)
+		if l := componentLength(c2.current); l < n {
+			n = l
+		}
+		switch {
+		case c1.current.Retain > 0 && c2.current.Retain > 0:
+			result.Retain(n)
+		case c1.current.Insert != "" && c2.current.Retain > 0:
+			result.Insert(string([]rune(c1.current.Insert)[:n]))
+		case c1.current.Retain > 0 && c2.current.Delete > 0:
+			result.Delete(n)
+		}
+		// An insert followed by a delete of the same characters cancels out.
+		c1.consume(n)
+		c2.consume(n)
+	}
+	return result, nil
+}
+
+// TransformTextOperations transforms two concurrent operations on the same
+// document so that they can be applied after each other.
+//
+// It returns a' and b' such that applying a then b' gives the same document
+// as applying b then a'. When both operations insert at the same position,
+// a's insert is placed first.
+//
+// Parameters:
+//   - a: First operation
+//   - b: Second operation, whose BaseLength must equal a's BaseLength
+//
+// Returns the transformed operations or an error if the operations are not concurrent.
+func TransformTextOperations(a, b *TextOperation) (*TextOperation, *TextOperation, error) {
+	if a.BaseLength != b.BaseLength {
+		return nil, nil, NewCollaborationError("operations to transform are not concurrent")
+	}
+
+	a2, b2 := NewTextOperation(), NewTextOperation()
+	c1, c2 := newComponentCursor(a), newComponentCursor(b)
+	for c1.current != nil || c2.current != nil {
+		if c1.current != nil && c1.current.Insert != "" {
+			a2.Insert(c1.current.Insert)
+			b2.Retain(componentLength(c1.current))
+			c1.next()
+			continue
+		}
+		if c2.current != nil && c2.current.Insert != "" {
+			a2.Retain(componentLength(c2.current))
+			b2.Insert(c2.current.Insert)
+			c2.next()
+			continue
+		}
+		if c1.current == nil || c2.current == nil {
+			return nil, nil, NewCollaborationError("operations to transform have mismatched components")
+		}
+
+		n := componentLength(c1.current)
+		if l := componentLength(c2.current); l < n {
+			n = l
+		}
+		switch {
+		case c1.current.Retain > 0 && c2.current.Retain > 0:
+			a2.Retain(n)
+			b2.Retain(n)
+		case c1.current.Delete > 0 && c2.current.Retain > 0:
+			a2.Delete(n)
+		case c1.current.Retain > 0 && c2.current.Delete > 0:
+			b2.Delete(n)
+		}
+		// Characters deleted by both operations need no further deletion.
+		c1.consume(n)
+		c2.consume(n)
+	}
+	return a2, b2, nil
+}
@@ -0,0 +1,117 @@
+package zoptal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Timestamp is a time in an API model.
+//
+// Endpoints do not agree on a time format, so Timestamp decodes any of:
+//   - RFC 3339 strings, with or without fractional seconds
+//   - RFC 3339 strings without a time zone, taken as UTC
+//   - Unix epoch numbers (or numeric strings) in seconds, with optional
+//     fractional part, or in milliseconds for values of 1e12 and above
+//   - null or "", decoded as the zero time
+//
+// It always encodes as an RFC 3339 string in UTC, and the zero time as null.
+// Timestamp embeds time.Time, so its methods, such as Format and Before,
+// can be called on it directly.
+type Timestamp struct {
+	time.Time
+}
+
+// epochMillisThreshold is the smallest epoch value taken as milliseconds
+// rather than seconds; as seconds it would be in the year 33658.
+const epochMillisThreshold = 1e12
+
+// timestampLayouts are the string layouts accepted when decoding, in order.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// NewTimestamp creates a Timestamp from a time.
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{Time: t}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(strconv.Quote(t.UTC().Format(time.RFC3339Nano))), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	if data[0] != '"' {
+		parsed, err := parseEpoch(string(data))
+		if err != nil {
+			return err
+		}
+		t.Time = parsed
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid timestamp %s: %w", data, err)
+	}
+	parsed, err := parseTimestamp(s)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+// parseTimestamp parses a timestamp string in any of the accepted formats.
+func parseTimestamp(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			return parsed, nil
+		}
+	}
+	if parsed, err := parseEpoch(s); err == nil {
+		return parsed, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp %q: not RFC 3339 or a Unix epoch", s)
+}
+
+// parseEpoch parses a Unix epoch in seconds or milliseconds.
+func parseEpoch(s string) (time.Time, error) {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		if n >= epochMillisThreshold || n <= -epochMillisThreshold {
+			return time.UnixMilli(n).UTC(), nil
+		}
+		return time.Unix(n, 0).UTC(), nil
+	}
+
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return time.Time{}, fmt.Errorf("invalid timestamp %s: not RFC 3339 or a Unix epoch", s)
+	}
+
+	if math.Abs(value) >= epochMillisThreshold {
+		value /= 1000
+	}
+	seconds, fraction := math.Modf(value)
+	return time.Unix(int64(seconds), int64(math.Round(fraction*1e9))).UTC(), nil
+}
@@ -0,0 +1,431 @@
+package zoptal
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Token is an OAuth access token.
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	TokenType    string    `json:"token_type,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at"`
+
+	// IssuedAt is when the token was obtained (default: when the client received it)
+	IssuedAt time.Time `json:"issued_at"`
+
+	// Subject identifies the principal the token was issued to, such as the
+	// sub claim of a JWT (optional). Responses cached for a token are reused
+	// for later tokens of the same subject; without a subject they are keyed
+	// by the access token, and not reused after a refresh.
+	Subject string `json:"subject,omitempty"`
+}
+
+// TokenSource obtains OAuth access tokens.
+//
+// Token is called to obtain the first token and each time the current one
+// is due for refresh; the client never calls it concurrently.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// TokenRefreshOptions contains options for refreshing OAuth tokens.
+type TokenRefreshOptions struct {
+	// RefreshAt is the fraction of a token's lifetime after which it is
+	// refreshed in the background, in the range (0, 1) (default: 0.8)
+	RefreshAt float64
+
+	// Jitter randomizes the refresh time by up to this fraction of the
+	// token's lifetime, so that many clients do not refresh at once (default: 0.05)
+	Jitter float64
+
+	// OnRefresh is called after every refresh attempt (optional)
+	OnRefresh func(TokenRefreshEvent)
+}
+
+// TokenRefreshEvent reports a token refresh.
+type TokenRefreshEvent struct {
+	// Token is the new token; nil if the refresh failed
+	Token *Token
+
+	// Err is set if the refresh failed
+	Err error
+
+	// Proactive is true for scheduled refreshes before expiry, and false for
+	// refreshes forced by a missing or expired token
+	Proactive bool
+
+	Time time.Time
+}
+
+// tokenManager holds the current OAuth token and refreshes it.
+//
+// Refreshes are serialized: concurrent callers needing a token while a
+// refresh is in flight wait for that refresh instead of starting their own.
+type tokenManager struct {
+	source    TokenSource
+	refreshAt float64
+	jitter    float64
+	onRefresh func(TokenRefreshEvent)
+	debug     bool
+	metrics   *runtimeMetrics
+
+	mu       sync.Mutex
+	token    *Token
+	inflight *tokenRefresh
+	changed  chan struct{}
+}
+
+// tokenRefresh is a refresh in flight.
+type tokenRefresh struct {
+	done  chan struct{}
+	token *Token
+	err   error
+}
+
+// newTokenManager creates a token manager, applying defaults.
+func newTokenManager(source TokenSource, options *TokenRefreshOptions, debug bool, metrics *runtimeMetrics) *tokenManager {
+	if options == nil {
+		options = &TokenRefreshOptions{}
+	}
+	m := &tokenManager{
+		source:    source,
+		refreshAt: options.RefreshAt,
+		jitter:    options.Jitter,
+		onRefresh: options.OnRefresh,
+		debug:     debug,
+		metrics:   metrics,
+		changed:   make(chan struct{}, 1),
+	}
+	if m.refreshAt <= 0 || m.refreshAt >= 1 {
+		m.refreshAt = 0.8
+	}
+	if m.jitter <= 0 {
+		m.jitter = 0.05
+	}
+	return m
+}
+
+// accessToken returns a valid access token, refreshing it if it is missing
+// or expired.
+func (m *tokenManager) accessToken(ctx context.Context) (string, error) {
+	token, err := m.currentToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	return token.AccessToken, nil
+}
+
+// currentToken returns a valid token, refreshing the current one if it has
+// expired.
+func (m *tokenManager) currentToken(ctx context.Context) (*Token, error) {
+	m.mu.Lock()
+	token := m.token
+	m.mu.Unlock()
+
+	if token != nil && (token.ExpiresAt.IsZero() || time.Now().Before(token.ExpiresAt)) {
+		return token, nil
+	}
+	return m.refresh(ctx, false)
+}
+
+// refresh obtains a new token, joining a refresh already in flight.
+func (m *tokenManager) refresh(ctx context.Context, proactive bool) (*Token, error) {
+	m.mu.Lock()
+	call := m.inflight
+	if call == nil {
+		call = &tokenRefresh{done: make(chan struct{})}
+		m.inflight = call
+		m.mu.Unlock()
+		go m.doRefresh(call, proactive)
+	} else {
+		m.mu.Unlock()
+	}
+
+	select {
+	case <-call.done:
+		return call.token, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// doRefresh performs a refresh. It is detached from the context of the
+// caller that started it, so that other waiters are not failed when that
+// caller gives up.
+func (m *tokenManager) doRefresh(call *tokenRefresh, proactive bool) {
+	defer m.metrics.track(GoroutineWorkers)()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	token, err := m.source.Token(ctx)
+	if err == nil && (token == nil || token.AccessToken == "") {
+		err = NewAuthenticationError("token source returned no access token")
+	}
+	if err == nil && token.IssuedAt.IsZero() {
+		issued := *token
+		issued.IssuedAt = time.Now()
+		token = &issued
+	}
+
+	m.mu.Lock()
+	if err == nil {
+		m.token = token
+	}
+	m.inflight = nil
+	m.mu.Unlock()
+
+	call.token, call.err = token, err
+	close(call.done)
+
+	if err == nil {
+		select {
+		case m.changed <- struct{}{}:
+		default:
+		}
+	}
+	if m.debug {
+		if err != nil {
+			log.Printf("Zoptal token refresh failed: %v", err)
+		} else {
+			log.Printf("Zoptal token refreshed, expires at %v", token.ExpiresAt)
+		}
+	}
+	if m.onRefresh != nil {
+		event := TokenRefreshEvent{Err: err, Proactive: proactive, Time: time.Now()}
+		if err == nil {
+			event.Token = token
+		}
+		m.onRefresh(event)
+	}
+}
+
+// nextRefresh returns when the current token should be refreshed, or the
+// zero time if it never expires.
+func (m *tokenManager) nextRefresh() time.Time {
+	m.mu.Lock()
+	token := m.token
+	m.mu.Unlock()
+
+	if token == nil {
+		return time.Now()
+	}
+	if token.ExpiresAt.IsZero() {
+		return time.Time{}
+	}
+
+	lifetime := token.ExpiresAt.Sub(token.IssuedAt)
+	fraction := m.refreshAt + (rand.Float64()*2-1)*m.jitter
+	if fraction > 0.95 {
+		fraction = 0.95
+	}
+	return token.IssuedAt.Add(time.Duration(float64(lifetime) * fraction))
+}
+
+// authorize sets the Authorization header of a request, using the current
+// OAuth token when a TokenSource is configured and the API key otherwise.
+func (c *HTTPClient) authorize(req *http.Request) error {
+	if c.tokens == nil {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+		return nil
+	}
+
+	token, err := c.tokens.accessToken(req.Context())
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// minTokenRefreshInterval is the shortest time between background token
+// refreshes, so that a token already due when it arrives, e.g. because its
+// IssuedAt or ExpiresAt is stale or skewed, cannot cause a tight refresh
+// loop. Requests still refresh an expired token on demand.
+const minTokenRefreshInterval = 10 * time.Second
+
+// refreshTokens refreshes the OAuth token in the background before it
+// expires, until the client, or the client it was derived from, is closed.
+func (c *Client) refreshTokens() {
+	m := c.httpClient.tokens
+	defer c.httpClient.metrics.track(GoroutinePollers)()
+
+	retryDelay := time.Second
+	var lastRefresh time.Time
+	for {
+		var wait <-chan time.Time
+		var timer *time.Timer
+		if due := m.nextRefresh(); !due.IsZero() {
+			if earliest := lastRefresh.Add(minTokenRefreshInterval); due.Before(earliest) {
+				due = earliest
+			}
+			timer = time.NewTimer(time.Until(due))
+			wait = timer.C
+		}
+
+		select {
+		case <-wait:
+		case <-m.changed:
+			// A token was obtained on demand; reschedule for it.
+			if timer != nil {
+				timer.Stop()
+			}
+			continue
+		case <-c.done:
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		case <-c.rootDone():
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		_, err := m.refresh(ctx, true)
+		cancel()
+		lastRefresh = time.Now()
+		if err == nil {
+			retryDelay = time.Second
+			continue
+		}
+
+		// Retry with backoff; the current token stays in use until it expires.
+		select {
+		case <-time.After(jitter(retryDelay)):
+		case <-c.done:
+			return
+		case <-c.rootDone():
+			return
+		}
+		if retryDelay < time.Minute {
+			retryDelay *= 2
+		}
+	}
+}
+
+// RefreshTokenSource obtains access tokens with the OAuth 2.0 refresh_token
+// grant. If the server rotates refresh tokens, the new refresh token is used
+// for the next refresh.
+type RefreshTokenSource struct {
+	// TokenURL is the OAuth token endpoint (default: "https://api.zoptal.com/oauth/token")
+	TokenURL string
+
+	// ClientID and ClientSecret identify the OAuth client
+	ClientID     string
+	ClientSecret string
+
+	// RefreshToken is the initial refresh token (required)
+	RefreshToken string
+
+	// HTTPClient is the HTTP client used for token requests (default: http.DefaultClient)
+	HTTPClient *http.Client
+
+	mu sync.Mutex
+}
+
+// Token implements TokenSource.
+func (s *RefreshTokenSource) Token(ctx context.Context) (*Token, error) {
+	return s.token(ctx, nil)
+}
+
+// ScopedToken implements ScopedTokenSource by requesting the narrower scopes
+// in the refresh, as allowed by RFC 6749 section 6.
+func (s *RefreshTokenSource) ScopedToken(ctx context.Context, scopes []string) (*Token, error) {
+	return s.token(ctx, scopes)
+}
+
+// token refreshes the access token, limited to scopes if any.
+func (s *RefreshTokenSource) token(ctx context.Context, scopes []string) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.RefreshToken == "" {
+		return nil, NewValidationError("refresh token is required")
+	}
+	tokenURL := s.TokenURL
+	if tokenURL == "" {
+		tokenURL = "https://api.zoptal.com/oauth/token"
+	}
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {s.RefreshToken},
+	}
+	if s.ClientID != "" {
+		form.Set("client_id", s.ClientID)
+	}
+	if s.ClientSecret != "" {
+		form.Set("client_secret", s.ClientSecret)
+	}
+	if len(scopes) > 0 {
+		form.Set("scope", strings.Join(scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "zoptal-go-sdk/"+Version)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := readBody(resp.Body, defaultMaxResponseBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh token: %w", err)
+	}
+	if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized {
+		err := NewAuthenticationError(errorMessage(body, "refresh token rejected", "error_description", "error"))
+		return nil, withResponse(err, resp, body)
+	}
+	if resp.StatusCode >= 400 {
+		err := NewAPIErrorWithStatus(errorMessage(body, fmt.Sprintf("HTTP %d", resp.StatusCode), "error_description", "error"), resp.StatusCode)
+		return nil, withResponse(err, resp, body)
+	}
+
+	var result struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		TokenType    string `json:"token_type"`
+		ExpiresIn    int64  `json:"expires_in"`
+	}
+	if err := decodeJSON(body, &result); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	token := &Token{
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
+		TokenType:    result.TokenType,
+		IssuedAt:     now,
+	}
+	if result.ExpiresIn > 0 {
+		token.ExpiresAt = now.Add(time.Duration(result.ExpiresIn) * time.Second)
+	}
+	if result.RefreshToken != "" {
+		s.RefreshToken = result.RefreshToken
+	}
+	return token, nil
+}
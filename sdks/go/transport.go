@@ -0,0 +1,73 @@
+package zoptal
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+)
+
+// TransportOptions contains options for tuning the underlying HTTP transport.
+//
+// Zero values keep the defaults of http.DefaultTransport. These options are
+// ignored when ClientOptions.HTTPClient is set. In WebAssembly builds
+// (GOOS=js), where requests are sent with the browser's fetch API, the dial
+// and keep-alive options have no effect.
+type TransportOptions struct {
+	// MaxIdleConns is the maximum number of idle connections across all hosts (default: 100)
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost is the maximum number of idle connections kept per host (default: 2)
+	MaxIdleConnsPerHost int
+
+	// MaxConnsPerHost limits the total number of connections per host; zero means no limit
+	MaxConnsPerHost int
+
+	// IdleConnTimeout is how long an idle connection is kept open (default: 90 seconds)
+	IdleConnTimeout time.Duration
+
+	// KeepAlive is the TCP keep-alive period for new connections (default: 30 seconds)
+	KeepAlive time.Duration
+
+	// DialTimeout is the maximum time to wait for a TCP connection (default: 30 seconds)
+	DialTimeout time.Duration
+
+	// TLSHandshakeTimeout is the maximum time to wait for a TLS handshake (default: 10 seconds)
+	TLSHandshakeTimeout time.Duration
+
+	// DisableKeepAlives disables HTTP keep-alives, using each connection for a single request
+	DisableKeepAlives bool
+
+	// DisableHTTP2 forces HTTP/1.1 even when the server supports HTTP/2
+	DisableHTTP2 bool
+}
+
+// newTransport creates an HTTP transport from the given options.
+func newTransport(options *TransportOptions) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	configureDialer(transport, options)
+
+	if options.MaxIdleConns > 0 {
+		transport.MaxIdleConns = options.MaxIdleConns
+	}
+	if options.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = options.MaxIdleConnsPerHost
+	}
+	if options.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = options.MaxConnsPerHost
+	}
+	if options.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = options.IdleConnTimeout
+	}
+	if options.TLSHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = options.TLSHandshakeTimeout
+	}
+	transport.DisableKeepAlives = options.DisableKeepAlives
+
+	if options.DisableHTTP2 {
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	}
+
+	return transport
+}
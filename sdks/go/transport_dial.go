@@ -0,0 +1,24 @@
+//go:build !js
+
+package zoptal
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// configureDialer sets the TCP dial timeout and keep-alive period of transport.
+func configureDialer(transport *http.Transport, options *TransportOptions) {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	if options.DialTimeout > 0 {
+		dialer.Timeout = options.DialTimeout
+	}
+	if options.KeepAlive != 0 {
+		dialer.KeepAlive = options.KeepAlive
+	}
+	transport.DialContext = dialer.DialContext
+}
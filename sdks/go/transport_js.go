@@ -0,0 +1,11 @@
+//go:build js
+
+package zoptal
+
+import "net/http"
+
+// configureDialer leaves the dialer of transport unset. In WebAssembly builds
+// net/http sends requests with the fetch API, which manages connections
+// itself, and only as long as no dialer is configured: with one, requests
+// would fall back to sockets, which are not available.
+func configureDialer(transport *http.Transport, options *TransportOptions) {}
@@ -0,0 +1,265 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UsageGranularity is the width of the time buckets of a usage query.
+type UsageGranularity string
+
+// Usage granularities.
+const (
+	UsageHourly  UsageGranularity = "hour"
+	UsageDaily   UsageGranularity = "day"
+	UsageWeekly  UsageGranularity = "week"
+	UsageMonthly UsageGranularity = "month"
+)
+
+// UsageDimension is a dimension usage can be grouped by.
+type UsageDimension string
+
+// Usage dimensions.
+const (
+	UsageByProject UsageDimension = "project"
+	UsageByUser    UsageDimension = "user"
+	UsageByAPIKey  UsageDimension = "api_key"
+
+	// UsageByModel groups AI tokens by model; API requests and storage are
+	// reported under the model ""
+	UsageByModel UsageDimension = "model"
+)
+
+// defaultUsagePeriod is the period queried when UsageQuery.From is not set.
+const defaultUsagePeriod = 30 * 24 * time.Hour
+
+// UsageService reports the usage of the organization over time, for
+// dashboards, chargeback, and capacity planning.
+type UsageService struct {
+	client *HTTPClient
+}
+
+// UsageQuery selects the usage to report.
+type UsageQuery struct {
+	// From and To bound the half-open period [From, To) to report (default:
+	// the 30 days up to now)
+	From time.Time
+	To   time.Time
+
+	// Granularity is the width of the time buckets (default: UsageDaily)
+	Granularity UsageGranularity
+
+	// GroupBy splits the usage into a series per combination of values of
+	// these dimensions (default: a single series)
+	GroupBy []UsageDimension
+}
+
+// UsagePoint is the usage in a time bucket.
+type UsagePoint struct {
+	// Time is the start of the bucket
+	Time Timestamp `json:"time"`
+
+	APIRequests int64 `json:"api_requests"`
+	AITokens    int64 `json:"ai_tokens"`
+
+	// StorageBytes is the storage used at the end of the bucket
+	StorageBytes int64 `json:"storage_bytes"`
+}
+
+// UsageSeries is the usage of a group over time.
+type UsageSeries struct {
+	// Group holds the value of each GroupBy dimension for the series, e.g.
+	// {"project": "proj_123"}; empty without GroupBy
+	Group map[UsageDimension]string `json:"group,omitempty"`
+
+	// Points are the buckets of the period, oldest first, including empty
+	// buckets
+	Points []UsagePoint `json:"points"`
+}
+
+// Totals returns the usage of the series over the period: the sums of API
+// requests and AI tokens, and the storage used at its end.
+func (s *UsageSeries) Totals() UsagePoint {
+	var totals UsagePoint
+	for _, point := range s.Points {
+		totals.APIRequests += point.APIRequests
+		totals.AITokens += point.AITokens
+	}
+	if len(s.Points) > 0 {
+		totals.Time = s.Points[0].Time
+		totals.StorageBytes = s.Points[len(s.Points)-1].StorageBytes
+	}
+	return totals
+}
+
+// UsageReport is the usage of the organization over a period.
+type UsageReport struct {
+	From        Timestamp        `json:"from"`
+	To          Timestamp        `json:"to"`
+	Granularity UsageGranularity `json:"granularity"`
+	GroupBy     []UsageDimension `json:"group_by,omitempty"`
+	Series      []UsageSeries    `json:"series"`
+
+	// Raw is the undecoded response, including fields not modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+// Totals returns the usage of all series over the period.
+func (r *UsageReport) Totals() UsagePoint {
+	totals := UsagePoint{Time: r.From}
+	for i := range r.Series {
+		series := r.Series[i].Totals()
+		totals.APIRequests += series.APIRequests
+		totals.AITokens += series.AITokens
+		totals.StorageBytes += series.StorageBytes
+	}
+	return totals
+}
+
+// WriteCSV writes the report as CSV with a header row, one row per series
+// and bucket: the start of the bucket in RFC 3339, a column per GroupBy
+// dimension, and the api_requests, ai_tokens, and storage_bytes columns.
+//
+// Parameters:
+//   - w: Destination for the CSV
+//
+// Returns an error if writing fails.
+func (r *UsageReport) WriteCSV(w io.Writer) error {
+	dimensions := r.dimensions()
+	header := []string{"time"}
+	for _, dimension := range dimensions {
+		header = append(header, string(dimension))
+	}
+	header = append(header, "api_requests", "ai_tokens", "storage_bytes")
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, series := range r.Series {
+		for _, point := range series.Points {
+			record := []string{point.Time.UTC().Format(time.RFC3339)}
+			for _, dimension := range dimensions {
+				record = append(record, csvSafe(series.Group[dimension]))
+			}
+			record = append(record,
+				strconv.FormatInt(point.APIRequests, 10),
+				strconv.FormatInt(point.AITokens, 10),
+				strconv.FormatInt(point.StorageBytes, 10),
+			)
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// dimensions returns the dimensions of the report's groups, in the order of
+// GroupBy, followed by any others the series have in sorted order.
+func (r *UsageReport) dimensions() []UsageDimension {
+	dimensions := append([]UsageDimension(nil), r.GroupBy...)
+	seen := make(map[UsageDimension]bool)
+	for _, dimension := range dimensions {
+		seen[dimension] = true
+	}
+	var others []string
+	for _, series := range r.Series {
+		for dimension := range series.Group {
+			if !seen[dimension] {
+				seen[dimension] = true
+				others = append(others, string(dimension))
+			}
+		}
+	}
+	sort.Strings(others)
+	for _, dimension := range others {
+		dimensions = append(dimensions, UsageDimension(dimension))
+	}
+	return dimensions
+}
+
+// Query reports the usage of the organization as time series of API
+// requests, AI tokens, and storage.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - query: Period, granularity, and grouping (can be nil for the daily
+//     usage of the last 30 days)
+//   - opts: Request options (optional)
+//
+// Returns the report or an error if the request fails.
+//
+// Example usage:
+//
+//	report, err := client.Usage.Query(ctx, &zoptal.UsageQuery{
+//	    From:    time.Now().AddDate(0, -1, 0),
+//	    GroupBy: []zoptal.UsageDimension{zoptal.UsageByProject},
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	for _, series := range report.Series {
+//	    totals := series.Totals()
+//	    fmt.Printf("%s: %d AI tokens\n", series.Group[zoptal.UsageByProject], totals.AITokens)
+//	}
+func (s *UsageService) Query(ctx context.Context, query *UsageQuery, opts ...RequestOption) (*UsageReport, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if query == nil {
+		query = &UsageQuery{}
+	}
+	to := query.To
+	if to.IsZero() {
+		to = time.Now()
+	}
+	from := query.From
+	if from.IsZero() {
+		from = to.Add(-defaultUsagePeriod)
+	}
+	if !from.Before(to) {
+		return nil, NewValidationError("usage period must end after it starts")
+	}
+	granularity := query.Granularity
+	switch granularity {
+	case "":
+		granularity = UsageDaily
+	case UsageHourly, UsageDaily, UsageWeekly, UsageMonthly:
+	default:
+		return nil, NewValidationError(fmt.Sprintf("unsupported usage granularity %q", granularity))
+	}
+
+	params := NewQuery().
+		SetTime("from", from).
+		SetTime("to", to).
+		Set("granularity", string(granularity))
+	for _, dimension := range query.GroupBy {
+		if strings.TrimSpace(string(dimension)) == "" {
+			return nil, NewValidationError("group by dimensions must not be empty")
+		}
+		params.Add("group_by", string(dimension))
+	}
+
+	var raw json.RawMessage
+	if err := s.client.GetQuery(ctx, "/usage", params, &raw); err != nil {
+		return nil, fmt.Errorf("failed to query usage: %w", err)
+	}
+	var report UsageReport
+	if err := decodeTyped(raw, &report, &report.Raw); err != nil {
+		return nil, fmt.Errorf("failed to query usage: %w", err)
+	}
+	if report.Granularity == "" {
+		report.Granularity = granularity
+	}
+	if report.GroupBy == nil {
+		report.GroupBy = query.GroupBy
+	}
+	return &report, nil
+}
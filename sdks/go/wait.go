@@ -0,0 +1,130 @@
+package zoptal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Project statuses.
+const (
+	ProjectStatusProvisioning = "provisioning"
+	ProjectStatusReady        = "ready"
+	ProjectStatusFailed       = "failed"
+)
+
+// WaitOptions contains options for waiting for a long-running resource.
+type WaitOptions struct {
+	// PollInterval is the initial interval between checks of the resource
+	// (default: 1 second)
+	PollInterval time.Duration
+
+	// MaxPollInterval bounds the interval, which doubles after each check
+	// (default: 30 seconds)
+	MaxPollInterval time.Duration
+}
+
+// WaitFor polls a resource until it reaches a terminal state, backing off
+// exponentially between checks. poll fetches the resource and reports
+// whether it is done; WaitFor stops at the first error poll returns, so poll
+// should return an error for terminal failure states. Bound the wait with
+// the deadline of ctx.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - poll: Function fetching the resource and reporting whether it is done
+//   - options: Wait options (can be nil for defaults)
+//
+// Returns the final resource, or the last one fetched and an error if poll
+// fails or ctx is done first.
+//
+// Example usage:
+//
+//	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+//	defer cancel()
+//	scan, err := zoptal.WaitFor(ctx, func(ctx context.Context) (*zoptal.SecurityScan, bool, error) {
+//	    scan, err := client.Security.GetScan(ctx, scanID)
+//	    if err != nil {
+//	        return nil, false, err
+//	    }
+//	    return scan, scan.Status == zoptal.SecurityScanCompleted, nil
+//	}, nil)
+func WaitFor[T any](ctx context.Context, poll func(ctx context.Context) (T, bool, error), options *WaitOptions) (T, error) {
+	if options == nil {
+		options = &WaitOptions{}
+	}
+	backoff := newPollBackoff(options.PollInterval, options.MaxPollInterval, time.Second)
+
+	var last T
+	for {
+		resource, done, err := poll(ctx)
+		if err != nil {
+			return last, err
+		}
+		last = resource
+		if done {
+			return resource, nil
+		}
+		if err := backoff.wait(ctx); err != nil {
+			return last, err
+		}
+	}
+}
+
+// WaitUntilReady waits for a project, such as one just created, imported,
+// or cloned, to finish provisioning.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - projectID: ID of the project
+//   - options: Wait options (can be nil for defaults)
+//   - opts: Request options (optional)
+//
+// Returns the ready project, or an error if provisioning fails, a request
+// fails, or ctx is done first.
+//
+// Example usage:
+//
+//	project, err := client.Projects.Clone(ctx, templateID, nil)
+//	if err != nil {
+//	    return err
+//	}
+//	project, err = client.Projects.WaitUntilReady(ctx, project.ID, nil)
+func (s *ProjectService) WaitUntilReady(ctx context.Context, projectID string, options *WaitOptions, opts ...RequestOption) (*Project, error) {
+	ctx = WithRequestOptions(ctx, opts...)
+	if projectID == "" {
+		return nil, NewValidationError("project ID is required")
+	}
+
+	project, err := WaitFor(ctx, func(ctx context.Context) (*Project, bool, error) {
+		project, err := s.get(ctx, projectID)
+		if err != nil {
+			return nil, false, err
+		}
+		if project.Status == ProjectStatusFailed {
+			return project, true, NewAPIError(fmt.Sprintf("project %s failed to provision", projectID))
+		}
+		// Projects created before statuses were reported have none
+		return project, project.Status == ProjectStatusReady || project.Status == "", nil
+	}, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for project %s: %w", projectID, err)
+	}
+	return project, nil
+}
+
+// get gets a project.
+func (s *ProjectService) get(ctx context.Context, projectID string) (*Project, error) {
+	var raw json.RawMessage
+	if err := s.client.Get(ctx, "/projects/"+url.PathEscape(projectID), nil, &raw); err != nil {
+		return nil, err
+	}
+
+	var project Project
+	if err := decodeTyped(raw, &project, &project.Raw); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
@@ -0,0 +1,164 @@
+// Package zoptalaudit provides zoptal.AuditSink adapters for keeping an
+// application's own audit trail of the changes it made through the Zoptal
+// SDK: JSONL writes JSON Lines files that Verify checks for tampering, and
+// OTel emits OpenTelemetry log records.
+//
+//	sink, err := zoptalaudit.OpenJSONL("zoptal-audit.jsonl")
+//	if err != nil {
+//		return err
+//	}
+//	defer sink.Close()
+//
+//	client := zoptal.NewClientWithOptions(apiKey, &zoptal.ClientOptions{
+//		Audit: &zoptal.AuditOptions{Sink: sink, PrevHash: sink.LastHash()},
+//	})
+package zoptalaudit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	zoptal "github.com/zoptal/zoptal-go-sdk"
+)
+
+// maxRecordSize bounds the size of a JSON Lines record read by Verify and
+// OpenJSONL.
+const maxRecordSize = 1 << 20
+
+// JSONL is a zoptal.AuditSink writing one JSON object per line.
+type JSONL struct {
+	mu       sync.Mutex
+	w        io.Writer
+	file     *os.File
+	lastHash string
+}
+
+var _ zoptal.AuditSink = (*JSONL)(nil)
+
+// NewJSONL creates a sink writing to w.
+//
+// Parameters:
+//   - w: Destination of the records
+//
+// Returns the sink.
+func NewJSONL(w io.Writer) *JSONL {
+	return &JSONL{w: w}
+}
+
+// OpenJSONL opens a JSON Lines audit trail for appending, creating it if
+// needed. The hash of its last record, for AuditOptions.PrevHash, is
+// available from LastHash.
+//
+// Parameters:
+//   - path: Path of the trail
+//
+// Returns the sink or an error if the file cannot be opened or its last
+// record cannot be read.
+func OpenJSONL(path string) (*JSONL, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit trail: %w", err)
+	}
+
+	var last zoptal.AuditRecord
+	err = scan(file, func(_ int, record zoptal.AuditRecord) error {
+		last = record
+		return nil
+	})
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to read audit trail: %w", err)
+	}
+	return &JSONL{w: file, file: file, lastHash: last.Hash}, nil
+}
+
+// LastHash returns the hash of the last record written, or read by
+// OpenJSONL, or "" if there is none.
+func (s *JSONL) LastHash() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastHash
+}
+
+// WriteAudit implements zoptal.AuditSink. Records written to a file opened
+// with OpenJSONL are synced to disk before WriteAudit returns.
+func (s *JSONL) WriteAudit(ctx context.Context, record zoptal.AuditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.w.Write(line); err != nil {
+		return err
+	}
+	if s.file != nil {
+		if err := s.file.Sync(); err != nil {
+			return err
+		}
+	}
+	s.lastHash = record.Hash
+	return nil
+}
+
+// Close closes a file opened with OpenJSONL; it does nothing for sinks
+// created with NewJSONL.
+func (s *JSONL) Close() error {
+	if s.file == nil {
+		return nil
+	}
+	return s.file.Close()
+}
+
+// Verify checks a JSON Lines audit trail for tampering: every record must
+// hash to its Hash and name the hash of the record before it in PrevHash.
+//
+// Parameters:
+//   - r: The trail
+//
+// Returns the number of records verified, and an error naming the first
+// line that fails verification, if any.
+func Verify(r io.Reader) (int, error) {
+	count := 0
+	prevHash := ""
+	err := scan(r, func(line int, record zoptal.AuditRecord) error {
+		if record.Hash != record.ComputeHash() {
+			return fmt.Errorf("line %d: record was modified", line)
+		}
+		if count > 0 && record.PrevHash != prevHash {
+			return fmt.Errorf("line %d: chain is broken; a record was removed, inserted, or reordered", line)
+		}
+		prevHash = record.Hash
+		count++
+		return nil
+	})
+	return count, err
+}
+
+// scan decodes the records of a JSON Lines trail, skipping blank lines.
+func scan(r io.Reader, fn func(line int, record zoptal.AuditRecord) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordSize)
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		var record zoptal.AuditRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		if err := fn(line, record); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
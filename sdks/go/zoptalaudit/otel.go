@@ -0,0 +1,113 @@
+package zoptalaudit
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	zoptal "github.com/zoptal/zoptal-go-sdk"
+)
+
+// OpenTelemetry severity numbers of audit records.
+const (
+	severityInfo = 9
+	severityWarn = 13
+)
+
+// OTelRecord is an audit record in the OpenTelemetry log data model, with
+// attributes named after the OpenTelemetry semantic conventions where one
+// applies and prefixed "zoptal." otherwise.
+type OTelRecord struct {
+	Timestamp      time.Time
+	SeverityNumber int
+	SeverityText   string
+
+	// Body is a summary such as "DELETE /projects/{id} succeeded"
+	Body string
+
+	Attributes map[string]interface{}
+}
+
+// OTel is a zoptal.AuditSink emitting OpenTelemetry log records.
+//
+// It does not depend on the OpenTelemetry SDK; the emit function passed to
+// NewOTel bridges to a logger with a few lines:
+//
+//	logger := global.GetLoggerProvider().Logger("zoptal-audit")
+//	sink := zoptalaudit.NewOTel(func(ctx context.Context, r zoptalaudit.OTelRecord) {
+//		var record log.Record
+//		record.SetTimestamp(r.Timestamp)
+//		record.SetSeverity(log.Severity(r.SeverityNumber))
+//		record.SetSeverityText(r.SeverityText)
+//		record.SetBody(log.StringValue(r.Body))
+//		for key, value := range r.Attributes {
+//			record.AddAttributes(log.String(key, fmt.Sprint(value)))
+//		}
+//		logger.Emit(ctx, record)
+//	})
+type OTel struct {
+	emit func(ctx context.Context, record OTelRecord)
+}
+
+var _ zoptal.AuditSink = (*OTel)(nil)
+
+// NewOTel creates a sink passing each record to emit, with the context of
+// the audited call so that the record is correlated with its trace.
+//
+// Parameters:
+//   - emit: Function emitting a log record
+//
+// Returns the sink.
+func NewOTel(emit func(ctx context.Context, record OTelRecord)) *OTel {
+	return &OTel{emit: emit}
+}
+
+// WriteAudit implements zoptal.AuditSink.
+func (s *OTel) WriteAudit(ctx context.Context, record zoptal.AuditRecord) error {
+	s.emit(ctx, toOTel(record))
+	return nil
+}
+
+// toOTel converts an audit record to an OpenTelemetry log record.
+func toOTel(record zoptal.AuditRecord) OTelRecord {
+	attributes := map[string]interface{}{
+		"event.name":          "zoptal.audit",
+		"http.request.method": record.Method,
+		"url.path":            record.Path,
+		"zoptal.operation":    record.Operation,
+		"zoptal.outcome":      string(record.Outcome),
+		"zoptal.credential":   record.Credential,
+		"zoptal.duration_ms":  record.Duration.Milliseconds(),
+		"zoptal.audit.hash":   record.Hash,
+	}
+	if record.Actor != "" {
+		attributes["enduser.id"] = record.Actor
+	}
+	if len(record.Scopes) > 0 {
+		attributes["zoptal.scopes"] = strings.Join(record.Scopes, " ")
+	}
+	if record.StatusCode != 0 {
+		attributes["http.response.status_code"] = record.StatusCode
+	}
+	if record.RequestID != "" {
+		attributes["zoptal.request_id"] = record.RequestID
+	}
+	if record.Error != "" {
+		attributes["error.message"] = record.Error
+	}
+	if record.PrevHash != "" {
+		attributes["zoptal.audit.prev_hash"] = record.PrevHash
+	}
+
+	otel := OTelRecord{
+		Timestamp:      record.Time,
+		SeverityNumber: severityInfo,
+		SeverityText:   "INFO",
+		Body:           record.Operation + " " + string(record.Outcome),
+		Attributes:     attributes,
+	}
+	if record.Outcome == zoptal.AuditFailed {
+		otel.SeverityNumber, otel.SeverityText = severityWarn, "WARN"
+	}
+	return otel
+}
@@ -0,0 +1,82 @@
+package zoptalcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	zoptal "github.com/zoptal/zoptal-go-sdk"
+)
+
+// ErrMemcacheMiss is returned by a MemcacheClient when a key is not present.
+var ErrMemcacheMiss = errors.New("zoptalcache: memcache miss")
+
+// MemcacheClient is the subset of a memcached client used by Memcache.
+// Clients such as gomemcache are adapted with a few lines, returning
+// ErrMemcacheMiss in place of their own miss error.
+type MemcacheClient interface {
+	Get(key string) ([]byte, error)
+	Set(key string, value []byte, expirationSeconds int32) error
+	Delete(key string) error
+}
+
+// Memcache is a zoptal.CacheBackend stored in memcached.
+type Memcache struct {
+	client MemcacheClient
+}
+
+var _ zoptal.CacheBackend = (*Memcache)(nil)
+
+// NewMemcache creates a memcached backend.
+//
+// Parameters:
+//   - client: memcached client
+//
+// Returns a new Memcache backend.
+func NewMemcache(client MemcacheClient) *Memcache {
+	return &Memcache{client: client}
+}
+
+// Get implements zoptal.CacheBackend.
+func (m *Memcache) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := m.client.Get(memcacheKey(key))
+	if errors.Is(err, ErrMemcacheMiss) {
+		return nil, zoptal.ErrCacheMiss
+	}
+	return value, err
+}
+
+// Set implements zoptal.CacheBackend.
+func (m *Memcache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	var expiration int32
+	if ttl > 0 {
+		expiration = int32((ttl + time.Second - 1) / time.Second)
+	}
+	return m.client.Set(memcacheKey(key), value, expiration)
+}
+
+// Delete implements zoptal.CacheBackend.
+func (m *Memcache) Delete(ctx context.Context, key string) error {
+	err := m.client.Delete(memcacheKey(key))
+	if errors.Is(err, ErrMemcacheMiss) {
+		return nil
+	}
+	return err
+}
+
+// memcacheKey returns key if it is a valid memcached key, or a hash of it
+// otherwise. memcached keys are limited to 250 bytes without spaces or
+// control characters, which cache keys containing URLs can exceed.
+func memcacheKey(key string) string {
+	valid := len(key) <= 250
+	for i := 0; valid && i < len(key); i++ {
+		valid = key[i] > ' ' && key[i] != 0x7f
+	}
+	if valid {
+		return key
+	}
+	sum := sha256.Sum256([]byte(key))
+	return "zoptal:" + hex.EncodeToString(sum[:])
+}
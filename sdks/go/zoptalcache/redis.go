@@ -0,0 +1,158 @@
+// Package zoptalcache provides zoptal.CacheBackend adapters for Redis and
+// memcached, for sharing cached responses and rate-limit state between
+// replicas of a service.
+//
+// The adapters do not depend on a particular client library. Redis is
+// reached through a single command function, which go-redis satisfies with
+//
+//	zoptalcache.NewRedis(func(ctx context.Context, args ...interface{}) (interface{}, error) {
+//		return rdb.Do(ctx, args...).Result()
+//	}, nil)
+//
+// and memcached through the small MemcacheClient interface.
+package zoptalcache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	zoptal "github.com/zoptal/zoptal-go-sdk"
+)
+
+// RedisDoFunc sends a single Redis command and returns its reply.
+type RedisDoFunc func(ctx context.Context, args ...interface{}) (interface{}, error)
+
+// RedisOptions contains options for a Redis backend.
+type RedisOptions struct {
+	// IsNil reports whether an error returned by the command function means
+	// the key does not exist (default: matches go-redis's "redis: nil")
+	IsNil func(error) bool
+}
+
+// Redis is a zoptal.CounterBackend and zoptal.RateLimitBackend stored in Redis.
+type Redis struct {
+	do    RedisDoFunc
+	isNil func(error) bool
+}
+
+var (
+	_ zoptal.CounterBackend   = (*Redis)(nil)
+	_ zoptal.RateLimitBackend = (*Redis)(nil)
+)
+
+// incrementScript increments a counter and sets its expiry when it is created.
+const incrementScript = `local v = redis.call('INCRBY', KEYS[1], ARGV[1])
+if tonumber(ARGV[2]) > 0 and v == tonumber(ARGV[1]) then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return v`
+
+// NewRedis creates a Redis backend.
+//
+// Parameters:
+//   - do: Function sending a Redis command
+//   - options: Redis options (can be nil for defaults)
+//
+// Returns a new Redis backend.
+func NewRedis(do RedisDoFunc, options *RedisOptions) *Redis {
+	if options == nil {
+		options = &RedisOptions{}
+	}
+	r := &Redis{do: do, isNil: options.IsNil}
+	if r.isNil == nil {
+		r.isNil = func(err error) bool { return err.Error() == "redis: nil" }
+	}
+	return r
+}
+
+// Get implements zoptal.CacheBackend.
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, error) {
+	reply, err := r.do(ctx, "GET", key)
+	if err != nil {
+		if r.isNil(err) {
+			return nil, zoptal.ErrCacheMiss
+		}
+		return nil, err
+	}
+
+	switch v := reply.(type) {
+	case nil:
+		return nil, zoptal.ErrCacheMiss
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("unexpected Redis reply type %T", reply)
+	}
+}
+
+// Set implements zoptal.CacheBackend.
+func (r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	args := []interface{}{"SET", key, value}
+	if ttl > 0 {
+		args = append(args, "PX", ttl.Milliseconds())
+	}
+	_, err := r.do(ctx, args...)
+	return err
+}
+
+// Delete implements zoptal.CacheBackend.
+func (r *Redis) Delete(ctx context.Context, key string) error {
+	_, err := r.do(ctx, "DEL", key)
+	return err
+}
+
+// Increment implements zoptal.CounterBackend.
+func (r *Redis) Increment(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	reply, err := r.do(ctx, "EVAL", incrementScript, 1, key, delta, ttl.Milliseconds())
+	if err != nil {
+		return 0, err
+	}
+
+	switch v := reply.(type) {
+	case int64:
+		return v, nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return 0, fmt.Errorf("unexpected Redis reply type %T", reply)
+	}
+}
+
+// takeScript implements a token bucket. The bucket's token count and last
+// refill time are stored in a hash, using the Redis server clock so that
+// replicas with skewed clocks agree.
+const takeScript = `local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or burst
+local ts = tonumber(state[2]) or now
+tokens = math.min(burst, tokens + (now - ts) * rate / 1000)
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+else
+	wait = math.ceil((1 - tokens) * 1000 / rate)
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+return wait`
+
+// Take implements zoptal.RateLimitBackend.
+func (r *Redis) Take(ctx context.Context, key string, rate float64, burst int) (time.Duration, error) {
+	reply, err := r.do(ctx, "EVAL", takeScript, 1, key, strconv.FormatFloat(rate, 'f', -1, 64), burst)
+	if err != nil {
+		return 0, err
+	}
+
+	wait, ok := reply.(int64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected Redis reply type %T", reply)
+	}
+	return time.Duration(wait) * time.Millisecond, nil
+}
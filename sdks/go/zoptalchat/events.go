@@ -0,0 +1,241 @@
+package zoptalchat
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	zoptal "github.com/zoptal/zoptal-go-sdk"
+)
+
+// DeployStatus is the state of a deployment.
+type DeployStatus string
+
+// Deployment statuses.
+const (
+	DeployStarted    DeployStatus = "started"
+	DeploySucceeded  DeployStatus = "succeeded"
+	DeployFailed     DeployStatus = "failed"
+	DeployRolledBack DeployStatus = "rolled_back"
+)
+
+// DeployEvent is a deployment of a project.
+type DeployEvent struct {
+	// Project is the name of the project (required)
+	Project string
+
+	// Environment is the environment deployed to, e.g. "production" (optional)
+	Environment string
+
+	Status DeployStatus
+
+	// Version is the deployed version or release (optional)
+	Version string
+
+	// Commit is the deployed commit (optional)
+	Commit string
+
+	// Author is who started the deployment (optional)
+	Author string
+
+	// Duration is how long the deployment took, once finished (optional)
+	Duration time.Duration
+
+	// Error describes why a failed deployment failed (optional)
+	Error string
+
+	// URL links to the deployment or its logs (optional)
+	URL string
+}
+
+// DeployMessage formats a deployment event, colored by its status.
+//
+// Parameters:
+//   - event: Deployment event to format
+//
+// Returns the message.
+func DeployMessage(event *DeployEvent) *Message {
+	target := event.Project
+	if event.Environment != "" {
+		target += " to " + event.Environment
+	}
+
+	message := &Message{}
+	switch event.Status {
+	case DeployStarted:
+		message.Title = "Deploying " + target
+		message.Level = LevelInfo
+	case DeploySucceeded:
+		message.Title = "Deployed " + target
+		message.Level = LevelSuccess
+	case DeployFailed:
+		message.Title = "Deployment of " + target + " failed"
+		message.Level = LevelError
+	case DeployRolledBack:
+		message.Title = "Deployment of " + target + " rolled back"
+		message.Level = LevelWarning
+	default:
+		message.Title = "Deployment of " + target + ": " + string(event.Status)
+		message.Level = LevelInfo
+	}
+	message.Summary = event.Error
+
+	if event.Version != "" {
+		message.Facts = append(message.Facts, Fact{Name: "Version", Value: event.Version})
+	}
+	if event.Commit != "" {
+		commit := event.Commit
+		if len(commit) == 40 {
+			// Shorten full commit hashes as git does.
+			commit = commit[:7]
+		}
+		message.Facts = append(message.Facts, Fact{Name: "Commit", Value: commit})
+	}
+	if event.Author != "" {
+		message.Facts = append(message.Facts, Fact{Name: "Author", Value: event.Author})
+	}
+	if event.Duration > 0 {
+		message.Facts = append(message.Facts, Fact{Name: "Duration", Value: event.Duration.Round(time.Second).String()})
+	}
+	if event.URL != "" {
+		message.Link = &Link{Text: "View deployment", URL: event.URL}
+	}
+	return message
+}
+
+// defaultBudgetWarning is the default of BudgetAlert.WarnAt.
+const defaultBudgetWarning = 0.8
+
+// BudgetAlert is the spending of a budget, such as an organization's
+// monthly AI spending.
+type BudgetAlert struct {
+	// Name is the name of the budget, e.g. "AI usage" (required)
+	Name string
+
+	// Spent is the amount spent in the period
+	Spent float64
+
+	// Limit is the budget for the period
+	Limit float64
+
+	// Currency is the currency of the amounts (default: "USD")
+	Currency string
+
+	// Period is the budget period, e.g. "October 2026" (optional)
+	Period string
+
+	// Forecast is the projected spending at the end of the period (optional)
+	Forecast float64
+
+	// WarnAt is the fraction of the limit from which the alert is a
+	// warning (default: 0.8)
+	WarnAt float64
+
+	// URL links to the usage dashboard (optional)
+	URL string
+}
+
+// BudgetMessage formats a budget alert: a warning once spending reaches
+// WarnAt of the limit or is forecast to exceed it, and an error once it
+// exceeds the limit.
+//
+// Parameters:
+//   - alert: Budget alert to format
+//
+// Returns the message.
+func BudgetMessage(alert *BudgetAlert) *Message {
+	currency := alert.Currency
+	if currency == "" {
+		currency = "USD"
+	}
+	warnAt := alert.WarnAt
+	if warnAt <= 0 {
+		warnAt = defaultBudgetWarning
+	}
+
+	message := &Message{}
+	var used float64
+	if alert.Limit > 0 {
+		used = alert.Spent / alert.Limit
+	}
+	switch {
+	case alert.Limit > 0 && alert.Spent > alert.Limit:
+		message.Title = alert.Name + " budget exceeded"
+		message.Level = LevelError
+	case alert.Limit > 0 && used >= warnAt:
+		message.Title = fmt.Sprintf("%s budget %.0f%% used", alert.Name, used*100)
+		message.Level = LevelWarning
+	case alert.Limit > 0 && alert.Forecast > alert.Limit:
+		message.Title = alert.Name + " budget forecast to be exceeded"
+		message.Level = LevelWarning
+	default:
+		message.Title = alert.Name + " budget"
+		message.Level = LevelInfo
+	}
+	if alert.Limit > 0 {
+		message.Summary = fmt.Sprintf("%s of %s spent", formatAmount(alert.Spent, currency), formatAmount(alert.Limit, currency))
+		if alert.Period != "" {
+			message.Summary += " in " + alert.Period
+		}
+		message.Summary += "."
+	}
+
+	message.Facts = append(message.Facts, Fact{Name: "Spent", Value: formatAmount(alert.Spent, currency)})
+	if alert.Limit > 0 {
+		message.Facts = append(message.Facts,
+			Fact{Name: "Budget", Value: formatAmount(alert.Limit, currency)},
+			Fact{Name: "Used", Value: fmt.Sprintf("%.0f%%", used*100)},
+		)
+	}
+	if alert.Forecast > 0 {
+		message.Facts = append(message.Facts, Fact{Name: "Forecast", Value: formatAmount(alert.Forecast, currency)})
+	}
+	if alert.URL != "" {
+		message.Link = &Link{Text: "View usage", URL: alert.URL}
+	}
+	return message
+}
+
+// formatAmount formats an amount of money, e.g. "1234.50 USD".
+func formatAmount(amount float64, currency string) string {
+	return fmt.Sprintf("%.2f %s", amount, currency)
+}
+
+// NotificationMessage formats a notification, such as one delivered by
+// NotificationsService.Subscribe, for forwarding to a chat. The level is
+// derived from the notification type: types ending in "failed" or "error"
+// are errors, those ending in "warning" or "exceeded" are warnings, and
+// those ending in "succeeded" or "completed" are successes.
+//
+// Parameters:
+//   - notification: Notification to format
+//
+// Returns the message.
+func NotificationMessage(notification *zoptal.Notification) *Message {
+	message := &Message{
+		Title:   notification.Title,
+		Summary: notification.Body,
+		Level:   LevelInfo,
+		Footer:  notification.Type,
+	}
+	if notification.ProjectID != "" {
+		message.Facts = append(message.Facts, Fact{Name: "Project", Value: notification.ProjectID})
+	}
+
+	kind := notification.Type
+	if i := strings.LastIndexAny(kind, "._"); i >= 0 {
+		kind = kind[i+1:]
+	}
+	switch kind {
+	case "failed", "error":
+		message.Level = LevelError
+	case "warning", "exceeded":
+		message.Level = LevelWarning
+	case "succeeded", "completed":
+		message.Level = LevelSuccess
+	}
+	if notification.URL != "" {
+		message.Link = &Link{Text: "Open", URL: notification.URL}
+	}
+	return message
+}
@@ -0,0 +1,281 @@
+package zoptalchat
+
+import (
+	"fmt"
+	"strings"
+
+	zoptal "github.com/zoptal/zoptal-go-sdk"
+)
+
+// defaultMaxFindings is the default of FindingOptions.MaxFindings.
+const defaultMaxFindings = 10
+
+// FindingOptions contains options for formatting findings.
+type FindingOptions struct {
+	// Title is the title of the message (default: "Code review" or
+	// "Security scan", with the number of findings)
+	Title string
+
+	// Subject names what was analyzed, e.g. "PR #42" or a project name,
+	// and is added to the default title (optional)
+	Subject string
+
+	// URL links to the analyzed change or the full report (optional)
+	URL string
+
+	// MaxFindings is the maximum number of findings shown; the others are
+	// counted in the footer (default: 10)
+	MaxFindings int
+}
+
+// ReviewMessage formats the findings of a change review, most severe
+// first, colored by the most severe finding.
+//
+// Parameters:
+//   - review: Review to format
+//   - options: Formatting options (can be nil for defaults)
+//
+// Returns the message.
+func ReviewMessage(review *zoptal.DiffReview, options *FindingOptions) *Message {
+	if options == nil {
+		options = &FindingOptions{}
+	}
+
+	findings := review.Findings
+	counts := make(map[zoptal.ReviewSeverity]int)
+	level := LevelSuccess
+	for _, finding := range findings {
+		counts[finding.Severity]++
+		level = maxLevel(level, reviewLevel(finding.Severity))
+	}
+
+	message := &Message{
+		Title:   findingsTitle(options, "Code review", len(findings)),
+		Summary: review.Summary,
+		Level:   level,
+	}
+	severities := []zoptal.ReviewSeverity{zoptal.SeverityCritical, zoptal.SeverityError, zoptal.SeverityWarning, zoptal.SeverityInfo}
+	for _, severity := range severities {
+		if counts[severity] > 0 {
+			message.Facts = append(message.Facts, Fact{Name: severityLabel(string(severity)), Value: fmt.Sprint(counts[severity])})
+		}
+	}
+
+	shown := limitFindings(len(findings), options.MaxFindings)
+	for _, finding := range findings[:shown] {
+		var context []string
+		if location := findingLocation(finding.File, finding.StartLine, finding.EndLine); location != "" {
+			context = append(context, location)
+		}
+		if finding.Category != "" {
+			context = append(context, finding.Category)
+		}
+		message.Sections = append(message.Sections, Section{
+			Title:   severityLabel(string(finding.Severity)) + ": " + finding.Title,
+			Text:    finding.Message,
+			Context: strings.Join(context, " · "),
+		})
+	}
+	message.Footer = moreFindings(len(findings) - shown)
+	message.Link = findingsLink(options.URL)
+	return message
+}
+
+// SecurityMessage formats security findings, such as those of a
+// SecurityScan, most severe first, colored by the most severe finding.
+//
+// Parameters:
+//   - findings: Findings to format
+//   - options: Formatting options (can be nil for defaults)
+//
+// Returns the message.
+func SecurityMessage(findings []zoptal.SecurityFinding, options *FindingOptions) *Message {
+	if options == nil {
+		options = &FindingOptions{}
+	}
+
+	severities := []zoptal.SecuritySeverity{
+		zoptal.SecuritySeverityCritical,
+		zoptal.SecuritySeverityHigh,
+		zoptal.SecuritySeverityMedium,
+		zoptal.SecuritySeverityLow,
+	}
+	counts := make(map[zoptal.SecuritySeverity]int)
+	level := LevelSuccess
+	for _, finding := range findings {
+		counts[finding.Severity]++
+		level = maxLevel(level, securityLevel(finding.Severity))
+	}
+
+	// Order the findings by severity, keeping the scan's order otherwise.
+	sorted := make([]zoptal.SecurityFinding, 0, len(findings))
+	for _, severity := range severities {
+		for _, finding := range findings {
+			if finding.Severity == severity {
+				sorted = append(sorted, finding)
+			}
+		}
+	}
+	for _, finding := range findings {
+		if isSecuritySeverity(finding.Severity) {
+			continue
+		}
+		sorted = append(sorted, finding)
+	}
+
+	message := &Message{
+		Title: findingsTitle(options, "Security scan", len(findings)),
+		Level: level,
+	}
+	if len(findings) == 0 {
+		message.Summary = "No vulnerabilities found."
+	}
+	for _, severity := range severities {
+		if counts[severity] > 0 {
+			message.Facts = append(message.Facts, Fact{Name: severityLabel(string(severity)), Value: fmt.Sprint(counts[severity])})
+		}
+	}
+
+	shown := limitFindings(len(sorted), options.MaxFindings)
+	for _, finding := range sorted[:shown] {
+		var context []string
+		if location := findingLocation(finding.Path, finding.Line, finding.EndLine); location != "" {
+			context = append(context, location)
+		}
+		if finding.CWE != "" {
+			context = append(context, finding.CWE)
+		}
+		if finding.RuleID != "" {
+			context = append(context, finding.RuleID)
+		}
+		message.Sections = append(message.Sections, Section{
+			Title:   severityLabel(string(finding.Severity)) + ": " + finding.Title,
+			Text:    finding.Description,
+			Context: strings.Join(context, " · "),
+		})
+	}
+	message.Footer = moreFindings(len(sorted) - shown)
+	message.Link = findingsLink(options.URL)
+	return message
+}
+
+// reviewLevel returns the message level of a review severity.
+func reviewLevel(severity zoptal.ReviewSeverity) Level {
+	switch severity {
+	case zoptal.SeverityCritical, zoptal.SeverityError:
+		return LevelError
+	case zoptal.SeverityWarning:
+		return LevelWarning
+	default:
+		return LevelInfo
+	}
+}
+
+// securityLevel returns the message level of a security severity.
+func securityLevel(severity zoptal.SecuritySeverity) Level {
+	switch severity {
+	case zoptal.SecuritySeverityCritical, zoptal.SecuritySeverityHigh:
+		return LevelError
+	case zoptal.SecuritySeverityMedium:
+		return LevelWarning
+	default:
+		return LevelInfo
+	}
+}
+
+// isSecuritySeverity reports whether severity is one of the known severities.
+func isSecuritySeverity(severity zoptal.SecuritySeverity) bool {
+	switch severity {
+	case zoptal.SecuritySeverityCritical, zoptal.SecuritySeverityHigh, zoptal.SecuritySeverityMedium, zoptal.SecuritySeverityLow:
+		return true
+	}
+	return false
+}
+
+// levelRanks orders the levels by importance.
+var levelRanks = map[Level]int{
+	LevelSuccess: 0,
+	LevelInfo:    1,
+	LevelWarning: 2,
+	LevelError:   3,
+}
+
+// maxLevel returns the more important of two levels.
+func maxLevel(a, b Level) Level {
+	if levelRanks[b] > levelRanks[a] {
+		return b
+	}
+	return a
+}
+
+// findingsTitle returns the title of a findings message.
+func findingsTitle(options *FindingOptions, kind string, count int) string {
+	if options.Title != "" {
+		return options.Title
+	}
+	title := kind
+	if options.Subject != "" {
+		title += " of " + options.Subject
+	}
+	switch count {
+	case 0:
+		return title + ": no findings"
+	case 1:
+		return title + ": 1 finding"
+	default:
+		return fmt.Sprintf("%s: %d findings", title, count)
+	}
+}
+
+// severityLabel capitalizes a severity, e.g. "Critical".
+func severityLabel(severity string) string {
+	if severity == "" {
+		return "Unknown"
+	}
+	return strings.ToUpper(severity[:1]) + severity[1:]
+}
+
+// findingLocation returns the location of a finding, e.g. "main.go:12-14".
+func findingLocation(path string, line, endLine int) string {
+	switch {
+	case path == "":
+		return ""
+	case line <= 0:
+		return path
+	case endLine > line:
+		return fmt.Sprintf("%s:%d-%d", path, line, endLine)
+	default:
+		return fmt.Sprintf("%s:%d", path, line)
+	}
+}
+
+// limitFindings returns the number of findings to show.
+func limitFindings(count, max int) int {
+	if max <= 0 {
+		max = defaultMaxFindings
+	}
+	if count < max {
+		return count
+	}
+	return max
+}
+
+// moreFindings returns the footer counting the findings not shown.
+func moreFindings(hidden int) string {
+	switch {
+	case hidden <= 0:
+		return ""
+	case hidden == 1:
+		return "1 more finding not shown"
+	default:
+		return fmt.Sprintf("%d more findings not shown", hidden)
+	}
+}
+
+// findingsLink returns the link to the full findings, or nil.
+func findingsLink(url string) *Link {
+	if url == "" {
+		return nil
+	}
+	return &Link{Text: "View details", URL: url}
+}
@@ -0,0 +1,105 @@
+// Package zoptalchat formats SDK results and events as chat messages, for
+// chat-ops bots built on the Zoptal SDK: review and security findings,
+// deployment events, budget alerts, and notifications become Slack Block
+// Kit or Microsoft Teams Adaptive Card payloads, ready to post to an
+// incoming webhook.
+//
+//	review, err := client.AI.ReviewDiff(ctx, request)
+//	if err != nil {
+//		return err
+//	}
+//	message := zoptalchat.ReviewMessage(review, &zoptalchat.FindingOptions{
+//		Subject: "PR #42",
+//		URL:     pullRequestURL,
+//	})
+//	payload, err := json.Marshal(message.Slack())
+//	if err != nil {
+//		return err
+//	}
+//	_, err = http.Post(slackWebhookURL, "application/json", bytes.NewReader(payload))
+package zoptalchat
+
+import (
+	"unicode/utf8"
+)
+
+// Level is the importance of a message, shown as its color.
+type Level string
+
+// Message levels.
+const (
+	LevelInfo    Level = "info"
+	LevelSuccess Level = "success"
+	LevelWarning Level = "warning"
+	LevelError   Level = "error"
+)
+
+// Message is a chat message independent of the chat service, rendered with
+// Slack or Teams. Messages can be built by hand or with the builders of
+// this package, and adjusted before they are rendered.
+type Message struct {
+	// Title is the heading of the message (required)
+	Title string
+
+	// Summary is a paragraph under the title (optional)
+	Summary string
+
+	// Level sets the color of the message (default: LevelInfo)
+	Level Level
+
+	// Facts are name-value pairs shown as a table, e.g. the environment of
+	// a deployment (optional)
+	Facts []Fact
+
+	// Sections are items shown one after the other, e.g. findings (optional)
+	Sections []Section
+
+	// Footer is a small note at the end of the message (optional)
+	Footer string
+
+	// Link is a button opening the subject of the message (optional)
+	Link *Link
+}
+
+// Fact is a name-value pair of a Message.
+type Fact struct {
+	Name  string
+	Value string
+}
+
+// Section is an item of a Message.
+type Section struct {
+	// Title is the heading of the section
+	Title string
+
+	// Text is the body of the section (optional)
+	Text string
+
+	// Context is a small note under the text, e.g. a file location (optional)
+	Context string
+}
+
+// Link is a button of a Message.
+type Link struct {
+	Text string
+	URL  string
+}
+
+// Text returns a plain text rendering of the message, used as the
+// notification text of chat services.
+func (m *Message) Text() string {
+	if m.Summary == "" {
+		return m.Title
+	}
+	return m.Title + ": " + m.Summary
+}
+
+// truncate shortens s to at most max runes, ending it with an ellipsis if
+// it was cut, to fit the length limits of the chat services.
+func truncate(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:max-1]) + "…"
+}
@@ -0,0 +1,157 @@
+package zoptalchat
+
+import (
+	"strings"
+)
+
+// Slack Block Kit limits.
+const (
+	slackMaxHeader  = 150
+	slackMaxText    = 3000
+	slackMaxFields  = 10
+	slackMaxField   = 2000
+	slackMaxBlocks  = 50
+	slackMaxButton  = 75
+	slackMaxContext = 2000
+)
+
+// slackColors are the attachment colors of the message levels.
+var slackColors = map[Level]string{
+	LevelInfo:    "#1D9BD1",
+	LevelSuccess: "#2EB67D",
+	LevelWarning: "#ECB22E",
+	LevelError:   "#E01E5A",
+}
+
+// SlackMessage is a Slack message payload, for an incoming webhook or the
+// chat.postMessage API. The blocks are in an attachment so that the message
+// is colored by its level.
+type SlackMessage struct {
+	// Text is shown in notifications, and by clients without block support
+	Text string `json:"text"`
+
+	// Channel overrides the channel of the webhook or is the channel of a
+	// chat.postMessage call (optional)
+	Channel string `json:"channel,omitempty"`
+
+	Attachments []SlackAttachment `json:"attachments"`
+}
+
+// SlackAttachment is a colored attachment of a SlackMessage.
+type SlackAttachment struct {
+	Color  string       `json:"color,omitempty"`
+	Blocks []SlackBlock `json:"blocks"`
+}
+
+// SlackBlock is a Block Kit block, as its JSON object; blocks of any type
+// can be added to a SlackAttachment.
+type SlackBlock map[string]interface{}
+
+// Slack renders the message as Slack Block Kit blocks. Text is escaped for
+// Slack's mrkdwn and shortened to the Block Kit limits; sections beyond the
+// block limit are left out.
+//
+// Returns the Slack payload.
+func (m *Message) Slack() *SlackMessage {
+	var blocks []SlackBlock
+	if m.Title != "" {
+		blocks = append(blocks, SlackBlock{
+			"type": "header",
+			"text": slackPlainText(truncate(m.Title, slackMaxHeader)),
+		})
+	}
+	if m.Summary != "" {
+		blocks = append(blocks, slackSection(slackEscape(m.Summary)))
+	}
+	if len(m.Facts) > 0 {
+		var fields []SlackBlock
+		for i, fact := range m.Facts {
+			if i == slackMaxFields {
+				break
+			}
+			fields = append(fields, slackMarkdown(truncate("*"+slackEscape(fact.Name)+"*\n"+slackEscape(fact.Value), slackMaxField)))
+		}
+		blocks = append(blocks, SlackBlock{"type": "section", "fields": fields})
+	}
+
+	// Keep room for the footer and the link.
+	room := slackMaxBlocks - len(blocks) - 2
+	for _, section := range m.Sections {
+		needed := 1
+		if section.Context != "" {
+			needed++
+		}
+		if room < needed {
+			break
+		}
+		room -= needed
+
+		text := "*" + slackEscape(section.Title) + "*"
+		if section.Text != "" {
+			text += "\n" + slackEscape(section.Text)
+		}
+		blocks = append(blocks, slackSection(text))
+		if section.Context != "" {
+			blocks = append(blocks, slackContext(section.Context))
+		}
+	}
+
+	if m.Footer != "" {
+		blocks = append(blocks, slackContext(m.Footer))
+	}
+	if m.Link != nil && m.Link.URL != "" {
+		text := m.Link.Text
+		if text == "" {
+			text = "View"
+		}
+		blocks = append(blocks, SlackBlock{
+			"type": "actions",
+			"elements": []SlackBlock{{
+				"type": "button",
+				"text": slackPlainText(truncate(text, slackMaxButton)),
+				"url":  m.Link.URL,
+			}},
+		})
+	}
+
+	level := m.Level
+	if level == "" {
+		level = LevelInfo
+	}
+	return &SlackMessage{
+		Text:        slackEscape(m.Text()),
+		Attachments: []SlackAttachment{{Color: slackColors[level], Blocks: blocks}},
+	}
+}
+
+// slackSection returns a section block of mrkdwn text.
+func slackSection(text string) SlackBlock {
+	return SlackBlock{"type": "section", "text": slackMarkdown(truncate(text, slackMaxText))}
+}
+
+// slackContext returns a context block of plain text.
+func slackContext(text string) SlackBlock {
+	return SlackBlock{
+		"type":     "context",
+		"elements": []SlackBlock{slackMarkdown(truncate(slackEscape(text), slackMaxContext))},
+	}
+}
+
+// slackMarkdown returns a mrkdwn text object.
+func slackMarkdown(text string) SlackBlock {
+	return SlackBlock{"type": "mrkdwn", "text": text}
+}
+
+// slackPlainText returns a plain_text text object.
+func slackPlainText(text string) SlackBlock {
+	return SlackBlock{"type": "plain_text", "text": text, "emoji": true}
+}
+
+// slackEscaper escapes the control characters of Slack's mrkdwn.
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// slackEscape escapes text for Slack's mrkdwn, so that it cannot form links
+// or mentions.
+func slackEscape(text string) string {
+	return slackEscaper.Replace(text)
+}
@@ -0,0 +1,139 @@
+package zoptalchat
+
+// teamsMaxSections bounds the sections of a card, which Teams rejects above
+// about 28 KB.
+const teamsMaxSections = 25
+
+// teamsMaxText bounds the length of a text block.
+const teamsMaxText = 2000
+
+// teamsColors are the title colors of the message levels.
+var teamsColors = map[Level]string{
+	LevelInfo:    "Accent",
+	LevelSuccess: "Good",
+	LevelWarning: "Warning",
+	LevelError:   "Attention",
+}
+
+// TeamsMessage is a Microsoft Teams message payload carrying an Adaptive
+// Card, for an incoming webhook or a Workflows webhook.
+type TeamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []TeamsAttachment `json:"attachments"`
+}
+
+// TeamsAttachment is the card attachment of a TeamsMessage.
+type TeamsAttachment struct {
+	ContentType string        `json:"contentType"`
+	ContentURL  *string       `json:"contentUrl"`
+	Content     *AdaptiveCard `json:"content"`
+}
+
+// AdaptiveCard is an Adaptive Card.
+type AdaptiveCard struct {
+	Schema  string        `json:"$schema"`
+	Type    string        `json:"type"`
+	Version string        `json:"version"`
+	Body    []CardElement `json:"body"`
+	Actions []CardElement `json:"actions,omitempty"`
+
+	// MSTeams holds the Teams-specific card properties
+	MSTeams map[string]interface{} `json:"msteams,omitempty"`
+}
+
+// CardElement is an Adaptive Card element or action, as its JSON object;
+// elements of any type can be added to an AdaptiveCard.
+type CardElement map[string]interface{}
+
+// Teams renders the message as an Adaptive Card for Microsoft Teams. Text
+// is shortened to keep the card within the Teams size limit, and sections
+// beyond it are left out.
+//
+// Returns the Teams payload.
+func (m *Message) Teams() *TeamsMessage {
+	level := m.Level
+	if level == "" {
+		level = LevelInfo
+	}
+
+	var body []CardElement
+	if m.Title != "" {
+		body = append(body, CardElement{
+			"type":   "TextBlock",
+			"text":   m.Title,
+			"size":   "Large",
+			"weight": "Bolder",
+			"color":  teamsColors[level],
+			"wrap":   true,
+		})
+	}
+	if m.Summary != "" {
+		body = append(body, teamsText(m.Summary))
+	}
+	if len(m.Facts) > 0 {
+		facts := make([]CardElement, len(m.Facts))
+		for i, fact := range m.Facts {
+			facts[i] = CardElement{"title": fact.Name, "value": truncate(fact.Value, teamsMaxText)}
+		}
+		body = append(body, CardElement{"type": "FactSet", "facts": facts})
+	}
+	for i, section := range m.Sections {
+		if i == teamsMaxSections {
+			break
+		}
+		items := []CardElement{{
+			"type":   "TextBlock",
+			"text":   section.Title,
+			"weight": "Bolder",
+			"wrap":   true,
+		}}
+		if section.Text != "" {
+			items = append(items, teamsText(section.Text))
+		}
+		if section.Context != "" {
+			items = append(items, teamsSubtle(section.Context))
+		}
+		body = append(body, CardElement{"type": "Container", "separator": true, "items": items})
+	}
+	if m.Footer != "" {
+		body = append(body, teamsSubtle(m.Footer))
+	}
+
+	card := &AdaptiveCard{
+		Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+		Type:    "AdaptiveCard",
+		Version: "1.4",
+		Body:    body,
+		MSTeams: map[string]interface{}{"width": "Full"},
+	}
+	if m.Link != nil && m.Link.URL != "" {
+		text := m.Link.Text
+		if text == "" {
+			text = "View"
+		}
+		card.Actions = []CardElement{{"type": "Action.OpenUrl", "title": text, "url": m.Link.URL}}
+	}
+	return &TeamsMessage{
+		Type: "message",
+		Attachments: []TeamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content:     card,
+		}},
+	}
+}
+
+// teamsText returns a wrapping text block.
+func teamsText(text string) CardElement {
+	return CardElement{"type": "TextBlock", "text": truncate(text, teamsMaxText), "wrap": true}
+}
+
+// teamsSubtle returns a small, subtle text block.
+func teamsSubtle(text string) CardElement {
+	return CardElement{
+		"type":     "TextBlock",
+		"text":     truncate(text, teamsMaxText),
+		"size":     "Small",
+		"isSubtle": true,
+		"wrap":     true,
+	}
+}
@@ -0,0 +1,63 @@
+// Package zoptalcompat provides v1-compatible wrappers for SDK methods whose
+// map[string]interface{} results were replaced by typed responses in v2.
+//
+// Code written against v1 can be moved to v2 without a manual audit by
+// running cmd/zoptal-migrate, which rewrites call sites such as
+//
+//	health, err := client.HealthCheck(ctx)
+//
+// into
+//
+//	health, err := zoptalcompat.HealthCheck(ctx, client)
+//
+// leaving the rest of the calling code unchanged. Call sites can then be
+// switched to the typed API one at a time.
+package zoptalcompat
+
+import (
+	"context"
+
+	zoptal "github.com/zoptal/zoptal-go-sdk"
+)
+
+// HealthCheck calls client.HealthCheck and returns the v1 map result.
+//
+// Deprecated: Use client.HealthCheck, which returns a typed *zoptal.HealthStatus.
+func HealthCheck(ctx context.Context, client *zoptal.Client) (map[string]interface{}, error) {
+	result, err := client.HealthCheck(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return rawOrEmpty(result.Raw), nil
+}
+
+// GetUserInfo calls client.GetUserInfo and returns the v1 map result.
+//
+// Deprecated: Use client.GetUserInfo, which returns a typed *zoptal.UserInfo.
+func GetUserInfo(ctx context.Context, client *zoptal.Client) (map[string]interface{}, error) {
+	result, err := client.GetUserInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return rawOrEmpty(result.Raw), nil
+}
+
+// GetUsageStats calls client.GetUsageStats and returns the v1 map result.
+//
+// Deprecated: Use client.Usage.Query, which returns typed time series.
+func GetUsageStats(ctx context.Context, client *zoptal.Client) (map[string]interface{}, error) {
+	result, err := client.GetUsageStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return rawOrEmpty(result.Raw), nil
+}
+
+// rawOrEmpty returns m, or an empty map if m is nil, matching v1 behavior for
+// empty response bodies.
+func rawOrEmpty(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return map[string]interface{}{}
+	}
+	return m
+}
@@ -0,0 +1,79 @@
+// Package zoptalmock provides fakes of the Zoptal SDK service interfaces for
+// unit testing code that uses the SDK without making HTTP requests.
+//
+// Each fake has a function field per method (for example Files.UsageFunc).
+// Set the fields a test needs; calling a method whose field is nil returns an
+// error. Every call is recorded and can be inspected with Calls; request
+// options are accepted but neither recorded nor passed to the functions.
+//
+// The fakes, like the interfaces they implement, are generated from the
+// services of the SDK by internal/mockgen; run go generate after changing a
+// service.
+//
+// Example usage:
+//
+//	files := &zoptalmock.Files{
+//	    UsageFunc: func(ctx context.Context, projectID string) (*zoptal.StorageUsage, error) {
+//	        return &zoptal.StorageUsage{TotalBytes: 1024}, nil
+//	    },
+//	}
+//	report, err := buildReport(ctx, files) // accepts zoptal.FilesAPI
+package zoptalmock
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Call is a single recorded method call.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// recorder records calls made to a fake.
+type recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// record records a call.
+func (r *recorder) record(method string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns the calls recorded so far, in order.
+func (r *recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	calls := make([]Call, len(r.calls))
+	copy(calls, r.calls)
+	return calls
+}
+
+// CallCount returns the number of recorded calls to method.
+func (r *recorder) CallCount(method string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, call := range r.calls {
+		if call.Method == method {
+			n++
+		}
+	}
+	return n
+}
+
+// Reset clears the recorded calls.
+func (r *recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
+
+// notImplemented returns the error used when a method's function field is nil.
+func notImplemented(method string) error {
+	return fmt.Errorf("zoptalmock: %s not implemented", method)
+}
@@ -0,0 +1,472 @@
+// Package zoptalpatch applies unified diffs, such as those returned by
+// AI.Refactor, to text, local files, and project files.
+//
+// Hunks are applied where their context is found, even if the file has
+// shifted since the diff was made. A hunk whose context is not found at all
+// is not dropped: it is written into the file between git-style conflict
+// markers, next to the lines it expected to change, and described in the
+// result's conflict report, so that apply-suggestion workflows can show the
+// conflict to the user or resolve it in an editor:
+//
+//	result, err := zoptalpatch.ApplyFile("main.go", refactor.Diff, nil)
+//	if err != nil {
+//		return err
+//	}
+//	for _, conflict := range result.Conflicts {
+//		fmt.Printf("%s:%d: suggestion does not apply\n", conflict.Path, conflict.Line)
+//	}
+//
+// With Options.Strict, a diff that does not apply cleanly fails with a
+// *ConflictError instead and nothing is written.
+package zoptalpatch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	zoptal "github.com/zoptal/zoptal-go-sdk"
+	"github.com/zoptal/zoptal-go-sdk/internal/unidiff"
+)
+
+// devNull is the path of the missing side of a created or deleted file.
+const devNull = "/dev/null"
+
+// Options contains options for applying a diff.
+type Options struct {
+	// CurrentLabel labels the current lines in conflict markers (default: "current")
+	CurrentLabel string
+
+	// PatchLabel labels the lines proposed by the diff in conflict markers (default: "patch")
+	PatchLabel string
+
+	// Strict fails with a *ConflictError, writing nothing, if any hunk does
+	// not apply, instead of writing conflict markers (default: false)
+	Strict bool
+}
+
+// Conflict describes a hunk that did not apply.
+type Conflict struct {
+	// Path is the path of the file, or "" for text without a path
+	Path string
+
+	// Hunk is the 0-based index of the hunk in the file's diff
+	Hunk int
+
+	// Line is the 1-based line of the <<<<<<< marker in the result
+	Line int
+
+	// Expected are the lines the hunk expected to find
+	Expected []string
+
+	// Current are the lines found in their place
+	Current []string
+
+	// Proposed are the lines the hunk would have written
+	Proposed []string
+}
+
+// Result is the outcome of applying a diff to a file or text.
+type Result struct {
+	// Path is the path of the file, or "" for text without a path
+	Path string
+
+	// Text is the patched text, including any conflict markers; empty for
+	// a deleted file
+	Text string
+
+	// Created and Deleted report whether the diff creates or deletes the file
+	Created bool
+	Deleted bool
+
+	// Applied is the number of hunks applied cleanly
+	Applied int
+
+	// Conflicts describes the hunks that did not apply
+	Conflicts []Conflict
+}
+
+// Clean reports whether all hunks applied without conflicts.
+func (r *Result) Clean() bool {
+	return len(r.Conflicts) == 0
+}
+
+// ConflictError is returned in strict mode for a diff that does not apply
+// cleanly. No file has been changed.
+type ConflictError struct {
+	Conflicts []Conflict
+}
+
+// Error implements the error interface.
+func (e *ConflictError) Error() string {
+	if len(e.Conflicts) == 1 {
+		c := e.Conflicts[0]
+		return fmt.Sprintf("zoptalpatch: hunk %d of %s does not apply", c.Hunk+1, displayPath(c.Path))
+	}
+	return fmt.Sprintf("zoptalpatch: %d hunks do not apply", len(e.Conflicts))
+}
+
+// Apply applies a diff of a single file to text.
+//
+// Parameters:
+//   - text: Text to patch
+//   - diff: Change in unified diff format
+//   - options: Apply options (can be nil for defaults)
+//
+// Returns the patched text and its conflicts, or an error if the diff is
+// malformed or describes several files.
+func Apply(text, diff string, options *Options) (*Result, error) {
+	files, err := parse(diff)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) > 1 {
+		return nil, fmt.Errorf("zoptalpatch: diff describes %d files; use ApplyDir or ApplyRemote", len(files))
+	}
+	result := apply(text, &files[0], "", options)
+	if err := check([]*Result{result}, options); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ApplyFile applies a diff to a local file and writes the result back. The
+// diff may describe several files; the one whose path matches path is
+// applied, or the only one if the paths do not match.
+//
+// Parameters:
+//   - path: Path of the local file
+//   - diff: Change in unified diff format
+//   - options: Apply options (can be nil for defaults)
+//
+// Returns the result, or an error if the diff is malformed, the file cannot
+// be read or written, or, in strict mode, the diff does not apply cleanly.
+func ApplyFile(path, diff string, options *Options) (*Result, error) {
+	files, err := parse(diff)
+	if err != nil {
+		return nil, err
+	}
+	file, err := forPath(files, filepath.ToSlash(filepath.Clean(path)))
+	if err != nil {
+		return nil, err
+	}
+	result, err := applyLocal(path, file, path, options)
+	if err != nil {
+		return nil, err
+	}
+	if err := check([]*Result{result}, options); err != nil {
+		return nil, err
+	}
+	if err := writeLocal(path, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ApplyDir applies a diff of one or more files to a local directory, such
+// as the root of a repository. File paths in the diff are relative to dir
+// and must not leave it.
+//
+// In strict mode, all files are checked before any is written, so that a
+// conflict in one file leaves the whole directory unchanged.
+//
+// Parameters:
+//   - dir: Directory the paths of the diff are relative to
+//   - diff: Change in unified diff format
+//   - options: Apply options (can be nil for defaults)
+//
+// Returns a result per file, or an error if the diff is malformed, a file
+// cannot be read or written, or, in strict mode, the diff does not apply
+// cleanly.
+func ApplyDir(dir, diff string, options *Options) ([]*Result, error) {
+	files, err := parse(diff)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*Result, 0, len(files))
+	paths := make([]string, 0, len(files))
+	for i := range files {
+		name := targetPath(&files[i])
+		if name == "" {
+			return nil, errors.New("zoptalpatch: diff has no file paths; use ApplyFile")
+		}
+		local, err := localPath(dir, name)
+		if err != nil {
+			return nil, err
+		}
+		result, err := applyLocal(local, &files[i], name, options)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+		paths = append(paths, local)
+	}
+	if err := check(results, options); err != nil {
+		return nil, err
+	}
+
+	for i, result := range results {
+		if err := writeLocal(paths[i], result); err != nil {
+			return results[:i], err
+		}
+	}
+	return results, nil
+}
+
+// ApplyRemote applies a diff of one or more files to the files of a
+// project, uploading each patched file. Diffs deleting files are not
+// supported.
+//
+// In strict mode, all files are checked before any is uploaded, so that a
+// conflict in one file leaves the whole project unchanged.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - files: File service of the project, usually client.Files
+//   - projectID: ID of the project
+//   - diff: Change in unified diff format
+//   - options: Apply options (can be nil for defaults)
+//
+// Returns a result per file, or an error if the diff is malformed, a
+// request fails, or, in strict mode, the diff does not apply cleanly.
+func ApplyRemote(ctx context.Context, files zoptal.FilesAPI, projectID, diff string, options *Options) ([]*Result, error) {
+	if projectID == "" {
+		return nil, zoptal.NewValidationError("project ID is required")
+	}
+	diffs, err := parse(diff)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*Result, 0, len(diffs))
+	contentTypes := make([]string, 0, len(diffs))
+	for i := range diffs {
+		file := &diffs[i]
+		name := targetPath(file)
+		if name == "" {
+			return nil, errors.New("zoptalpatch: diff has no file paths")
+		}
+		if file.NewPath == devNull {
+			return nil, fmt.Errorf("zoptalpatch: diff deletes %s; deleting project files is not supported", name)
+		}
+
+		var original bytes.Buffer
+		var contentType string
+		if file.OldPath != devNull {
+			download, err := files.DownloadTo(ctx, projectID, name, &original, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to download %s: %w", name, err)
+			}
+			contentType = download.ContentType
+		}
+		results = append(results, apply(original.String(), file, name, options))
+		contentTypes = append(contentTypes, contentType)
+	}
+	if err := check(results, options); err != nil {
+		return nil, err
+	}
+
+	for i, result := range results {
+		_, err := files.Upload(ctx, projectID, result.Path, strings.NewReader(result.Text), &zoptal.UploadOptions{
+			ContentType: contentTypes[i],
+			Overwrite:   true,
+		})
+		if err != nil {
+			return results[:i], fmt.Errorf("failed to upload %s: %w", result.Path, err)
+		}
+	}
+	return results, nil
+}
+
+// parse parses a diff.
+func parse(diff string) ([]unidiff.FileDiff, error) {
+	files, err := unidiff.Parse(diff)
+	if err != nil {
+		return nil, fmt.Errorf("zoptalpatch: invalid diff: %w", err)
+	}
+	return files, nil
+}
+
+// check returns a *ConflictError in strict mode if any result has conflicts.
+func check(results []*Result, options *Options) error {
+	if options == nil || !options.Strict {
+		return nil
+	}
+	var conflicts []Conflict
+	for _, result := range results {
+		conflicts = append(conflicts, result.Conflicts...)
+	}
+	if len(conflicts) > 0 {
+		return &ConflictError{Conflicts: conflicts}
+	}
+	return nil
+}
+
+// forPath returns the file diff of a multi-file diff that applies to path.
+func forPath(files []unidiff.FileDiff, path string) (*unidiff.FileDiff, error) {
+	for i := range files {
+		if target := targetPath(&files[i]); target != "" && (path == target || strings.HasSuffix(path, "/"+target)) {
+			return &files[i], nil
+		}
+	}
+	if len(files) == 1 {
+		return &files[0], nil
+	}
+	return nil, fmt.Errorf("zoptalpatch: diff has no changes for %s", path)
+}
+
+// targetPath returns the path of the file a diff changes, or "".
+func targetPath(file *unidiff.FileDiff) string {
+	if file.NewPath != devNull && file.NewPath != "" {
+		return file.NewPath
+	}
+	if file.OldPath != devNull {
+		return file.OldPath
+	}
+	return ""
+}
+
+// localPath returns the local path of a diff path within dir.
+func localPath(dir, name string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(name))
+	if filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("zoptalpatch: path %s is outside the directory", name)
+	}
+	return filepath.Join(dir, clean), nil
+}
+
+// applyLocal applies a file diff to a local file without writing it.
+func applyLocal(path string, file *unidiff.FileDiff, name string, options *Options) (*Result, error) {
+	var original []byte
+	if file.OldPath != devNull {
+		var err error
+		if original, err = os.ReadFile(path); err != nil {
+			return nil, fmt.Errorf("zoptalpatch: %w", err)
+		}
+	}
+	return apply(string(original), file, name, options), nil
+}
+
+// writeLocal writes the result of a file diff to a local file.
+func writeLocal(path string, result *Result) error {
+	if result.Deleted && result.Clean() {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("zoptalpatch: %w", err)
+		}
+		return nil
+	}
+
+	mode := fs.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	} else if result.Created {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("zoptalpatch: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, []byte(result.Text), mode); err != nil {
+		return fmt.Errorf("zoptalpatch: %w", err)
+	}
+	return nil
+}
+
+// apply applies the hunks of a file diff to text, writing conflict markers
+// for hunks whose context is not found.
+func apply(text string, file *unidiff.FileDiff, name string, options *Options) *Result {
+	currentLabel, patchLabel := "current", "patch"
+	if options != nil && options.CurrentLabel != "" {
+		currentLabel = options.CurrentLabel
+	}
+	if options != nil && options.PatchLabel != "" {
+		patchLabel = options.PatchLabel
+	}
+
+	result := &Result{
+		Path:    name,
+		Created: file.OldPath == devNull,
+		Deleted: file.NewPath == devNull,
+	}
+	lines := unidiff.SplitLines(text)
+	var out strings.Builder
+	written := 0
+	write := func(lines ...string) {
+		for _, line := range lines {
+			out.WriteString(line)
+		}
+		written += len(lines)
+	}
+
+	pos, offset := 0, 0
+	for i := range file.Hunks {
+		hunk := &file.Hunks[i]
+		old := hunk.Old()
+		if at := unidiff.Locate(lines, hunk, pos, offset); at >= 0 {
+			write(lines[pos:at]...)
+			write(hunk.New()...)
+			pos = at + len(old)
+			offset = at - hunk.Start()
+			result.Applied++
+			continue
+		}
+
+		// Put the conflict where the hunk expected its lines.
+		at := clamp(hunk.Start()+offset, pos, len(lines))
+		end := clamp(at+len(old), at, len(lines))
+		write(lines[pos:at]...)
+		current, proposed := lines[at:end], hunk.New()
+		result.Conflicts = append(result.Conflicts, Conflict{
+			Path:     name,
+			Hunk:     i,
+			Line:     written + 1,
+			Expected: old,
+			Current:  append([]string(nil), current...),
+			Proposed: proposed,
+		})
+		write("<<<<<<< " + currentLabel + "\n")
+		write(terminated(current)...)
+		write("=======\n")
+		write(terminated(proposed)...)
+		write(">>>>>>> " + patchLabel + "\n")
+		pos = end
+	}
+	write(lines[pos:]...)
+
+	if !result.Deleted || !result.Clean() {
+		result.Text = out.String()
+	}
+	return result
+}
+
+// terminated returns lines with a line ending on the last line, so that a
+// following conflict marker starts a line of its own.
+func terminated(lines []string) []string {
+	if n := len(lines); n > 0 && !strings.HasSuffix(lines[n-1], "\n") {
+		lines = append(lines[:n-1:n-1], lines[n-1]+"\n")
+	}
+	return lines
+}
+
+// clamp limits n to [lo, hi].
+func clamp(n, lo, hi int) int {
+	if n < lo {
+		return lo
+	}
+	if n > hi {
+		return hi
+	}
+	return n
+}
+
+// displayPath returns a path for messages.
+func displayPath(path string) string {
+	if path == "" {
+		return "the text"
+	}
+	return path
+}
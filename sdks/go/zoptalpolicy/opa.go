@@ -0,0 +1,224 @@
+// Package zoptalpolicy provides zoptal.PolicyEngine adapters for Open Policy
+// Agent, so that guardrails on Zoptal operations can be written in Rego and
+// managed with the rest of an organization's policies.
+//
+// The adapters do not depend on the OPA libraries. An OPA server is queried
+// through its REST API with NewOPA, and an embedded Rego query through a
+// single evaluation function with NewRego, which the rego package satisfies
+// with
+//
+//	query, err := rego.New(rego.Query("data.zoptal.decision"), rego.Load(paths, nil)).PrepareForEval(ctx)
+//	...
+//	engine := zoptalpolicy.NewRego(func(ctx context.Context, input interface{}) (interface{}, error) {
+//		results, err := query.Eval(ctx, rego.EvalInput(input))
+//		if err != nil || len(results) == 0 {
+//			return nil, err
+//		}
+//		return results[0].Expressions[0].Value, nil
+//	})
+//
+// The input document is the zoptal.PolicyInput, e.g.
+//
+//	{"action": "project.visibility", "method": "PATCH", "path": "/projects/proj_123",
+//	 "project_id": "proj_123", "body": {"visibility": "public"}, "credential": "oauth"}
+//
+// and the decision document is one of
+//   - a boolean, true to allow the operation
+//   - an object {"allow": bool, "rule": string, "reason": string}
+//   - an object {"deny": [...]}, denying the operation if the set is not
+//     empty, whose first element is a reason string or an object
+//     {"rule": string, "reason": string}, following the deny[msg] idiom
+//
+// An undefined decision, such as a query for a package without rules,
+// denies the operation. For example:
+//
+//	package zoptal
+//
+//	default decision := {"allow": true}
+//
+//	decision := {"allow": false, "rule": "no_public_projects", "reason": "projects must not be public"} if {
+//		input.body.visibility == "public"
+//	}
+package zoptalpolicy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	zoptal "github.com/zoptal/zoptal-go-sdk"
+)
+
+// maxDecisionSize bounds the size of an OPA response read by the adapter.
+const maxDecisionSize = 1 << 20
+
+// RegoEvalFunc evaluates a prepared Rego query with an input document and
+// returns the value of its result, or nil if the result is undefined.
+type RegoEvalFunc func(ctx context.Context, input interface{}) (interface{}, error)
+
+// OPAOptions contains options for querying an OPA server.
+type OPAOptions struct {
+	// HTTPClient sends the queries (default: a client with a 5 second timeout)
+	HTTPClient *http.Client
+
+	// Token is sent as a bearer token, for servers with authentication
+	// enabled (optional)
+	Token string
+}
+
+// OPA is a zoptal.PolicyEngine evaluating Rego policies, on an OPA server
+// or embedded.
+type OPA struct {
+	eval RegoEvalFunc
+}
+
+var _ zoptal.PolicyEngine = (*OPA)(nil)
+
+// NewOPA creates an engine querying a document of an OPA server with the
+// Data API.
+//
+// Parameters:
+//   - serverURL: URL of the OPA server, e.g. "http://localhost:8181"
+//   - path: Path of the decision document, e.g. "zoptal/decision" for
+//     data.zoptal.decision
+//   - options: Server options (can be nil for defaults)
+//
+// Returns the engine.
+//
+// Example usage:
+//
+//	client := zoptal.NewClientWithOptions(apiKey, &zoptal.ClientOptions{
+//		Policy: &zoptal.PolicyOptions{
+//			Engine: zoptalpolicy.NewOPA("http://localhost:8181", "zoptal/decision", nil),
+//		},
+//	})
+func NewOPA(serverURL, path string, options *OPAOptions) *OPA {
+	if options == nil {
+		options = &OPAOptions{}
+	}
+	httpClient := options.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 5 * time.Second}
+	}
+	endpoint := strings.TrimRight(serverURL, "/") + "/v1/data/" + strings.Trim(path, "/")
+	token := options.Token
+
+	return NewRego(func(ctx context.Context, input interface{}) (interface{}, error) {
+		body, err := json.Marshal(map[string]interface{}{"input": input})
+		if err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		data, err := io.ReadAll(io.LimitReader(resp.Body, maxDecisionSize))
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("OPA server returned %s: %s", resp.Status, bytes.TrimSpace(data))
+		}
+
+		var response struct {
+			Result interface{} `json:"result"`
+		}
+		if err := json.Unmarshal(data, &response); err != nil {
+			return nil, fmt.Errorf("invalid OPA response: %w", err)
+		}
+		return response.Result, nil
+	})
+}
+
+// NewRego creates an engine evaluating an embedded Rego query.
+//
+// Parameters:
+//   - eval: Function evaluating the query
+//
+// Returns the engine.
+func NewRego(eval RegoEvalFunc) *OPA {
+	return &OPA{eval: eval}
+}
+
+// Evaluate implements zoptal.PolicyEngine.
+func (o *OPA) Evaluate(ctx context.Context, input *zoptal.PolicyInput) (*zoptal.PolicyDecision, error) {
+	// Rego sees the input as JSON, so it is passed as its JSON document
+	// rather than as a Go struct.
+	data, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	result, err := o.eval(ctx, document)
+	if err != nil {
+		return nil, err
+	}
+	return decision(result)
+}
+
+// decision converts a decision document to a decision.
+func decision(result interface{}) (*zoptal.PolicyDecision, error) {
+	switch value := result.(type) {
+	case nil:
+		return &zoptal.PolicyDecision{Reason: "policy decision is undefined"}, nil
+	case bool:
+		return &zoptal.PolicyDecision{Allowed: value}, nil
+	case map[string]interface{}:
+		if deny, ok := value["deny"]; ok {
+			return denyDecision(deny)
+		}
+		allow, ok := value["allow"].(bool)
+		if !ok {
+			return nil, fmt.Errorf("policy decision has no boolean \"allow\" or \"deny\" set: %v", value)
+		}
+		rule, _ := value["rule"].(string)
+		reason, _ := value["reason"].(string)
+		return &zoptal.PolicyDecision{Allowed: allow, Rule: rule, Reason: reason}, nil
+	default:
+		return nil, fmt.Errorf("policy decision must be a boolean or an object, not %T", result)
+	}
+}
+
+// denyDecision converts a deny set to a decision.
+func denyDecision(deny interface{}) (*zoptal.PolicyDecision, error) {
+	denials, ok := deny.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("policy decision \"deny\" must be a set, not %T", deny)
+	}
+	if len(denials) == 0 {
+		return &zoptal.PolicyDecision{Allowed: true}, nil
+	}
+
+	switch first := denials[0].(type) {
+	case string:
+		return &zoptal.PolicyDecision{Reason: first}, nil
+	case map[string]interface{}:
+		rule, _ := first["rule"].(string)
+		reason, _ := first["reason"].(string)
+		if reason == "" {
+			reason, _ = first["msg"].(string)
+		}
+		return &zoptal.PolicyDecision{Rule: rule, Reason: reason}, nil
+	default:
+		return &zoptal.PolicyDecision{Reason: fmt.Sprint(first)}, nil
+	}
+}
